@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kube-zen/zen-lock/pkg/crypto"
+)
+
+// Output formats of the algorithms command
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+)
+
+func newAlgorithmsCmd() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "algorithms",
+		Short: "List the encryption algorithms supported by this build",
+		Long: `List the encryption algorithms compiled into this build and whether each
+can decrypt with the current configuration (ZEN_LOCK_PRIVATE_KEY or
+ZEN_LOCK_IDENTITIES_DIR). Use it to troubleshoot "unsupported algorithm" denials.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return printAlgorithms(os.Stdout, crypto.Algorithms(), format)
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", formatTable, "Output format: table or json")
+
+	return cmd
+}
+
+// printAlgorithms writes the algorithms as an aligned table or a JSON array
+func printAlgorithms(out io.Writer, algorithms []crypto.AlgorithmInfo, format string) error {
+	switch format {
+	case formatJSON:
+		data, err := json.MarshalIndent(algorithms, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal algorithms: %w", err)
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	case formatTable:
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tAVAILABLE\tREASON")
+		for _, alg := range algorithms {
+			fmt.Fprintf(w, "%s\t%t\t%s\n", alg.Name, alg.Available, alg.Reason)
+		}
+		return w.Flush()
+	default:
+		return fmt.Errorf("invalid --format %q (must be %s or %s)", format, formatTable, formatJSON)
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kube-zen/zen-lock/pkg/crypto"
+)
+
+func TestPrintAlgorithms_JSON(t *testing.T) {
+	t.Setenv("ZEN_LOCK_IDENTITIES_DIR", "")
+	t.Setenv("ZEN_LOCK_PRIVATE_KEY", generateTestIdentity(t).String())
+
+	var out bytes.Buffer
+	if err := printAlgorithms(&out, crypto.Algorithms(), formatJSON); err != nil {
+		t.Fatalf("printAlgorithms failed: %v", err)
+	}
+
+	var algorithms []crypto.AlgorithmInfo
+	if err := json.Unmarshal(out.Bytes(), &algorithms); err != nil {
+		t.Fatalf("Output is not JSON: %v\n%s", err, out.String())
+	}
+	if len(algorithms) != 1 || algorithms[0].Name != "age" || !algorithms[0].Available {
+		t.Errorf("Expected age to be listed as available, got %+v", algorithms)
+	}
+}
+
+func TestPrintAlgorithms_Table(t *testing.T) {
+	t.Setenv("ZEN_LOCK_IDENTITIES_DIR", "")
+	t.Setenv("ZEN_LOCK_PRIVATE_KEY", "")
+
+	var out bytes.Buffer
+	if err := printAlgorithms(&out, crypto.Algorithms(), formatTable); err != nil {
+		t.Fatalf("printAlgorithms failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "NAME") {
+		t.Fatalf("Expected a header and one row, got:\n%s", out.String())
+	}
+	if fields := strings.Fields(lines[1]); fields[0] != "age" || fields[1] != "false" {
+		t.Errorf("Expected age unavailable without a private key, got %q", lines[1])
+	}
+}
+
+func TestPrintAlgorithms_InvalidFormat(t *testing.T) {
+	if err := printAlgorithms(&bytes.Buffer{}, crypto.Algorithms(), "xml"); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+}
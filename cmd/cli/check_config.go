@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Defaults matching config/webhook/manifests.yaml
+const (
+	defaultWebhookConfigName       = "zen-lock-mutating-webhook"
+	defaultWebhookServiceName      = "zen-lock-webhook"
+	defaultWebhookServiceNamespace = "zen-lock-system"
+	webhookPodsPath                = "/mutate-pods"
+)
+
+// Severities of configuration problems found by check-config
+const (
+	severityError   = "error"
+	severityWarning = "warning"
+)
+
+// checkConfigOptions describes the expected webhook deployment
+type checkConfigOptions struct {
+	name             string
+	serviceName      string
+	serviceNamespace string
+}
+
+// configProblem is a single misconfiguration with a remediation hint
+type configProblem struct {
+	severity string
+	webhook  string
+	message  string
+	hint     string
+}
+
+func newCheckConfigCmd() *cobra.Command {
+	opts := checkConfigOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "check-config",
+		Short: "Validate the deployed MutatingWebhookConfiguration",
+		Long: `Read the live MutatingWebhookConfiguration and check it for misconfigurations that
+break injection in subtle ways: a missing CA bundle, a client config that does not
+point at the zen-lock webhook Service, a failurePolicy that silently admits Pods
+without secrets, Pod CREATE rules, and a missing namespaceSelector.
+
+Uses the current kubeconfig context. Exits non-zero when an error is found;
+warnings are reported but do not fail the check.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			scheme := runtime.NewScheme()
+			utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+
+			cfg, err := ctrl.GetConfig()
+			if err != nil {
+				return fmt.Errorf("failed to load kubeconfig: %w", err)
+			}
+			c, err := client.New(cfg, client.Options{Scheme: scheme})
+			if err != nil {
+				return fmt.Errorf("failed to create Kubernetes client: %w", err)
+			}
+
+			return runCheckConfig(cmd.Context(), c, opts, os.Stdout)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.name, "name", defaultWebhookConfigName, "Name of the MutatingWebhookConfiguration")
+	cmd.Flags().StringVar(&opts.serviceName, "service-name", defaultWebhookServiceName, "Expected webhook Service name")
+	cmd.Flags().StringVar(&opts.serviceNamespace, "service-namespace", defaultWebhookServiceNamespace, "Expected webhook Service namespace")
+
+	return cmd
+}
+
+// runCheckConfig fetches the MutatingWebhookConfiguration, reports problems and fails if any is an error
+func runCheckConfig(ctx context.Context, c client.Client, opts checkConfigOptions, out io.Writer) error {
+	webhookConfig := &admissionregistrationv1.MutatingWebhookConfiguration{}
+	if err := c.Get(ctx, types.NamespacedName{Name: opts.name}, webhookConfig); err != nil {
+		return fmt.Errorf("failed to get MutatingWebhookConfiguration %q: %w", opts.name, err)
+	}
+
+	problems := checkWebhookConfig(webhookConfig, opts)
+	if len(problems) == 0 {
+		fmt.Fprintf(out, "✅ MutatingWebhookConfiguration %s looks good\n", opts.name)
+		return nil
+	}
+
+	errorCount := 0
+	for _, p := range problems {
+		icon := "⚠️ "
+		if p.severity == severityError {
+			icon = "❌"
+			errorCount++
+		}
+		fmt.Fprintf(out, "%s %s: %s\n", icon, p.webhook, p.message)
+		fmt.Fprintf(out, "   hint: %s\n", p.hint)
+	}
+	if errorCount > 0 {
+		return fmt.Errorf("MutatingWebhookConfiguration %s has %d error(s)", opts.name, errorCount)
+	}
+	return nil
+}
+
+// checkWebhookConfig returns the problems found in a MutatingWebhookConfiguration
+func checkWebhookConfig(webhookConfig *admissionregistrationv1.MutatingWebhookConfiguration, opts checkConfigOptions) []configProblem {
+	if len(webhookConfig.Webhooks) == 0 {
+		return []configProblem{{
+			severity: severityError,
+			webhook:  webhookConfig.Name,
+			message:  "no webhooks are defined",
+			hint:     "re-apply config/webhook/manifests.yaml",
+		}}
+	}
+
+	var problems []configProblem
+	for i := range webhookConfig.Webhooks {
+		wh := &webhookConfig.Webhooks[i]
+		add := func(severity, message, hint string) {
+			problems = append(problems, configProblem{severity: severity, webhook: wh.Name, message: message, hint: hint})
+		}
+
+		if len(wh.ClientConfig.CABundle) == 0 {
+			add(severityError, "clientConfig.caBundle is empty, the API server cannot verify the webhook certificate",
+				"check that cert-manager's cainjector is running and the cert-manager.io/inject-ca-from annotation names the webhook Certificate")
+		}
+
+		svc := wh.ClientConfig.Service
+		switch {
+		case svc == nil:
+			add(severityError, "clientConfig does not reference a Service",
+				fmt.Sprintf("set clientConfig.service to %s/%s with path %s", opts.serviceNamespace, opts.serviceName, webhookPodsPath))
+		case svc.Name != opts.serviceName || svc.Namespace != opts.serviceNamespace:
+			add(severityError, fmt.Sprintf("clientConfig.service is %s/%s, expected %s/%s", svc.Namespace, svc.Name, opts.serviceNamespace, opts.serviceName),
+				"point clientConfig.service at the zen-lock webhook Service, or pass --service-name/--service-namespace if it was renamed")
+		case svc.Path == nil || *svc.Path != webhookPodsPath:
+			add(severityError, fmt.Sprintf("clientConfig.service.path is not %s", webhookPodsPath),
+				fmt.Sprintf("set clientConfig.service.path to %s", webhookPodsPath))
+		}
+
+		if wh.FailurePolicy == nil || *wh.FailurePolicy != admissionregistrationv1.Fail {
+			add(severityWarning, "failurePolicy is not Fail, Pods are admitted without their secrets whenever the webhook is unavailable",
+				"set failurePolicy: Fail; with Ignore, check kubectl describe zenlock for InjectionFailed events")
+		}
+
+		if !rulesCoverPodCreate(wh.Rules) {
+			add(severityError, "rules do not match CREATE of v1 pods, no Pod will be injected",
+				`add a rule with apiGroups [""], apiVersions ["v1"], operations ["CREATE"] and resources ["pods"]`)
+		}
+
+		if wh.NamespaceSelector == nil || (len(wh.NamespaceSelector.MatchLabels) == 0 && len(wh.NamespaceSelector.MatchExpressions) == 0) {
+			add(severityWarning, "namespaceSelector is empty, every Pod in every namespace (including kube-system) goes through the webhook",
+				"restrict it, e.g. namespaceSelector.matchLabels: {zen-lock: enabled}, and label the namespaces that use zen-lock")
+		}
+	}
+	return problems
+}
+
+// rulesCoverPodCreate reports whether any rule matches CREATE of core v1 pods
+func rulesCoverPodCreate(rules []admissionregistrationv1.RuleWithOperations) bool {
+	for _, rule := range rules {
+		if matchesAny(rule.APIGroups, "") && matchesAny(rule.APIVersions, "v1") && matchesAny(rule.Resources, "pods") &&
+			matchesOperation(rule.Operations, admissionregistrationv1.Create) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAny reports whether values contains want or the "*" wildcard
+func matchesAny(values []string, want string) bool {
+	for _, v := range values {
+		if v == want || v == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesOperation reports whether operations contains want or the "*" wildcard
+func matchesOperation(operations []admissionregistrationv1.OperationType, want admissionregistrationv1.OperationType) bool {
+	for _, op := range operations {
+		if op == want || op == admissionregistrationv1.OperationAll {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func defaultCheckConfigOptions() checkConfigOptions {
+	return checkConfigOptions{
+		name:             defaultWebhookConfigName,
+		serviceName:      defaultWebhookServiceName,
+		serviceNamespace: defaultWebhookServiceNamespace,
+	}
+}
+
+// validWebhookConfig mirrors config/webhook/manifests.yaml with an injected CA bundle
+func validWebhookConfig() *admissionregistrationv1.MutatingWebhookConfiguration {
+	path := webhookPodsPath
+	failurePolicy := admissionregistrationv1.Fail
+	return &admissionregistrationv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: defaultWebhookConfigName},
+		Webhooks: []admissionregistrationv1.MutatingWebhook{{
+			Name: "mutate-pods.zen-lock.security.kube-zen.io",
+			ClientConfig: admissionregistrationv1.WebhookClientConfig{
+				Service: &admissionregistrationv1.ServiceReference{
+					Name:      defaultWebhookServiceName,
+					Namespace: defaultWebhookServiceNamespace,
+					Path:      &path,
+				},
+				CABundle: []byte("-----BEGIN CERTIFICATE-----"),
+			},
+			Rules: []admissionregistrationv1.RuleWithOperations{{
+				Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+				Rule: admissionregistrationv1.Rule{
+					APIGroups:   []string{""},
+					APIVersions: []string{"v1"},
+					Resources:   []string{"pods"},
+				},
+			}},
+			FailurePolicy: &failurePolicy,
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"zen-lock": "enabled"},
+			},
+		}},
+	}
+}
+
+func TestCheckWebhookConfig(t *testing.T) {
+	ignore := admissionregistrationv1.Ignore
+	otherPath := "/mutate"
+	url := "https://zen-lock.example.com/mutate-pods"
+
+	tests := []struct {
+		name         string
+		mutate       func(*admissionregistrationv1.MutatingWebhookConfiguration)
+		wantSeverity string
+		wantMessage  string
+	}{
+		{
+			name:   "valid",
+			mutate: func(*admissionregistrationv1.MutatingWebhookConfiguration) {},
+		},
+		{
+			name:         "no webhooks",
+			mutate:       func(c *admissionregistrationv1.MutatingWebhookConfiguration) { c.Webhooks = nil },
+			wantSeverity: severityError,
+			wantMessage:  "no webhooks",
+		},
+		{
+			name: "missing CA bundle",
+			mutate: func(c *admissionregistrationv1.MutatingWebhookConfiguration) {
+				c.Webhooks[0].ClientConfig.CABundle = nil
+			},
+			wantSeverity: severityError,
+			wantMessage:  "caBundle is empty",
+		},
+		{
+			name: "URL instead of service",
+			mutate: func(c *admissionregistrationv1.MutatingWebhookConfiguration) {
+				c.Webhooks[0].ClientConfig.Service = nil
+				c.Webhooks[0].ClientConfig.URL = &url
+			},
+			wantSeverity: severityError,
+			wantMessage:  "does not reference a Service",
+		},
+		{
+			name: "wrong service",
+			mutate: func(c *admissionregistrationv1.MutatingWebhookConfiguration) {
+				c.Webhooks[0].ClientConfig.Service.Namespace = "default"
+			},
+			wantSeverity: severityError,
+			wantMessage:  "expected zen-lock-system/zen-lock-webhook",
+		},
+		{
+			name: "wrong path",
+			mutate: func(c *admissionregistrationv1.MutatingWebhookConfiguration) {
+				c.Webhooks[0].ClientConfig.Service.Path = &otherPath
+			},
+			wantSeverity: severityError,
+			wantMessage:  "path is not /mutate-pods",
+		},
+		{
+			name:         "failurePolicy Ignore",
+			mutate:       func(c *admissionregistrationv1.MutatingWebhookConfiguration) { c.Webhooks[0].FailurePolicy = &ignore },
+			wantSeverity: severityWarning,
+			wantMessage:  "failurePolicy is not Fail",
+		},
+		{
+			name: "rules miss pod CREATE",
+			mutate: func(c *admissionregistrationv1.MutatingWebhookConfiguration) {
+				c.Webhooks[0].Rules[0].Operations = []admissionregistrationv1.OperationType{admissionregistrationv1.Update}
+			},
+			wantSeverity: severityError,
+			wantMessage:  "rules do not match CREATE",
+		},
+		{
+			name:         "missing namespaceSelector",
+			mutate:       func(c *admissionregistrationv1.MutatingWebhookConfiguration) { c.Webhooks[0].NamespaceSelector = nil },
+			wantSeverity: severityWarning,
+			wantMessage:  "namespaceSelector is empty",
+		},
+		{
+			name: "empty namespaceSelector",
+			mutate: func(c *admissionregistrationv1.MutatingWebhookConfiguration) {
+				c.Webhooks[0].NamespaceSelector = &metav1.LabelSelector{}
+			},
+			wantSeverity: severityWarning,
+			wantMessage:  "namespaceSelector is empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhookConfig := validWebhookConfig()
+			tt.mutate(webhookConfig)
+			problems := checkWebhookConfig(webhookConfig, defaultCheckConfigOptions())
+
+			if tt.wantMessage == "" {
+				if len(problems) != 0 {
+					t.Errorf("Expected no problems, got %+v", problems)
+				}
+				return
+			}
+			if len(problems) != 1 {
+				t.Fatalf("Expected exactly one problem, got %+v", problems)
+			}
+			if problems[0].severity != tt.wantSeverity {
+				t.Errorf("Expected severity %q, got %q", tt.wantSeverity, problems[0].severity)
+			}
+			if !strings.Contains(problems[0].message, tt.wantMessage) {
+				t.Errorf("Expected message containing %q, got %q", tt.wantMessage, problems[0].message)
+			}
+			if problems[0].hint == "" {
+				t.Error("Expected a remediation hint")
+			}
+		})
+	}
+}
+
+func TestRunCheckConfig(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(admissionregistrationv1.AddToScheme(scheme))
+
+	// Warnings are reported without failing
+	warned := validWebhookConfig()
+	warned.Webhooks[0].NamespaceSelector = nil
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(warned).Build()
+	var out bytes.Buffer
+	if err := runCheckConfig(context.Background(), c, defaultCheckConfigOptions(), &out); err != nil {
+		t.Errorf("Expected warnings not to fail the check, got %v", err)
+	}
+	if !strings.Contains(out.String(), "hint:") {
+		t.Errorf("Expected a hint in the output, got %q", out.String())
+	}
+
+	// Errors fail the check
+	broken := validWebhookConfig()
+	broken.Webhooks[0].ClientConfig.CABundle = nil
+	c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(broken).Build()
+	out.Reset()
+	if err := runCheckConfig(context.Background(), c, defaultCheckConfigOptions(), &out); err == nil {
+		t.Error("Expected an error for a missing CA bundle")
+	}
+
+	// A missing configuration is an error
+	c = fake.NewClientBuilder().WithScheme(scheme).Build()
+	if err := runCheckConfig(context.Background(), c, defaultCheckConfigOptions(), &out); err == nil {
+		t.Error("Expected an error for a missing MutatingWebhookConfiguration")
+	}
+}
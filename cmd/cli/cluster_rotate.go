@@ -0,0 +1,338 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"filippo.io/age"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/crypto"
+)
+
+// Rotation phases for cluster-rotate
+const (
+	// rotatePhaseAdd re-encrypts every value to both the old and the new recipient
+	rotatePhaseAdd = "add"
+	// rotatePhaseFinalize re-encrypts every value to the new recipient only
+	rotatePhaseFinalize = "finalize"
+	// rotatePhaseComplete means no ZenLock is decryptable with the old key anymore
+	rotatePhaseComplete = "complete"
+)
+
+// valueKeyState describes which identities can decrypt an encrypted value
+type valueKeyState int
+
+const (
+	valueOldOnly valueKeyState = iota
+	valueDual
+	valueNewOnly
+)
+
+// rotationKeys holds the old and new identities used during a rotation
+type rotationKeys struct {
+	oldIdentity  string
+	oldRecipient string
+	newIdentity  string
+	newRecipient string
+}
+
+// zenLockRotation is the planned change for a single ZenLock
+type zenLockRotation struct {
+	zenlock       *securityv1alpha1.ZenLock
+	encryptedData map[string]string
+}
+
+func newClusterRotateCmd() *cobra.Command {
+	var oldKey string
+	var newKey string
+	var phase string
+	var namespace string
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "cluster-rotate",
+		Short: "Rotate the webhook private key across all ZenLocks without downtime",
+		Long: `Rotate the webhook private key across all ZenLocks in the cluster in two phases.
+
+Phase "add" re-encrypts every ZenLock value to both the old and the new public key,
+so data decrypts under either identity. Once it completes, swap the webhook's
+ZEN_LOCK_PRIVATE_KEY to the new identity and roll out the webhook.
+
+Phase "finalize" re-encrypts every value to the new public key only, removing the
+old recipient. Run it after the webhook is using the new identity.
+
+Without --phase, the current state is detected from the ZenLocks: any value not yet
+decryptable with the new key selects "add", otherwise any value still decryptable
+with the old key selects "finalize". All values are re-encrypted in memory before any
+ZenLock is written, so a decryption failure leaves the cluster untouched.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if oldKey == "" {
+				return fmt.Errorf("--old-key flag is required")
+			}
+			if newKey == "" {
+				return fmt.Errorf("--new-key flag is required")
+			}
+			if phase != "" && phase != rotatePhaseAdd && phase != rotatePhaseFinalize {
+				return fmt.Errorf("--phase must be %q or %q", rotatePhaseAdd, rotatePhaseFinalize)
+			}
+
+			keys, err := loadRotationKeys(oldKey, newKey)
+			if err != nil {
+				return err
+			}
+
+			scheme := runtime.NewScheme()
+			utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+			utilruntime.Must(securityv1alpha1.AddToScheme(scheme))
+
+			cfg, err := ctrl.GetConfig()
+			if err != nil {
+				return fmt.Errorf("failed to load kubeconfig: %w", err)
+			}
+			c, err := client.New(cfg, client.Options{Scheme: scheme})
+			if err != nil {
+				return fmt.Errorf("failed to create Kubernetes client: %w", err)
+			}
+
+			return runClusterRotate(cmd.Context(), c, keys, namespace, phase, dryRun, os.Stdout)
+		},
+	}
+
+	cmd.Flags().StringVar(&oldKey, "old-key", "", "File containing the current private key (required)")
+	cmd.Flags().StringVar(&newKey, "new-key", "", "File containing the new private key (required)")
+	cmd.Flags().StringVar(&phase, "phase", "", `Rotation phase to run: "add" or "finalize" (default: detected)`)
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Only rotate ZenLocks in this namespace (default: all namespaces)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Report the planned changes without updating any ZenLock")
+
+	return cmd
+}
+
+// loadRotationKeys reads and parses the old and new private key files
+func loadRotationKeys(oldKeyFile, newKeyFile string) (rotationKeys, error) {
+	oldIdentity, err := readIdentityFile(oldKeyFile)
+	if err != nil {
+		return rotationKeys{}, fmt.Errorf("failed to load old key: %w", err)
+	}
+	newIdentity, err := readIdentityFile(newKeyFile)
+	if err != nil {
+		return rotationKeys{}, fmt.Errorf("failed to load new key: %w", err)
+	}
+	if oldIdentity.String() == newIdentity.String() {
+		return rotationKeys{}, fmt.Errorf("old and new keys are identical")
+	}
+
+	return rotationKeys{
+		oldIdentity:  oldIdentity.String(),
+		oldRecipient: oldIdentity.Recipient().String(),
+		newIdentity:  newIdentity.String(),
+		newRecipient: newIdentity.Recipient().String(),
+	}, nil
+}
+
+// readIdentityFile reads an age X25519 identity from a file
+func readIdentityFile(path string) (*age.X25519Identity, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key file: %w", err)
+	}
+	identity, err := age.ParseX25519Identity(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	return identity, nil
+}
+
+// runClusterRotate plans the rotation for every ZenLock and applies it unless dryRun is set
+func runClusterRotate(ctx context.Context, c client.Client, keys rotationKeys, namespace, phase string, dryRun bool, out io.Writer) error {
+	zenlockList := &securityv1alpha1.ZenLockList{}
+	var listOpts []client.ListOption
+	if namespace != "" {
+		listOpts = append(listOpts, client.InNamespace(namespace))
+	}
+	if err := c.List(ctx, zenlockList, listOpts...); err != nil {
+		return fmt.Errorf("failed to list ZenLocks: %w", err)
+	}
+
+	encryptor := crypto.NewAgeEncryptor()
+
+	// Detect the state of every value before changing anything
+	states := make(map[string]map[string]valueKeyState, len(zenlockList.Items))
+	encryptedData := make(map[string]map[string]string, len(zenlockList.Items))
+	for i := range zenlockList.Items {
+		zl := &zenlockList.Items[i]
+		data, err := crypto.EncryptedDataAsBase64(zl.Spec.EncryptedData, zl.Spec.Encoding)
+		if err != nil {
+			return fmt.Errorf("ZenLock %s/%s: %w", zl.Namespace, zl.Name, err)
+		}
+		zlStates, err := detectKeyStates(encryptor, data, keys)
+		if err != nil {
+			return fmt.Errorf("ZenLock %s/%s: %w", zl.Namespace, zl.Name, err)
+		}
+		states[zenLockID(zl)] = zlStates
+		encryptedData[zenLockID(zl)] = data
+	}
+
+	detected := detectRotationPhase(states)
+	if phase == "" {
+		phase = detected
+	}
+	if phase == rotatePhaseComplete {
+		fmt.Fprintf(out, "✅ Rotation complete: no ZenLock is decryptable with the old key\n")
+		return nil
+	}
+	if phase == rotatePhaseFinalize && detected == rotatePhaseAdd {
+		return fmt.Errorf("cannot finalize: some ZenLocks are not yet decryptable with the new key, run --phase=%s first", rotatePhaseAdd)
+	}
+
+	// Re-encrypt everything in memory first so a failure leaves the cluster untouched
+	var plan []zenLockRotation
+	for i := range zenlockList.Items {
+		zl := &zenlockList.Items[i]
+		var updated map[string]string
+		var changed bool
+		var err error
+		switch phase {
+		case rotatePhaseAdd:
+			updated, changed, err = addRecipient(encryptor, encryptedData[zenLockID(zl)], states[zenLockID(zl)], keys)
+		case rotatePhaseFinalize:
+			updated, changed, err = stripOldRecipient(encryptor, encryptedData[zenLockID(zl)], states[zenLockID(zl)], keys)
+		}
+		if err != nil {
+			return fmt.Errorf("ZenLock %s/%s: %w", zl.Namespace, zl.Name, err)
+		}
+		if changed {
+			plan = append(plan, zenLockRotation{zenlock: zl, encryptedData: updated})
+		}
+	}
+
+	fmt.Fprintf(out, "Phase %q: %d of %d ZenLocks need re-encryption\n", phase, len(plan), len(zenlockList.Items))
+	for _, p := range plan {
+		fmt.Fprintf(out, "  %s/%s\n", p.zenlock.Namespace, p.zenlock.Name)
+	}
+	if dryRun {
+		fmt.Fprintf(out, "Dry run: no ZenLocks were updated\n")
+		return nil
+	}
+
+	for _, p := range plan {
+		// Re-encrypted values are written as base64, whatever the previous encoding
+		p.zenlock.Spec.EncryptedData = p.encryptedData
+		p.zenlock.Spec.Encoding = ""
+		if err := c.Update(ctx, p.zenlock); err != nil {
+			return fmt.Errorf("failed to update ZenLock %s/%s: %w (re-run the same phase to resume)", p.zenlock.Namespace, p.zenlock.Name, err)
+		}
+	}
+
+	switch phase {
+	case rotatePhaseAdd:
+		fmt.Fprintf(out, "\n✅ All ZenLocks now decrypt with both the old and the new key.\n")
+		fmt.Fprintf(out, "Next: set the webhook's ZEN_LOCK_PRIVATE_KEY to the new key, roll out the webhook,\n")
+		fmt.Fprintf(out, "then run: zen-lock cluster-rotate --phase=%s\n", rotatePhaseFinalize)
+	case rotatePhaseFinalize:
+		fmt.Fprintf(out, "\n✅ Old recipient removed from all ZenLocks. The old key can now be revoked.\n")
+	}
+
+	return nil
+}
+
+// detectKeyStates reports which identities can decrypt each value of a ZenLock
+func detectKeyStates(encryptor *crypto.AgeEncryptor, encryptedData map[string]string, keys rotationKeys) (map[string]valueKeyState, error) {
+	states := make(map[string]valueKeyState, len(encryptedData))
+	for key, value := range encryptedData {
+		ciphertext, err := crypto.DecodeBase64(value, crypto.Base64Tolerant())
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode base64 for key %q: %w", key, err)
+		}
+		_, oldErr := encryptor.Decrypt(ciphertext, keys.oldIdentity)
+		_, newErr := encryptor.Decrypt(ciphertext, keys.newIdentity)
+		switch {
+		case oldErr == nil && newErr == nil:
+			states[key] = valueDual
+		case newErr == nil:
+			states[key] = valueNewOnly
+		case oldErr == nil:
+			states[key] = valueOldOnly
+		default:
+			return nil, fmt.Errorf("key %q cannot be decrypted with the old or the new key", key)
+		}
+	}
+	return states, nil
+}
+
+// detectRotationPhase derives the next phase from the state of every value
+func detectRotationPhase(states map[string]map[string]valueKeyState) string {
+	phase := rotatePhaseComplete
+	for _, zlStates := range states {
+		for _, state := range zlStates {
+			switch state {
+			case valueOldOnly:
+				return rotatePhaseAdd
+			case valueDual:
+				phase = rotatePhaseFinalize
+			}
+		}
+	}
+	return phase
+}
+
+// addRecipient re-encrypts values readable only by the old key to both the old and the new recipient
+func addRecipient(encryptor *crypto.AgeEncryptor, encryptedData map[string]string, states map[string]valueKeyState, keys rotationKeys) (map[string]string, bool, error) {
+	return reencrypt(encryptor, encryptedData, states, valueOldOnly, keys.oldIdentity, []string{keys.oldRecipient, keys.newRecipient})
+}
+
+// stripOldRecipient re-encrypts values still readable by the old key to the new recipient only
+func stripOldRecipient(encryptor *crypto.AgeEncryptor, encryptedData map[string]string, states map[string]valueKeyState, keys rotationKeys) (map[string]string, bool, error) {
+	return reencrypt(encryptor, encryptedData, states, valueDual, keys.newIdentity, []string{keys.newRecipient})
+}
+
+// reencrypt re-encrypts the values in the given state and copies all others unchanged
+func reencrypt(encryptor *crypto.AgeEncryptor, encryptedData map[string]string, states map[string]valueKeyState, target valueKeyState, identity string, recipients []string) (map[string]string, bool, error) {
+	keys := make([]string, 0, len(encryptedData))
+	for key := range encryptedData {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := make(map[string]string, len(encryptedData))
+	changed := false
+	for _, key := range keys {
+		value := encryptedData[key]
+		if states[key] != target {
+			result[key] = value
+			continue
+		}
+
+		ciphertext, err := crypto.DecodeBase64(value, crypto.Base64Tolerant())
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to decode base64 for key %q: %w", key, err)
+		}
+		plaintext, err := encryptor.Decrypt(ciphertext, identity)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to decrypt key %q: %w", key, err)
+		}
+		reencrypted, err := encryptor.Encrypt(plaintext, recipients)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to encrypt key %q: %w", key, err)
+		}
+		result[key] = base64.StdEncoding.EncodeToString(reencrypted)
+		changed = true
+	}
+	return result, changed, nil
+}
+
+// zenLockID returns the namespace/name identifier of a ZenLock
+func zenLockID(zl *securityv1alpha1.ZenLock) string {
+	return zl.Namespace + "/" + zl.Name
+}
@@ -0,0 +1,279 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"filippo.io/age"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/crypto"
+)
+
+func setupRotationKeys(t *testing.T) rotationKeys {
+	oldIdentity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate old identity: %v", err)
+	}
+	newIdentity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate new identity: %v", err)
+	}
+	return rotationKeys{
+		oldIdentity:  oldIdentity.String(),
+		oldRecipient: oldIdentity.Recipient().String(),
+		newIdentity:  newIdentity.String(),
+		newRecipient: newIdentity.Recipient().String(),
+	}
+}
+
+func encryptTestValue(t *testing.T, plaintext string, recipients ...string) string {
+	ciphertext, err := crypto.NewAgeEncryptor().Encrypt([]byte(plaintext), recipients)
+	if err != nil {
+		t.Fatalf("Failed to encrypt: %v", err)
+	}
+	return base64.StdEncoding.EncodeToString(ciphertext)
+}
+
+func canDecrypt(t *testing.T, value, identity, expected string) bool {
+	ciphertext, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		t.Fatalf("Failed to decode value: %v", err)
+	}
+	plaintext, err := crypto.NewAgeEncryptor().Decrypt(ciphertext, identity)
+	if err != nil {
+		return false
+	}
+	if string(plaintext) != expected {
+		t.Errorf("Expected plaintext %q, got %q", expected, string(plaintext))
+	}
+	return true
+}
+
+func setupRotateClient(t *testing.T, objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(securityv1alpha1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func testRotateZenLock(name string, encryptedData map[string]string) *securityv1alpha1.ZenLock {
+	return &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       securityv1alpha1.ZenLockSpec{EncryptedData: encryptedData},
+	}
+}
+
+func TestAddRecipient(t *testing.T) {
+	keys := setupRotationKeys(t)
+	encryptor := crypto.NewAgeEncryptor()
+	encryptedData := map[string]string{
+		"USERNAME": encryptTestValue(t, "admin", keys.oldRecipient),
+		"PASSWORD": encryptTestValue(t, "secret", keys.oldRecipient, keys.newRecipient),
+	}
+
+	states, err := detectKeyStates(encryptor, encryptedData, keys)
+	if err != nil {
+		t.Fatalf("detectKeyStates failed: %v", err)
+	}
+	if states["USERNAME"] != valueOldOnly || states["PASSWORD"] != valueDual {
+		t.Fatalf("Unexpected states: %v", states)
+	}
+
+	updated, changed, err := addRecipient(encryptor, encryptedData, states, keys)
+	if err != nil {
+		t.Fatalf("addRecipient failed: %v", err)
+	}
+	if !changed {
+		t.Fatal("Expected addRecipient to report a change")
+	}
+	if updated["PASSWORD"] != encryptedData["PASSWORD"] {
+		t.Error("Expected already dual-encrypted value to be left unchanged")
+	}
+	if !canDecrypt(t, updated["USERNAME"], keys.oldIdentity, "admin") {
+		t.Error("Expected USERNAME to remain decryptable with the old key")
+	}
+	if !canDecrypt(t, updated["USERNAME"], keys.newIdentity, "admin") {
+		t.Error("Expected USERNAME to be decryptable with the new key")
+	}
+}
+
+func TestStripOldRecipient(t *testing.T) {
+	keys := setupRotationKeys(t)
+	encryptor := crypto.NewAgeEncryptor()
+	encryptedData := map[string]string{
+		"USERNAME": encryptTestValue(t, "admin", keys.oldRecipient, keys.newRecipient),
+		"PASSWORD": encryptTestValue(t, "secret", keys.newRecipient),
+	}
+
+	states, err := detectKeyStates(encryptor, encryptedData, keys)
+	if err != nil {
+		t.Fatalf("detectKeyStates failed: %v", err)
+	}
+
+	updated, changed, err := stripOldRecipient(encryptor, encryptedData, states, keys)
+	if err != nil {
+		t.Fatalf("stripOldRecipient failed: %v", err)
+	}
+	if !changed {
+		t.Fatal("Expected stripOldRecipient to report a change")
+	}
+	if updated["PASSWORD"] != encryptedData["PASSWORD"] {
+		t.Error("Expected new-only value to be left unchanged")
+	}
+	if canDecrypt(t, updated["USERNAME"], keys.oldIdentity, "admin") {
+		t.Error("Expected USERNAME to no longer be decryptable with the old key")
+	}
+	if !canDecrypt(t, updated["USERNAME"], keys.newIdentity, "admin") {
+		t.Error("Expected USERNAME to be decryptable with the new key")
+	}
+}
+
+func TestDetectKeyStates_Undecryptable(t *testing.T) {
+	keys := setupRotationKeys(t)
+	other := setupRotationKeys(t)
+	encryptedData := map[string]string{
+		"KEY": encryptTestValue(t, "value", other.oldRecipient),
+	}
+
+	if _, err := detectKeyStates(crypto.NewAgeEncryptor(), encryptedData, keys); err == nil {
+		t.Error("Expected error for value encrypted to an unknown recipient")
+	}
+}
+
+func TestDetectRotationPhase(t *testing.T) {
+	tests := []struct {
+		name   string
+		states map[string]map[string]valueKeyState
+		want   string
+	}{
+		{
+			name:   "old only selects add",
+			states: map[string]map[string]valueKeyState{"default/a": {"k": valueDual}, "default/b": {"k": valueOldOnly}},
+			want:   rotatePhaseAdd,
+		},
+		{
+			name:   "dual selects finalize",
+			states: map[string]map[string]valueKeyState{"default/a": {"k": valueDual}, "default/b": {"k": valueNewOnly}},
+			want:   rotatePhaseFinalize,
+		},
+		{
+			name:   "new only is complete",
+			states: map[string]map[string]valueKeyState{"default/a": {"k": valueNewOnly}},
+			want:   rotatePhaseComplete,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectRotationPhase(tt.states); got != tt.want {
+				t.Errorf("detectRotationPhase() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunClusterRotate_Phases(t *testing.T) {
+	keys := setupRotationKeys(t)
+	zl := testRotateZenLock("app", map[string]string{
+		"KEY": encryptTestValue(t, "value", keys.oldRecipient),
+	})
+	c := setupRotateClient(t, zl)
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "app", Namespace: "default"}
+
+	// Phase 1 (detected): old and new both decrypt
+	if err := runClusterRotate(ctx, c, keys, "", "", false, &bytes.Buffer{}); err != nil {
+		t.Fatalf("add phase failed: %v", err)
+	}
+	got := &securityv1alpha1.ZenLock{}
+	if err := c.Get(ctx, key, got); err != nil {
+		t.Fatalf("Failed to get ZenLock: %v", err)
+	}
+	if !canDecrypt(t, got.Spec.EncryptedData["KEY"], keys.oldIdentity, "value") || !canDecrypt(t, got.Spec.EncryptedData["KEY"], keys.newIdentity, "value") {
+		t.Fatal("Expected value to decrypt under both keys after add phase")
+	}
+
+	// Phase 3 (detected): only the new key decrypts
+	if err := runClusterRotate(ctx, c, keys, "", "", false, &bytes.Buffer{}); err != nil {
+		t.Fatalf("finalize phase failed: %v", err)
+	}
+	if err := c.Get(ctx, key, got); err != nil {
+		t.Fatalf("Failed to get ZenLock: %v", err)
+	}
+	if canDecrypt(t, got.Spec.EncryptedData["KEY"], keys.oldIdentity, "value") {
+		t.Error("Expected old key to be stripped after finalize phase")
+	}
+	if !canDecrypt(t, got.Spec.EncryptedData["KEY"], keys.newIdentity, "value") {
+		t.Error("Expected value to decrypt under the new key after finalize phase")
+	}
+
+	// Further runs are a no-op
+	out := &bytes.Buffer{}
+	if err := runClusterRotate(ctx, c, keys, "", "", false, out); err != nil {
+		t.Fatalf("complete run failed: %v", err)
+	}
+	if !bytes.Contains(out.Bytes(), []byte("Rotation complete")) {
+		t.Errorf("Expected completion message, got %q", out.String())
+	}
+}
+
+func TestRunClusterRotate_FinalizeBeforeAdd(t *testing.T) {
+	keys := setupRotationKeys(t)
+	original := encryptTestValue(t, "value", keys.oldRecipient)
+	c := setupRotateClient(t, testRotateZenLock("app", map[string]string{"KEY": original}))
+
+	err := runClusterRotate(context.Background(), c, keys, "", rotatePhaseFinalize, false, &bytes.Buffer{})
+	if err == nil {
+		t.Fatal("Expected error when finalizing before the add phase")
+	}
+
+	got := &securityv1alpha1.ZenLock{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "app", Namespace: "default"}, got); err != nil {
+		t.Fatalf("Failed to get ZenLock: %v", err)
+	}
+	if got.Spec.EncryptedData["KEY"] != original {
+		t.Error("Expected ZenLock to be left untouched")
+	}
+}
+
+func TestRunClusterRotate_DryRun(t *testing.T) {
+	keys := setupRotationKeys(t)
+	original := encryptTestValue(t, "value", keys.oldRecipient)
+	c := setupRotateClient(t, testRotateZenLock("app", map[string]string{"KEY": original}))
+
+	if err := runClusterRotate(context.Background(), c, keys, "", rotatePhaseAdd, true, &bytes.Buffer{}); err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+
+	got := &securityv1alpha1.ZenLock{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "app", Namespace: "default"}, got); err != nil {
+		t.Fatalf("Failed to get ZenLock: %v", err)
+	}
+	if got.Spec.EncryptedData["KEY"] != original {
+		t.Error("Expected dry run to leave the ZenLock untouched")
+	}
+}
@@ -3,29 +3,55 @@ package main
 import (
 	"fmt"
 	"os"
+	"regexp"
+	"sort"
+	"strings"
 
-	"github.com/kube-zen/zen-lock/pkg/crypto"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/crypto"
 )
 
 func newDecryptCmd() *cobra.Command {
 	var privkey string
 	var input string
+	var fromZenLock string
 	var output string
+	var format string
+	var iUnderstandPlaintext bool
 
 	cmd := &cobra.Command{
 		Use:   "decrypt",
 		Short: "Decrypt a ZenLock CRD file (debug only)",
 		Long: `Decrypt a ZenLock CRD file back to plain text. This is only useful for
 local debugging or disaster recovery. The decrypted output should never be
-committed to version control.`,
+committed to version control.
+
+Use --from-zenlock NAMESPACE/NAME to read the ZenLock from the cluster (current
+kubeconfig context) instead of a file. --format docker-env prints KEY=value lines
+for "docker run --env-file" or "source"; it requires --i-understand-plaintext.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if privkey == "" {
 				return fmt.Errorf("--privkey flag is required")
 			}
-			if input == "" {
-				return fmt.Errorf("--input flag is required")
+			if (input == "") == (fromZenLock == "") {
+				return fmt.Errorf("exactly one of --input or --from-zenlock is required")
+			}
+			switch format {
+			case formatYAML:
+			case formatDockerEnv:
+				if !iUnderstandPlaintext {
+					return fmt.Errorf("--format %s prints plaintext secrets; pass --i-understand-plaintext to confirm", formatDockerEnv)
+				}
+			default:
+				return fmt.Errorf("invalid --format %q (must be %s or %s)", format, formatYAML, formatDockerEnv)
 			}
 
 			// Read private key
@@ -34,62 +60,55 @@ committed to version control.`,
 				return fmt.Errorf("failed to read private key file: %w", err)
 			}
 
-			// Read input YAML
-			inputData, err := os.ReadFile(input)
-			if err != nil {
-				return fmt.Errorf("failed to read input file: %w", err)
-			}
-
-			// Parse ZenLock YAML
-			var zenlock map[string]interface{}
-			if err := yaml.Unmarshal(inputData, &zenlock); err != nil {
-				return fmt.Errorf("failed to parse YAML: %w", err)
-			}
-
-			// Extract spec.encryptedData
-			spec, ok := zenlock["spec"].(map[string]interface{})
-			if !ok {
-				return fmt.Errorf("invalid ZenLock: missing 'spec' field")
-			}
-
-			encryptedDataRaw, ok := spec["encryptedData"].(map[string]interface{})
-			if !ok {
-				return fmt.Errorf("invalid ZenLock: missing 'spec.encryptedData' field")
-			}
-
-			// Convert to map[string]string
-			encryptedData := make(map[string]string)
-			for k, v := range encryptedDataRaw {
-				val, ok := v.(string)
-				if !ok {
-					return fmt.Errorf("encrypted value for key %q must be a string", k)
+			// Read ZenLock from file or cluster
+			var encryptedData map[string]string
+			if input != "" {
+				inputData, err := os.ReadFile(input)
+				if err != nil {
+					return fmt.Errorf("failed to read input file: %w", err)
+				}
+				if encryptedData, err = manifestEncryptedData(inputData); err != nil {
+					return err
+				}
+			} else {
+				namespace, name, ok := strings.Cut(fromZenLock, "/")
+				if !ok || namespace == "" || name == "" {
+					return fmt.Errorf("ZenLock must be given as NAMESPACE/NAME, got %q", fromZenLock)
+				}
+				scheme := runtime.NewScheme()
+				utilruntime.Must(securityv1alpha1.AddToScheme(scheme))
+				cfg, err := ctrl.GetConfig()
+				if err != nil {
+					return fmt.Errorf("failed to load kubeconfig: %w", err)
+				}
+				c, err := client.New(cfg, client.Options{Scheme: scheme})
+				if err != nil {
+					return fmt.Errorf("failed to create Kubernetes client: %w", err)
+				}
+				zenlock := &securityv1alpha1.ZenLock{}
+				if err := c.Get(cmd.Context(), types.NamespacedName{Namespace: namespace, Name: name}, zenlock); err != nil {
+					return fmt.Errorf("failed to get ZenLock %s: %w", fromZenLock, err)
+				}
+				if encryptedData, err = crypto.EncryptedDataAsBase64(zenlock.Spec.EncryptedData, zenlock.Spec.Encoding); err != nil {
+					return err
 				}
-				encryptedData[k] = val
 			}
 
-			// Initialize decryptor
-			encryptor := crypto.NewAgeEncryptor()
-
 			// Decrypt
-			decrypted, err := encryptor.DecryptMap(encryptedData, string(privateKeyData))
+			decrypted, err := crypto.NewAgeEncryptor().DecryptMap(encryptedData, string(privateKeyData))
 			if err != nil {
 				return fmt.Errorf("failed to decrypt: %w", err)
 			}
 
-			// Construct output YAML
-			stringData := make(map[string]string)
-			for k, v := range decrypted {
-				stringData[k] = string(v)
-			}
-
-			outputObj := map[string]interface{}{
-				"stringData": stringData,
-			}
-
-			// Marshal to YAML
-			outputData, err := yaml.Marshal(outputObj)
-			if err != nil {
-				return fmt.Errorf("failed to marshal YAML: %w", err)
+			var outputData []byte
+			if format == formatDockerEnv {
+				var skipped []string
+				outputData, skipped = formatDockerEnvFile(decrypted)
+				for _, k := range skipped {
+					fmt.Fprintf(os.Stderr, "⚠️  Skipping key %q: not a valid environment variable name\n", k)
+				}
+			} else if outputData, err = stringDataYAML(decrypted); err != nil {
+				return err
 			}
 
 			// Write output
@@ -108,8 +127,122 @@ committed to version control.`,
 	}
 
 	cmd.Flags().StringVarP(&privkey, "privkey", "k", "", "Private key file (required)")
-	cmd.Flags().StringVarP(&input, "input", "i", "", "Input ZenLock YAML file (required)")
+	cmd.Flags().StringVarP(&input, "input", "i", "", "Input ZenLock YAML file")
+	cmd.Flags().StringVar(&fromZenLock, "from-zenlock", "", "Read the ZenLock NAMESPACE/NAME from the cluster instead of --input")
 	cmd.Flags().StringVarP(&output, "output", "o", "", "Output file (default: stdout)")
+	cmd.Flags().StringVar(&format, "format", formatYAML, "Output format: yaml (stringData) or docker-env (KEY=value lines)")
+	cmd.Flags().BoolVar(&iUnderstandPlaintext, "i-understand-plaintext", false, "Confirm printing plaintext secrets in docker-env format")
 
 	return cmd
 }
+
+// Output formats of the decrypt command
+const (
+	formatYAML      = "yaml"
+	formatDockerEnv = "docker-env"
+)
+
+// envNamePattern matches keys usable as environment variable names
+var envNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// safeEnvValuePattern matches values that need no quoting in an env file or shell
+var safeEnvValuePattern = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]*$`)
+
+// formatDockerEnvFile renders decrypted data as sorted KEY=value lines
+// Values with spaces, quotes, newlines or other shell metacharacters are single-quoted (see quoteEnvValue)
+// so `source` restores every byte. Keys that are not valid environment variable names are returned as skipped.
+func formatDockerEnvFile(decrypted map[string][]byte) ([]byte, []string) {
+	keys := make([]string, 0, len(decrypted))
+	for k := range decrypted {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	var skipped []string
+	for _, k := range keys {
+		if !envNamePattern.MatchString(k) {
+			skipped = append(skipped, k)
+			continue
+		}
+		fmt.Fprintf(&b, "%s=%s\n", k, quoteEnvValue(string(decrypted[k])))
+	}
+	return []byte(b.String()), skipped
+}
+
+// quoteEnvValue returns the value unchanged if it is safe, otherwise single-quoted for a POSIX shell
+// An embedded single quote closes the quoting, is emitted escaped and reopens it.
+func quoteEnvValue(value string) string {
+	if safeEnvValuePattern.MatchString(value) {
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// manifestEncryptedData extracts spec.encryptedData from a ZenLock manifest
+func manifestEncryptedData(inputData []byte) (map[string]string, error) {
+	// Parse ZenLock YAML
+	var zenlock map[string]interface{}
+	if err := yaml.Unmarshal(inputData, &zenlock); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+
+	// Extract spec.encryptedData
+	spec, ok := zenlock["spec"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid ZenLock: missing 'spec' field")
+	}
+
+	encryptedDataRaw, ok := spec["encryptedData"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid ZenLock: missing 'spec.encryptedData' field")
+	}
+
+	// Convert to map[string]string
+	encryptedData := make(map[string]string)
+	for k, v := range encryptedDataRaw {
+		val, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("encrypted value for key %q must be a string", k)
+		}
+		encryptedData[k] = val
+	}
+
+	// Armored values (spec.encoding: armor) are converted to base64 for decryption
+	encoding, _ := spec["encoding"].(string)
+	return crypto.EncryptedDataAsBase64(encryptedData, encoding)
+}
+
+// stringDataYAML renders decrypted data as a stringData YAML document
+func stringDataYAML(decrypted map[string][]byte) ([]byte, error) {
+	stringData := make(map[string]string)
+	for k, v := range decrypted {
+		stringData[k] = string(v)
+	}
+
+	outputObj := map[string]interface{}{
+		"stringData": stringData,
+	}
+
+	// Marshal to YAML
+	outputData, err := yaml.Marshal(outputObj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal YAML: %w", err)
+	}
+	return outputData, nil
+}
+
+// decryptToStringData decrypts a ZenLock manifest into a stringData YAML document
+// Values keep their exact bytes, including CRLF and trailing newlines, so the output can be fed back to encrypt.
+func decryptToStringData(inputData []byte, identity string) ([]byte, error) {
+	encryptedData, err := manifestEncryptedData(inputData)
+	if err != nil {
+		return nil, err
+	}
+
+	decrypted, err := crypto.NewAgeEncryptor().DecryptMap(encryptedData, identity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
+	}
+	return stringDataYAML(decrypted)
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestQuoteEnvValue(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "plain", want: "plain"},
+		{value: "postgres://db:5432/app", want: "postgres://db:5432/app"},
+		{value: "", want: ""},
+		{value: "two words", want: "'two words'"},
+		{value: `say "hi"`, want: `'say "hi"'`},
+		{value: "it's", want: `'it'\''s'`},
+		{value: "line1\nline2\n", want: "'line1\nline2\n'"},
+		{value: "$HOME", want: "'$HOME'"},
+	}
+
+	for _, tt := range tests {
+		if got := quoteEnvValue(tt.value); got != tt.want {
+			t.Errorf("quoteEnvValue(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestFormatDockerEnvFile(t *testing.T) {
+	out, skipped := formatDockerEnvFile(map[string][]byte{
+		"USERNAME": []byte("admin"),
+		"PASSWORD": []byte("p@ss word"),
+		"tls.crt":  []byte("cert"),
+	})
+
+	want := "PASSWORD='p@ss word'\nUSERNAME=admin\n"
+	if string(out) != want {
+		t.Errorf("Expected %q, got %q", want, string(out))
+	}
+	if len(skipped) != 1 || skipped[0] != "tls.crt" {
+		t.Errorf("Expected tls.crt to be skipped, got %v", skipped)
+	}
+}
+
+func TestFormatDockerEnvFile_SourceRoundTrip(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh not available")
+	}
+
+	values := map[string]string{
+		"SPACES":   "a value with spaces",
+		"QUOTES":   `single ' and double " quotes`,
+		"NEWLINES": "-----BEGIN KEY-----\nabc\n-----END KEY-----\n",
+		"SHELL":    "$(echo no) `echo no` $HOME \\ ; & |",
+	}
+	decrypted := make(map[string][]byte, len(values))
+	for k, v := range values {
+		decrypted[k] = []byte(v)
+	}
+	out, _ := formatDockerEnvFile(decrypted)
+
+	envFile := filepath.Join(t.TempDir(), "secrets.env")
+	if err := os.WriteFile(envFile, out, 0600); err != nil {
+		t.Fatalf("Failed to write env file: %v", err)
+	}
+
+	for k, want := range values {
+		got, err := exec.Command(sh, "-c", `. "$1" && eval "v=\${$2}" && printf '%s' "$v"`, "sh", envFile, k).Output()
+		if err != nil {
+			t.Fatalf("Failed to source env file: %v", err)
+		}
+		if string(got) != want {
+			t.Errorf("Key %s: expected %q, got %q", k, want, string(got))
+		}
+	}
+}
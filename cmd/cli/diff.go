@@ -0,0 +1,168 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/kube-zen/zen-lock/pkg/crypto"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// changeKind describes how a key differs between two ZenLocks
+type changeKind string
+
+const (
+	changeAdded   changeKind = "added"
+	changeRemoved changeKind = "removed"
+	changeChanged changeKind = "changed"
+)
+
+// keyChange is a single key-level difference between two ZenLocks
+type keyChange struct {
+	key      string
+	kind     changeKind
+	oldValue []byte
+	newValue []byte
+}
+
+func newDiffCmd() *cobra.Command {
+	var privkey string
+	var showValues bool
+
+	cmd := &cobra.Command{
+		Use:   "diff OLD NEW",
+		Short: "Show plaintext changes between two ZenLock manifests",
+		Long: `Decrypt two ZenLock manifests and report which keys were added, removed
+or changed. Ciphertext differs on every encryption, so this is the only way
+to review what a ZenLock change actually does.
+
+Values are redacted unless --show-values is passed. The private key is read
+from --privkey or, if not set, from ZEN_LOCK_PRIVATE_KEY.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			identity := os.Getenv("ZEN_LOCK_PRIVATE_KEY")
+			if privkey != "" {
+				privateKeyData, err := os.ReadFile(privkey)
+				if err != nil {
+					return fmt.Errorf("failed to read private key file: %w", err)
+				}
+				identity = string(privateKeyData)
+			}
+			if identity == "" {
+				return fmt.Errorf("--privkey flag or ZEN_LOCK_PRIVATE_KEY environment variable is required")
+			}
+
+			encryptor := crypto.NewAgeEncryptor()
+			oldData, err := decryptManifest(encryptor, args[0], identity)
+			if err != nil {
+				return err
+			}
+			newData, err := decryptManifest(encryptor, args[1], identity)
+			if err != nil {
+				return err
+			}
+
+			printDiff(cmd.OutOrStdout(), diffDecrypted(oldData, newData), showValues)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&privkey, "privkey", "k", "", "Private key file (default: ZEN_LOCK_PRIVATE_KEY)")
+	cmd.Flags().BoolVar(&showValues, "show-values", false, "Print plaintext values of changed keys (never commit or share the output)")
+
+	return cmd
+}
+
+// decryptManifest reads a ZenLock manifest and decrypts its spec.encryptedData
+func decryptManifest(encryptor crypto.Encryptor, path, identity string) (map[string][]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var manifest struct {
+		Spec struct {
+			EncryptedData map[string]string `yaml:"encryptedData"`
+			Encoding      string            `yaml:"encoding"`
+		} `yaml:"spec"`
+	}
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if manifest.Spec.EncryptedData == nil {
+		return nil, fmt.Errorf("invalid ZenLock %s: missing 'spec.encryptedData' field", path)
+	}
+
+	encryptedData, err := crypto.EncryptedDataAsBase64(manifest.Spec.EncryptedData, manifest.Spec.Encoding)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ZenLock %s: %w", path, err)
+	}
+	decrypted, err := encryptor.DecryptMap(encryptedData, identity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt %s: %w", path, err)
+	}
+	return decrypted, nil
+}
+
+// diffDecrypted compares decrypted data and returns the changes sorted by key
+func diffDecrypted(oldData, newData map[string][]byte) []keyChange {
+	var changes []keyChange
+	for key, oldValue := range oldData {
+		newValue, ok := newData[key]
+		switch {
+		case !ok:
+			changes = append(changes, keyChange{key: key, kind: changeRemoved, oldValue: oldValue})
+		case !bytes.Equal(oldValue, newValue):
+			changes = append(changes, keyChange{key: key, kind: changeChanged, oldValue: oldValue, newValue: newValue})
+		}
+	}
+	for key, newValue := range newData {
+		if _, ok := oldData[key]; !ok {
+			changes = append(changes, keyChange{key: key, kind: changeAdded, newValue: newValue})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].key < changes[j].key })
+	return changes
+}
+
+// printDiff writes the changes, redacting values unless showValues is set
+func printDiff(out io.Writer, changes []keyChange, showValues bool) {
+	if len(changes) == 0 {
+		fmt.Fprintln(out, "No changes")
+		return
+	}
+
+	for _, c := range changes {
+		var marker string
+		switch c.kind {
+		case changeAdded:
+			marker = "+"
+		case changeRemoved:
+			marker = "-"
+		default:
+			marker = "~"
+		}
+
+		line := fmt.Sprintf("%s %s (%s)", marker, c.key, c.kind)
+		if showValues {
+			switch c.kind {
+			case changeAdded:
+				line += fmt.Sprintf(": %q", c.newValue)
+			case changeRemoved:
+				line += fmt.Sprintf(": %q", c.oldValue)
+			default:
+				line += fmt.Sprintf(": %q -> %q", c.oldValue, c.newValue)
+			}
+		}
+		fmt.Fprintln(out, line)
+	}
+
+	if showValues {
+		fmt.Fprintln(out, "⚠️  Output contains plaintext secrets. Do not share or commit it.")
+	}
+}
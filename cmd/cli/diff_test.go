@@ -0,0 +1,137 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+)
+
+func writeTestManifest(t *testing.T, dir, name string, encryptedData map[string]string) string {
+	var b strings.Builder
+	b.WriteString("apiVersion: security.kube-zen.io/v1alpha1\nkind: ZenLock\nmetadata:\n  name: app\nspec:\n  encryptedData:\n")
+	for k, v := range encryptedData {
+		fmt.Fprintf(&b, "    %s: %s\n", k, v)
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(b.String()), 0600); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+	return path
+}
+
+func runDiff(t *testing.T, showValues bool) string {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	recipient := identity.Recipient().String()
+	dir := t.TempDir()
+
+	oldPath := writeTestManifest(t, dir, "old.yaml", map[string]string{
+		"SAME":    encryptTestValue(t, "unchanged", recipient),
+		"CHANGED": encryptTestValue(t, "old-secret", recipient),
+		"REMOVED": encryptTestValue(t, "gone-secret", recipient),
+	})
+	newPath := writeTestManifest(t, dir, "new.yaml", map[string]string{
+		// Re-encrypted: ciphertext differs but the plaintext does not
+		"SAME":    encryptTestValue(t, "unchanged", recipient),
+		"CHANGED": encryptTestValue(t, "new-secret", recipient),
+		"ADDED":   encryptTestValue(t, "added-secret", recipient),
+	})
+
+	t.Setenv("ZEN_LOCK_PRIVATE_KEY", identity.String())
+	cmd := newDiffCmd()
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	args := []string{oldPath, newPath}
+	if showValues {
+		args = append(args, "--show-values")
+	}
+	cmd.SetArgs(args)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("diff failed: %v", err)
+	}
+	return out.String()
+}
+
+func TestDiffDecrypted(t *testing.T) {
+	changes := diffDecrypted(
+		map[string][]byte{"SAME": []byte("v"), "CHANGED": []byte("a"), "REMOVED": []byte("x")},
+		map[string][]byte{"SAME": []byte("v"), "CHANGED": []byte("b"), "ADDED": []byte("y")},
+	)
+
+	want := []struct {
+		key  string
+		kind changeKind
+	}{
+		{"ADDED", changeAdded},
+		{"CHANGED", changeChanged},
+		{"REMOVED", changeRemoved},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("Expected %d changes, got %+v", len(want), changes)
+	}
+	for i, w := range want {
+		if changes[i].key != w.key || changes[i].kind != w.kind {
+			t.Errorf("change %d = %s (%s), want %s (%s)", i, changes[i].key, changes[i].kind, w.key, w.kind)
+		}
+	}
+}
+
+func TestDiffCmd_RedactedByDefault(t *testing.T) {
+	out := runDiff(t, false)
+
+	for _, line := range []string{"+ ADDED (added)", "~ CHANGED (changed)", "- REMOVED (removed)"} {
+		if !strings.Contains(out, line) {
+			t.Errorf("Expected output to contain %q, got:\n%s", line, out)
+		}
+	}
+	if strings.Contains(out, "SAME") {
+		t.Errorf("Expected re-encrypted but unchanged key to be omitted, got:\n%s", out)
+	}
+	for _, secret := range []string{"old-secret", "new-secret", "gone-secret", "added-secret"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("Expected value %q to be redacted, got:\n%s", secret, out)
+		}
+	}
+}
+
+func TestDiffCmd_ShowValues(t *testing.T) {
+	out := runDiff(t, true)
+
+	if !strings.Contains(out, `"old-secret" -> "new-secret"`) {
+		t.Errorf("Expected changed values in output, got:\n%s", out)
+	}
+	if !strings.Contains(out, `"added-secret"`) || !strings.Contains(out, `"gone-secret"`) {
+		t.Errorf("Expected added and removed values in output, got:\n%s", out)
+	}
+}
+
+func TestDiffCmd_NoChanges(t *testing.T) {
+	out := &bytes.Buffer{}
+	printDiff(out, nil, false)
+	if strings.TrimSpace(out.String()) != "No changes" {
+		t.Errorf("Expected 'No changes', got %q", out.String())
+	}
+}
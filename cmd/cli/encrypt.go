@@ -14,13 +14,19 @@ func newEncryptCmd() *cobra.Command {
 	var pubkey string
 	var input string
 	var output string
+	var withChecksums bool
 
 	cmd := &cobra.Command{
 		Use:   "encrypt",
 		Short: "Encrypt a YAML file containing secret data",
 		Long: `Encrypt a YAML file containing secret data. The input file should have a
 'stringData' field with key-value pairs. The output will be a ZenLock CRD YAML
-file with encrypted data that can be safely committed to Git.`,
+file with encrypted data that can be safely committed to Git.
+
+Values are encrypted byte for byte, including trailing newlines. YAML folds CRLF
+line breaks inside block scalars to LF, so for values that must keep CRLF (or any
+exact bytes) either write them as double-quoted strings with \r\n escapes or put
+them base64-encoded under a 'data' field, as in a Kubernetes Secret.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if pubkey == "" {
 				return fmt.Errorf("--pubkey flag is required")
@@ -35,63 +41,9 @@ file with encrypted data that can be safely committed to Git.`,
 				return fmt.Errorf("failed to read input file: %w", err)
 			}
 
-			// Parse YAML
-			var obj map[string]interface{}
-			if err := yaml.Unmarshal(inputData, &obj); err != nil {
-				return fmt.Errorf("failed to parse YAML: %w", err)
-			}
-
-			// Extract stringData
-			stringData, ok := obj["stringData"].(map[string]interface{})
-			if !ok {
-				return fmt.Errorf("input YAML must contain a 'stringData' field with key-value pairs")
-			}
-
-			// Initialize encryptor
-			encryptor := crypto.NewAgeEncryptor()
-
-			// Encrypt each value
-			encryptedData := make(map[string]string)
-			for k, v := range stringData {
-				val, ok := v.(string)
-				if !ok {
-					return fmt.Errorf("value for key %q must be a string", k)
-				}
-
-				// Encrypt
-				ciphertext, err := encryptor.Encrypt([]byte(val), []string{pubkey})
-				if err != nil {
-					return fmt.Errorf("failed to encrypt key %q: %w", k, err)
-				}
-
-				// Base64 encode for storage
-				encryptedData[k] = base64.StdEncoding.EncodeToString(ciphertext)
-			}
-
-			// Construct ZenLock CRD
-			zenlock := map[string]interface{}{
-				"apiVersion": "security.kube-zen.io/v1alpha1",
-				"kind":       "ZenLock",
-				"metadata": map[string]interface{}{
-					"name": obj["metadata"].(map[string]interface{})["name"],
-				},
-				"spec": map[string]interface{}{
-					"encryptedData": encryptedData,
-					"algorithm":     "age",
-				},
-			}
-
-			// Add namespace if present
-			if metadata, ok := obj["metadata"].(map[string]interface{}); ok {
-				if ns, ok := metadata["namespace"].(string); ok {
-					zenlock["metadata"].(map[string]interface{})["namespace"] = ns
-				}
-			}
-
-			// Marshal to YAML
-			outputData, err := yaml.Marshal(zenlock)
+			outputData, err := encryptManifest(inputData, pubkey, withChecksums)
 			if err != nil {
-				return fmt.Errorf("failed to marshal YAML: %w", err)
+				return err
 			}
 
 			// Write output
@@ -109,8 +61,111 @@ file with encrypted data that can be safely committed to Git.`,
 	}
 
 	cmd.Flags().StringVarP(&pubkey, "pubkey", "p", "", "Public key for encryption (required)")
-	cmd.Flags().StringVarP(&input, "input", "i", "", "Input YAML file with stringData (required)")
+	cmd.Flags().StringVarP(&input, "input", "i", "", "Input YAML file with stringData and/or base64 data (required)")
 	cmd.Flags().StringVarP(&output, "output", "o", "", "Output file (default: stdout)")
+	cmd.Flags().BoolVar(&withChecksums, "checksums", false, "Include SHA-256 checksums of the plaintext values in spec.checksums")
 
 	return cmd
 }
+
+// encryptManifest encrypts the stringData and data entries of an input YAML document into a ZenLock manifest
+// data values are base64-decoded first, so arbitrary bytes (CRLF, binary) are preserved exactly.
+// The manifest only ever contains base64 ciphertext, so multiline plaintext cannot break its YAML.
+func encryptManifest(inputData []byte, pubkey string, withChecksums bool) ([]byte, error) {
+	// Parse YAML
+	var obj map[string]interface{}
+	if err := yaml.Unmarshal(inputData, &obj); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+
+	// Collect plaintext from stringData and base64 data
+	plaintexts := make(map[string][]byte)
+	if raw, ok := obj["stringData"]; ok {
+		stringData, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("'stringData' must be a map of key-value pairs")
+		}
+		for k, v := range stringData {
+			val, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("value for key %q must be a string", k)
+			}
+			plaintexts[k] = []byte(val)
+		}
+	}
+	if raw, ok := obj["data"]; ok {
+		data, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("'data' must be a map of key-value pairs")
+		}
+		for k, v := range data {
+			val, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("value for key %q must be a base64 string", k)
+			}
+			if _, dup := plaintexts[k]; dup {
+				return nil, fmt.Errorf("key %q is set in both 'stringData' and 'data'", k)
+			}
+			decoded, err := base64.StdEncoding.DecodeString(val)
+			if err != nil {
+				return nil, fmt.Errorf("value for key %q in 'data' is not valid base64: %w", k, err)
+			}
+			plaintexts[k] = decoded
+		}
+	}
+	if len(plaintexts) == 0 {
+		return nil, fmt.Errorf("input YAML must contain a 'stringData' field with key-value pairs")
+	}
+
+	// Initialize encryptor
+	encryptor := crypto.NewAgeEncryptor()
+
+	// Encrypt each value
+	encryptedData := make(map[string]string, len(plaintexts))
+	checksums := make(map[string]string)
+	for k, val := range plaintexts {
+		// Encrypt
+		ciphertext, err := encryptor.Encrypt(val, []string{pubkey})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt key %q: %w", k, err)
+		}
+
+		// Base64 encode for storage
+		encryptedData[k] = base64.StdEncoding.EncodeToString(ciphertext)
+		if withChecksums {
+			checksums[k] = crypto.Checksum(val)
+		}
+	}
+
+	// Construct ZenLock CRD
+	metadata := map[string]interface{}{}
+	zenlock := map[string]interface{}{
+		"apiVersion": "security.kube-zen.io/v1alpha1",
+		"kind":       "ZenLock",
+		"metadata":   metadata,
+		"spec": map[string]interface{}{
+			"encryptedData": encryptedData,
+			"algorithm":     "age",
+		},
+	}
+
+	// Add checksums if requested
+	if withChecksums {
+		zenlock["spec"].(map[string]interface{})["checksums"] = checksums
+	}
+
+	// Copy name and namespace if present
+	if inputMetadata, ok := obj["metadata"].(map[string]interface{}); ok {
+		metadata["name"] = inputMetadata["name"]
+		if ns, ok := inputMetadata["namespace"].(string); ok {
+			metadata["namespace"] = ns
+		}
+	}
+
+	// Marshal to YAML
+	outputData, err := yaml.Marshal(zenlock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal YAML: %w", err)
+	}
+	return outputData, nil
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kube-zen/zen-lock/pkg/crypto"
+)
+
+func newImportSecretCmd() *cobra.Command {
+	var recipients []string
+	var keys []string
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "import-secret NAMESPACE/NAME",
+		Short: "Convert an existing Kubernetes Secret into a ZenLock manifest",
+		Long: `Read a Secret from the cluster, encrypt each of its data entries to the given
+recipients and write a ZenLock manifest with the same name and namespace. The
+Secret itself is left untouched, so it can be deleted once the ZenLock is applied
+and the workloads are switched to zen-lock injection.
+
+Uses the current kubeconfig context. Non-Opaque Secret types are preserved in
+spec.secretType.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(recipients) == 0 {
+				return fmt.Errorf("--recipient flag is required")
+			}
+			namespace, name, ok := strings.Cut(args[0], "/")
+			if !ok || namespace == "" || name == "" {
+				return fmt.Errorf("secret must be given as NAMESPACE/NAME, got %q", args[0])
+			}
+
+			scheme := runtime.NewScheme()
+			utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+
+			cfg, err := ctrl.GetConfig()
+			if err != nil {
+				return fmt.Errorf("failed to load kubeconfig: %w", err)
+			}
+			c, err := client.New(cfg, client.Options{Scheme: scheme})
+			if err != nil {
+				return fmt.Errorf("failed to create Kubernetes client: %w", err)
+			}
+
+			zenlock, err := importSecret(cmd.Context(), c, types.NamespacedName{Namespace: namespace, Name: name}, recipients, keys)
+			if err != nil {
+				return err
+			}
+
+			outputData, err := yaml.Marshal(zenlock)
+			if err != nil {
+				return fmt.Errorf("failed to marshal YAML: %w", err)
+			}
+
+			if output == "" {
+				fmt.Fprint(os.Stdout, string(outputData))
+			} else {
+				if err := os.WriteFile(output, outputData, 0600); err != nil {
+					return fmt.Errorf("failed to write output file: %w", err)
+				}
+				fmt.Fprintf(os.Stderr, "✅ ZenLock for Secret %s written to: %s\n", args[0], output)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&recipients, "recipient", "r", nil, "Public key to encrypt to (required, repeatable)")
+	cmd.Flags().StringSliceVar(&keys, "keys", nil, "Comma-separated data keys to import (default: all keys)")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Output file (default: stdout)")
+
+	return cmd
+}
+
+// importSecret reads a Secret and returns a ZenLock manifest with its data encrypted to recipients
+// When keys is non-empty only those entries are imported, and each must exist in the Secret.
+func importSecret(ctx context.Context, c client.Client, key types.NamespacedName, recipients, keys []string) (map[string]interface{}, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, key, secret); err != nil {
+		return nil, fmt.Errorf("failed to get Secret %s: %w", key, err)
+	}
+
+	selected := keys
+	if len(selected) == 0 {
+		for k := range secret.Data {
+			selected = append(selected, k)
+		}
+		sort.Strings(selected)
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("secret %s has no data to import", key)
+	}
+
+	encryptor := crypto.NewAgeEncryptor()
+	encryptedData := make(map[string]string, len(selected))
+	for _, k := range selected {
+		value, ok := secret.Data[k]
+		if !ok {
+			return nil, fmt.Errorf("secret %s has no key %q", key, k)
+		}
+		ciphertext, err := encryptor.Encrypt(value, recipients)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt key %q: %w", k, err)
+		}
+		encryptedData[k] = base64.StdEncoding.EncodeToString(ciphertext)
+	}
+
+	spec := map[string]interface{}{
+		"encryptedData": encryptedData,
+		"algorithm":     "age",
+	}
+	if secret.Type != "" && secret.Type != corev1.SecretTypeOpaque {
+		spec["secretType"] = string(secret.Type)
+	}
+
+	return map[string]interface{}{
+		"apiVersion": "security.kube-zen.io/v1alpha1",
+		"kind":       "ZenLock",
+		"metadata": map[string]interface{}{
+			"name":      secret.Name,
+			"namespace": secret.Namespace,
+		},
+		"spec": spec,
+	}, nil
+}
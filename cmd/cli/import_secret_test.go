@@ -0,0 +1,145 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kube-zen/zen-lock/pkg/crypto"
+)
+
+func setupImportClient(t *testing.T, secret *corev1.Secret) client.Client {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(corev1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+}
+
+func TestImportSecret_RoundTrip(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "db-credentials", Namespace: "production"},
+		Type:       corev1.SecretTypeBasicAuth,
+		Data: map[string][]byte{
+			"username": []byte("admin"),
+			"password": []byte("s3cr3t\n"),
+			"binary":   {0x00, 0xff, 0x10},
+		},
+	}
+	c := setupImportClient(t, secret)
+
+	zenlock, err := importSecret(context.Background(), c, types.NamespacedName{Namespace: "production", Name: "db-credentials"}, []string{identity.Recipient().String()}, nil)
+	if err != nil {
+		t.Fatalf("importSecret() error = %v", err)
+	}
+
+	// Write the manifest and read it back the way diff does
+	out, err := yaml.Marshal(zenlock)
+	if err != nil {
+		t.Fatalf("Failed to marshal manifest: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "zenlock.yaml")
+	if err := os.WriteFile(path, out, 0600); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+	decrypted, err := decryptManifest(crypto.NewAgeEncryptor(), path, identity.String())
+	if err != nil {
+		t.Fatalf("Failed to decrypt imported manifest: %v", err)
+	}
+
+	if len(decrypted) != len(secret.Data) {
+		t.Fatalf("Expected %d keys, got %d", len(secret.Data), len(decrypted))
+	}
+	for k, want := range secret.Data {
+		if string(decrypted[k]) != string(want) {
+			t.Errorf("Key %q: expected %q, got %q", k, want, decrypted[k])
+		}
+	}
+
+	var manifest struct {
+		Kind     string `yaml:"kind"`
+		Metadata struct {
+			Name      string `yaml:"name"`
+			Namespace string `yaml:"namespace"`
+		} `yaml:"metadata"`
+		Spec struct {
+			SecretType string `yaml:"secretType"`
+		} `yaml:"spec"`
+	}
+	if err := yaml.Unmarshal(out, &manifest); err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+	if manifest.Kind != "ZenLock" || manifest.Metadata.Name != "db-credentials" || manifest.Metadata.Namespace != "production" {
+		t.Errorf("Unexpected manifest identity: %+v", manifest)
+	}
+	if manifest.Spec.SecretType != string(corev1.SecretTypeBasicAuth) {
+		t.Errorf("Expected secretType %q, got %q", corev1.SecretTypeBasicAuth, manifest.Spec.SecretType)
+	}
+}
+
+func TestImportSecret_Keys(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Data: map[string][]byte{
+			"API_KEY": []byte("key"),
+			"DEBUG":   []byte("true"),
+		},
+	}
+	c := setupImportClient(t, secret)
+	key := types.NamespacedName{Namespace: "default", Name: "app"}
+	recipients := []string{identity.Recipient().String()}
+
+	zenlock, err := importSecret(context.Background(), c, key, recipients, []string{"API_KEY"})
+	if err != nil {
+		t.Fatalf("importSecret() error = %v", err)
+	}
+	spec := zenlock["spec"].(map[string]interface{})
+	encryptedData := spec["encryptedData"].(map[string]string)
+	if len(encryptedData) != 1 || encryptedData["API_KEY"] == "" {
+		t.Errorf("Expected only API_KEY to be imported, got %v", encryptedData)
+	}
+	if _, ok := spec["secretType"]; ok {
+		t.Error("Expected no secretType for an Opaque Secret")
+	}
+
+	if _, err := importSecret(context.Background(), c, key, recipients, []string{"MISSING"}); err == nil {
+		t.Error("Expected error for a key missing from the Secret")
+	}
+	if _, err := importSecret(context.Background(), c, types.NamespacedName{Namespace: "default", Name: "absent"}, recipients, nil); err == nil {
+		t.Error("Expected error for a missing Secret")
+	}
+}
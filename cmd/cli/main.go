@@ -27,6 +27,15 @@ ZenLock CRDs store only ciphertext (source-of-truth). During Pod injection, zen-
 	rootCmd.AddCommand(newPubkeyCmd())
 	rootCmd.AddCommand(newEncryptCmd())
 	rootCmd.AddCommand(newDecryptCmd())
+	rootCmd.AddCommand(newClusterRotateCmd())
+	rootCmd.AddCommand(newDiffCmd())
+	rootCmd.AddCommand(newImportSecretCmd())
+	rootCmd.AddCommand(newCheckConfigCmd())
+	rootCmd.AddCommand(newSimulateCmd())
+	rootCmd.AddCommand(newReportCmd())
+	rootCmd.AddCommand(newMetricsCmd())
+	rootCmd.AddCommand(newAlgorithmsCmd())
+	rootCmd.AddCommand(newVersionCmd())
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
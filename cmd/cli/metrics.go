@@ -0,0 +1,251 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/common/model"
+	"github.com/spf13/cobra"
+)
+
+// Series summarized by the metrics command, as exported by pkg/controller/metrics
+const (
+	metricInjectionTotal     = "zenlock_webhook_injection_total"
+	metricCacheHitsTotal     = "zenlock_cache_hits_total"
+	metricCacheMissesTotal   = "zenlock_cache_misses_total"
+	metricDecryptionDuration = "zenlock_decryption_duration_seconds"
+)
+
+// metricsScrapeTimeout bounds the request to the metrics endpoint
+const metricsScrapeTimeout = 10 * time.Second
+
+// metricsSummary is the output of the metrics command
+type metricsSummary struct {
+	// Injections counts webhook injections by result (success, denied, error)
+	Injections  map[string]float64 `json:"injections"`
+	CacheHits   float64            `json:"cacheHits"`
+	CacheMisses float64            `json:"cacheMisses"`
+	// CacheHitRate is hits / (hits + misses); nil before the first lookup
+	CacheHitRate *float64 `json:"cacheHitRate,omitempty"`
+	Decryptions  float64  `json:"decryptions"`
+	// DecryptP99Seconds is estimated from the histogram buckets like histogram_quantile; nil without decryptions
+	DecryptP99Seconds *float64 `json:"decryptP99Seconds,omitempty"`
+}
+
+func newMetricsCmd() *cobra.Command {
+	var webhookURL string
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "metrics",
+		Short: "Summarize the webhook's zen-lock metrics",
+		Long: `Scrape the metrics endpoint of a zen-lock webhook and print a summary of the
+zen-lock series: injections by result, the ZenLock cache hit rate and the p99
+decryption latency, all since the webhook started. No Prometheus is needed, e.g.:
+
+  kubectl port-forward -n zen-lock-system deploy/zen-lock-webhook 8080
+  zen-lock metrics --webhook-url http://localhost:8080
+
+The path defaults to /metrics when the URL has none.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != formatTable && format != formatJSON {
+				return fmt.Errorf("invalid --format %q (must be %s or %s)", format, formatTable, formatJSON)
+			}
+			ctx, cancel := context.WithTimeout(cmd.Context(), metricsScrapeTimeout)
+			defer cancel()
+			return runMetrics(ctx, http.DefaultClient, webhookURL, format, os.Stdout)
+		},
+	}
+
+	cmd.Flags().StringVar(&webhookURL, "webhook-url", "", "URL of the webhook's metrics endpoint (required)")
+	cmd.Flags().StringVar(&format, "format", formatTable, "Output format: table or json")
+	_ = cmd.MarkFlagRequired("webhook-url")
+
+	return cmd
+}
+
+// runMetrics scrapes the metrics endpoint at webhookURL and prints the summary to out
+func runMetrics(ctx context.Context, httpClient *http.Client, webhookURL, format string, out io.Writer) error {
+	endpoint, err := metricsEndpoint(webhookURL)
+	if err != nil {
+		return err
+	}
+	families, err := scrapeMetrics(ctx, httpClient, endpoint)
+	if err != nil {
+		return err
+	}
+	return printMetricsSummary(out, summarizeMetrics(families), format)
+}
+
+// metricsEndpoint returns webhookURL with the /metrics path when it has none
+func metricsEndpoint(webhookURL string) (string, error) {
+	u, err := url.Parse(webhookURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("invalid --webhook-url %q: must be an absolute http(s) URL", webhookURL)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = "/metrics"
+	}
+	return u.String(), nil
+}
+
+// scrapeMetrics fetches and parses the Prometheus text exposition at endpoint
+func scrapeMetrics(ctx context.Context, httpClient *http.Client, endpoint string) (map[string]*dto.MetricFamily, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	// Ask for the text format: the parser does not read protobuf or OpenMetrics
+	req.Header.Set("Accept", string(expfmt.NewFormat(expfmt.TypeTextPlain)))
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scrape %s: %w", endpoint, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to scrape %s: %s", endpoint, resp.Status)
+	}
+
+	parser := expfmt.NewTextParser(model.UTF8Validation)
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse metrics from %s: %w", endpoint, err)
+	}
+	return families, nil
+}
+
+// summarizeMetrics aggregates the zen-lock series over all namespaces and ZenLocks
+func summarizeMetrics(families map[string]*dto.MetricFamily) *metricsSummary {
+	summary := &metricsSummary{Injections: make(map[string]float64)}
+
+	if family := families[metricInjectionTotal]; family != nil {
+		for _, m := range family.GetMetric() {
+			summary.Injections[labelValue(m, "result")] += m.GetCounter().GetValue()
+		}
+	}
+
+	summary.CacheHits = sumCounter(families[metricCacheHitsTotal])
+	summary.CacheMisses = sumCounter(families[metricCacheMissesTotal])
+	if lookups := summary.CacheHits + summary.CacheMisses; lookups > 0 {
+		rate := summary.CacheHits / lookups
+		summary.CacheHitRate = &rate
+	}
+
+	if family := families[metricDecryptionDuration]; family != nil {
+		count, p99 := histogramQuantile(0.99, family.GetMetric())
+		summary.Decryptions = count
+		if count > 0 {
+			summary.DecryptP99Seconds = &p99
+		}
+	}
+
+	return summary
+}
+
+// labelValue returns the value of the named label of m ("" when absent)
+func labelValue(m *dto.Metric, name string) string {
+	for _, pair := range m.GetLabel() {
+		if pair.GetName() == name {
+			return pair.GetValue()
+		}
+	}
+	return ""
+}
+
+// sumCounter adds up every series of a counter family
+func sumCounter(family *dto.MetricFamily) float64 {
+	total := 0.0
+	for _, m := range family.GetMetric() {
+		total += m.GetCounter().GetValue()
+	}
+	return total
+}
+
+// histogramQuantile merges the histogram series and estimates quantile q by linear interpolation within
+// the bucket holding it, as PromQL's histogram_quantile does. Observations above the highest finite bucket
+// are reported at that bound. It returns the number of observations and the estimate.
+func histogramQuantile(q float64, metrics []*dto.Metric) (count, value float64) {
+	cumulative := make(map[float64]float64)
+	for _, m := range metrics {
+		h := m.GetHistogram()
+		count += float64(h.GetSampleCount())
+		for _, b := range h.GetBucket() {
+			if !math.IsInf(b.GetUpperBound(), +1) {
+				cumulative[b.GetUpperBound()] += float64(b.GetCumulativeCount())
+			}
+		}
+	}
+	if count == 0 || len(cumulative) == 0 {
+		return count, 0
+	}
+
+	bounds := make([]float64, 0, len(cumulative))
+	for bound := range cumulative {
+		bounds = append(bounds, bound)
+	}
+	sort.Float64s(bounds)
+
+	rank := q * count
+	lowerBound, lowerCount := 0.0, 0.0
+	for _, bound := range bounds {
+		if upperCount := cumulative[bound]; upperCount >= rank {
+			if upperCount == lowerCount {
+				return count, bound
+			}
+			return count, lowerBound + (bound-lowerBound)*(rank-lowerCount)/(upperCount-lowerCount)
+		}
+		lowerBound, lowerCount = bound, cumulative[bound]
+	}
+	return count, bounds[len(bounds)-1]
+}
+
+// printMetricsSummary writes the summary as aligned text or a JSON document
+func printMetricsSummary(out io.Writer, summary *metricsSummary, format string) error {
+	switch format {
+	case formatJSON:
+		data, err := json.MarshalIndent(summary, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal metrics summary: %w", err)
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	case formatTable:
+		results := make([]string, 0, len(summary.Injections))
+		total := 0.0
+		for result, n := range summary.Injections {
+			results = append(results, result)
+			total += n
+		}
+		sort.Strings(results)
+
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "Injections:\t%.0f\n", total)
+		for _, result := range results {
+			fmt.Fprintf(w, "  %s\t%.0f\n", result, summary.Injections[result])
+		}
+		hitRate := "n/a"
+		if summary.CacheHitRate != nil {
+			hitRate = fmt.Sprintf("%.1f%%", *summary.CacheHitRate*100)
+		}
+		fmt.Fprintf(w, "Cache hit rate:\t%s (%.0f hits, %.0f misses)\n", hitRate, summary.CacheHits, summary.CacheMisses)
+		p99 := "n/a"
+		if summary.DecryptP99Seconds != nil {
+			p99 = time.Duration(*summary.DecryptP99Seconds * float64(time.Second)).Round(time.Microsecond).String()
+		}
+		fmt.Fprintf(w, "Decrypt p99:\t%s (%.0f decryptions)\n", p99, summary.Decryptions)
+		return w.Flush()
+	default:
+		return fmt.Errorf("invalid --format %q (must be %s or %s)", format, formatTable, formatJSON)
+	}
+}
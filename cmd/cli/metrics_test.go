@@ -0,0 +1,148 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// testMetricsExposition is a /metrics page with two ZenLocks' worth of zen-lock series
+const testMetricsExposition = `# HELP zenlock_webhook_injection_total Total number of webhook secret injections
+# TYPE zenlock_webhook_injection_total counter
+zenlock_webhook_injection_total{namespace="default",result="success",zenlock_name="db"} 12
+zenlock_webhook_injection_total{namespace="team-a",result="success",zenlock_name="api"} 8
+zenlock_webhook_injection_total{namespace="team-a",result="denied",zenlock_name="api"} 3
+# HELP zenlock_cache_hits_total Total number of ZenLock cache hits
+# TYPE zenlock_cache_hits_total counter
+zenlock_cache_hits_total{namespace="default",zenlock_name="db"} 20
+zenlock_cache_hits_total{namespace="team-a",zenlock_name="api"} 10
+# HELP zenlock_cache_misses_total Total number of ZenLock cache misses
+# TYPE zenlock_cache_misses_total counter
+zenlock_cache_misses_total{namespace="default",zenlock_name="db"} 4
+zenlock_cache_misses_total{namespace="team-a",zenlock_name="api"} 6
+# HELP zenlock_decryption_duration_seconds Duration of decryption operations in seconds
+# TYPE zenlock_decryption_duration_seconds histogram
+zenlock_decryption_duration_seconds_bucket{namespace="default",zenlock_name="db",le="0.001"} 30
+zenlock_decryption_duration_seconds_bucket{namespace="default",zenlock_name="db",le="0.002"} 55
+zenlock_decryption_duration_seconds_bucket{namespace="default",zenlock_name="db",le="0.004"} 60
+zenlock_decryption_duration_seconds_bucket{namespace="default",zenlock_name="db",le="+Inf"} 60
+zenlock_decryption_duration_seconds_sum{namespace="default",zenlock_name="db"} 0.09
+zenlock_decryption_duration_seconds_count{namespace="default",zenlock_name="db"} 60
+zenlock_decryption_duration_seconds_bucket{namespace="team-a",zenlock_name="api",le="0.001"} 20
+zenlock_decryption_duration_seconds_bucket{namespace="team-a",zenlock_name="api",le="0.002"} 35
+zenlock_decryption_duration_seconds_bucket{namespace="team-a",zenlock_name="api",le="0.004"} 40
+zenlock_decryption_duration_seconds_bucket{namespace="team-a",zenlock_name="api",le="+Inf"} 40
+zenlock_decryption_duration_seconds_sum{namespace="team-a",zenlock_name="api"} 0.06
+zenlock_decryption_duration_seconds_count{namespace="team-a",zenlock_name="api"} 40
+# HELP go_goroutines Number of goroutines that currently exist.
+# TYPE go_goroutines gauge
+go_goroutines 42
+`
+
+// fakeMetricsServer serves body at /metrics and records the requested paths
+func fakeMetricsServer(t *testing.T, body string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metrics" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRunMetrics_JSON(t *testing.T) {
+	server := fakeMetricsServer(t, testMetricsExposition)
+
+	var out bytes.Buffer
+	if err := runMetrics(context.Background(), server.Client(), server.URL, formatJSON, &out); err != nil {
+		t.Fatalf("runMetrics() error = %v", err)
+	}
+	var summary metricsSummary
+	if err := json.Unmarshal(out.Bytes(), &summary); err != nil {
+		t.Fatalf("Failed to parse summary: %v\n%s", err, out.String())
+	}
+
+	if summary.Injections["success"] != 20 || summary.Injections["denied"] != 3 {
+		t.Errorf("Expected 20 successful and 3 denied injections, got %v", summary.Injections)
+	}
+	// 30 hits out of 40 lookups across both ZenLocks
+	if summary.CacheHitRate == nil || *summary.CacheHitRate != 0.75 {
+		t.Errorf("Expected a cache hit rate of 0.75, got %v", summary.CacheHitRate)
+	}
+	// 100 decryptions: the 99th falls 9/10 into the merged (0.002, 0.004] bucket holding 90..100
+	if summary.Decryptions != 100 || summary.DecryptP99Seconds == nil || math.Abs(*summary.DecryptP99Seconds-0.0038) > 1e-9 {
+		t.Errorf("Expected a decrypt p99 of 0.0038s over 100 decryptions, got %v over %v", summary.DecryptP99Seconds, summary.Decryptions)
+	}
+}
+
+func TestRunMetrics_Table(t *testing.T) {
+	server := fakeMetricsServer(t, testMetricsExposition)
+
+	var out bytes.Buffer
+	if err := runMetrics(context.Background(), server.Client(), server.URL+"/", formatTable, &out); err != nil {
+		t.Fatalf("runMetrics() error = %v", err)
+	}
+	for _, want := range []string{"Injections:", "denied", "75.0% (30 hits, 10 misses)", "3.8ms (100 decryptions)"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected the summary to contain %q, got:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestRunMetrics_NoZenLockSeries(t *testing.T) {
+	server := fakeMetricsServer(t, "# TYPE go_goroutines gauge\ngo_goroutines 42\n")
+
+	var out bytes.Buffer
+	if err := runMetrics(context.Background(), server.Client(), server.URL, formatTable, &out); err != nil {
+		t.Fatalf("runMetrics() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "Cache hit rate:  n/a") || !strings.Contains(out.String(), "Decrypt p99:     n/a") {
+		t.Errorf("Expected n/a without zen-lock series, got:\n%s", out.String())
+	}
+}
+
+func TestRunMetrics_Errors(t *testing.T) {
+	server := fakeMetricsServer(t, testMetricsExposition)
+
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{name: "relative URL", url: "localhost:8080", want: "invalid --webhook-url"},
+		{name: "not found", url: server.URL + "/other", want: "404"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runMetrics(context.Background(), server.Client(), tt.url, formatTable, &bytes.Buffer{})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("runMetrics() error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
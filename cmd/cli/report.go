@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/common"
+	"github.com/kube-zen/zen-lock/pkg/crypto"
+)
+
+// zenLockReportEntry summarizes a single ZenLock in the report
+type zenLockReportEntry struct {
+	Namespace  string `json:"namespace"`
+	Name       string `json:"name"`
+	Keys       int    `json:"keys"`
+	Algorithm  string `json:"algorithm"`
+	Subjects   int    `json:"subjects"`
+	Phase      string `json:"phase"`
+	Injections int    `json:"injections"`
+}
+
+// zenLockReportTotals aggregates the report entries
+type zenLockReportTotals struct {
+	ZenLocks   int `json:"zenLocks"`
+	Keys       int `json:"keys"`
+	Injections int `json:"injections"`
+}
+
+// zenLockReport is the output of the report command
+type zenLockReport struct {
+	ZenLocks []zenLockReportEntry `json:"zenLocks"`
+	Totals   zenLockReportTotals  `json:"totals"`
+}
+
+func newReportCmd() *cobra.Command {
+	var namespace string
+	var selector string
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Report ZenLock usage across the cluster",
+		Long: `List every ZenLock with its key count, algorithm, subject count, phase and
+current injection count, i.e. the number of injection Secrets labeled with the
+ZenLock's name. Uses the current kubeconfig context.
+
+Use --namespace and --selector to limit the report; injections are counted for the
+reported ZenLocks only.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != formatTable && format != formatJSON {
+				return fmt.Errorf("invalid --format %q (must be %s or %s)", format, formatTable, formatJSON)
+			}
+
+			scheme := runtime.NewScheme()
+			utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+			utilruntime.Must(securityv1alpha1.AddToScheme(scheme))
+
+			cfg, err := ctrl.GetConfig()
+			if err != nil {
+				return fmt.Errorf("failed to load kubeconfig: %w", err)
+			}
+			c, err := client.New(cfg, client.Options{Scheme: scheme})
+			if err != nil {
+				return fmt.Errorf("failed to create Kubernetes client: %w", err)
+			}
+
+			return runReport(cmd.Context(), c, namespace, selector, format, os.Stdout)
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Only report ZenLocks in this namespace (default: all namespaces)")
+	cmd.Flags().StringVarP(&selector, "selector", "l", "", "Only report ZenLocks matching this label selector")
+	cmd.Flags().StringVar(&format, "format", formatTable, "Output format: table or json")
+
+	return cmd
+}
+
+// runReport builds the usage report for the matching ZenLocks and prints it to out
+func runReport(ctx context.Context, c client.Client, namespace, selector, format string, out io.Writer) error {
+	report, err := buildReport(ctx, c, namespace, selector)
+	if err != nil {
+		return err
+	}
+	return printReport(out, report, format)
+}
+
+// buildReport lists the matching ZenLocks and counts the injection Secrets of each
+func buildReport(ctx context.Context, c client.Client, namespace, selector string) (*zenLockReport, error) {
+	listOpts := []client.ListOption{}
+	if namespace != "" {
+		listOpts = append(listOpts, client.InNamespace(namespace))
+	}
+	if selector != "" {
+		sel, err := labels.Parse(selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --selector %q: %w", selector, err)
+		}
+		listOpts = append(listOpts, client.MatchingLabelsSelector{Selector: sel})
+	}
+
+	zenlockList := &securityv1alpha1.ZenLockList{}
+	if err := c.List(ctx, zenlockList, listOpts...); err != nil {
+		return nil, fmt.Errorf("failed to list ZenLocks: %w", err)
+	}
+
+	secretOpts := []client.ListOption{client.HasLabels{common.ZenLockNameLabel()}}
+	if namespace != "" {
+		secretOpts = append(secretOpts, client.InNamespace(namespace))
+	}
+	secretList := &corev1.SecretList{}
+	if err := c.List(ctx, secretList, secretOpts...); err != nil {
+		return nil, fmt.Errorf("failed to list Secrets: %w", err)
+	}
+
+	injections := make(map[types.NamespacedName]int)
+	for i := range secretList.Items {
+		secret := &secretList.Items[i]
+		if secret.DeletionTimestamp != nil {
+			continue
+		}
+		key := types.NamespacedName{Namespace: secret.Namespace, Name: secret.Labels[common.ZenLockNameLabel()]}
+		injections[key]++
+	}
+
+	report := &zenLockReport{ZenLocks: make([]zenLockReportEntry, 0, len(zenlockList.Items))}
+	for i := range zenlockList.Items {
+		zl := &zenlockList.Items[i]
+		entry := zenLockReportEntry{
+			Namespace:  zl.Namespace,
+			Name:       zl.Name,
+			Keys:       len(zl.Spec.EncryptedData),
+			Algorithm:  crypto.ResolveAlgorithm(zl.Spec.Algorithm),
+			Subjects:   len(zl.Spec.AllowedSubjects),
+			Phase:      zl.Status.Phase,
+			Injections: injections[types.NamespacedName{Namespace: zl.Namespace, Name: zl.Name}],
+		}
+		report.ZenLocks = append(report.ZenLocks, entry)
+		report.Totals.ZenLocks++
+		report.Totals.Keys += entry.Keys
+		report.Totals.Injections += entry.Injections
+	}
+
+	sort.Slice(report.ZenLocks, func(i, j int) bool {
+		if report.ZenLocks[i].Namespace != report.ZenLocks[j].Namespace {
+			return report.ZenLocks[i].Namespace < report.ZenLocks[j].Namespace
+		}
+		return report.ZenLocks[i].Name < report.ZenLocks[j].Name
+	})
+
+	return report, nil
+}
+
+// printReport writes the report as an aligned table or a JSON document
+func printReport(out io.Writer, report *zenLockReport, format string) error {
+	switch format {
+	case formatJSON:
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal report: %w", err)
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	case formatTable:
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAMESPACE\tNAME\tKEYS\tALGORITHM\tSUBJECTS\tPHASE\tINJECTIONS")
+		for _, e := range report.ZenLocks {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%d\t%s\t%d\n", e.Namespace, e.Name, e.Keys, e.Algorithm, e.Subjects, e.Phase, e.Injections)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "\n%d ZenLocks, %d keys, %d injections\n", report.Totals.ZenLocks, report.Totals.Keys, report.Totals.Injections)
+		return nil
+	default:
+		return fmt.Errorf("invalid --format %q (must be %s or %s)", format, formatTable, formatJSON)
+	}
+}
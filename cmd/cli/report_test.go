@@ -0,0 +1,167 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/common"
+)
+
+func testReportZenLock(namespace, name string, keys, subjects int, phase string, lbls map[string]string) *securityv1alpha1.ZenLock {
+	zl := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: lbls},
+		Spec:       securityv1alpha1.ZenLockSpec{EncryptedData: map[string]string{}},
+		Status:     securityv1alpha1.ZenLockStatus{Phase: phase},
+	}
+	for i := 0; i < keys; i++ {
+		zl.Spec.EncryptedData[string(rune('A'+i))] = "ciphertext"
+	}
+	for i := 0; i < subjects; i++ {
+		zl.Spec.AllowedSubjects = append(zl.Spec.AllowedSubjects, securityv1alpha1.SubjectReference{
+			Kind: "ServiceAccount", Name: "sa", Namespace: namespace,
+		})
+	}
+	return zl
+}
+
+func testInjectionSecret(namespace, name, zenlockName string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{common.ZenLockNameLabel(): zenlockName},
+		},
+	}
+}
+
+func setupReportClient(t *testing.T) client.Client {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(securityv1alpha1.AddToScheme(scheme))
+
+	objs := []client.Object{
+		testReportZenLock("team-a", "db", 2, 1, "Ready", map[string]string{"tier": "backend"}),
+		testReportZenLock("team-a", "api", 3, 0, "Ready", map[string]string{"tier": "frontend"}),
+		testReportZenLock("team-b", "db", 1, 2, "Error", map[string]string{"tier": "backend"}),
+		testInjectionSecret("team-a", "zen-lock-inject-team-a-pod-1", "db"),
+		testInjectionSecret("team-a", "zen-lock-inject-team-a-pod-2", "db"),
+		testInjectionSecret("team-a", "zen-lock-inject-team-a-pod-3", "api"),
+		testInjectionSecret("team-b", "zen-lock-inject-team-b-pod-1", "db"),
+		// Unrelated Secret without the ZenLock label must not be counted
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "team-a"}},
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).WithStatusSubresource(&securityv1alpha1.ZenLock{}).Build()
+}
+
+func runReportJSON(t *testing.T, c client.Client, namespace, selector string) zenLockReport {
+	var out bytes.Buffer
+	if err := runReport(context.Background(), c, namespace, selector, formatJSON, &out); err != nil {
+		t.Fatalf("runReport failed: %v", err)
+	}
+	var report zenLockReport
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("Failed to parse report JSON: %v\n%s", err, out.String())
+	}
+	return report
+}
+
+func TestRunReport_AllNamespaces(t *testing.T) {
+	report := runReportJSON(t, setupReportClient(t), "", "")
+
+	expected := []zenLockReportEntry{
+		{Namespace: "team-a", Name: "api", Keys: 3, Algorithm: "age", Subjects: 0, Phase: "Ready", Injections: 1},
+		{Namespace: "team-a", Name: "db", Keys: 2, Algorithm: "age", Subjects: 1, Phase: "Ready", Injections: 2},
+		{Namespace: "team-b", Name: "db", Keys: 1, Algorithm: "age", Subjects: 2, Phase: "Error", Injections: 1},
+	}
+	if len(report.ZenLocks) != len(expected) {
+		t.Fatalf("Expected %d entries, got %d: %+v", len(expected), len(report.ZenLocks), report.ZenLocks)
+	}
+	for i, e := range expected {
+		if report.ZenLocks[i] != e {
+			t.Errorf("Entry %d: expected %+v, got %+v", i, e, report.ZenLocks[i])
+		}
+	}
+
+	if report.Totals != (zenLockReportTotals{ZenLocks: 3, Keys: 6, Injections: 4}) {
+		t.Errorf("Unexpected totals: %+v", report.Totals)
+	}
+}
+
+func TestRunReport_NamespaceFilter(t *testing.T) {
+	report := runReportJSON(t, setupReportClient(t), "team-b", "")
+
+	if len(report.ZenLocks) != 1 || report.ZenLocks[0].Namespace != "team-b" {
+		t.Fatalf("Expected only the team-b ZenLock, got %+v", report.ZenLocks)
+	}
+	if report.Totals != (zenLockReportTotals{ZenLocks: 1, Keys: 1, Injections: 1}) {
+		t.Errorf("Unexpected totals: %+v", report.Totals)
+	}
+}
+
+func TestRunReport_SelectorFilter(t *testing.T) {
+	report := runReportJSON(t, setupReportClient(t), "", "tier=backend")
+
+	if len(report.ZenLocks) != 2 {
+		t.Fatalf("Expected 2 backend ZenLocks, got %+v", report.ZenLocks)
+	}
+	for _, e := range report.ZenLocks {
+		if e.Name != "db" {
+			t.Errorf("Unexpected ZenLock %s/%s in backend report", e.Namespace, e.Name)
+		}
+	}
+	if report.Totals != (zenLockReportTotals{ZenLocks: 2, Keys: 3, Injections: 3}) {
+		t.Errorf("Unexpected totals: %+v", report.Totals)
+	}
+}
+
+func TestRunReport_InvalidSelector(t *testing.T) {
+	var out bytes.Buffer
+	if err := runReport(context.Background(), setupReportClient(t), "", "tier in (", formatJSON, &out); err == nil {
+		t.Error("Expected an error for an invalid selector")
+	}
+}
+
+func TestRunReport_Table(t *testing.T) {
+	var out bytes.Buffer
+	if err := runReport(context.Background(), setupReportClient(t), "team-a", "", formatTable, &out); err != nil {
+		t.Fatalf("runReport failed: %v", err)
+	}
+	output := out.String()
+	for _, want := range []string{"NAMESPACE", "INJECTIONS", "team-a", "2 ZenLocks, 5 keys, 3 injections"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected table output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	if err := runReport(context.Background(), setupReportClient(t), "", "", "yaml", &out); err == nil {
+		t.Error("Expected an error for an invalid format")
+	}
+}
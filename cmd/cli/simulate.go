@@ -0,0 +1,218 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/spf13/cobra"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/yaml"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/common"
+	"github.com/kube-zen/zen-lock/pkg/webhook"
+)
+
+func newSimulateCmd() *cobra.Command {
+	var podFile string
+	var zenlockFiles []string
+
+	cmd := &cobra.Command{
+		Use:   "simulate",
+		Short: "Preview how the webhook would inject a ZenLock into a Pod",
+		Long: `Run the webhook's injection logic locally against a Pod manifest and one or
+more ZenLock manifests, without a cluster. The ZenLocks are decrypted with
+ZEN_LOCK_PRIVATE_KEY (or ZEN_LOCK_IDENTITIES_DIR), exactly as the webhook would.
+
+Prints the mutated Pod, followed by the key names of every Secret the webhook
+would create. Secret values are never printed.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if podFile == "" {
+				return fmt.Errorf("--pod flag is required")
+			}
+			if len(zenlockFiles) == 0 {
+				return fmt.Errorf("--zenlock flag is required")
+			}
+
+			pod := &corev1.Pod{}
+			if err := readObjectFile(podFile, pod); err != nil {
+				return err
+			}
+			zenlocks := make([]*securityv1alpha1.ZenLock, 0, len(zenlockFiles))
+			for _, path := range zenlockFiles {
+				zenlock := &securityv1alpha1.ZenLock{}
+				if err := readObjectFile(path, zenlock); err != nil {
+					return err
+				}
+				zenlocks = append(zenlocks, zenlock)
+			}
+
+			result, err := simulateInjection(cmd.Context(), pod, zenlocks)
+			if err != nil {
+				return err
+			}
+			for _, warning := range result.warnings {
+				fmt.Fprintf(os.Stderr, "⚠️  %s\n", warning)
+			}
+			return printSimulation(os.Stdout, result)
+		},
+	}
+
+	cmd.Flags().StringVar(&podFile, "pod", "", "Pod manifest to inject into (required)")
+	cmd.Flags().StringArrayVar(&zenlockFiles, "zenlock", nil, "ZenLock manifest, repeatable (required)")
+
+	return cmd
+}
+
+// simulatedSecret describes a Secret the webhook would create, without its values
+type simulatedSecret struct {
+	name    string
+	zenlock string
+	keys    []string
+}
+
+// simulationResult is the outcome of a local injection
+type simulationResult struct {
+	pod      *corev1.Pod
+	secrets  []simulatedSecret
+	warnings []string
+}
+
+// readObjectFile decodes a YAML or JSON Kubernetes manifest into obj
+func readObjectFile(path string, obj interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := yaml.UnmarshalStrict(data, obj); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return nil
+}
+
+// simulateInjection runs the webhook's PodHandler in-process against an in-memory cluster holding the ZenLocks
+// The admission request is a regular Pod CREATE, so Secrets are "created" in the in-memory client only,
+// which is where their key names are read from.
+func simulateInjection(ctx context.Context, pod *corev1.Pod, zenlocks []*securityv1alpha1.ZenLock) (*simulationResult, error) {
+	pod = pod.DeepCopy()
+	if pod.Namespace == "" {
+		pod.Namespace = "default"
+	}
+
+	objs := make([]client.Object, 0, len(zenlocks))
+	for _, zenlock := range zenlocks {
+		zenlock = zenlock.DeepCopy()
+		if zenlock.Namespace == "" {
+			zenlock.Namespace = pod.Namespace
+		}
+		if zenlock.Namespace != pod.Namespace {
+			return nil, fmt.Errorf("ZenLock %s/%s is not in the Pod's namespace %q", zenlock.Namespace, zenlock.Name, pod.Namespace)
+		}
+		objs = append(objs, zenlock)
+	}
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(securityv1alpha1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+
+	handler, err := webhook.NewPodHandler(c, scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := json.Marshal(pod)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode Pod: %w", err)
+	}
+	req := admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			UID:       "zen-lock-simulate",
+			Operation: admissionv1.Create,
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+			Object:    runtime.RawExtension{Raw: raw},
+		},
+	}
+
+	resp := handler.Handle(ctx, req)
+	if !resp.Allowed {
+		message := "unknown reason"
+		if resp.Result != nil && resp.Result.Message != "" {
+			message = resp.Result.Message
+		}
+		return nil, fmt.Errorf("injection rejected: %s", message)
+	}
+
+	result := &simulationResult{pod: pod, warnings: resp.Warnings}
+	if len(resp.Patches) > 0 {
+		patchData, err := json.Marshal(resp.Patches)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode patch: %w", err)
+		}
+		patch, err := jsonpatch.DecodePatch(patchData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode patch: %w", err)
+		}
+		patched, err := patch.Apply(raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply patch: %w", err)
+		}
+		mutated := &corev1.Pod{}
+		if err := json.Unmarshal(patched, mutated); err != nil {
+			return nil, fmt.Errorf("failed to decode mutated Pod: %w", err)
+		}
+		result.pod = mutated
+	}
+
+	secrets := &corev1.SecretList{}
+	if err := c.List(ctx, secrets, client.InNamespace(pod.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list simulated Secrets: %w", err)
+	}
+	for _, secret := range secrets.Items {
+		keys := make([]string, 0, len(secret.Data))
+		for k := range secret.Data {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		result.secrets = append(result.secrets, simulatedSecret{
+			name:    secret.Name,
+			zenlock: secret.Labels[common.ZenLockNameLabel()],
+			keys:    keys,
+		})
+	}
+	sort.Slice(result.secrets, func(i, j int) bool { return result.secrets[i].name < result.secrets[j].name })
+
+	return result, nil
+}
+
+// printSimulation writes the mutated Pod as YAML followed by one comment line per would-be Secret
+func printSimulation(out io.Writer, result *simulationResult) error {
+	podData, err := yaml.Marshal(result.pod)
+	if err != nil {
+		return fmt.Errorf("failed to marshal Pod: %w", err)
+	}
+	fmt.Fprint(out, string(podData))
+
+	if len(result.secrets) == 0 {
+		fmt.Fprintln(out, "# No Secrets would be created")
+		return nil
+	}
+	for _, secret := range result.secrets {
+		fmt.Fprintf(out, "# Secret %s (ZenLock %s) keys: %s\n", secret.name, secret.zenlock, strings.Join(secret.keys, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
+	"github.com/kube-zen/zen-lock/pkg/webhook"
+)
+
+func setupSimulation(t *testing.T) *securityv1alpha1.ZenLock {
+	identity := generateTestIdentity(t)
+	t.Setenv("ZEN_LOCK_PRIVATE_KEY", identity.String())
+	recipient := identity.Recipient().String()
+
+	return &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{Name: "db-credentials", Namespace: "default"},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{
+				"USERNAME": encryptTestValue(t, "admin", recipient),
+				"PASSWORD": encryptTestValue(t, "s3cret", recipient),
+			},
+		},
+	}
+}
+
+func simulationTestPod(annotations map[string]string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Annotations: annotations},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app", Image: "app:latest"}},
+		},
+	}
+}
+
+func TestSimulateInjection(t *testing.T) {
+	zenlock := setupSimulation(t)
+	pod := simulationTestPod(map[string]string{
+		config.AnnotationInject:    "db-credentials",
+		config.AnnotationMountPath: "/config/db",
+	})
+
+	result, err := simulateInjection(context.Background(), pod, []*securityv1alpha1.ZenLock{zenlock})
+	if err != nil {
+		t.Fatalf("simulateInjection failed: %v", err)
+	}
+
+	secretName := webhook.GenerateSecretName("default", "app")
+	if len(result.pod.Spec.Volumes) != 1 {
+		t.Fatalf("Expected 1 volume, got %d", len(result.pod.Spec.Volumes))
+	}
+	volume := result.pod.Spec.Volumes[0]
+	if volume.Secret == nil || volume.Secret.SecretName != secretName {
+		t.Errorf("Expected Secret volume %q, got %+v", secretName, volume.VolumeSource)
+	}
+	mounts := result.pod.Spec.Containers[0].VolumeMounts
+	if len(mounts) != 1 || mounts[0].MountPath != "/config/db" || mounts[0].Name != volume.Name {
+		t.Errorf("Expected volume mounted at /config/db, got %+v", mounts)
+	}
+
+	if len(result.secrets) != 1 {
+		t.Fatalf("Expected 1 Secret, got %d", len(result.secrets))
+	}
+	secret := result.secrets[0]
+	if secret.name != secretName || secret.zenlock != "db-credentials" {
+		t.Errorf("Unexpected Secret %+v", secret)
+	}
+	if strings.Join(secret.keys, ",") != "PASSWORD,USERNAME" {
+		t.Errorf("Expected keys PASSWORD,USERNAME, got %v", secret.keys)
+	}
+
+	var out bytes.Buffer
+	if err := printSimulation(&out, result); err != nil {
+		t.Fatalf("printSimulation failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "keys: PASSWORD, USERNAME") {
+		t.Errorf("Expected key names in output, got:\n%s", out.String())
+	}
+	if strings.Contains(out.String(), "s3cret") || strings.Contains(out.String(), "admin") {
+		t.Errorf("Output must not contain secret values:\n%s", out.String())
+	}
+}
+
+func TestSimulateInjection_NotRequested(t *testing.T) {
+	zenlock := setupSimulation(t)
+
+	result, err := simulateInjection(context.Background(), simulationTestPod(nil), []*securityv1alpha1.ZenLock{zenlock})
+	if err != nil {
+		t.Fatalf("simulateInjection failed: %v", err)
+	}
+	if len(result.pod.Spec.Volumes) != 0 || len(result.secrets) != 0 {
+		t.Errorf("Expected Pod without zen-lock/inject to be left alone, got %+v", result)
+	}
+}
+
+func TestSimulateInjection_Denied(t *testing.T) {
+	zenlock := setupSimulation(t)
+	zenlock.Spec.AllowedSubjects = []securityv1alpha1.SubjectReference{
+		{Kind: "ServiceAccount", Name: "backend", Namespace: "default"},
+	}
+	pod := simulationTestPod(map[string]string{config.AnnotationInject: "db-credentials"})
+
+	_, err := simulateInjection(context.Background(), pod, []*securityv1alpha1.ZenLock{zenlock})
+	if err == nil || !strings.Contains(err.Error(), "not allowed") {
+		t.Errorf("Expected ServiceAccount denial, got %v", err)
+	}
+}
+
+func TestSimulateInjection_NamespaceMismatch(t *testing.T) {
+	zenlock := setupSimulation(t)
+	zenlock.Namespace = "other"
+	pod := simulationTestPod(map[string]string{config.AnnotationInject: "db-credentials"})
+
+	if _, err := simulateInjection(context.Background(), pod, []*securityv1alpha1.ZenLock{zenlock}); err == nil {
+		t.Error("Expected error for a ZenLock in another namespace")
+	}
+}
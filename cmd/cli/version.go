@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kube-zen/zen-lock/pkg/crypto"
+)
+
+// versionInfo is the JSON output of the version command
+type versionInfo struct {
+	Version   string              `json:"version"`
+	Commit    string              `json:"commit"`
+	BuildDate string              `json:"buildDate"`
+	Crypto    crypto.Capabilities `json:"crypto"`
+}
+
+func newVersionCmd() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "version",
+		Short: "Print the version and crypto capabilities",
+		Long: `Print the zen-lock version, the age library version, the supported recipient
+types and whether a valid identity is configured (ZEN_LOCK_PRIVATE_KEY or
+ZEN_LOCK_IDENTITIES_DIR). No key material is printed. Include this output in
+support requests.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			info := versionInfo{
+				Version:   version,
+				Commit:    commit,
+				BuildDate: buildDate,
+				Crypto:    crypto.GetCapabilities(),
+			}
+			return printVersion(os.Stdout, info, format)
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", formatTable, "Output format: table or json")
+
+	return cmd
+}
+
+// printVersion writes the version and crypto capabilities as text or JSON
+func printVersion(out io.Writer, info versionInfo, format string) error {
+	switch format {
+	case formatJSON:
+		data, err := json.MarshalIndent(info, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal version: %w", err)
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	case formatTable:
+		fmt.Fprintf(out, "zen-lock %s (commit: %s, built: %s)\n", info.Version, info.Commit, info.BuildDate)
+		fmt.Fprintf(out, "age library: %s %s\n", info.Crypto.Library, info.Crypto.LibraryVersion)
+		fmt.Fprintf(out, "identity loaded: %t\n\n", info.Crypto.IdentityLoaded)
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "RECIPIENT TYPE\tSUPPORTED\tREASON")
+		for _, rt := range info.Crypto.RecipientTypes {
+			fmt.Fprintf(w, "%s\t%t\t%s\n", rt.Name, rt.Supported, rt.Reason)
+		}
+		return w.Flush()
+	default:
+		return fmt.Errorf("invalid --format %q (must be %s or %s)", format, formatTable, formatJSON)
+	}
+}
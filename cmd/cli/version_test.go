@@ -0,0 +1,76 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kube-zen/zen-lock/pkg/crypto"
+)
+
+func TestPrintVersion_JSON(t *testing.T) {
+	identity := generateTestIdentity(t)
+	t.Setenv("ZEN_LOCK_IDENTITIES_DIR", "")
+	t.Setenv("ZEN_LOCK_PRIVATE_KEY", identity.String())
+
+	var out bytes.Buffer
+	info := versionInfo{Version: "1.2.3", Commit: "abc", BuildDate: "today", Crypto: crypto.GetCapabilities()}
+	if err := printVersion(&out, info, formatJSON); err != nil {
+		t.Fatalf("printVersion failed: %v", err)
+	}
+	if strings.Contains(out.String(), identity.String()) {
+		t.Fatalf("Expected no key material in the output, got:\n%s", out.String())
+	}
+
+	var got versionInfo
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("Output is not JSON: %v\n%s", err, out.String())
+	}
+	if got.Version != "1.2.3" || !got.Crypto.IdentityLoaded || got.Crypto.Library != "filippo.io/age" {
+		t.Errorf("Unexpected version info: %+v", got)
+	}
+}
+
+func TestPrintVersion_Table(t *testing.T) {
+	t.Setenv("ZEN_LOCK_IDENTITIES_DIR", "")
+	t.Setenv("ZEN_LOCK_PRIVATE_KEY", "")
+
+	var out bytes.Buffer
+	info := versionInfo{Version: "1.2.3", Commit: "abc", BuildDate: "today", Crypto: crypto.GetCapabilities()}
+	if err := printVersion(&out, info, formatTable); err != nil {
+		t.Fatalf("printVersion failed: %v", err)
+	}
+
+	text := out.String()
+	for _, want := range []string{"zen-lock 1.2.3", "identity loaded: false", "X25519", "SSH"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, text)
+		}
+	}
+	for _, line := range strings.Split(text, "\n") {
+		if fields := strings.Fields(line); len(fields) >= 2 && fields[0] == "SSH" && fields[1] != "false" {
+			t.Errorf("Expected SSH recipients unsupported, got %q", line)
+		}
+	}
+
+	if err := printVersion(&bytes.Buffer{}, info, "xml"); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
 	"strconv"
 	"time"
@@ -12,11 +13,14 @@ import (
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/common"
 	"github.com/kube-zen/zen-lock/pkg/controller"
+	"github.com/kube-zen/zen-lock/pkg/crypto"
 	webhookpkg "github.com/kube-zen/zen-lock/pkg/webhook"
 	"github.com/kube-zen/zen-sdk/pkg/health"
 	"github.com/kube-zen/zen-sdk/pkg/leader"
@@ -31,6 +35,7 @@ var (
 	leaderElectionMode      = flag.String("leader-election-mode", "builtin", "Leader election mode: builtin (default), zenlead, or disabled (controller only)")
 	leaderElectionID        = flag.String("leader-election-id", "", "The ID for leader election (default: zen-lock-controller-leader-election). Required for builtin mode.")
 	leaderElectionLeaseName = flag.String("leader-election-lease-name", "", "The LeaderGroup CRD name (required for zenlead mode)")
+	watchNamespace          = flag.String("watch-namespace", os.Getenv("ZEN_LOCK_WATCH_NAMESPACE"), "Restrict the manager's cache and reconcilers to a single namespace; requires --enable-webhook=false (default: all namespaces, env: ZEN_LOCK_WATCH_NAMESPACE)")
 )
 
 func init() {
@@ -117,6 +122,27 @@ func configureLeaderElection(enableController bool, enableWebhook bool, baseOpts
 	return mgrOpts, nil
 }
 
+// validateWatchNamespace refuses a single-namespace cache when the webhook runs in the same manager
+// The webhook reads ZenLocks and Namespaces through the manager's cache, so injection into any other
+// namespace would fail on cache misses. Run the webhook as a separate --enable-controller=false process.
+func validateWatchNamespace(namespace string, enableWebhook bool) error {
+	if namespace != "" && enableWebhook {
+		return fmt.Errorf("--watch-namespace requires --enable-webhook=false: the webhook admits Pods in every namespace and must not share a namespace-scoped cache")
+	}
+	return nil
+}
+
+// applyWatchNamespace restricts the manager's cache to a single namespace (empty = all namespaces)
+func applyWatchNamespace(opts ctrl.Options, namespace string) ctrl.Options {
+	if namespace == "" {
+		return opts
+	}
+	opts.Cache.DefaultNamespaces = map[string]cache.Config{
+		namespace: {},
+	}
+	return opts
+}
+
 // setupComponents sets up the controller and webhook components
 func setupComponents(mgr ctrl.Manager, enableController, enableWebhook bool) error {
 	// Setup ZenLock controller (if enabled)
@@ -125,6 +151,7 @@ func setupComponents(mgr ctrl.Manager, enableController, enableWebhook bool) err
 		if err != nil {
 			return fmt.Errorf("unable to create ZenLock reconciler: %w", err)
 		}
+		zenlockReconciler.Recorder = mgr.GetEventRecorderFor("zen-lock-controller")
 		if err := zenlockReconciler.SetupWithManager(mgr); err != nil {
 			return fmt.Errorf("unable to setup ZenLock controller: %w", err)
 		}
@@ -134,6 +161,28 @@ func setupComponents(mgr ctrl.Manager, enableController, enableWebhook bool) err
 		if err := secretReconciler.SetupWithManager(mgr); err != nil {
 			return fmt.Errorf("unable to setup Secret controller: %w", err)
 		}
+
+		// Setup mirror controller (copies ZenLocks into namespaces selected by mirrorNamespaceSelector)
+		// Mirroring spans namespaces, so it is unavailable when the manager watches a single namespace
+		if *watchNamespace == "" {
+			mirrorReconciler := controller.NewMirrorReconciler(mgr.GetClient(), mgr.GetScheme())
+			if err := mirrorReconciler.SetupWithManager(mgr); err != nil {
+				return fmt.Errorf("unable to setup ZenLock mirror controller: %w", err)
+			}
+		} else {
+			setupLog.Info("ZenLock mirroring disabled in single-namespace mode", sdklog.Component("controller"))
+		}
+
+		// Setup Pod Secret controller (creates Secrets when the webhook delegates creation)
+		if webhookpkg.SecretCreationDelegated() {
+			podSecretReconciler, err := controller.NewPodSecretReconciler(mgr.GetClient(), mgr.GetScheme())
+			if err != nil {
+				return fmt.Errorf("unable to create Pod Secret reconciler: %w", err)
+			}
+			if err := podSecretReconciler.SetupWithManager(mgr); err != nil {
+				return fmt.Errorf("unable to setup Pod Secret controller: %w", err)
+			}
+		}
 		setupLog.Info("Controller enabled", sdklog.Component("controller"))
 	} else {
 		setupLog.Info("Controller disabled", sdklog.Component("controller"))
@@ -178,8 +227,38 @@ func main() {
 	// For now, continue without tracing
 
 	// Check for private key
-	if os.Getenv("ZEN_LOCK_PRIVATE_KEY") == "" {
-		setupLog.Error(fmt.Errorf("ZEN_LOCK_PRIVATE_KEY not set"), "Private key environment variable is required", sdklog.ErrorCode("MISSING_PRIVATE_KEY"))
+	if os.Getenv("ZEN_LOCK_PRIVATE_KEY") == "" && os.Getenv("ZEN_LOCK_IDENTITIES_DIR") == "" {
+		setupLog.Error(fmt.Errorf("ZEN_LOCK_PRIVATE_KEY not set"), "Private key environment variable or identities directory is required", sdklog.ErrorCode("MISSING_PRIVATE_KEY"))
+		os.Exit(1)
+	}
+
+	// Every component derives the Secret tracking labels from the same prefix; refuse to start on an invalid one
+	if err := common.ValidateLabelPrefix(); err != nil {
+		setupLog.Error(err, "Invalid label prefix", sdklog.ErrorCode("INVALID_LABEL_PREFIX"))
+		os.Exit(1)
+	}
+
+	// The webhook, validator and controllers resolve an empty spec.algorithm to the same default
+	if err := crypto.ValidateDefaultAlgorithm(); err != nil {
+		setupLog.Error(err, "Invalid default algorithm", sdklog.ErrorCode("INVALID_DEFAULT_ALGORITHM"))
+		os.Exit(1)
+	}
+
+	// An unknown mode must not silently fall back to enforcing
+	if err := webhookpkg.ValidateWebhookMode(); err != nil {
+		setupLog.Error(err, "Invalid webhook mode", sdklog.ErrorCode("INVALID_WEBHOOK_MODE"))
+		os.Exit(1)
+	}
+
+	// A mistyped redaction pattern would silently leak the key names it was meant to hide
+	if err := common.ValidateRedactKeyPatterns(); err != nil {
+		setupLog.Error(err, "Invalid key redaction patterns", sdklog.ErrorCode("INVALID_REDACT_KEYS"))
+		os.Exit(1)
+	}
+
+	// Injected init containers must not carry resources the API server rejects
+	if err := webhookpkg.ValidateInitResources(); err != nil {
+		setupLog.Error(err, "Invalid init container resources", sdklog.ErrorCode("INVALID_INIT_RESOURCES"))
 		os.Exit(1)
 	}
 
@@ -196,6 +275,39 @@ func main() {
 		HealthProbeBindAddress: probeAddr,
 	}
 
+	// Only an explicit ZEN_LOCK_INFORMER_RESYNC changes the resync period of every informer
+	if syncPeriod := webhookpkg.InformerSyncPeriodFromEnv(); syncPeriod != nil {
+		baseOpts.Cache.SyncPeriod = syncPeriod
+	}
+
+	// Scope the cache (and so every reconciler) to one namespace if requested
+	if err := validateWatchNamespace(*watchNamespace, enableWebhook); err != nil {
+		setupLog.Error(err, "Invalid watch namespace", sdklog.ErrorCode("INVALID_WATCH_NAMESPACE"))
+		os.Exit(1)
+	}
+	baseOpts = applyWatchNamespace(baseOpts, *watchNamespace)
+	if *watchNamespace != "" {
+		setupLog.Info("Watching a single namespace", sdklog.Operation("config"), sdklog.String("namespace", *watchNamespace))
+	}
+
+	// Opt-in debug endpoints on the metrics server (metadata only, no secret data)
+	extraHandlers := map[string]http.Handler{}
+	if webhookpkg.DebugEndpointEnabled() {
+		extraHandlers[webhookpkg.DebugCachePath] = webhookpkg.NewDebugCacheHandler()
+		extraHandlers[webhookpkg.DebugCryptoPath] = webhookpkg.NewDebugCryptoHandler()
+		setupLog.Info("Debug endpoints enabled", sdklog.Operation("config"),
+			sdklog.String("cachePath", webhookpkg.DebugCachePath), sdklog.String("cryptoPath", webhookpkg.DebugCryptoPath))
+	}
+	// The decryption benchmark burns CPU on demand, so it is gated by a token rather than the debug flag
+	if token := webhookpkg.BenchmarkToken(); token != "" {
+		extraHandlers[webhookpkg.DebugBenchmarkPath] = webhookpkg.NewDebugBenchmarkHandler(token)
+		setupLog.Info("Decryption benchmark endpoint enabled", sdklog.Operation("config"),
+			sdklog.String("path", webhookpkg.DebugBenchmarkPath))
+	}
+	if len(extraHandlers) > 0 {
+		baseOpts.Metrics.ExtraHandlers = extraHandlers
+	}
+
 	// Configure leader election based on component type
 	mgrOpts, err := configureLeaderElection(enableController, enableWebhook, baseOpts)
 	if err != nil {
@@ -256,6 +368,14 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Report not ready before the serving certificate expires (ZEN_LOCK_CERT_EXPIRY_WINDOW, 0 disables)
+	if window := webhookpkg.CertExpiryWindowFromEnv(); enableWebhook && window > 0 {
+		if err := mgr.AddReadyzCheck("cert-expiry", webhookpkg.CertExpiryCheck(certDir, window)); err != nil {
+			setupLog.Error(err, "unable to set up certificate expiry check", sdklog.ErrorCode("READY_CHECK_ERROR"))
+			os.Exit(1)
+		}
+	}
+
 	setupLog.Info("starting manager", sdklog.Operation("start"))
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		setupLog.Error(err, "problem running manager", sdklog.ErrorCode("MANAGER_RUN_ERROR"))
@@ -0,0 +1,66 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"testing"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestApplyWatchNamespace(t *testing.T) {
+	opts := applyWatchNamespace(ctrl.Options{Scheme: scheme}, "tenant-a")
+
+	if len(opts.Cache.DefaultNamespaces) != 1 {
+		t.Fatalf("Expected exactly one cached namespace, got %v", opts.Cache.DefaultNamespaces)
+	}
+	if _, ok := opts.Cache.DefaultNamespaces["tenant-a"]; !ok {
+		t.Errorf("Expected cache to be restricted to tenant-a, got %v", opts.Cache.DefaultNamespaces)
+	}
+	if opts.Scheme != scheme {
+		t.Error("Expected other options to be preserved")
+	}
+}
+
+func TestApplyWatchNamespace_AllNamespaces(t *testing.T) {
+	opts := applyWatchNamespace(ctrl.Options{}, "")
+
+	if opts.Cache.DefaultNamespaces != nil {
+		t.Errorf("Expected cluster-wide cache, got %v", opts.Cache.DefaultNamespaces)
+	}
+}
+
+func TestValidateWatchNamespace(t *testing.T) {
+	tests := []struct {
+		name          string
+		namespace     string
+		enableWebhook bool
+		wantErr       bool
+	}{
+		{name: "all namespaces with webhook", namespace: "", enableWebhook: true},
+		{name: "single namespace controller only", namespace: "tenant-a", enableWebhook: false},
+		{name: "single namespace with webhook", namespace: "tenant-a", enableWebhook: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateWatchNamespace(tt.namespace, tt.enableWebhook)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateWatchNamespace() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
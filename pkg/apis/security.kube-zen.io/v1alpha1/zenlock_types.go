@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -10,15 +11,77 @@ type ZenLockSpec struct {
 	// +kubebuilder:validation:Required
 	EncryptedData map[string]string `json:"encryptedData"`
 
-	// Algorithm specifies the encryption method (default: "age")
-	// +kubebuilder:default="age"
-	// +kubebuilder:validation:Enum=age
+	// Algorithm specifies the encryption method.
+	// When empty, the operator-wide default applies (ZEN_LOCK_DEFAULT_ALGORITHM, default: "age");
+	// the zen-lock mutating webhook records it explicitly on create and update.
+	// Not an enum: the validating webhook accepts the algorithms compiled into zen-lock, so the
+	// default can name any of them.
 	Algorithm string `json:"algorithm,omitempty"`
 
 	// AllowedSubjects is an optional list of ServiceAccounts allowed to use this secret
 	// Currently only ServiceAccount kind is supported. User and Group kinds are not yet implemented.
 	// +optional
 	AllowedSubjects []SubjectReference `json:"allowedSubjects,omitempty"`
+
+	// InjectionSelector selects Pods in the ZenLock's namespace that receive this secret
+	// automatically, without carrying the zen-lock/inject annotation.
+	// An empty selector matches every Pod in the namespace.
+	// +optional
+	InjectionSelector *metav1.LabelSelector `json:"injectionSelector,omitempty"`
+
+	// MirrorNamespaceSelector selects namespaces that receive a managed copy of this ZenLock.
+	// Only namespaces whose zen-lock/mirror-sources annotation lists this namespace receive one.
+	// Copies carry the same spec, allowedSubjects included, are kept in sync by the controller
+	// and are deleted with the source. An empty selector matches every namespace.
+	// +optional
+	MirrorNamespaceSelector *metav1.LabelSelector `json:"mirrorNamespaceSelector,omitempty"`
+
+	// Checksums is an optional map of key -> hex-encoded SHA-256 of the expected plaintext.
+	// When set, decrypted values are verified against it, catching corruption or data encrypted
+	// with the wrong key. Keys without a checksum are not verified.
+	// Note: a checksum allows offline guessing of low-entropy values; only use it for high-entropy secrets.
+	// +optional
+	Checksums map[string]string `json:"checksums,omitempty"`
+
+	// SecretType is the type of the Secret created on injection (default: Opaque).
+	// Well-known types such as kubernetes.io/tls must have their required keys in encryptedData.
+	// +optional
+	SecretType corev1.SecretType `json:"secretType,omitempty"`
+
+	// Immutable marks the Secrets created on injection as immutable.
+	// When unset, the namespace's zen-lock/default-immutable annotation applies, then the
+	// operator-wide default (ZEN_LOCK_IMMUTABLE_SECRETS).
+	// +optional
+	Immutable *bool `json:"immutable,omitempty"`
+
+	// RequiredNodeSelector restricts injection to Pods pinned to matching nodes.
+	// A Pod is only injected when its nodeSelector carries every key with the same value;
+	// node affinity is not considered.
+	// +optional
+	RequiredNodeSelector map[string]string `json:"requiredNodeSelector,omitempty"`
+
+	// RequiredKeys lists keys that encryptedData must always contain, e.g. tls.crt.
+	// ZenLocks missing any of them are denied on create and update.
+	// +optional
+	RequiredKeys []string `json:"requiredKeys,omitempty"`
+
+	// OptionalKeys lists encryptedData keys an application can run without.
+	// A value that fails to decrypt is omitted from the injected Secret with a warning,
+	// instead of failing the injection; every other key must still decrypt.
+	// +optional
+	OptionalKeys []string `json:"optionalKeys,omitempty"`
+
+	// KeyID selects the webhook identity used to decrypt this ZenLock, by the name of its file
+	// in ZEN_LOCK_IDENTITIES_DIR without extension. Only that identity is tried; when no identity
+	// carries the key ID, all configured identities are tried.
+	// +optional
+	KeyID string `json:"keyID,omitempty"`
+
+	// Encoding is how encryptedData values store the age ciphertext: "base64" (default) or "armor",
+	// the PEM-style ASCII armor written by `age --armor`.
+	// +kubebuilder:validation:Enum=base64;armor
+	// +optional
+	Encoding string `json:"encoding,omitempty"`
 }
 
 // SubjectReference references a Kubernetes subject
@@ -51,6 +114,21 @@ type ZenLockStatus struct {
 	// Conditions represent the latest available observations of the ZenLock's state
 	// +optional
 	Conditions []ZenLockCondition `json:"conditions,omitempty"`
+
+	// DenialCount is the number of Pod injections the webhook denied for this ZenLock.
+	// Updates are coalesced, so the count may lag behind by a few seconds.
+	// +optional
+	DenialCount int `json:"denialCount,omitempty"`
+
+	// LastDenialReason is the message of the most recent denied injection
+	// +optional
+	LastDenialReason string `json:"lastDenialReason,omitempty"`
+
+	// DecryptedByKeyFingerprint identifies the controller identity that decrypted the data:
+	// "SHA256:" followed by the first 8 bytes, hex-encoded, of the SHA-256 of its age public key.
+	// It reveals neither key. Empty while the ZenLock does not decrypt.
+	// +optional
+	DecryptedByKeyFingerprint string `json:"decryptedByKeyFingerprint,omitempty"`
 }
 
 // ZenLockCondition describes the state of a ZenLock at a certain point
@@ -22,6 +22,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -118,6 +119,45 @@ func (in *ZenLockSpec) DeepCopyInto(out *ZenLockSpec) {
 		*out = make([]SubjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.InjectionSelector != nil {
+		in, out := &in.InjectionSelector, &out.InjectionSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MirrorNamespaceSelector != nil {
+		in, out := &in.MirrorNamespaceSelector, &out.MirrorNamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Checksums != nil {
+		in, out := &in.Checksums, &out.Checksums
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Immutable != nil {
+		in, out := &in.Immutable, &out.Immutable
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RequiredNodeSelector != nil {
+		in, out := &in.RequiredNodeSelector, &out.RequiredNodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.RequiredKeys != nil {
+		in, out := &in.RequiredKeys, &out.RequiredKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OptionalKeys != nil {
+		in, out := &in.OptionalKeys, &out.OptionalKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZenLockSpec.
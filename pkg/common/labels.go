@@ -16,14 +16,96 @@ limitations under the License.
 
 package common
 
-// Label keys for zen-lock Secrets
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// DefaultLabelPrefix is the prefix of the tracking labels unless ZEN_LOCK_LABEL_PREFIX overrides it
+const DefaultLabelPrefix = "zen-lock.security.kube-zen.io"
+
+// Label keys for zen-lock Secrets under DefaultLabelPrefix
+// Read and write them through the resolvers below (PodNameLabel, ...) so a custom prefix applies everywhere.
 const (
 	// LabelPodName identifies the Pod name associated with a zen-lock Secret
-	LabelPodName = "zen-lock.security.kube-zen.io/pod-name"
+	LabelPodName = DefaultLabelPrefix + "/pod-name"
 
 	// LabelPodNamespace identifies the Pod namespace associated with a zen-lock Secret
-	LabelPodNamespace = "zen-lock.security.kube-zen.io/pod-namespace"
+	LabelPodNamespace = DefaultLabelPrefix + "/pod-namespace"
 
 	// LabelZenLockName identifies the ZenLock CRD name associated with a zen-lock Secret
-	LabelZenLockName = "zen-lock.security.kube-zen.io/zenlock-name"
+	LabelZenLockName = DefaultLabelPrefix + "/zenlock-name"
 )
+
+// Label keys for mirrored ZenLocks
+const (
+	// LabelManagedBy marks objects created and owned by a zen-lock controller
+	LabelManagedBy = "app.kubernetes.io/managed-by"
+
+	// ManagedByMirror is the LabelManagedBy value of ZenLock copies created by the mirror controller
+	ManagedByMirror = "zen-lock-mirror"
+
+	// ManagedByWebhook is the LabelManagedBy value of objects written by the webhook, such as the audit ConfigMap
+	ManagedByWebhook = "zen-lock-webhook"
+
+	// LabelMirrorSourceNamespace identifies the namespace of the ZenLock a mirror was copied from
+	LabelMirrorSourceNamespace = DefaultLabelPrefix + "/mirror-source-namespace"
+
+	// LabelMirrorSourceName identifies the name of the ZenLock a mirror was copied from
+	LabelMirrorSourceName = DefaultLabelPrefix + "/mirror-source-name"
+)
+
+// LabelPrefix returns the prefix of the tracking labels (ZEN_LOCK_LABEL_PREFIX)
+// An invalid prefix falls back to DefaultLabelPrefix; ValidateLabelPrefix reports it at startup.
+func LabelPrefix() string {
+	prefix := os.Getenv("ZEN_LOCK_LABEL_PREFIX")
+	if prefix == "" || ValidateLabelPrefix() != nil {
+		return DefaultLabelPrefix
+	}
+	return prefix
+}
+
+// ValidateLabelPrefix checks that ZEN_LOCK_LABEL_PREFIX, when set, is a valid label key prefix (a DNS subdomain)
+func ValidateLabelPrefix() error {
+	prefix := os.Getenv("ZEN_LOCK_LABEL_PREFIX")
+	if prefix == "" {
+		return nil
+	}
+	if errs := validation.IsDNS1123Subdomain(prefix); len(errs) > 0 {
+		return fmt.Errorf("ZEN_LOCK_LABEL_PREFIX %q is not a valid label prefix: %s", prefix, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// resolveLabel moves a default label key under the configured prefix
+func resolveLabel(key string) string {
+	return LabelPrefix() + strings.TrimPrefix(key, DefaultLabelPrefix)
+}
+
+// PodNameLabel returns the key of the LabelPodName tracking label under the configured prefix
+func PodNameLabel() string {
+	return resolveLabel(LabelPodName)
+}
+
+// PodNamespaceLabel returns the key of the LabelPodNamespace tracking label under the configured prefix
+func PodNamespaceLabel() string {
+	return resolveLabel(LabelPodNamespace)
+}
+
+// ZenLockNameLabel returns the key of the LabelZenLockName tracking label under the configured prefix
+func ZenLockNameLabel() string {
+	return resolveLabel(LabelZenLockName)
+}
+
+// MirrorSourceNamespaceLabel returns the key of the LabelMirrorSourceNamespace label under the configured prefix
+func MirrorSourceNamespaceLabel() string {
+	return resolveLabel(LabelMirrorSourceNamespace)
+}
+
+// MirrorSourceNameLabel returns the key of the LabelMirrorSourceName label under the configured prefix
+func MirrorSourceNameLabel() string {
+	return resolveLabel(LabelMirrorSourceName)
+}
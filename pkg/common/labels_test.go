@@ -0,0 +1,57 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package common
+
+import "testing"
+
+func TestLabelResolvers_DefaultPrefix(t *testing.T) {
+	t.Setenv("ZEN_LOCK_LABEL_PREFIX", "")
+	if PodNameLabel() != LabelPodName || PodNamespaceLabel() != LabelPodNamespace || ZenLockNameLabel() != LabelZenLockName {
+		t.Errorf("Expected the default label keys, got %s, %s, %s", PodNameLabel(), PodNamespaceLabel(), ZenLockNameLabel())
+	}
+	if MirrorSourceNamespaceLabel() != LabelMirrorSourceNamespace || MirrorSourceNameLabel() != LabelMirrorSourceName {
+		t.Errorf("Expected the default mirror label keys, got %s, %s", MirrorSourceNamespaceLabel(), MirrorSourceNameLabel())
+	}
+}
+
+func TestLabelResolvers_CustomPrefix(t *testing.T) {
+	t.Setenv("ZEN_LOCK_LABEL_PREFIX", "secrets.example.com")
+	if err := ValidateLabelPrefix(); err != nil {
+		t.Fatalf("Expected a valid prefix, got %v", err)
+	}
+	tests := map[string]string{
+		PodNameLabel():               "secrets.example.com/pod-name",
+		PodNamespaceLabel():          "secrets.example.com/pod-namespace",
+		ZenLockNameLabel():           "secrets.example.com/zenlock-name",
+		MirrorSourceNamespaceLabel(): "secrets.example.com/mirror-source-namespace",
+		MirrorSourceNameLabel():      "secrets.example.com/mirror-source-name",
+	}
+	for got, want := range tests {
+		if got != want {
+			t.Errorf("Expected %s, got %s", want, got)
+		}
+	}
+}
+
+func TestLabelResolvers_InvalidPrefix(t *testing.T) {
+	t.Setenv("ZEN_LOCK_LABEL_PREFIX", "Not A Prefix")
+	if err := ValidateLabelPrefix(); err == nil {
+		t.Error("Expected an invalid prefix to be reported")
+	}
+	if PodNameLabel() != LabelPodName {
+		t.Errorf("Expected an invalid prefix to fall back to the default, got %s", PodNameLabel())
+	}
+}
@@ -0,0 +1,30 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"os"
+	"strconv"
+)
+
+// BlockOwnerDeletion reports whether owner references on zen-lock Secrets set blockOwnerDeletion
+// (ZEN_LOCK_BLOCK_OWNER_DELETION=true). Foreground deletion of the owning Pod or ZenLock then waits
+// until its Secrets are gone; the API server requires update on the owner's finalizers subresource to set it.
+func BlockOwnerDeletion() bool {
+	block, _ := strconv.ParseBool(os.Getenv("ZEN_LOCK_BLOCK_OWNER_DELETION"))
+	return block
+}
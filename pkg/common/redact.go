@@ -0,0 +1,121 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// RedactedKey replaces key names matching ZEN_LOCK_REDACT_KEYS in logs, status messages and metrics
+const RedactedKey = "[redacted]"
+
+// quotedString matches a Go-quoted string, the form key names take in zen-lock messages (%q)
+var quotedString = regexp.MustCompile(`"(?:[^"\\]|\\.)*"`)
+
+// RedactKeyPatterns returns the key-name glob patterns to redact (ZEN_LOCK_REDACT_KEYS, comma-separated)
+func RedactKeyPatterns() []string {
+	var patterns []string
+	for _, pattern := range strings.Split(os.Getenv("ZEN_LOCK_REDACT_KEYS"), ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
+
+// ValidateRedactKeyPatterns checks that every ZEN_LOCK_REDACT_KEYS pattern is a valid glob
+func ValidateRedactKeyPatterns() error {
+	for _, pattern := range RedactKeyPatterns() {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("ZEN_LOCK_REDACT_KEYS pattern %q is invalid: %v", pattern, err)
+		}
+	}
+	return nil
+}
+
+// keyRedacted reports whether the key name matches one of patterns
+func keyRedacted(key string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, key); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactKeyName returns RedactedKey for a key name matching ZEN_LOCK_REDACT_KEYS, and the name otherwise
+func RedactKeyName(key string) string {
+	if keyRedacted(key, RedactKeyPatterns()) {
+		return RedactedKey
+	}
+	return key
+}
+
+// RedactKeyNames applies RedactKeyName to each key of a list
+func RedactKeyNames(keys []string) []string {
+	patterns := RedactKeyPatterns()
+	redacted := make([]string, len(keys))
+	for i, key := range keys {
+		redacted[i] = key
+		if keyRedacted(key, patterns) {
+			redacted[i] = RedactedKey
+		}
+	}
+	return redacted
+}
+
+// RedactMessage replaces the quoted key names matching ZEN_LOCK_REDACT_KEYS in a log or status message
+// zen-lock quotes key names in its messages, so only quoted strings are considered.
+func RedactMessage(message string) string {
+	patterns := RedactKeyPatterns()
+	if len(patterns) == 0 {
+		return message
+	}
+	return quotedString.ReplaceAllStringFunc(message, func(quoted string) string {
+		key, err := strconv.Unquote(quoted)
+		if err != nil || !keyRedacted(key, patterns) {
+			return quoted
+		}
+		return strconv.Quote(RedactedKey)
+	})
+}
+
+// redactedError is an error whose message went through RedactMessage
+type redactedError struct {
+	err error
+}
+
+func (e *redactedError) Error() string {
+	return RedactMessage(e.err.Error())
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// RedactError wraps err so its message redacts key names matching ZEN_LOCK_REDACT_KEYS, for logging
+func RedactError(err error) error {
+	if err == nil || len(RedactKeyPatterns()) == 0 {
+		return err
+	}
+	return &redactedError{err: err}
+}
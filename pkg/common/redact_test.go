@@ -0,0 +1,78 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package common
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRedactMessage(t *testing.T) {
+	t.Setenv("ZEN_LOCK_REDACT_KEYS", "oauth-*, internal_token")
+
+	tests := []struct {
+		message string
+		want    string
+	}{
+		{`failed to decrypt key "oauth-client-id": bad`, `failed to decrypt key "[redacted]": bad`},
+		{`key "internal_token" references undefined key "PASSWORD"`, `key "[redacted]" references undefined key "PASSWORD"`},
+		{`failed to decrypt key "PASSWORD": bad`, `failed to decrypt key "PASSWORD": bad`},
+		{`oauth-client-id is only redacted when quoted`, `oauth-client-id is only redacted when quoted`},
+	}
+	for _, tt := range tests {
+		if got := RedactMessage(tt.message); got != tt.want {
+			t.Errorf("RedactMessage(%q) = %q, want %q", tt.message, got, tt.want)
+		}
+	}
+
+	if got := RedactKeyName("oauth-client-id"); got != RedactedKey {
+		t.Errorf("Expected oauth-client-id to be redacted, got %q", got)
+	}
+	if got := RedactKeyName("USERNAME"); got != "USERNAME" {
+		t.Errorf("Expected USERNAME to be kept, got %q", got)
+	}
+}
+
+func TestRedactMessage_NoPatterns(t *testing.T) {
+	t.Setenv("ZEN_LOCK_REDACT_KEYS", "")
+	message := `failed to decrypt key "oauth-client-id": bad`
+	if got := RedactMessage(message); got != message {
+		t.Errorf("Expected the message unchanged without patterns, got %q", got)
+	}
+}
+
+func TestRedactError(t *testing.T) {
+	t.Setenv("ZEN_LOCK_REDACT_KEYS", "oauth-*")
+	cause := errors.New("checksum mismatch")
+	err := RedactError(errors.Join(cause, errors.New(`key "oauth-client-id"`)))
+	if got := err.Error(); got != "checksum mismatch\nkey \"[redacted]\"" {
+		t.Errorf("Unexpected redacted error %q", got)
+	}
+	if !errors.Is(err, cause) {
+		t.Error("Expected the redacted error to wrap its cause")
+	}
+}
+
+func TestValidateRedactKeyPatterns(t *testing.T) {
+	t.Setenv("ZEN_LOCK_REDACT_KEYS", "oauth-*,tls.*")
+	if err := ValidateRedactKeyPatterns(); err != nil {
+		t.Errorf("Expected valid patterns, got %v", err)
+	}
+	t.Setenv("ZEN_LOCK_REDACT_KEYS", "oauth-[")
+	if err := ValidateRedactKeyPatterns(); err == nil {
+		t.Error("Expected an invalid pattern to be rejected")
+	}
+}
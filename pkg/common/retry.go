@@ -0,0 +1,44 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"time"
+
+	"github.com/kube-zen/zen-sdk/pkg/retry"
+
+	"github.com/kube-zen/zen-lock/pkg/crypto"
+)
+
+// RetryConfig returns the retry configuration for API and Secret operations
+// Only transient API errors are retried; decryption failures are permanent and fail on the first attempt.
+func RetryConfig(maxAttempts int, initialDelay, maxDelay time.Duration) retry.Config {
+	retryConfig := retry.DefaultConfig()
+	retryConfig.MaxAttempts = maxAttempts
+	retryConfig.InitialDelay = initialDelay
+	retryConfig.MaxDelay = maxDelay
+	retryConfig.RetryableErrors = IsRetryable
+	return retryConfig
+}
+
+// IsRetryable reports whether err is a transient error worth another attempt
+func IsRetryable(err error) bool {
+	if crypto.IsPermanent(err) {
+		return false
+	}
+	return retry.DefaultConfig().RetryableErrors(err)
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"filippo.io/age"
+	"github.com/kube-zen/zen-sdk/pkg/retry"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kube-zen/zen-lock/pkg/crypto"
+)
+
+func TestRetryConfig_DecryptionFailureAttemptedOnce(t *testing.T) {
+	sender, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	wrongKey, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	encryptor := crypto.NewAgeEncryptor()
+	ciphertext, err := encryptor.Encrypt([]byte("value"), []string{sender.Recipient().String()})
+	if err != nil {
+		t.Fatalf("Failed to encrypt: %v", err)
+	}
+	encryptedData := map[string]string{"key": base64.StdEncoding.EncodeToString(ciphertext)}
+
+	attempts := 0
+	err = retry.Do(context.Background(), RetryConfig(5, time.Millisecond, time.Millisecond), func() error {
+		attempts++
+		_, err := encryptor.DecryptMap(encryptedData, wrongKey.String())
+		return err
+	})
+	if err == nil {
+		t.Fatal("Expected decryption with the wrong key to fail")
+	}
+	if attempts != 1 {
+		t.Errorf("Expected a decryption failure to be attempted exactly once, got %d attempts", attempts)
+	}
+}
+
+func TestRetryConfig_RetriesTransientAPIErrors(t *testing.T) {
+	conflict := k8serrors.NewConflict(schema.GroupResource{Resource: "secrets"}, "zen-lock-inject", nil)
+
+	attempts := 0
+	err := retry.Do(context.Background(), RetryConfig(3, time.Millisecond, time.Millisecond), func() error {
+		attempts++
+		return conflict
+	})
+	if err == nil {
+		t.Fatal("Expected the conflict to be returned after the last attempt")
+	}
+	if attempts != 3 {
+		t.Errorf("Expected a conflict to be retried 3 times, got %d attempts", attempts)
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"decryption failure", &crypto.KeyError{Key: "key", Op: "decrypt", Err: age.ErrIncorrectIdentity}, false},
+		{"conflict", k8serrors.NewConflict(schema.GroupResource{Resource: "secrets"}, "s", nil), true},
+		{"server timeout", k8serrors.NewServerTimeout(schema.GroupResource{Resource: "secrets"}, "update", 1), true},
+		{"not found", k8serrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "s"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.want {
+				t.Errorf("IsRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -29,6 +29,12 @@ const (
 	// DefaultWebhookTimeout is the default timeout for webhook requests
 	DefaultWebhookTimeout = 10 * time.Second
 
+	// DefaultDecryptTimeout bounds a single decryption in the admission path, within the webhook timeout
+	DefaultDecryptTimeout = 5 * time.Second
+
+	// DefaultPolicyHookTimeout bounds a call to the external policy endpoint (ZEN_LOCK_POLICY_URL)
+	DefaultPolicyHookTimeout = 2 * time.Second
+
 	// DefaultRetryMaxAttempts is the default maximum number of retry attempts
 	DefaultRetryMaxAttempts = 3
 
@@ -50,18 +56,258 @@ const (
 	// RequeueDelayPodNoUID is the delay when Pod exists but has no UID yet
 	RequeueDelayPodNoUID = 2 * time.Second
 
+	// DefaultKeyMissingRequeue is the requeue delay while no private key is configured
+	DefaultKeyMissingRequeue = 30 * time.Second
+
+	// DefaultFailureRequeueBase is the first requeue delay after a ZenLock fails to decrypt or verify
+	DefaultFailureRequeueBase = 10 * time.Second
+
+	// DefaultFailureRequeueMax caps the exponential requeue delay for repeatedly failing ZenLocks
+	DefaultFailureRequeueMax = 10 * time.Minute
+
+	// DefaultNamespaceCacheTTL is how long the webhook caches a Namespace read during admission
+	DefaultNamespaceCacheTTL = 30 * time.Second
+
+	// DefaultInformerResync is how often cached ZenLocks are refreshed from the informer cache
+	DefaultInformerResync = 10 * time.Minute
+
+	// DefaultSecretSyncAttempts bounds how often the webhook re-reads a Secret changed concurrently by another replica
+	DefaultSecretSyncAttempts = 5
+
+	// DefaultCertExpiryWindow is how long before its expiry the webhook serving certificate fails the readiness check
+	DefaultCertExpiryWindow = 24 * time.Hour
+
+	// DefaultMaxConcurrentReconciles is the number of ZenLocks or Secrets each controller reconciles in parallel
+	DefaultMaxConcurrentReconciles = 1
+
 	// DefaultAlgorithm is the default encryption algorithm
 	DefaultAlgorithm = "age"
 
 	// SupportedAlgorithm is the currently supported encryption algorithm
 	SupportedAlgorithm = "age"
+
+	// EncodingBase64 stores encryptedData values as base64-encoded binary age ciphertext (default)
+	EncodingBase64 = "base64"
+
+	// EncodingArmor stores encryptedData values as ASCII-armored age ciphertext
+	EncodingArmor = "armor"
+
+	// DefaultMaxSelectorZenLocks bounds how many ZenLocks with an InjectionSelector are evaluated per namespace
+	DefaultMaxSelectorZenLocks = 50
+
+	// DefaultMaxKeys is the default maximum number of encryptedData keys in a ZenLock
+	DefaultMaxKeys = 256
+
+	// DefaultMaxTotalBytes is the default maximum total size of decoded ciphertext in a ZenLock (512 KiB)
+	DefaultMaxTotalBytes = 512 * 1024
+
+	// DefaultMaxSubjects is the default maximum number of allowedSubjects in a ZenLock
+	DefaultMaxSubjects = 100
+
+	// StreamingDecryptThreshold is the size of a base64 encryptedData value above which it is decrypted as a stream (64 KiB)
+	StreamingDecryptThreshold = 64 * 1024
+
+	// DefaultCopyImage is the default image of the init container copying secrets into writable mounts (needs sh and cp)
+	DefaultCopyImage = "busybox:1.36"
+
+	// DefaultForbiddenMountPaths lists the system directories mount paths may not be in (ZEN_LOCK_FORBIDDEN_MOUNT_PATHS)
+	DefaultForbiddenMountPaths = "/bin,/boot,/dev,/etc,/lib,/lib64,/proc,/sbin,/sys,/usr,/var"
+
+	// DefaultInitCPURequest is the default CPU request of injected init containers (ZEN_LOCK_INIT_CPU_REQUEST)
+	DefaultInitCPURequest = "10m"
+
+	// DefaultInitMemoryRequest is the default memory request of injected init containers (ZEN_LOCK_INIT_MEMORY_REQUEST)
+	DefaultInitMemoryRequest = "16Mi"
+
+	// DefaultInitCPULimit is the default CPU limit of injected init containers (ZEN_LOCK_INIT_CPU_LIMIT)
+	DefaultInitCPULimit = "100m"
+
+	// DefaultInitMemoryLimit is the default memory limit of injected init containers (ZEN_LOCK_INIT_MEMORY_LIMIT)
+	DefaultInitMemoryLimit = "64Mi"
+
+	// AuditConfigMapName is the per-namespace ConfigMap holding the injection audit trail
+	AuditConfigMapName = "zen-lock-audit"
+
+	// AuditConfigMapKey is the ConfigMap key holding audit entries, one JSON object per line, oldest first
+	AuditConfigMapKey = "entries"
+
+	// DefaultAuditMaxEntries bounds the entries kept in the audit ConfigMap; older ones are trimmed
+	DefaultAuditMaxEntries = 100
+
+	// DefaultAuditBurst is how many audit entries a namespace may write in a burst
+	DefaultAuditBurst = 10
+
+	// DefaultAuditRefillInterval is how often a namespace regains one audit write
+	DefaultAuditRefillInterval = time.Second
+
+	// DefaultDenialFlushInterval is how often coalesced injection denials are written to ZenLock statuses
+	DefaultDenialFlushInterval = 10 * time.Second
+
+	// DefaultStartupPruneInterval spaces the Secrets enqueued by the startup prune sweep (ZEN_LOCK_STARTUP_PRUNE), 50 per second
+	DefaultStartupPruneInterval = 20 * time.Millisecond
+
+	// DefaultOrphanGaugeInterval is how often the number of orphaned Secrets pending cleanup is recomputed
+	DefaultOrphanGaugeInterval = time.Minute
+
+	// MaxDenialReasonLength bounds the denial message kept in a ZenLock's status
+	MaxDenialReasonLength = 256
+)
+
+// Limits of the decryption benchmark endpoint (ZEN_LOCK_BENCHMARK_TOKEN)
+const (
+	// DefaultBenchmarkIterations is how many times the benchmark decrypts the ZenLock by default
+	DefaultBenchmarkIterations = 100
+
+	// MaxBenchmarkIterations caps the iterations of a single benchmark run
+	MaxBenchmarkIterations = 1000
+
+	// DefaultBenchmarkKeys is the number of keys of the synthetic ZenLock
+	DefaultBenchmarkKeys = 5
+
+	// MaxBenchmarkKeys caps the number of keys of the synthetic ZenLock
+	MaxBenchmarkKeys = 100
+
+	// DefaultBenchmarkValueBytes is the plaintext size of each synthetic value
+	DefaultBenchmarkValueBytes = 64
+
+	// MaxBenchmarkValueBytes caps the plaintext size of each synthetic value
+	MaxBenchmarkValueBytes = 64 * 1024
+)
+
+// Container scopes for the zen-lock/inject-scope annotation
+const (
+	// InjectScopeAll injects into every container, init container and native sidecar (default)
+	InjectScopeAll = "all"
+
+	// InjectScopeMainOnly injects into the Pod's regular containers only
+	InjectScopeMainOnly = "main-only"
+
+	// InjectScopeInitOnly injects into plain init containers only, not native sidecars
+	InjectScopeInitOnly = "init-only"
+
+	// InjectScopeSidecarOnly injects into native sidecars only (init containers with restartPolicy Always)
+	InjectScopeSidecarOnly = "sidecar-only"
+)
+
+// Secret naming strategies for the zen-lock/secret-naming annotation
+const (
+	// SecretNamingPod names the Secret after the Pod, one Secret per Pod (default)
+	SecretNamingPod = "pod"
+
+	// SecretNamingZenLock names the Secret after the ZenLock, shared by every Pod that injects it
+	SecretNamingZenLock = "zenlock"
+)
+
+// Files added to the secret mount by zen-lock/project-metadata
+const (
+	// MetadataFilePodName holds the Pod's name
+	MetadataFilePodName = "zen-lock-pod-name"
+
+	// MetadataFilePodNamespace holds the Pod's namespace
+	MetadataFilePodNamespace = "zen-lock-pod-namespace"
+)
+
+// Key cases for the zen-lock/key-case annotation
+const (
+	// KeyCaseNone keeps key names as written in the ZenLock (default)
+	KeyCaseNone = "none"
+
+	// KeyCaseUpper uppercases key names, e.g. for env-style consumers
+	KeyCaseUpper = "upper"
+
+	// KeyCaseLower lowercases key names
+	KeyCaseLower = "lower"
+)
+
+// HostPath policies for ZEN_LOCK_DENY_HOSTPATH_PODS
+const (
+	// HostPathPolicyDeny denies injection into Pods that declare a hostPath volume
+	HostPathPolicyDeny = "deny"
+
+	// HostPathPolicyWarn injects into such Pods but returns an admission warning
+	HostPathPolicyWarn = "warn"
+)
+
+// Webhook modes for ZEN_LOCK_MODE
+const (
+	// WebhookModeEnforce injects secrets and denies invalid requests (default)
+	WebhookModeEnforce = "enforce"
+
+	// WebhookModeObserve evaluates every request as a dry run and admits all Pods unchanged
+	WebhookModeObserve = "observe"
 )
 
 // Annotation keys
 const (
+	// AnnotationPrefix is the prefix of every zen-lock annotation (see ZEN_LOCK_STRICT_ANNOTATIONS)
+	AnnotationPrefix = "zen-lock/"
+
 	// AnnotationInject is the annotation key for specifying which ZenLock to inject
 	AnnotationInject = "zen-lock/inject"
 
 	// AnnotationMountPath is the annotation key for specifying a custom mount path
 	AnnotationMountPath = "zen-lock/mount-path"
+
+	// AnnotationMountPathPrefix prefixes per-ZenLock mount path overrides (zen-lock/mount-path.<zenlock-name>)
+	AnnotationMountPathPrefix = "zen-lock/mount-path."
+
+	// AnnotationConfirmed is the label or annotation that confirms zen-lock/inject when
+	// ZEN_LOCK_REQUIRE_OPT_IN_LABEL is enabled (value must be "true")
+	AnnotationConfirmed = "zen-lock/confirmed"
+
+	// AnnotationInjectIf gates zen-lock/inject on another Pod annotation (key=value or key!=value)
+	AnnotationInjectIf = "zen-lock/inject-if"
+
+	// AnnotationEnvPrefix exposes injected keys as environment variables, named with this prefix
+	AnnotationEnvPrefix = "zen-lock/env-prefix"
+
+	// AnnotationMountWritable makes injected mounts writable when set to "true" (Secret-mode data is copied into an emptyDir)
+	AnnotationMountWritable = "zen-lock/mount-writable"
+
+	// AnnotationProjectMetadata adds the Pod's name and namespace as files next to the secrets when set to "true"
+	AnnotationProjectMetadata = "zen-lock/project-metadata"
+
+	// AnnotationInjectScope is the annotation key for choosing which kinds of containers receive the secrets
+	AnnotationInjectScope = "zen-lock/inject-scope"
+
+	// AnnotationDefaultImmutable is the Namespace annotation making injected Secrets immutable by default ("true" or "false")
+	AnnotationDefaultImmutable = "zen-lock/default-immutable"
+
+	// AnnotationCacheTTL is the Namespace annotation overriding ZEN_LOCK_CACHE_TTL for its ZenLocks (Go duration)
+	AnnotationCacheTTL = "zen-lock/cache-ttl"
+
+	// AnnotationMirrorSources is the Namespace annotation listing the namespaces (comma-separated) whose ZenLocks
+	// may be mirrored into it; namespaces without it receive no mirrors
+	AnnotationMirrorSources = "zen-lock/mirror-sources"
+
+	// AnnotationPaused is the ZenLock annotation that pauses reconciliation when set to "true"
+	AnnotationPaused = "zen-lock/paused"
+
+	// AnnotationDisabled is the ZenLock annotation that stops all injection of the ZenLock when set to "true"
+	AnnotationDisabled = "zen-lock/disabled"
+
+	// AnnotationSecretNaming is the annotation key for choosing per-Pod or per-ZenLock Secret names
+	AnnotationSecretNaming = "zen-lock/secret-naming"
+
+	// AnnotationExpandTemplates is the ZenLock annotation that enables ${key} references between keys when set to "true"
+	AnnotationExpandTemplates = "zen-lock/expand-templates"
+
+	// AnnotationKeyExtensions is the ZenLock annotation appending file extensions to injected keys
+	// Value: comma-separated <key>=<extension> pairs, e.g. "tls.crt=.pem"
+	AnnotationKeyExtensions = "zen-lock/key-extensions"
+
+	// AnnotationKeyCase is the ZenLock annotation normalizing the case of injected key names
+	// Value: "upper", "lower" or "none" (default)
+	AnnotationKeyCase = "zen-lock/key-case"
+
+	// AnnotationConcatenate is the ZenLock annotation joining several keys into one additional Secret key
+	// Value: <name>=<key>,<key>,..., e.g. "bundle.pem=tls.crt,ca.crt,tls.key"
+	AnnotationConcatenate = "zen-lock/concatenate"
+
+	// AnnotationPerKeySecrets is the annotation key for materializing each injected key as its own Secret when set to "true"
+	AnnotationPerKeySecrets = "zen-lock/per-key-secrets"
+
+	// AnnotationDelegatedSecrets is set by the webhook on Pods whose Secrets the controller creates
+	// Value: comma-separated <zenlock>=<secret> pairs
+	AnnotationDelegatedSecrets = "zen-lock/delegated-secrets"
 )
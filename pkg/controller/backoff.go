@@ -0,0 +1,45 @@
+package controller
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// failureBackoff tracks consecutive reconcile failures per object and computes a capped exponential requeue delay
+type failureBackoff struct {
+	mu       sync.Mutex
+	failures map[types.NamespacedName]int
+}
+
+// next records a failure for key and returns the delay before it should be retried
+// The delay is base * 2^(failures-1), capped at max.
+func (b *failureBackoff) next(key types.NamespacedName, base, max time.Duration) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures == nil {
+		b.failures = make(map[types.NamespacedName]int)
+	}
+	b.failures[key]++
+
+	delay := base
+	for i := 1; i < b.failures[key]; i++ {
+		delay *= 2
+		if delay >= max {
+			return max
+		}
+	}
+	if delay > max {
+		return max
+	}
+	return delay
+}
+
+// reset forgets the failures recorded for key
+func (b *failureBackoff) reset(key types.NamespacedName) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.failures, key)
+}
@@ -18,8 +18,21 @@ limitations under the License.
 package metrics
 
 import (
+	"context"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Components that use the private key, for PrivateKeyUseTotal.
+const (
+	// ComponentWebhook is the mutating webhook decrypting ZenLocks for Pod injection
+	ComponentWebhook = "webhook"
+	// ComponentController is the ZenLock reconciler verifying decryptability
+	ComponentController = "controller"
+	// ComponentValidator is the validating webhook checking ZenLocks on admission
+	ComponentValidator = "validator"
 )
 
 var (
@@ -51,6 +64,15 @@ var (
 		[]string{"namespace", "zenlock_name", "result"},
 	)
 
+	// WebhookObservedTotal counts the decisions the webhook would have made in observe mode (ZEN_LOCK_MODE=observe).
+	WebhookObservedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "zenlock_webhook_observed_total",
+			Help: "Total number of Pod admissions evaluated in observe mode, by the decision that would have been made",
+		},
+		[]string{"namespace", "mode", "decision"}, // mode: observe; decision: inject, deny, skip
+	)
+
 	// WebhookInjectionDuration measures the duration of webhook injections.
 	WebhookInjectionDuration = promauto.NewHistogramVec(
 		prometheus.HistogramOpts{
@@ -80,6 +102,25 @@ var (
 		[]string{"namespace", "zenlock_name"},
 	)
 
+	// DecryptionKeyFailures counts controller decryption failures by the first failing encryptedData key.
+	// Cardinality is bounded by the per-ZenLock key limit (ZEN_LOCK_MAX_KEYS).
+	DecryptionKeyFailures = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "zenlock_decryption_key_failures_total",
+			Help: "Total number of ZenLock decryption failures by failing key",
+		},
+		[]string{"namespace", "zenlock_name", "key"},
+	)
+
+	// DecryptionTimeoutsTotal counts admission decryptions abandoned after ZEN_LOCK_DECRYPT_TIMEOUT.
+	DecryptionTimeoutsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "zenlock_decryption_timeouts_total",
+			Help: "Total number of decryptions that exceeded the decryption timeout",
+		},
+		[]string{"component", "namespace", "zenlock_name"},
+	)
+
 	// ZenLockCacheHits counts cache hits for ZenLock lookups.
 	ZenLockCacheHits = promauto.NewCounterVec(
 		prometheus.CounterOpts{
@@ -125,6 +166,51 @@ var (
 		[]string{"algorithm", "reason"}, // reason: unsupported, invalid, decryption_failed
 	)
 
+	// PrivateKeyUseTotal counts private-key decryption operations by component.
+	PrivateKeyUseTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "zenlock_private_key_use_total",
+			Help: "Total number of times the private key was used to decrypt a ZenLock",
+		},
+		[]string{"component"}, // component: webhook, controller, validator
+	)
+
+	// OrphanSecretsPending tracks webhook-created Secrets whose Pod does not exist and that approach or passed the orphan TTL.
+	OrphanSecretsPending = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "zenlock_orphan_secrets_pending",
+			Help: "Number of zen-lock Secrets whose Pod does not exist and that are past half the orphan TTL, pending orphan cleanup",
+		},
+		[]string{"namespace"},
+	)
+
+	// RotationPending tracks ZenLocks the rotation target identity cannot decrypt yet (ZEN_LOCK_ROTATION_RECIPIENT).
+	RotationPending = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "zenlock_rotation_pending",
+			Help: "Number of ZenLocks decryptable only by identities other than the rotation target, pending re-encryption",
+		},
+		[]string{"namespace"},
+	)
+
+	// ZenLockKeyCount observes the number of encryptedData keys per ZenLock, once per generation.
+	ZenLockKeyCount = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "zenlock_spec_keys",
+			Help:    "Number of encryptedData keys per ZenLock, observed once per generation",
+			Buckets: prometheus.ExponentialBuckets(1, 2, 10),
+		},
+	)
+
+	// ZenLockByteSize observes the encoded size of a ZenLock's encryptedData, once per generation.
+	ZenLockByteSize = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "zenlock_spec_bytes",
+			Help:    "Total size in bytes of the keys and encoded values of a ZenLock's encryptedData, observed once per generation",
+			Buckets: prometheus.ExponentialBuckets(256, 4, 8),
+		},
+	)
+
 	// CacheSizeGauge tracks the current cache size
 	CacheSizeGauge = promauto.NewGauge(
 		prometheus.GaugeOpts{
@@ -154,10 +240,38 @@ func RecordWebhookInjection(namespace, zenlockName, result string, duration floa
 	WebhookInjectionDuration.WithLabelValues(namespace, zenlockName).Observe(duration)
 }
 
+// RecordWebhookObserved records the decision an observe-mode admission would have made.
+func RecordWebhookObserved(namespace, decision string) {
+	WebhookObservedTotal.WithLabelValues(namespace, "observe", decision).Inc()
+}
+
 // RecordDecryption records a decryption metric.
-func RecordDecryption(namespace, zenlockName, result string, duration float64) {
+// When tracing is enabled and ctx carries a sampled span, its trace ID is attached to the duration as an exemplar.
+func RecordDecryption(ctx context.Context, namespace, zenlockName, result string, duration float64) {
 	DecryptionTotal.WithLabelValues(namespace, zenlockName, result).Inc()
-	DecryptionDuration.WithLabelValues(namespace, zenlockName).Observe(duration)
+	observeWithTraceExemplar(ctx, DecryptionDuration.WithLabelValues(namespace, zenlockName), duration)
+}
+
+// observeWithTraceExemplar observes value, with the trace ID of the sampled span in ctx as an exemplar if any
+// Unsampled spans are skipped: their traces are not exported, so the exemplar would link nowhere.
+func observeWithTraceExemplar(ctx context.Context, observer prometheus.Observer, value float64) {
+	spanContext := trace.SpanContextFromContext(ctx)
+	exemplarObserver, ok := observer.(prometheus.ExemplarObserver)
+	if !ok || !spanContext.IsValid() || !spanContext.IsSampled() {
+		observer.Observe(value)
+		return
+	}
+	exemplarObserver.ObserveWithExemplar(value, prometheus.Labels{"trace_id": spanContext.TraceID().String()})
+}
+
+// RecordDecryptionKeyFailure records a decryption failure attributed to a specific key.
+func RecordDecryptionKeyFailure(namespace, zenlockName, key string) {
+	DecryptionKeyFailures.WithLabelValues(namespace, zenlockName, key).Inc()
+}
+
+// RecordDecryptionTimeout records a decryption abandoned after the decryption timeout.
+func RecordDecryptionTimeout(component, namespace, zenlockName string) {
+	DecryptionTimeoutsTotal.WithLabelValues(component, namespace, zenlockName).Inc()
 }
 
 // RecordCacheHit records a cache hit.
@@ -185,6 +299,31 @@ func RecordAlgorithmError(algorithm, reason string) {
 	AlgorithmErrorsTotal.WithLabelValues(algorithm, reason).Inc()
 }
 
+// RecordKeyUse records a use of the private key by a component.
+func RecordKeyUse(component string) {
+	PrivateKeyUseTotal.WithLabelValues(component).Inc()
+}
+
+// SetOrphanSecretsPending sets the number of orphaned Secrets pending cleanup in a namespace.
+func SetOrphanSecretsPending(namespace string, count int) {
+	OrphanSecretsPending.WithLabelValues(namespace).Set(float64(count))
+}
+
+// SetRotationPending sets the number of ZenLocks pending re-encryption to the rotation target in a namespace.
+func SetRotationPending(namespace string, count int) {
+	RotationPending.WithLabelValues(namespace).Set(float64(count))
+}
+
+// ObserveZenLockKeyCount records the number of keys of a ZenLock.
+func ObserveZenLockKeyCount(keys int) {
+	ZenLockKeyCount.Observe(float64(keys))
+}
+
+// ObserveZenLockByteSize records the encryptedData size of a ZenLock.
+func ObserveZenLockByteSize(bytes int) {
+	ZenLockByteSize.Observe(float64(bytes))
+}
+
 // UpdateCacheMetrics updates cache size and hit rate metrics
 func UpdateCacheMetrics(size int, hits, misses int64) {
 	CacheSizeGauge.Set(float64(size))
@@ -0,0 +1,85 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracedContext returns a context carrying a span context with the given sampling decision
+func tracedContext(t *testing.T, sampled bool) (context.Context, trace.TraceID) {
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	if err != nil {
+		t.Fatalf("Failed to parse trace ID: %v", err)
+	}
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	if err != nil {
+		t.Fatalf("Failed to parse span ID: %v", err)
+	}
+	config := trace.SpanContextConfig{TraceID: traceID, SpanID: spanID}
+	if sampled {
+		config.TraceFlags = trace.FlagsSampled
+	}
+	return trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(config)), traceID
+}
+
+// decryptionExemplars returns the exemplars on the decryption duration histogram of a ZenLock
+func decryptionExemplars(t *testing.T, zenlockName string) []*dto.Exemplar {
+	m := &dto.Metric{}
+	if err := DecryptionDuration.WithLabelValues("default", zenlockName).(prometheus.Metric).Write(m); err != nil {
+		t.Fatalf("Failed to write histogram: %v", err)
+	}
+	var exemplars []*dto.Exemplar
+	for _, bucket := range m.GetHistogram().GetBucket() {
+		if bucket.GetExemplar() != nil {
+			exemplars = append(exemplars, bucket.GetExemplar())
+		}
+	}
+	return exemplars
+}
+
+func TestRecordDecryption_TraceExemplar(t *testing.T) {
+	ctx, traceID := tracedContext(t, true)
+	RecordDecryption(ctx, "default", "exemplar-zenlock", "success", 0.004)
+
+	exemplars := decryptionExemplars(t, "exemplar-zenlock")
+	if len(exemplars) != 1 {
+		t.Fatalf("Expected one exemplar, got %d", len(exemplars))
+	}
+	labels := exemplars[0].GetLabel()
+	if len(labels) != 1 || labels[0].GetName() != "trace_id" || labels[0].GetValue() != traceID.String() {
+		t.Errorf("Expected a trace_id exemplar of %s, got %v", traceID, labels)
+	}
+	if exemplars[0].GetValue() != 0.004 {
+		t.Errorf("Expected the exemplar to hold the observed duration, got %v", exemplars[0].GetValue())
+	}
+}
+
+func TestRecordDecryption_NoExemplarWithoutSampledSpan(t *testing.T) {
+	RecordDecryption(context.Background(), "default", "untraced-zenlock", "success", 0.004)
+	unsampled, _ := tracedContext(t, false)
+	RecordDecryption(unsampled, "default", "untraced-zenlock", "success", 0.004)
+
+	if exemplars := decryptionExemplars(t, "untraced-zenlock"); len(exemplars) != 0 {
+		t.Errorf("Expected no exemplar without a sampled span, got %v", exemplars)
+	}
+}
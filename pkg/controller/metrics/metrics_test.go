@@ -17,6 +17,7 @@ limitations under the License.
 package metrics
 
 import (
+	"context"
 	"testing"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -149,8 +150,8 @@ func TestRecordDecryption(t *testing.T) {
 	}
 
 	// Also test the actual functions
-	RecordDecryption("default", "test-zenlock2", "success", 0.01)
-	RecordDecryption("default", "test-zenlock2", "error", 0.02)
+	RecordDecryption(context.Background(), "default", "test-zenlock2", "success", 0.01)
+	RecordDecryption(context.Background(), "default", "test-zenlock2", "error", 0.02)
 }
 
 func TestRecordCacheHit(t *testing.T) {
@@ -297,3 +298,19 @@ func TestRecordAlgorithmError(t *testing.T) {
 	RecordAlgorithmError("age", "invalid")
 	RecordAlgorithmError("age", "decryption_failed")
 }
+
+func TestRecordKeyUse(t *testing.T) {
+	webhookBefore := testutil.ToFloat64(PrivateKeyUseTotal.WithLabelValues(ComponentWebhook))
+	controllerBefore := testutil.ToFloat64(PrivateKeyUseTotal.WithLabelValues(ComponentController))
+
+	RecordKeyUse(ComponentWebhook)
+	RecordKeyUse(ComponentWebhook)
+	RecordKeyUse(ComponentController)
+
+	if got := testutil.ToFloat64(PrivateKeyUseTotal.WithLabelValues(ComponentWebhook)) - webhookBefore; got != 2 {
+		t.Errorf("Expected webhook key use to increase by 2, got %f", got)
+	}
+	if got := testutil.ToFloat64(PrivateKeyUseTotal.WithLabelValues(ComponentController)) - controllerBefore; got != 1 {
+		t.Errorf("Expected controller key use to increase by 1, got %f", got)
+	}
+}
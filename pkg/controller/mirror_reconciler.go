@@ -0,0 +1,312 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/common"
+	"github.com/kube-zen/zen-lock/pkg/config"
+	"github.com/kube-zen/zen-sdk/pkg/lifecycle"
+)
+
+// mirrorFinalizer keeps a source ZenLock until its mirrors are deleted
+// Owner references cannot cross namespaces, so mirrors are tracked by label and cleaned up here.
+const mirrorFinalizer = "zenlocks.security.kube-zen.io/mirror"
+
+// MirrorReconciler copies ZenLocks with a MirrorNamespaceSelector into every selected namespace
+type MirrorReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// NewMirrorReconciler creates a new MirrorReconciler
+func NewMirrorReconciler(client client.Client, scheme *runtime.Scheme) *MirrorReconciler {
+	return &MirrorReconciler{
+		Client: client,
+		Scheme: scheme,
+	}
+}
+
+//+kubebuilder:rbac:groups=security.kube-zen.io,resources=zenlocks,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
+// Reconcile creates, updates and deletes the mirrors of a source ZenLock
+func (r *MirrorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	source := &securityv1alpha1.ZenLock{}
+	if err := r.Get(ctx, req.NamespacedName, source); err != nil {
+		if k8serrors.IsNotFound(err) {
+			// Source gone without our finalizer (e.g. removed by hand): still drop its mirrors
+			return ctrl.Result{}, r.deleteMirrors(ctx, req.NamespacedName, nil)
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Mirrors are never mirrored themselves
+	if isMirror(source) {
+		return ctrl.Result{}, nil
+	}
+
+	if lifecycle.IsDeleting(source) || source.Spec.MirrorNamespaceSelector == nil {
+		if !lifecycle.HasFinalizer(source, mirrorFinalizer) {
+			return ctrl.Result{}, nil
+		}
+		if err := r.deleteMirrors(ctx, req.NamespacedName, nil); err != nil {
+			return ctrl.Result{}, err
+		}
+		lifecycle.RemoveFinalizer(source, mirrorFinalizer)
+		if err := r.Update(ctx, source); err != nil {
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+		logger.Info("Removed ZenLock mirrors", "source", req.NamespacedName)
+		return ctrl.Result{}, nil
+	}
+
+	if lifecycle.AddFinalizer(source, mirrorFinalizer) {
+		if err := r.Update(ctx, source); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	namespaces, err := r.targetNamespaces(ctx, source)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	var errs []error
+	for ns := range namespaces {
+		if err := r.syncMirror(ctx, source, ns); err != nil {
+			logger.Error(err, "Failed to sync ZenLock mirror", "source", req.NamespacedName, "namespace", ns)
+			errs = append(errs, err)
+		}
+	}
+	if err := r.deleteMirrors(ctx, req.NamespacedName, namespaces); err != nil {
+		errs = append(errs, err)
+	}
+	return ctrl.Result{}, errors.Join(errs...)
+}
+
+// targetNamespaces returns the active namespaces selected by the source that accept its mirrors, excluding its own
+func (r *MirrorReconciler) targetNamespaces(ctx context.Context, source *securityv1alpha1.ZenLock) (map[string]bool, error) {
+	selector, err := metav1.LabelSelectorAsSelector(source.Spec.MirrorNamespaceSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mirrorNamespaceSelector: %w", err)
+	}
+
+	namespaceList := &corev1.NamespaceList{}
+	if err := r.List(ctx, namespaceList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+
+	namespaces := make(map[string]bool, len(namespaceList.Items))
+	for _, ns := range namespaceList.Items {
+		if ns.Name == source.Namespace || ns.Status.Phase == corev1.NamespaceTerminating {
+			continue
+		}
+		if !acceptsMirrorsFrom(&ns, source.Namespace) {
+			log.FromContext(ctx).V(1).Info("Skipping mirror: namespace has not opted in", "namespace", ns.Name, "annotation", config.AnnotationMirrorSources)
+			continue
+		}
+		namespaces[ns.Name] = true
+	}
+	return namespaces, nil
+}
+
+// acceptsMirrorsFrom reports whether the namespace lists the source namespace in its mirror-sources annotation
+// Namespace annotations are set by cluster administrators, so a ZenLock author cannot push copies into
+// namespaces that did not ask for them.
+func acceptsMirrorsFrom(ns *corev1.Namespace, sourceNamespace string) bool {
+	for _, name := range strings.Split(ns.Annotations[config.AnnotationMirrorSources], ",") {
+		if strings.TrimSpace(name) == sourceNamespace {
+			return true
+		}
+	}
+	return false
+}
+
+// syncMirror creates or updates the copy of the source in a namespace
+// A ZenLock of the same name not managed by this source is left alone.
+func (r *MirrorReconciler) syncMirror(ctx context.Context, source *securityv1alpha1.ZenLock, namespace string) error {
+	desired := mirrorSpec(source)
+
+	existing := &securityv1alpha1.ZenLock{}
+	err := r.Get(ctx, types.NamespacedName{Name: source.Name, Namespace: namespace}, existing)
+	if k8serrors.IsNotFound(err) {
+		mirror := &securityv1alpha1.ZenLock{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        source.Name,
+				Namespace:   namespace,
+				Labels:      mirrorLabels(source),
+				Annotations: mirrorAnnotations(source, nil),
+			},
+			Spec: desired,
+		}
+		return r.Create(ctx, mirror)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !isMirrorOf(existing, source.Namespace, source.Name) {
+		log.FromContext(ctx).Info("Skipping mirror: a ZenLock with the same name already exists", "namespace", namespace, "name", source.Name)
+		return nil
+	}
+	annotations := mirrorAnnotations(source, existing.Annotations)
+	if reflect.DeepEqual(existing.Spec, desired) && reflect.DeepEqual(existing.Annotations, annotations) {
+		return nil
+	}
+	existing.Spec = desired
+	existing.Annotations = annotations
+	return r.Update(ctx, existing)
+}
+
+// deleteMirrors deletes the source's mirrors outside keep (nil deletes all of them)
+func (r *MirrorReconciler) deleteMirrors(ctx context.Context, source types.NamespacedName, keep map[string]bool) error {
+	mirrors := &securityv1alpha1.ZenLockList{}
+	if err := r.List(ctx, mirrors, client.MatchingLabels{
+		common.LabelManagedBy:               common.ManagedByMirror,
+		common.MirrorSourceNamespaceLabel(): source.Namespace,
+		common.MirrorSourceNameLabel():      source.Name,
+	}); err != nil {
+		return fmt.Errorf("failed to list ZenLock mirrors: %w", err)
+	}
+
+	var errs []error
+	for i := range mirrors.Items {
+		mirror := &mirrors.Items[i]
+		if keep[mirror.Namespace] {
+			continue
+		}
+		if err := r.Delete(ctx, mirror); client.IgnoreNotFound(err) != nil {
+			errs = append(errs, fmt.Errorf("failed to delete mirror %s/%s: %w", mirror.Namespace, mirror.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// mirrorSpec returns the spec of a copy: everything but the mirror selector
+// An allowedSubjects entry without a namespace means the source's namespace, so it is pinned there;
+// otherwise each copy would admit a ServiceAccount of the same name in its own namespace.
+func mirrorSpec(source *securityv1alpha1.ZenLock) securityv1alpha1.ZenLockSpec {
+	spec := securityv1alpha1.ZenLockSpec{
+		EncryptedData:     source.Spec.EncryptedData,
+		Algorithm:         source.Spec.Algorithm,
+		Checksums:         source.Spec.Checksums,
+		SecretType:        source.Spec.SecretType,
+		Immutable:         source.Spec.Immutable,
+		AllowedSubjects:   source.Spec.AllowedSubjects,
+		InjectionSelector: source.Spec.InjectionSelector,
+		// Node placement is a property of the secret, not of its namespace
+		RequiredNodeSelector: source.Spec.RequiredNodeSelector,
+		RequiredKeys:         source.Spec.RequiredKeys,
+		OptionalKeys:         source.Spec.OptionalKeys,
+		KeyID:                source.Spec.KeyID,
+		Encoding:             source.Spec.Encoding,
+	}
+	spec = *spec.DeepCopy()
+	for i := range spec.AllowedSubjects {
+		if spec.AllowedSubjects[i].Namespace == "" {
+			spec.AllowedSubjects[i].Namespace = source.Namespace
+		}
+	}
+	return spec
+}
+
+// mirrorAnnotations returns the annotations of a copy: the source's zen-lock/* annotations over the
+// copy's other annotations
+// Settings such as disabled, paused, key-case or expand-templates thus behave the same in every namespace,
+// and one removed from the source is removed from its copies.
+func mirrorAnnotations(source *securityv1alpha1.ZenLock, existing map[string]string) map[string]string {
+	annotations := make(map[string]string)
+	for k, v := range existing {
+		if !strings.HasPrefix(k, config.AnnotationPrefix) {
+			annotations[k] = v
+		}
+	}
+	for k, v := range source.Annotations {
+		if strings.HasPrefix(k, config.AnnotationPrefix) {
+			annotations[k] = v
+		}
+	}
+	if len(annotations) == 0 {
+		return nil
+	}
+	return annotations
+}
+
+// mirrorLabels returns the labels identifying a copy of the source
+func mirrorLabels(source *securityv1alpha1.ZenLock) map[string]string {
+	return map[string]string{
+		common.LabelManagedBy:               common.ManagedByMirror,
+		common.MirrorSourceNamespaceLabel(): source.Namespace,
+		common.MirrorSourceNameLabel():      source.Name,
+	}
+}
+
+// isMirror reports whether the ZenLock was created by the mirror controller
+func isMirror(zenlock *securityv1alpha1.ZenLock) bool {
+	return zenlock.Labels[common.LabelManagedBy] == common.ManagedByMirror
+}
+
+// isMirrorOf reports whether the ZenLock is a copy of the given source
+func isMirrorOf(zenlock *securityv1alpha1.ZenLock, sourceNamespace, sourceName string) bool {
+	return isMirror(zenlock) &&
+		zenlock.Labels[common.MirrorSourceNamespaceLabel()] == sourceNamespace &&
+		zenlock.Labels[common.MirrorSourceNameLabel()] == sourceName
+}
+
+// SetupWithManager sets up the controller with the Manager
+// Mirror changes requeue their source so edits to a copy are reverted; Namespace changes requeue every source.
+func (r *MirrorReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("zenlock-mirror").
+		For(&securityv1alpha1.ZenLock{}).
+		Watches(&securityv1alpha1.ZenLock{}, handler.EnqueueRequestsFromMapFunc(mirrorSource)).
+		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.mirrorSources)).
+		Complete(r)
+}
+
+// mirrorSource maps a mirror to its source ZenLock
+func mirrorSource(_ context.Context, obj client.Object) []reconcile.Request {
+	zenlock, ok := obj.(*securityv1alpha1.ZenLock)
+	if !ok || !isMirror(zenlock) {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{
+		Namespace: zenlock.Labels[common.MirrorSourceNamespaceLabel()],
+		Name:      zenlock.Labels[common.MirrorSourceNameLabel()],
+	}}}
+}
+
+// mirrorSources maps any event to every ZenLock with a MirrorNamespaceSelector
+func (r *MirrorReconciler) mirrorSources(ctx context.Context, _ client.Object) []reconcile.Request {
+	zenlocks := &securityv1alpha1.ZenLockList{}
+	if err := r.List(ctx, zenlocks); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list ZenLocks for mirroring")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, zenlock := range zenlocks.Items {
+		if zenlock.Spec.MirrorNamespaceSelector != nil {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: zenlock.Namespace, Name: zenlock.Name}})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,355 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
+	"github.com/kube-zen/zen-lock/pkg/webhook"
+)
+
+var mirrorSourceKey = types.NamespacedName{Name: "shared-creds", Namespace: "platform"}
+
+func setupMirrorReconciler(t *testing.T, objs ...client.Object) (*MirrorReconciler, client.Client) {
+	scheme := runtime.NewScheme()
+	if err := securityv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Failed to add securityv1alpha1 to scheme: %v", err)
+	}
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Failed to add corev1 to scheme: %v", err)
+	}
+
+	optIn := map[string]string{config.AnnotationMirrorSources: "platform"}
+	namespaces := []client.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "platform", Labels: map[string]string{"mirror": "true"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"mirror": "true"}, Annotations: optIn}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b", Labels: map[string]string{"mirror": "true"}, Annotations: optIn}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(namespaces, objs...)...).Build()
+	return NewMirrorReconciler(client, scheme), client
+}
+
+func newMirrorSource() *securityv1alpha1.ZenLock {
+	return &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{Name: mirrorSourceKey.Name, Namespace: mirrorSourceKey.Namespace},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData:           map[string]string{"API_KEY": "ZW5jcnlwdGVk"},
+			Algorithm:               "age",
+			AllowedSubjects:         []securityv1alpha1.SubjectReference{{Kind: "ServiceAccount", Name: "platform-sa"}},
+			MirrorNamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"mirror": "true"}},
+		},
+	}
+}
+
+func reconcileMirror(t *testing.T, reconciler *MirrorReconciler) {
+	t.Helper()
+	if _, err := reconciler.Reconcile(context.Background(), reconcile.Request{NamespacedName: mirrorSourceKey}); err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+}
+
+func getMirror(t *testing.T, c client.Client, namespace string) *securityv1alpha1.ZenLock {
+	t.Helper()
+	mirror := &securityv1alpha1.ZenLock{}
+	err := c.Get(context.Background(), types.NamespacedName{Name: mirrorSourceKey.Name, Namespace: namespace}, mirror)
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		t.Fatalf("Failed to get mirror in %s: %v", namespace, err)
+	}
+	return mirror
+}
+
+func TestMirrorReconciler_InitialMirroring(t *testing.T) {
+	reconciler, client := setupMirrorReconciler(t, newMirrorSource())
+
+	reconcileMirror(t, reconciler)
+
+	for _, ns := range []string{"team-a", "team-b"} {
+		mirror := getMirror(t, client, ns)
+		if mirror == nil {
+			t.Fatalf("Expected a mirror in %s", ns)
+		}
+		if mirror.Spec.EncryptedData["API_KEY"] != "ZW5jcnlwdGVk" || mirror.Spec.Algorithm != "age" {
+			t.Errorf("Mirror in %s has unexpected spec: %+v", ns, mirror.Spec)
+		}
+		if len(mirror.Spec.AllowedSubjects) != 1 || mirror.Spec.AllowedSubjects[0].Name != "platform-sa" ||
+			mirror.Spec.AllowedSubjects[0].Namespace != "platform" {
+			t.Errorf("Mirror in %s should keep the source's allowedSubjects pinned to its namespace: %+v", ns, mirror.Spec.AllowedSubjects)
+		}
+		if mirror.Spec.MirrorNamespaceSelector != nil {
+			t.Errorf("Mirror in %s should not inherit the mirror selector: %+v", ns, mirror.Spec)
+		}
+		if !isMirrorOf(mirror, mirrorSourceKey.Namespace, mirrorSourceKey.Name) {
+			t.Errorf("Mirror in %s missing tracking labels: %v", ns, mirror.Labels)
+		}
+	}
+	if getMirror(t, client, "other") != nil {
+		t.Error("Expected no mirror in a namespace not matching the selector")
+	}
+
+	source := &securityv1alpha1.ZenLock{}
+	if err := client.Get(context.Background(), mirrorSourceKey, source); err != nil {
+		t.Fatalf("Failed to get source: %v", err)
+	}
+	if isMirror(source) {
+		t.Error("Source should not be overwritten by its own mirror")
+	}
+	hasFinalizer := false
+	for _, f := range source.Finalizers {
+		if f == mirrorFinalizer {
+			hasFinalizer = true
+		}
+	}
+	if !hasFinalizer {
+		t.Errorf("Expected mirror finalizer on source, got %v", source.Finalizers)
+	}
+}
+
+func TestMirrorReconciler_PropagatesUpdate(t *testing.T) {
+	reconciler, client := setupMirrorReconciler(t, newMirrorSource())
+	ctx := context.Background()
+
+	reconcileMirror(t, reconciler)
+
+	source := &securityv1alpha1.ZenLock{}
+	if err := client.Get(ctx, mirrorSourceKey, source); err != nil {
+		t.Fatalf("Failed to get source: %v", err)
+	}
+	source.Spec.EncryptedData = map[string]string{"API_KEY": "cm90YXRlZA==", "NEW_KEY": "bmV3"}
+	if err := client.Update(ctx, source); err != nil {
+		t.Fatalf("Failed to update source: %v", err)
+	}
+
+	reconcileMirror(t, reconciler)
+
+	for _, ns := range []string{"team-a", "team-b"} {
+		mirror := getMirror(t, client, ns)
+		if mirror == nil {
+			t.Fatalf("Expected a mirror in %s", ns)
+		}
+		if mirror.Spec.EncryptedData["API_KEY"] != "cm90YXRlZA==" || mirror.Spec.EncryptedData["NEW_KEY"] != "bmV3" {
+			t.Errorf("Mirror in %s not updated: %v", ns, mirror.Spec.EncryptedData)
+		}
+	}
+}
+
+func TestMirrorReconciler_CleansUpOnSourceDeletion(t *testing.T) {
+	reconciler, client := setupMirrorReconciler(t, newMirrorSource())
+	ctx := context.Background()
+
+	reconcileMirror(t, reconciler)
+	if getMirror(t, client, "team-a") == nil {
+		t.Fatal("Expected a mirror in team-a before deletion")
+	}
+
+	source := &securityv1alpha1.ZenLock{}
+	if err := client.Get(ctx, mirrorSourceKey, source); err != nil {
+		t.Fatalf("Failed to get source: %v", err)
+	}
+	// The finalizer keeps the source around with a DeletionTimestamp
+	if err := client.Delete(ctx, source); err != nil {
+		t.Fatalf("Failed to delete source: %v", err)
+	}
+
+	reconcileMirror(t, reconciler)
+
+	for _, ns := range []string{"team-a", "team-b"} {
+		if getMirror(t, client, ns) != nil {
+			t.Errorf("Expected mirror in %s to be deleted", ns)
+		}
+	}
+	if err := client.Get(ctx, mirrorSourceKey, source); !k8serrors.IsNotFound(err) {
+		t.Errorf("Expected source to be gone once its finalizer was removed, got %v", err)
+	}
+}
+
+func TestMirrorReconciler_RemovesMirrorWhenNamespaceStopsMatching(t *testing.T) {
+	reconciler, client := setupMirrorReconciler(t, newMirrorSource())
+	ctx := context.Background()
+
+	reconcileMirror(t, reconciler)
+
+	ns := &corev1.Namespace{}
+	if err := client.Get(ctx, types.NamespacedName{Name: "team-b"}, ns); err != nil {
+		t.Fatalf("Failed to get namespace: %v", err)
+	}
+	delete(ns.Labels, "mirror")
+	if err := client.Update(ctx, ns); err != nil {
+		t.Fatalf("Failed to update namespace: %v", err)
+	}
+
+	reconcileMirror(t, reconciler)
+
+	if getMirror(t, client, "team-a") == nil {
+		t.Error("Expected mirror in team-a to be kept")
+	}
+	if getMirror(t, client, "team-b") != nil {
+		t.Error("Expected mirror in team-b to be deleted")
+	}
+}
+
+func TestMirrorReconciler_SkipsUnmanagedZenLock(t *testing.T) {
+	existing := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{Name: mirrorSourceKey.Name, Namespace: "team-a"},
+		Spec:       securityv1alpha1.ZenLockSpec{EncryptedData: map[string]string{"OWN_KEY": "b3du"}},
+	}
+	reconciler, client := setupMirrorReconciler(t, newMirrorSource(), existing)
+
+	reconcileMirror(t, reconciler)
+
+	got := getMirror(t, client, "team-a")
+	if got == nil || isMirror(got) || got.Spec.EncryptedData["OWN_KEY"] != "b3du" {
+		t.Errorf("Expected unmanaged ZenLock in team-a to be left alone, got %+v", got)
+	}
+	if getMirror(t, client, "team-b") == nil {
+		t.Error("Expected a mirror in team-b")
+	}
+}
+
+func TestMirrorReconciler_RequiresNamespaceOptIn(t *testing.T) {
+	// Selected by the source, but only accepting mirrors from another namespace
+	teamC := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:        "team-c",
+		Labels:      map[string]string{"mirror": "true"},
+		Annotations: map[string]string{config.AnnotationMirrorSources: "security, other-platform"},
+	}}
+	reconciler, client := setupMirrorReconciler(t, newMirrorSource(), teamC)
+	ctx := context.Background()
+
+	reconcileMirror(t, reconciler)
+	if getMirror(t, client, "team-c") != nil {
+		t.Error("Expected no mirror in a namespace that did not opt in to the source namespace")
+	}
+
+	// Withdrawing the opt-in deletes the existing mirror
+	ns := &corev1.Namespace{}
+	if err := client.Get(ctx, types.NamespacedName{Name: "team-b"}, ns); err != nil {
+		t.Fatalf("Failed to get namespace: %v", err)
+	}
+	delete(ns.Annotations, config.AnnotationMirrorSources)
+	if err := client.Update(ctx, ns); err != nil {
+		t.Fatalf("Failed to update namespace: %v", err)
+	}
+
+	reconcileMirror(t, reconciler)
+
+	if getMirror(t, client, "team-a") == nil {
+		t.Error("Expected mirror in team-a to be kept")
+	}
+	if getMirror(t, client, "team-b") != nil {
+		t.Error("Expected mirror in team-b to be deleted once it stopped accepting mirrors")
+	}
+}
+
+func TestMirrorReconciler_PinsSubjectsToSourceNamespace(t *testing.T) {
+	source := newMirrorSource()
+	source.Spec.AllowedSubjects = append(source.Spec.AllowedSubjects,
+		securityv1alpha1.SubjectReference{Kind: "ServiceAccount", Name: "team-a-sa", Namespace: "team-a"})
+	reconciler, client := setupMirrorReconciler(t, source)
+
+	reconcileMirror(t, reconciler)
+
+	mirror := getMirror(t, client, "team-a")
+	if mirror == nil {
+		t.Fatal("Expected a mirror in team-a")
+	}
+	// A ServiceAccount named like the source's one, but in the mirror's namespace
+	lookalike := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "team-a"},
+		Spec:       corev1.PodSpec{ServiceAccountName: "platform-sa"},
+	}
+	if err := webhook.AuthorizeInjection(lookalike, mirror); err == nil {
+		t.Error("Expected the mirror to refuse a same-named ServiceAccount in its own namespace")
+	}
+	listed := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "team-a"},
+		Spec:       corev1.PodSpec{ServiceAccountName: "team-a-sa"},
+	}
+	if err := webhook.AuthorizeInjection(listed, mirror); err != nil {
+		t.Errorf("Expected the mirror to admit a subject listed for its namespace, got %v", err)
+	}
+}
+
+func TestMirrorReconciler_PropagatesAnnotations(t *testing.T) {
+	source := newMirrorSource()
+	source.Annotations = map[string]string{
+		config.AnnotationKeyCase: "upper",
+		"example.com/owner":      "platform-team",
+	}
+	reconciler, client := setupMirrorReconciler(t, source)
+	ctx := context.Background()
+
+	reconcileMirror(t, reconciler)
+
+	mirror := getMirror(t, client, "team-a")
+	if mirror == nil {
+		t.Fatal("Expected a mirror in team-a")
+	}
+	if mirror.Annotations[config.AnnotationKeyCase] != "upper" {
+		t.Errorf("Expected the source's zen-lock annotations on the mirror, got %v", mirror.Annotations)
+	}
+	if _, ok := mirror.Annotations["example.com/owner"]; ok {
+		t.Errorf("Expected foreign annotations to stay on the source, got %v", mirror.Annotations)
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "platform"},
+		Spec:       corev1.PodSpec{ServiceAccountName: "platform-sa"},
+	}
+	if err := webhook.AuthorizeInjection(pod, mirror); err != nil {
+		t.Fatalf("Expected the mirror to be injectable before disabling, got %v", err)
+	}
+
+	// Disabling the source disables every copy; dropping a setting drops it from the copies
+	if err := client.Get(ctx, mirrorSourceKey, source); err != nil {
+		t.Fatalf("Failed to get source: %v", err)
+	}
+	source.Annotations = map[string]string{config.AnnotationDisabled: "true"}
+	if err := client.Update(ctx, source); err != nil {
+		t.Fatalf("Failed to update source: %v", err)
+	}
+
+	reconcileMirror(t, reconciler)
+
+	for _, ns := range []string{"team-a", "team-b"} {
+		mirror := getMirror(t, client, ns)
+		if mirror == nil {
+			t.Fatalf("Expected a mirror in %s", ns)
+		}
+		if _, ok := mirror.Annotations[config.AnnotationKeyCase]; ok {
+			t.Errorf("Expected the removed key-case setting to be dropped in %s, got %v", ns, mirror.Annotations)
+		}
+		if err := webhook.AuthorizeInjection(pod, mirror); err == nil {
+			t.Errorf("Expected the mirror in %s to refuse injection once the source is disabled", ns)
+		}
+	}
+}
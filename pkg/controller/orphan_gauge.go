@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kube-zen/zen-lock/pkg/common"
+	"github.com/kube-zen/zen-lock/pkg/controller/metrics"
+)
+
+// orphanSecretsGauge periodically publishes the number of orphaned Secrets approaching the orphan TTL
+// An orphan is a zen-lock Secret with no OwnerReference whose Pod does not exist. Only those in the second
+// half of their TTL are counted: a younger Secret usually belongs to a Pod that is still being created.
+// Orphans past the TTL stay counted until they are deleted, so stuck cleanup keeps the gauge up.
+type orphanSecretsGauge struct {
+	reader   client.Reader
+	ttl      time.Duration
+	interval time.Duration
+
+	// reported holds the namespaces of the last update, so namespaces left without orphans drop to zero
+	reported map[string]bool
+}
+
+// newOrphanSecretsGauge creates a gauge updater listing Secrets and Pods through reader every interval
+func newOrphanSecretsGauge(reader client.Reader, ttl, interval time.Duration) *orphanSecretsGauge {
+	return &orphanSecretsGauge{reader: reader, ttl: ttl, interval: interval, reported: map[string]bool{}}
+}
+
+// Start updates the gauge every interval until ctx is done (manager.Runnable)
+func (g *orphanSecretsGauge) Start(ctx context.Context) error {
+	ticker := time.NewTicker(g.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			g.update(ctx)
+		}
+	}
+}
+
+// NeedLeaderElection runs the gauge on the leader only, like the Secret controller that cleans up orphans
+func (g *orphanSecretsGauge) NeedLeaderElection() bool {
+	return true
+}
+
+// update counts the pending orphans of every namespace with one Secret list and one Pod read per candidate
+func (g *orphanSecretsGauge) update(ctx context.Context) {
+	secrets := &corev1.SecretList{}
+	if err := g.reader.List(ctx, secrets, client.HasLabels{common.PodNameLabel(), common.PodNamespaceLabel()}); err != nil {
+		log.FromContext(ctx).V(4).Info("Failed to list zen-lock secrets for orphan metric", "error", err)
+		return
+	}
+
+	pending := make(map[string]int)
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		if len(secret.OwnerReferences) > 0 || !secret.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if time.Since(secret.CreationTimestamp.Time) < g.ttl/2 {
+			continue
+		}
+		podKey := types.NamespacedName{
+			Name:      secret.Labels[common.PodNameLabel()],
+			Namespace: secret.Labels[common.PodNamespaceLabel()],
+		}
+		if err := g.reader.Get(ctx, podKey, &corev1.Pod{}); k8serrors.IsNotFound(err) {
+			pending[secret.Namespace]++
+		}
+	}
+
+	for namespace := range g.reported {
+		if _, ok := pending[namespace]; !ok {
+			metrics.SetOrphanSecretsPending(namespace, 0)
+		}
+	}
+	g.reported = make(map[string]bool, len(pending))
+	for namespace, count := range pending {
+		metrics.SetOrphanSecretsPending(namespace, count)
+		g.reported[namespace] = true
+	}
+}
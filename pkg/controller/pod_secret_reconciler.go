@@ -0,0 +1,272 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/common"
+	"github.com/kube-zen/zen-lock/pkg/config"
+	"github.com/kube-zen/zen-lock/pkg/controller/metrics"
+	"github.com/kube-zen/zen-lock/pkg/crypto"
+	"github.com/kube-zen/zen-lock/pkg/webhook"
+	"github.com/kube-zen/zen-sdk/pkg/lifecycle"
+)
+
+// PodSecretReconciler creates the Secrets for Pods whose injection was delegated by the webhook
+// Used when the webhook runs with ZEN_LOCK_WEBHOOK_CREATE_SECRET=false.
+type PodSecretReconciler struct {
+	client.Client
+	Scheme     *runtime.Scheme
+	crypto     crypto.Encryptor
+	privateKey string
+
+	// immutableByDefault makes Secrets immutable unless the ZenLock or its namespace says otherwise (ZEN_LOCK_IMMUTABLE_SECRETS)
+	immutableByDefault bool
+	// blockOwnerDeletion sets blockOwnerDeletion on the Secret's owner reference (ZEN_LOCK_BLOCK_OWNER_DELETION)
+	blockOwnerDeletion bool
+	// adoptUnmanagedSecrets lets the controller overwrite an unlabeled Secret at its target name (ZEN_LOCK_ADOPT_UNMANAGED_SECRETS)
+	adoptUnmanagedSecrets bool
+	// policyHook is consulted before each Secret is created, as in the webhook (ZEN_LOCK_POLICY_URL)
+	policyHook *webhook.PolicyHook
+}
+
+// NewPodSecretReconciler creates a new PodSecretReconciler
+func NewPodSecretReconciler(client client.Client, scheme *runtime.Scheme) (*PodSecretReconciler, error) {
+	privateKey := crypto.ResolvePrivateKey()
+	if privateKey == "" {
+		return nil, fmt.Errorf("ZEN_LOCK_PRIVATE_KEY environment variable is not set and ZEN_LOCK_IDENTITIES_DIR provided no identities")
+	}
+	adoptUnmanagedSecrets, _ := strconv.ParseBool(os.Getenv("ZEN_LOCK_ADOPT_UNMANAGED_SECRETS"))
+	policyHook, err := webhook.PolicyHookFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	return &PodSecretReconciler{
+		Client:                client,
+		Scheme:                scheme,
+		crypto:                crypto.NewAgeEncryptor(),
+		privateKey:            privateKey,
+		immutableByDefault:    webhook.SecretsImmutableByDefault(),
+		blockOwnerDeletion:    common.BlockOwnerDeletion(),
+		adoptUnmanagedSecrets: adoptUnmanagedSecrets,
+		policyHook:            policyHook,
+	}, nil
+}
+
+//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;create;update;delete
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
+// Reconcile creates or refreshes the delegated Secrets of a Pod
+func (r *PodSecretReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	pod := &corev1.Pod{}
+	if err := r.Get(ctx, req.NamespacedName, pod); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	// Terminated Pods have their Secrets deleted by the SecretReconciler; do not recreate them
+	if lifecycle.IsDeleting(pod) || podTerminated(pod) {
+		return ctrl.Result{}, nil
+	}
+
+	// The annotation only says which ZenLocks to materialize: anyone able to annotate the Pod can edit it,
+	// so Secret names are derived from the Pod and access is checked again
+	zenlockNames := webhook.DelegatedZenLocks(pod)
+	secrets := webhook.DelegatedSecretNames(pod, zenlockNames)
+	var errs []error
+	for _, zenlockName := range zenlockNames {
+		secretName := secrets[zenlockName]
+		if err := r.materializeSecret(ctx, pod, zenlockName, secretName); err != nil {
+			logger.Error(err, "Failed to create delegated Secret", "zenlock", zenlockName, "secret", secretName)
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		// Pod stays in ContainerCreating until the Secret exists; retry with backoff
+		return ctrl.Result{}, errors.Join(errs...)
+	}
+	return ctrl.Result{}, nil
+}
+
+// materializeSecret decrypts a ZenLock into the named Secret, owned by the Pod, once the Pod may use it
+func (r *PodSecretReconciler) materializeSecret(ctx context.Context, pod *corev1.Pod, zenlockName, secretName string) error {
+	zenlock := &securityv1alpha1.ZenLock{}
+	if err := r.Get(ctx, types.NamespacedName{Name: zenlockName, Namespace: pod.Namespace}, zenlock); err != nil {
+		return fmt.Errorf("failed to get ZenLock: %w", err)
+	}
+
+	if err := webhook.AuthorizeInjection(pod, zenlock); err != nil {
+		return err
+	}
+	if r.policyHook != nil {
+		if err := r.policyHook.Authorize(ctx, pod, zenlockName); err != nil {
+			return err
+		}
+	}
+
+	metrics.RecordKeyUse(metrics.ComponentController)
+	decryptStart := time.Now()
+	var decrypted map[string][]byte
+	var omitted []string
+	encryptedData, err := crypto.EncryptedDataAsBase64(zenlock.Spec.EncryptedData, zenlock.Spec.Encoding)
+	if err == nil {
+		decrypted, omitted, err = crypto.DecryptMapOptional(r.crypto, encryptedData, crypto.SelectKeyID(r.privateKey, zenlock.Spec.KeyID), zenlock.Spec.OptionalKeys)
+	}
+	decryptDuration := time.Since(decryptStart).Seconds()
+	if err == nil {
+		err = crypto.VerifyChecksums(decrypted, crypto.OmitKeys(zenlock.Spec.Checksums, omitted))
+	}
+	if err != nil {
+		metrics.RecordDecryption(ctx, pod.Namespace, zenlockName, "error", decryptDuration)
+		return webhook.SanitizeError(err, "decrypt ZenLock")
+	}
+	metrics.RecordDecryption(ctx, pod.Namespace, zenlockName, "success", decryptDuration)
+	if len(omitted) > 0 {
+		log.FromContext(ctx).Info("Omitting optional keys that failed to decrypt", "zenlock", zenlockName, "keys", common.RedactKeyNames(omitted))
+	}
+
+	// Resolve ${key} references between keys (opt-in per ZenLock)
+	if webhook.TemplatesEnabled(zenlock) {
+		if decrypted, err = webhook.ExpandTemplates(decrypted); err != nil {
+			return common.RedactError(err)
+		}
+	}
+	if decrypted, err = webhook.ApplyConcatenation(zenlock, decrypted); err != nil {
+		return common.RedactError(err)
+	}
+	if decrypted, err = webhook.ApplyKeyExtensions(zenlock, decrypted); err != nil {
+		return common.RedactError(err)
+	}
+	if decrypted, err = webhook.ApplyKeyCase(zenlock, decrypted); err != nil {
+		return common.RedactError(err)
+	}
+
+	secretData := make(map[string][]byte, len(decrypted))
+	for k, v := range decrypted {
+		// Keep intentionally empty values: an empty key is distinct from a missing one
+		if v == nil {
+			v = []byte{}
+		}
+		secretData[k] = v
+	}
+	if err := webhook.ValidateSecretType(zenlock.Spec.SecretType, secretData); err != nil {
+		return common.RedactError(err)
+	}
+
+	secretType := zenlock.Spec.SecretType
+	if secretType == "" {
+		secretType = corev1.SecretTypeOpaque
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretName,
+			Namespace: pod.Namespace,
+			Labels: map[string]string{
+				common.PodNameLabel():      pod.Name,
+				common.PodNamespaceLabel(): pod.Namespace,
+				common.ZenLockNameLabel():  zenlockName,
+			},
+		},
+		Type: secretType,
+		Data: secretData,
+	}
+	// The Pod already exists, so ownership can be set up front.
+	// Shared (ZenLock-named) Secrets are owned by the ZenLock and carry no Pod labels.
+	var owner client.Object = pod
+	if secretName == webhook.GenerateZenLockSecretName(zenlockName) {
+		owner = zenlock
+		delete(secret.Labels, common.PodNameLabel())
+		delete(secret.Labels, common.PodNamespaceLabel())
+	}
+	if err := controllerutil.SetControllerReference(owner, secret, r.Scheme, controllerutil.WithBlockOwnerDeletion(r.blockOwnerDeletion)); err != nil {
+		return fmt.Errorf("failed to set controller reference: %w", err)
+	}
+	immutable, err := webhook.ResolveSecretImmutability(ctx, r.Client, zenlock, r.immutableByDefault)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to read namespace immutability default, using the global default", "namespace", pod.Namespace)
+	}
+	if immutable {
+		secret.Immutable = &immutable
+	}
+
+	err = r.Create(ctx, secret)
+	if err == nil || !k8serrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	// Refresh an existing Secret if it is stale
+	existing := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Name: secretName, Namespace: pod.Namespace}, existing); err != nil {
+		return err
+	}
+	// Never overwrite a Secret zen-lock does not manage, or one holding another ZenLock
+	userManaged := webhook.IsUserManagedSecret(existing)
+	if userManaged && !r.adoptUnmanagedSecrets {
+		return &webhook.SecretCollisionError{Name: secretName}
+	}
+	if !userManaged && existing.Labels[common.ZenLockNameLabel()] != zenlockName {
+		return fmt.Errorf("Secret %q already holds ZenLock %q, not %q", secretName, existing.Labels[common.ZenLockNameLabel()], zenlockName)
+	}
+	fresh := !userManaged && secretDataEqual(existing.Data, secretData)
+	if fresh && webhook.SecretImmutable(existing) == immutable {
+		return nil
+	}
+	// Immutable Secrets cannot be updated, so they are recreated
+	if webhook.SecretImmutable(existing) {
+		return webhook.ReplaceSecret(ctx, r.Client, existing, secret)
+	}
+	existing.Data = secretData
+	existing.Immutable = secret.Immutable
+	if existing.Labels == nil {
+		existing.Labels = make(map[string]string, 3)
+	}
+	for k, v := range secret.Labels {
+		existing.Labels[k] = v
+	}
+	return r.Update(ctx, existing)
+}
+
+// secretDataEqual checks if two secret data maps are equal
+func secretDataEqual(a, b map[string][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		other, ok := b[k]
+		if !ok || string(other) != string(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// SetupWithManager sets up the controller with the Manager, watching only Pods with delegated Secrets
+func (r *PodSecretReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	hasDelegatedSecrets := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		_, ok := obj.GetAnnotations()[config.AnnotationDelegatedSecrets]
+		return ok
+	})
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("pod-secret").
+		For(&corev1.Pod{}, builder.WithPredicates(hasDelegatedSecrets)).
+		Complete(r)
+}
@@ -0,0 +1,318 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/common"
+	"github.com/kube-zen/zen-lock/pkg/config"
+	"github.com/kube-zen/zen-lock/pkg/crypto"
+	"github.com/kube-zen/zen-lock/pkg/webhook"
+)
+
+func setupTestPodSecretReconciler(t *testing.T, zenlockData map[string]string) (*PodSecretReconciler, *fake.ClientBuilder) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	t.Setenv("ZEN_LOCK_PRIVATE_KEY", identity.String())
+
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Failed to add corev1 to scheme: %v", err)
+	}
+	if err := securityv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Failed to add securityv1alpha1 to scheme: %v", err)
+	}
+
+	encryptedData := make(map[string]string, len(zenlockData))
+	for k, v := range zenlockData {
+		ciphertext, err := crypto.NewAgeEncryptor().Encrypt([]byte(v), []string{identity.Recipient().String()})
+		if err != nil {
+			t.Fatalf("Failed to encrypt: %v", err)
+		}
+		encryptedData[k] = base64.StdEncoding.EncodeToString(ciphertext)
+	}
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-zenlock", Namespace: "default"},
+		Spec:       securityv1alpha1.ZenLockSpec{EncryptedData: encryptedData},
+	}
+
+	clientBuilder := fake.NewClientBuilder().WithScheme(scheme).WithObjects(zenlock)
+	reconciler, err := NewPodSecretReconciler(clientBuilder.Build(), scheme)
+	if err != nil {
+		t.Fatalf("Failed to create reconciler: %v", err)
+	}
+	return reconciler, clientBuilder
+}
+
+func TestPodSecretReconciler_CreatesDelegatedSecret(t *testing.T) {
+	reconciler, clientBuilder := setupTestPodSecretReconciler(t, map[string]string{"USERNAME": "admin"})
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pod",
+			Namespace: "default",
+			UID:       "pod-uid",
+			Annotations: map[string]string{
+				config.AnnotationDelegatedSecrets: "test-zenlock=zen-lock-inject-default-test-pod",
+			},
+		},
+	}
+	reconciler.Client = clientBuilder.WithObjects(pod).Build()
+
+	ctx := context.Background()
+	podKey := types.NamespacedName{Name: "test-pod", Namespace: "default"}
+	if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: podKey}); err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+
+	secret := &corev1.Secret{}
+	if err := reconciler.Get(ctx, types.NamespacedName{Name: "zen-lock-inject-default-test-pod", Namespace: "default"}, secret); err != nil {
+		t.Fatalf("Expected Secret to be created: %v", err)
+	}
+	if string(secret.Data["USERNAME"]) != "admin" {
+		t.Errorf("Expected USERNAME=admin, got %q", secret.Data["USERNAME"])
+	}
+	if secret.Labels[common.LabelZenLockName] != "test-zenlock" || secret.Labels[common.LabelPodName] != "test-pod" {
+		t.Errorf("Expected zen-lock labels, got %v", secret.Labels)
+	}
+	if len(secret.OwnerReferences) != 1 || secret.OwnerReferences[0].UID != "pod-uid" {
+		t.Errorf("Expected Secret to be owned by the Pod, got %+v", secret.OwnerReferences)
+	}
+	if secret.Type != corev1.SecretTypeOpaque {
+		t.Errorf("Expected Opaque Secret, got %q", secret.Type)
+	}
+
+	// Reconciling again is a no-op
+	if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: podKey}); err != nil {
+		t.Fatalf("Second reconcile returned error: %v", err)
+	}
+}
+
+func TestPodSecretReconciler_IgnoresPodsWithoutAnnotation(t *testing.T) {
+	reconciler, clientBuilder := setupTestPodSecretReconciler(t, map[string]string{"USERNAME": "admin"})
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "default"},
+	}
+	reconciler.Client = clientBuilder.WithObjects(pod).Build()
+
+	ctx := context.Background()
+	if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-pod", Namespace: "default"}}); err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+
+	secrets := &corev1.SecretList{}
+	if err := reconciler.List(ctx, secrets); err != nil {
+		t.Fatalf("Failed to list secrets: %v", err)
+	}
+	if len(secrets.Items) != 0 {
+		t.Errorf("Expected no Secrets, got %d", len(secrets.Items))
+	}
+}
+
+func TestPodSecretReconciler_MissingZenLock(t *testing.T) {
+	reconciler, clientBuilder := setupTestPodSecretReconciler(t, map[string]string{"USERNAME": "admin"})
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pod",
+			Namespace: "default",
+			Annotations: map[string]string{
+				config.AnnotationDelegatedSecrets: "missing=zen-lock-inject-default-test-pod",
+			},
+		},
+	}
+	reconciler.Client = clientBuilder.WithObjects(pod).Build()
+
+	if _, err := reconciler.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-pod", Namespace: "default"}}); err == nil {
+		t.Error("Expected error for missing ZenLock so the Pod is retried")
+	}
+}
+
+func TestPodSecretReconciler_RecreatesStaleImmutableSecret(t *testing.T) {
+	reconciler, clientBuilder := setupTestPodSecretReconciler(t, map[string]string{"USERNAME": "admin"})
+
+	immutable := true
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pod",
+			Namespace: "default",
+			UID:       "pod-uid",
+			Annotations: map[string]string{
+				config.AnnotationDelegatedSecrets: "test-zenlock=zen-lock-inject-default-test-pod",
+			},
+		},
+	}
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:        "default",
+		Annotations: map[string]string{config.AnnotationDefaultImmutable: "true"},
+	}}
+	stale := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "zen-lock-inject-default-test-pod",
+			Namespace: "default",
+			Labels:    map[string]string{common.LabelZenLockName: "test-zenlock"},
+		},
+		Data:      map[string][]byte{"USERNAME": []byte("stale")},
+		Immutable: &immutable,
+	}
+	reconciler.Client = clientBuilder.WithObjects(pod, namespace, stale).Build()
+
+	ctx := context.Background()
+	podKey := types.NamespacedName{Name: "test-pod", Namespace: "default"}
+	if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: podKey}); err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+
+	secret := &corev1.Secret{}
+	if err := reconciler.Get(ctx, types.NamespacedName{Name: "zen-lock-inject-default-test-pod", Namespace: "default"}, secret); err != nil {
+		t.Fatalf("Expected Secret to exist: %v", err)
+	}
+	if string(secret.Data["USERNAME"]) != "admin" || secret.Immutable == nil || !*secret.Immutable {
+		t.Errorf("Expected an immutable Secret with fresh data, got immutable=%v data=%q", secret.Immutable, secret.Data["USERNAME"])
+	}
+	if len(secret.OwnerReferences) != 1 || secret.OwnerReferences[0].UID != "pod-uid" {
+		t.Errorf("Expected the recreated Secret to be owned by the Pod, got %+v", secret.OwnerReferences)
+	}
+}
+
+func delegatedTestPod(annotation string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-pod",
+			Namespace:   "default",
+			UID:         "pod-uid",
+			Annotations: map[string]string{config.AnnotationDelegatedSecrets: annotation},
+		},
+	}
+}
+
+func TestPodSecretReconciler_IgnoresSuppliedSecretName(t *testing.T) {
+	reconciler, clientBuilder := setupTestPodSecretReconciler(t, map[string]string{"USERNAME": "admin"})
+
+	// The annotation names another workload's Secret; only the ZenLock name is used
+	victim := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "victim-secret",
+			Namespace: "default",
+			Labels:    map[string]string{common.LabelZenLockName: "test-zenlock", common.LabelPodName: "victim"},
+		},
+		Data: map[string][]byte{"USERNAME": []byte("victim")},
+	}
+	reconciler.Client = clientBuilder.WithObjects(delegatedTestPod("test-zenlock=victim-secret"), victim).Build()
+
+	ctx := context.Background()
+	if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-pod", Namespace: "default"}}); err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+
+	secret := &corev1.Secret{}
+	if err := reconciler.Get(ctx, types.NamespacedName{Name: "zen-lock-inject-default-test-pod", Namespace: "default"}, secret); err != nil {
+		t.Fatalf("Expected the Secret at the derived name: %v", err)
+	}
+	if err := reconciler.Get(ctx, types.NamespacedName{Name: "victim-secret", Namespace: "default"}, secret); err != nil {
+		t.Fatalf("Failed to get victim Secret: %v", err)
+	}
+	if string(secret.Data["USERNAME"]) != "victim" || secret.Labels[common.LabelPodName] != "victim" {
+		t.Errorf("Expected the named Secret to be left alone, got labels %v", secret.Labels)
+	}
+}
+
+func TestPodSecretReconciler_ChecksAllowedSubjects(t *testing.T) {
+	reconciler, clientBuilder := setupTestPodSecretReconciler(t, map[string]string{"USERNAME": "admin"})
+	reconciler.Client = clientBuilder.WithObjects(delegatedTestPod("test-zenlock=zen-lock-inject-default-test-pod")).Build()
+
+	ctx := context.Background()
+	zenlock := &securityv1alpha1.ZenLock{}
+	if err := reconciler.Get(ctx, types.NamespacedName{Name: "test-zenlock", Namespace: "default"}, zenlock); err != nil {
+		t.Fatalf("Failed to get ZenLock: %v", err)
+	}
+	zenlock.Spec.AllowedSubjects = []securityv1alpha1.SubjectReference{{Kind: "ServiceAccount", Name: "backend"}}
+	if err := reconciler.Update(ctx, zenlock); err != nil {
+		t.Fatalf("Failed to update ZenLock: %v", err)
+	}
+
+	if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-pod", Namespace: "default"}}); err == nil {
+		t.Error("Expected error for a ServiceAccount outside allowedSubjects")
+	}
+	secrets := &corev1.SecretList{}
+	if err := reconciler.List(ctx, secrets); err != nil {
+		t.Fatalf("Failed to list secrets: %v", err)
+	}
+	if len(secrets.Items) != 0 {
+		t.Errorf("Expected no Secrets, got %d", len(secrets.Items))
+	}
+}
+
+func TestPodSecretReconciler_ConsultsPolicyHook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"allowed":false,"reason":"not on weekends"}`))
+	}))
+	defer server.Close()
+	t.Setenv("ZEN_LOCK_POLICY_URL", server.URL)
+
+	reconciler, clientBuilder := setupTestPodSecretReconciler(t, map[string]string{"USERNAME": "admin"})
+	reconciler.Client = clientBuilder.WithObjects(delegatedTestPod("test-zenlock=zen-lock-inject-default-test-pod")).Build()
+
+	_, err := reconciler.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-pod", Namespace: "default"}})
+	if err == nil || !strings.Contains(err.Error(), "not on weekends") {
+		t.Errorf("Expected the policy denial, got %v", err)
+	}
+}
+
+func TestPodSecretReconciler_RefusesUserManagedSecret(t *testing.T) {
+	reconciler, clientBuilder := setupTestPodSecretReconciler(t, map[string]string{"USERNAME": "admin"})
+
+	userSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "zen-lock-inject-default-test-pod", Namespace: "default"},
+		Data:       map[string][]byte{"USERNAME": []byte("mine")},
+	}
+	reconciler.Client = clientBuilder.WithObjects(delegatedTestPod("test-zenlock=zen-lock-inject-default-test-pod"), userSecret).Build()
+
+	ctx := context.Background()
+	_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-pod", Namespace: "default"}})
+	var collision *webhook.SecretCollisionError
+	if !errors.As(err, &collision) {
+		t.Fatalf("Expected SecretCollisionError, got %v", err)
+	}
+
+	secret := &corev1.Secret{}
+	if err := reconciler.Get(ctx, types.NamespacedName{Name: "zen-lock-inject-default-test-pod", Namespace: "default"}, secret); err != nil {
+		t.Fatalf("Failed to get Secret: %v", err)
+	}
+	if string(secret.Data["USERNAME"]) != "mine" {
+		t.Errorf("Expected the user-managed Secret to be left alone, got %q", secret.Data["USERNAME"])
+	}
+}
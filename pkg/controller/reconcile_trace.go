@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/common"
+	"github.com/kube-zen/zen-lock/pkg/webhook"
+)
+
+// reconcileTrace summarizes one ZenLock reconcile, logged as a single structured line (ZEN_LOCK_RECONCILE_TRACE)
+// It never carries plaintext: decryption is reported per key as a boolean, under the redacted key name.
+type reconcileTrace struct {
+	Object     string `json:"object"`
+	Generation int64  `json:"generation"`
+	// Decrypt maps each key to whether it decrypted; absent when decryption was not attempted
+	Decrypt          map[string]bool `json:"decrypt,omitempty"`
+	CacheInvalidated bool            `json:"cacheInvalidated"`
+	PhaseFrom        string          `json:"phaseFrom"`
+	PhaseTo          string          `json:"phaseTo"`
+	DurationMs       int64           `json:"durationMs"`
+
+	start   time.Time
+	zenlock *securityv1alpha1.ZenLock
+}
+
+type reconcileTraceKey struct{}
+
+// startTrace returns a context carrying a new trace for req, or ctx and nil when tracing is off
+func (r *ZenLockReconciler) startTrace(ctx context.Context, req types.NamespacedName) (context.Context, *reconcileTrace) {
+	if !r.traceReconciles {
+		return ctx, nil
+	}
+	trace := &reconcileTrace{Object: req.String(), start: time.Now()}
+	return context.WithValue(ctx, reconcileTraceKey{}, trace), trace
+}
+
+// observe records the fetched ZenLock; its phase at the end of the reconcile is the transition's target
+func (t *reconcileTrace) observe(zenlock *securityv1alpha1.ZenLock) {
+	if t == nil {
+		return
+	}
+	t.Generation = zenlock.Generation
+	t.PhaseFrom = zenlock.Status.Phase
+	t.zenlock = zenlock
+}
+
+// traceDecryption records which keys decrypt
+// After a failure each key is decrypted on its own, so the trace names every failing key, not just the first.
+// encryptedData is nil when the values could not be decoded, in which case every key failed.
+func (r *ZenLockReconciler) traceDecryption(trace *reconcileTrace, zenlock *securityv1alpha1.ZenLock, encryptedData map[string]string, identities string, decrypted map[string][]byte, err error) {
+	if trace == nil {
+		return
+	}
+	trace.Decrypt = make(map[string]bool, len(zenlock.Spec.EncryptedData))
+	for key := range zenlock.Spec.EncryptedData {
+		ok := false
+		switch {
+		case encryptedData == nil:
+		case err == nil:
+			_, ok = decrypted[key]
+		default:
+			_, keyErr := r.crypto.DecryptMap(map[string]string{key: encryptedData[key]}, identities)
+			ok = keyErr == nil
+		}
+		trace.Decrypt[common.RedactKeyName(key)] = ok
+	}
+}
+
+// log writes the trace; a nil trace logs nothing
+func (t *reconcileTrace) log(logger logr.Logger) {
+	if t == nil {
+		return
+	}
+	if t.zenlock != nil {
+		t.PhaseTo = t.zenlock.Status.Phase
+	}
+	t.DurationMs = time.Since(t.start).Milliseconds()
+	logger.Info("Reconcile summary", "summary", t)
+}
+
+// invalidateZenLock evicts the ZenLock from the webhook cache, noting it in the reconcile's trace if any
+func invalidateZenLock(ctx context.Context, key types.NamespacedName) {
+	webhook.InvalidateZenLock(key)
+	if trace, ok := ctx.Value(reconcileTraceKey{}).(*reconcileTrace); ok {
+		trace.CacheInvalidated = true
+	}
+}
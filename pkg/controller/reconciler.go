@@ -2,16 +2,29 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
 	"github.com/kube-zen/zen-lock/pkg/common"
@@ -29,41 +42,207 @@ type ZenLockReconciler struct {
 	Scheme     *runtime.Scheme
 	crypto     crypto.Encryptor
 	privateKey string // Cached private key to avoid repeated env lookups
+
+	// Recorder emits Kubernetes Events for the ZenLock (optional; nil disables events)
+	Recorder record.EventRecorder
+
+	// mirrorReadyCondition maintains a conventional Ready condition alongside Decryptable
+	mirrorReadyCondition bool
+
+	// keyMissingRequeue is the requeue delay while no private key is configured (0 = default)
+	keyMissingRequeue time.Duration
+	// failureRequeueBase and failureRequeueMax bound the backoff for failing ZenLocks (0 = default)
+	failureRequeueBase time.Duration
+	failureRequeueMax  time.Duration
+	// failures counts consecutive decryption/verification failures per ZenLock
+	failures failureBackoff
+
+	// finalizer is the finalizer this instance manages (ZEN_LOCK_FINALIZER; "" = zenLockFinalizer)
+	finalizer string
+
+	// rotationIdentity is the identity being rotated to (ZEN_LOCK_ROTATION_RECIPIENT; "" disables tracking)
+	rotationIdentity string
+	// rotation tracks ZenLocks only decryptable by the other identities
+	rotation rotationTracker
+	// specSizes records ZenLock sizes once per generation
+	specSizes specSizeTracker
+
+	// maxConcurrentReconciles is the number of ZenLocks reconciled in parallel (ZEN_LOCK_MAX_CONCURRENT_RECONCILES)
+	maxConcurrentReconciles int
+
+	// selector restricts this instance to matching ZenLocks (ZEN_LOCK_CONTROLLER_SELECTOR; nil selects all)
+	selector labels.Selector
+
+	// pruneRemovedKeys drops keys removed from a ZenLock from its injected Secrets (ZEN_LOCK_PRUNE_REMOVED_KEYS)
+	pruneRemovedKeys bool
+	// traceReconciles logs a structured summary of every reconcile (ZEN_LOCK_RECONCILE_TRACE)
+	traceReconciles bool
 }
 
 // NewZenLockReconciler creates a new ZenLockReconciler
 // Leader election is handled by controller-runtime Manager, not in the reconciler
 func NewZenLockReconciler(client client.Client, scheme *runtime.Scheme) (*ZenLockReconciler, error) {
 	// Load private key from environment
-	privateKey := os.Getenv("ZEN_LOCK_PRIVATE_KEY")
+	privateKey := crypto.ResolvePrivateKey()
 	if privateKey == "" {
-		return nil, fmt.Errorf("ZEN_LOCK_PRIVATE_KEY environment variable is not set")
+		return nil, fmt.Errorf("ZEN_LOCK_PRIVATE_KEY environment variable is not set and ZEN_LOCK_IDENTITIES_DIR provided no identities")
 	}
 
 	// Initialize crypto
 	encryptor := crypto.NewAgeEncryptor()
 
+	// Mirror Decryptable into a Ready condition for tooling that expects one (ZEN_LOCK_MIRROR_READY_CONDITION=true)
+	mirrorReadyCondition, _ := strconv.ParseBool(os.Getenv("ZEN_LOCK_MIRROR_READY_CONDITION"))
+
+	// Keep injected Secrets in step when keys are removed from a ZenLock (ZEN_LOCK_PRUNE_REMOVED_KEYS=true)
+	pruneRemovedKeys, _ := strconv.ParseBool(os.Getenv("ZEN_LOCK_PRUNE_REMOVED_KEYS"))
+
+	// Debugging aid: one summary line per reconcile (ZEN_LOCK_RECONCILE_TRACE=true)
+	traceReconciles, _ := strconv.ParseBool(os.Getenv("ZEN_LOCK_RECONCILE_TRACE"))
+
+	// Separate controller instances use distinct finalizers so they never block each other's deletions
+	finalizer := os.Getenv("ZEN_LOCK_FINALIZER")
+	if finalizer != "" {
+		if errs := validation.IsQualifiedName(finalizer); len(errs) > 0 || !strings.Contains(finalizer, "/") {
+			return nil, fmt.Errorf("invalid ZEN_LOCK_FINALIZER %q: must be a domain-qualified name such as example.com/finalizer", finalizer)
+		}
+	}
+
+	// Track a key rotation by the recipient of the new identity, which must be one of the loaded identities
+	var rotationIdentity string
+	if recipient := strings.TrimSpace(os.Getenv("ZEN_LOCK_ROTATION_RECIPIENT")); recipient != "" {
+		identity, err := crypto.SelectIdentity(privateKey, recipient)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ZEN_LOCK_ROTATION_RECIPIENT: %w", err)
+		}
+		rotationIdentity = identity
+	}
+
+	// Shard ZenLocks across controller instances by label
+	var selector labels.Selector
+	if value := strings.TrimSpace(os.Getenv("ZEN_LOCK_CONTROLLER_SELECTOR")); value != "" {
+		parsed, err := labels.Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ZEN_LOCK_CONTROLLER_SELECTOR %q: %w", value, err)
+		}
+		selector = parsed
+	}
+
 	return &ZenLockReconciler{
-		Client:     client,
-		Scheme:     scheme,
-		crypto:     encryptor,
-		privateKey: privateKey,
+		Client:               client,
+		Scheme:               scheme,
+		crypto:               encryptor,
+		privateKey:           privateKey,
+		mirrorReadyCondition: mirrorReadyCondition,
+		keyMissingRequeue:    durationFromEnv("ZEN_LOCK_KEY_MISSING_REQUEUE", config.DefaultKeyMissingRequeue),
+		failureRequeueBase:   durationFromEnv("ZEN_LOCK_FAILURE_REQUEUE_BASE", config.DefaultFailureRequeueBase),
+		failureRequeueMax:    durationFromEnv("ZEN_LOCK_FAILURE_REQUEUE_MAX", config.DefaultFailureRequeueMax),
+		finalizer:            finalizer,
+		rotationIdentity:     rotationIdentity,
+
+		maxConcurrentReconciles: maxConcurrentReconcilesFromEnv(),
+		selector:                selector,
+		pruneRemovedKeys:        pruneRemovedKeys,
+		traceReconciles:         traceReconciles,
 	}, nil
 }
 
+// finalizerName returns the finalizer managed by this reconciler
+func (r *ZenLockReconciler) finalizerName() string {
+	if r.finalizer == "" {
+		return zenLockFinalizer
+	}
+	return r.finalizer
+}
+
+// selects reports whether this instance handles the ZenLock according to ZEN_LOCK_CONTROLLER_SELECTOR
+func (r *ZenLockReconciler) selects(obj client.Object) bool {
+	return r.selector == nil || r.selector.Matches(labels.Set(obj.GetLabels()))
+}
+
+// durationFromEnv parses a positive duration from the environment, falling back to def
+func durationFromEnv(name string, def time.Duration) time.Duration {
+	if value := os.Getenv(name); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil && parsed > 0 {
+			return parsed
+		}
+	}
+	return def
+}
+
+// maxConcurrentReconcilesFromEnv returns ZEN_LOCK_MAX_CONCURRENT_RECONCILES, or the default when unset or not positive
+func maxConcurrentReconcilesFromEnv() int {
+	if value := os.Getenv("ZEN_LOCK_MAX_CONCURRENT_RECONCILES"); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil && parsed > 0 {
+			return parsed
+		}
+	}
+	return config.DefaultMaxConcurrentReconciles
+}
+
+// controllerOptions returns the controller options for a reconciler running maxConcurrent reconciles in parallel
+func controllerOptions(maxConcurrent int) controller.Options {
+	if maxConcurrent <= 0 {
+		maxConcurrent = config.DefaultMaxConcurrentReconciles
+	}
+	return controller.Options{MaxConcurrentReconciles: maxConcurrent}
+}
+
+// failureRequeue records a failed reconcile of key and returns the backoff before the next attempt
+func (r *ZenLockReconciler) failureRequeue(key types.NamespacedName) time.Duration {
+	base := r.failureRequeueBase
+	if base <= 0 {
+		base = config.DefaultFailureRequeueBase
+	}
+	max := r.failureRequeueMax
+	if max <= 0 {
+		max = config.DefaultFailureRequeueMax
+	}
+	return r.failures.next(key, base, max)
+}
+
 //+kubebuilder:rbac:groups=security.kube-zen.io,resources=zenlocks,verbs=get;list;watch;update;patch
 //+kubebuilder:rbac:groups=security.kube-zen.io,resources=zenlocks/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=security.kube-zen.io,resources=zenlocks/finalizers,verbs=update
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;delete
+//+kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 const (
+	// zenLockFinalizer is the default finalizer, overridable via ZEN_LOCK_FINALIZER
 	zenLockFinalizer = "zenlocks.security.kube-zen.io/finalizer"
+
+	// conditionTypeDecryptable reports whether the ZenLock's data decrypts with the loaded key
+	conditionTypeDecryptable = "Decryptable"
+
+	// conditionTypeReady mirrors Decryptable when ZEN_LOCK_MIRROR_READY_CONDITION is enabled
+	conditionTypeReady = "Ready"
+
+	// conditionTypePaused reports whether reconciliation is paused via the zen-lock/paused annotation
+	conditionTypePaused = "Paused"
+
+	// conditionTypeDisabled reports whether injection is stopped via the zen-lock/disabled annotation
+	conditionTypeDisabled = "Disabled"
+
+	// conditionTypeSubjectsResolved reports whether every allowed ServiceAccount exists (advisory only)
+	conditionTypeSubjectsResolved = "SubjectsResolved"
+
+	// conditionTypeOpenAccess warns that no allowedSubjects restrict which Pods may inject the ZenLock (advisory only)
+	conditionTypeOpenAccess = "OpenAccess"
+
+	// conditionTypeStaleKeys reports injected Secrets still carrying keys removed from the ZenLock (ZEN_LOCK_PRUNE_REMOVED_KEYS)
+	conditionTypeStaleKeys = "StaleKeys"
+
+	// eventReasonSubjectMissing is the Warning Event reason for a nonexistent allowed ServiceAccount
+	eventReasonSubjectMissing = "SubjectMissing"
 )
 
 // Reconcile is part of the main kubernetes reconciliation loop
 func (r *ZenLockReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 	startTime := time.Now()
+	ctx, trace := r.startTrace(ctx, req.NamespacedName)
+	defer trace.log(logger)
 
 	// Leader election is handled by controller-runtime Manager
 	// No need to check leader status here - Manager only starts reconciler on leader
@@ -71,16 +250,69 @@ func (r *ZenLockReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	// Fetch ZenLock
 	zenlock := &securityv1alpha1.ZenLock{}
 	if err := r.Get(ctx, req.NamespacedName, zenlock); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.failures.reset(req.NamespacedName)
+			r.rotation.forget(req.NamespacedName)
+			r.specSizes.forget(req.NamespacedName)
+		}
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
+	trace.observe(zenlock)
 
 	// Handle deletion
 	if lifecycle.IsDeleting(zenlock) {
+		r.failures.reset(req.NamespacedName)
+		r.rotation.forget(req.NamespacedName)
+		r.specSizes.forget(req.NamespacedName)
 		return r.handleDeletion(ctx, zenlock, logger, startTime, req)
 	}
 
+	// Leave ZenLocks outside ZEN_LOCK_CONTROLLER_SELECTOR to the instance that selects them
+	// (deletion above still proceeds so a ZenLock relabeled away never keeps this instance's finalizer)
+	if !r.selects(zenlock) {
+		r.failures.reset(req.NamespacedName)
+		r.rotation.forget(req.NamespacedName)
+		r.specSizes.forget(req.NamespacedName)
+		return r.releaseUnselected(ctx, zenlock, logger)
+	}
+
+	// Skip all reconcile work while paused, leaving the webhook cache and metrics alone
+	// (deletion above still proceeds so finalizers never hang)
+	if isPaused(zenlock) {
+		return r.handlePaused(ctx, zenlock, logger, startTime, req)
+	}
+
+	// Size distribution for capacity planning, including ZenLocks that fail to decrypt
+	r.specSizes.observe(req.NamespacedName, zenlock)
+
+	// Kill switch: evict the webhook cache so injection stops at once
+	if webhook.InjectionDisabled(zenlock) {
+		return r.handleDisabled(ctx, zenlock, logger, startTime, req)
+	}
+
+	// Mark a previously disabled ZenLock as re-enabled and drop the cached disabled copy
+	if c := findCondition(zenlock, conditionTypeDisabled); c != nil && c.Status == "True" {
+		invalidateZenLock(ctx, req.NamespacedName)
+		setCondition(zenlock, securityv1alpha1.ZenLockCondition{
+			Type:    conditionTypeDisabled,
+			Status:  "False",
+			Reason:  "Enabled",
+			Message: "Injection re-enabled",
+		})
+	}
+
+	// Mark a previously paused ZenLock as resumed; persisted by the status update below
+	if c := findCondition(zenlock, conditionTypePaused); c != nil && c.Status == "True" {
+		setCondition(zenlock, securityv1alpha1.ZenLockCondition{
+			Type:    conditionTypePaused,
+			Status:  "False",
+			Reason:  "Resumed",
+			Message: "Reconciliation resumed",
+		})
+	}
+
 	// Add finalizer if not present
-	if lifecycle.AddFinalizer(zenlock, zenLockFinalizer) {
+	if lifecycle.AddFinalizer(zenlock, r.finalizerName()) {
 		if err := r.Update(ctx, zenlock); err != nil {
 			logger.Error(err, "Failed to add finalizer")
 			return ctrl.Result{}, err
@@ -92,38 +324,102 @@ func (r *ZenLockReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	// Use cached private key, but check if it's still valid (allows for runtime key updates)
 	if r.privateKey == "" {
 		// Try to reload from environment (allows for key restoration)
-		r.privateKey = os.Getenv("ZEN_LOCK_PRIVATE_KEY")
+		r.privateKey = crypto.ResolvePrivateKey()
 		if r.privateKey == "" {
 			logger.Error(fmt.Errorf("ZEN_LOCK_PRIVATE_KEY not set"), "Cannot decrypt ZenLock")
 			r.updateStatus(ctx, zenlock, "Error", "KeyNotFound", "Private key not configured")
 			duration := time.Since(startTime).Seconds()
 			metrics.RecordReconcile(req.Namespace, req.Name, "error", duration)
 			// Requeue with delay to allow for key restoration
-			return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+			requeue := r.keyMissingRequeue
+			if requeue <= 0 {
+				requeue = config.DefaultKeyMissingRequeue
+			}
+			return ctrl.Result{RequeueAfter: requeue}, nil
 		}
 	}
 
+	// Check allowed subjects exist; persisted by the status update below and never changes the phase
+	r.checkSubjects(ctx, zenlock)
+	checkOpenAccess(zenlock)
+
+	// An empty algorithm resolves to the configured default, as in the webhook
+	if algorithm := crypto.ResolveAlgorithm(zenlock.Spec.Algorithm); !crypto.IsRegistered(algorithm) {
+		logger.Error(fmt.Errorf("unsupported algorithm %q", algorithm), "Cannot decrypt ZenLock", "name", zenlock.Name)
+		metrics.RecordAlgorithmError(algorithm, "unsupported")
+		r.updateStatus(ctx, zenlock, "Error", "UnsupportedAlgorithm", fmt.Sprintf("Unsupported algorithm %q", algorithm))
+		duration := time.Since(startTime).Seconds()
+		metrics.RecordReconcile(req.Namespace, req.Name, "error", duration)
+		return ctrl.Result{RequeueAfter: r.failureRequeue(req.NamespacedName)}, nil
+	}
+
 	// Try to decrypt to verify the secret is valid
+	metrics.RecordKeyUse(metrics.ComponentController)
 	decryptStart := time.Now()
-	_, err := r.crypto.DecryptMap(zenlock.Spec.EncryptedData, r.privateKey)
+	identities := crypto.SelectKeyID(r.privateKey, zenlock.Spec.KeyID)
+	var decrypted map[string][]byte
+	var omitted []string
+	encryptedData, err := crypto.EncryptedDataAsBase64(zenlock.Spec.EncryptedData, zenlock.Spec.Encoding)
+	if err == nil {
+		decrypted, omitted, err = crypto.DecryptMapOptional(r.crypto, encryptedData, identities, zenlock.Spec.OptionalKeys)
+	}
 	decryptDuration := time.Since(decryptStart).Seconds()
+	r.traceDecryption(trace, zenlock, encryptedData, identities, decrypted, err)
 	if err != nil {
-		logger.Error(err, "Failed to decrypt ZenLock", "name", zenlock.Name)
-		r.updateStatus(ctx, zenlock, "Error", "DecryptionFailed", fmt.Sprintf("Decryption failed: %v", err))
+		message := fmt.Sprintf("Decryption failed: %v", err)
+		var keyErr *crypto.KeyError
+		if errors.As(err, &keyErr) {
+			// Name the failing key so a single bad value in a large ZenLock is easy to find
+			keyName := common.RedactKeyName(keyErr.Key)
+			message = fmt.Sprintf("Decryption failed for key %q: %v", keyName, keyErr.Err)
+			metrics.RecordDecryptionKeyFailure(req.Namespace, req.Name, keyName)
+			logger.Error(common.RedactError(err), "Failed to decrypt ZenLock", "name", zenlock.Name, "key", keyName)
+		} else {
+			logger.Error(common.RedactError(err), "Failed to decrypt ZenLock", "name", zenlock.Name)
+		}
+		r.rotation.forget(req.NamespacedName)
+		r.updateStatus(ctx, zenlock, "Error", "DecryptionFailed", message)
 		duration := time.Since(startTime).Seconds()
 		metrics.RecordReconcile(req.Namespace, req.Name, "error", duration)
-		metrics.RecordDecryption(req.Namespace, req.Name, "error", decryptDuration)
-		return ctrl.Result{}, nil
+		metrics.RecordDecryption(ctx, req.Namespace, req.Name, "error", decryptDuration)
+		// Back off exponentially so a permanently broken ZenLock does not reconcile tightly
+		return ctrl.Result{RequeueAfter: r.failureRequeue(req.NamespacedName)}, nil
+	}
+
+	// Verify decrypted data against expected checksums (if specified)
+	if err := crypto.VerifyChecksums(decrypted, crypto.OmitKeys(zenlock.Spec.Checksums, omitted)); err != nil {
+		logger.Error(common.RedactError(err), "ZenLock checksum verification failed", "name", zenlock.Name)
+		r.updateStatus(ctx, zenlock, "Error", "ChecksumMismatch", err.Error())
+		duration := time.Since(startTime).Seconds()
+		metrics.RecordReconcile(req.Namespace, req.Name, "error", duration)
+		metrics.RecordDecryption(ctx, req.Namespace, req.Name, "error", decryptDuration)
+		return ctrl.Result{RequeueAfter: r.failureRequeue(req.NamespacedName)}, nil
 	}
 
 	// Record successful decryption
-	metrics.RecordDecryption(req.Namespace, req.Name, "success", decryptDuration)
+	metrics.RecordDecryption(ctx, req.Namespace, req.Name, "success", decryptDuration)
+	r.failures.reset(req.NamespacedName)
+	r.trackRotation(req.NamespacedName, encryptedData)
 
 	// Invalidate cache when ZenLock is updated (to ensure webhook uses fresh data)
-	webhook.InvalidateZenLock(req.NamespacedName)
+	invalidateZenLock(ctx, req.NamespacedName)
+
+	// Record which identity decrypts the data, e.g. to spot ZenLocks still on the old key during a rotation
+	zenlock.Status.DecryptedByKeyFingerprint = decryptingKeyFingerprint(encryptedData, identities, omitted)
+
+	// Drop keys removed from the ZenLock from shared Secrets; persisted by the status update below
+	if r.pruneRemovedKeys {
+		r.checkRemovedKeys(ctx, zenlock, decrypted)
+	}
 
-	// Update status to Ready
-	r.updateStatus(ctx, zenlock, "Ready", "KeyValid", "Private key loaded and decryption successful")
+	// Update status to Ready; optional keys that fail to decrypt are omitted on injection
+	message := "Private key loaded and decryption successful"
+	if len(omitted) > 0 {
+		keys := common.RedactKeyNames(omitted)
+		logger.Info("Optional keys failed to decrypt and will be omitted on injection", "name", zenlock.Name, "keys", keys)
+		message = fmt.Sprintf("Private key loaded; optional keys failed to decrypt and will be omitted on injection: %s", strings.Join(keys, ", "))
+	}
+	r.updateStatus(ctx, zenlock, "Ready", "KeyValid", message)
 
 	// Record successful reconciliation
 	duration := time.Since(startTime).Seconds()
@@ -132,12 +428,43 @@ func (r *ZenLockReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	return ctrl.Result{}, nil
 }
 
+// decryptingKeyFingerprint returns the fingerprint of the identity that decrypts the ZenLock's first decrypted key
+// encryptedData holds base64 values, as returned by crypto.EncryptedDataAsBase64.
+func decryptingKeyFingerprint(encryptedData map[string]string, identities string, omitted []string) string {
+	keys := make([]string, 0, len(encryptedData))
+	for key := range encryptedData {
+		if !slices.Contains(omitted, key) {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return ""
+	}
+	sort.Strings(keys)
+	// The value already decrypted, so accept unpadded base64 whatever ZEN_LOCK_BASE64_TOLERANT says
+	ciphertext, err := crypto.DecodeBase64(encryptedData[keys[0]], true)
+	if err != nil {
+		return ""
+	}
+	return crypto.DecryptingKeyFingerprint(ciphertext, identities)
+}
+
+// trackRotation records whether a decryptable ZenLock still needs re-encrypting to the rotation target
+// Data the target identity cannot decrypt is only readable with the old identities.
+func (r *ZenLockReconciler) trackRotation(key types.NamespacedName, encryptedData map[string]string) {
+	if r.rotationIdentity == "" {
+		return
+	}
+	_, err := r.crypto.DecryptMap(encryptedData, r.rotationIdentity)
+	r.rotation.set(key, err != nil)
+}
+
 // handleDeletion handles ZenLock deletion by cleaning up associated Secrets
 func (r *ZenLockReconciler) handleDeletion(ctx context.Context, zenlock *securityv1alpha1.ZenLock, logger interface {
 	Info(string, ...interface{})
 	Error(error, string, ...interface{})
 }, startTime time.Time, req ctrl.Request) (ctrl.Result, error) {
-	if !lifecycle.HasFinalizer(zenlock, zenLockFinalizer) {
+	if !lifecycle.HasFinalizer(zenlock, r.finalizerName()) {
 		// Finalizer already removed, nothing to do
 		return ctrl.Result{}, nil
 	}
@@ -147,7 +474,7 @@ func (r *ZenLockReconciler) handleDeletion(ctx context.Context, zenlock *securit
 	// List all Secrets with the ZenLock label
 	secretList := &corev1.SecretList{}
 	if err := r.List(ctx, secretList, client.MatchingLabels{
-		common.LabelZenLockName: zenlock.Name,
+		common.ZenLockNameLabel(): zenlock.Name,
 	}); err != nil {
 		logger.Error(err, "Failed to list Secrets for cleanup")
 		return ctrl.Result{}, err
@@ -166,7 +493,7 @@ func (r *ZenLockReconciler) handleDeletion(ctx context.Context, zenlock *securit
 	}
 
 	// Remove finalizer
-	if err := lifecycle.RemoveFinalizerAndUpdate(ctx, r.Client, zenlock, zenLockFinalizer); err != nil {
+	if err := lifecycle.RemoveFinalizerAndUpdate(ctx, r.Client, zenlock, r.finalizerName()); err != nil {
 		logger.Error(err, "Failed to remove finalizer")
 		return ctrl.Result{}, err
 	}
@@ -177,54 +504,199 @@ func (r *ZenLockReconciler) handleDeletion(ctx context.Context, zenlock *securit
 	return ctrl.Result{}, nil
 }
 
+// handleDisabled invalidates the webhook cache and records the Disabled condition
+// The phase is left untouched: the ZenLock's data is fine, it is just not injected.
+func (r *ZenLockReconciler) handleDisabled(ctx context.Context, zenlock *securityv1alpha1.ZenLock, logger interface {
+	Info(string, ...interface{})
+	Error(error, string, ...interface{})
+}, startTime time.Time, req ctrl.Request) (ctrl.Result, error) {
+	logger.Info("ZenLock injection is disabled", "annotation", config.AnnotationDisabled)
+
+	invalidateZenLock(ctx, req.NamespacedName)
+
+	if c := findCondition(zenlock, conditionTypeDisabled); c == nil || c.Status != "True" {
+		setCondition(zenlock, securityv1alpha1.ZenLockCondition{
+			Type:    conditionTypeDisabled,
+			Status:  "True",
+			Reason:  "DisabledByAnnotation",
+			Message: fmt.Sprintf("Injection disabled via %s annotation", config.AnnotationDisabled),
+		})
+		r.writeStatus(ctx, zenlock)
+	}
+
+	duration := time.Since(startTime).Seconds()
+	metrics.RecordReconcile(req.Namespace, req.Name, "disabled", duration)
+	return ctrl.Result{}, nil
+}
+
+// handlePaused records the Paused condition and returns without touching the phase or the webhook cache
+func (r *ZenLockReconciler) handlePaused(ctx context.Context, zenlock *securityv1alpha1.ZenLock, logger interface {
+	Info(string, ...interface{})
+	Error(error, string, ...interface{})
+}, startTime time.Time, req ctrl.Request) (ctrl.Result, error) {
+	logger.Info("ZenLock reconciliation is paused", "annotation", config.AnnotationPaused)
+
+	if c := findCondition(zenlock, conditionTypePaused); c == nil || c.Status != "True" {
+		setCondition(zenlock, securityv1alpha1.ZenLockCondition{
+			Type:    conditionTypePaused,
+			Status:  "True",
+			Reason:  "PausedByAnnotation",
+			Message: fmt.Sprintf("Reconciliation paused via %s annotation", config.AnnotationPaused),
+		})
+		r.writeStatus(ctx, zenlock)
+	}
+
+	duration := time.Since(startTime).Seconds()
+	metrics.RecordReconcile(req.Namespace, req.Name, "paused", duration)
+	return ctrl.Result{}, nil
+}
+
+// checkSubjects records whether the ZenLock's allowed ServiceAccounts exist
+// Missing ServiceAccounts produce a SubjectMissing Warning Event and a False SubjectsResolved condition.
+func (r *ZenLockReconciler) checkSubjects(ctx context.Context, zenlock *securityv1alpha1.ZenLock) {
+	if len(zenlock.Spec.AllowedSubjects) == 0 {
+		if c := findCondition(zenlock, conditionTypeSubjectsResolved); c != nil && c.Status != "True" {
+			setCondition(zenlock, securityv1alpha1.ZenLockCondition{
+				Type:    conditionTypeSubjectsResolved,
+				Status:  "True",
+				Reason:  "NoSubjects",
+				Message: "No allowed subjects configured",
+			})
+		}
+		return
+	}
+
+	var missing []string
+	for _, subject := range zenlock.Spec.AllowedSubjects {
+		if subject.Kind != "ServiceAccount" {
+			continue
+		}
+		namespace := subject.Namespace
+		if namespace == "" {
+			namespace = zenlock.Namespace
+		}
+		sa := &corev1.ServiceAccount{}
+		err := r.Get(ctx, types.NamespacedName{Name: subject.Name, Namespace: namespace}, sa)
+		if apierrors.IsNotFound(err) {
+			missing = append(missing, namespace+"/"+subject.Name)
+		} else if err != nil {
+			// Transient lookup failure - keep the previous condition rather than guessing
+			log.FromContext(ctx).Error(err, "Failed to check allowed subject", "serviceAccount", namespace+"/"+subject.Name)
+			return
+		}
+	}
+
+	if len(missing) == 0 {
+		setCondition(zenlock, securityv1alpha1.ZenLockCondition{
+			Type:    conditionTypeSubjectsResolved,
+			Status:  "True",
+			Reason:  "SubjectsFound",
+			Message: "All allowed ServiceAccounts exist",
+		})
+		return
+	}
+
+	sort.Strings(missing)
+	message := fmt.Sprintf("Allowed ServiceAccount(s) not found: %s", strings.Join(missing, ", "))
+	setCondition(zenlock, securityv1alpha1.ZenLockCondition{
+		Type:    conditionTypeSubjectsResolved,
+		Status:  "False",
+		Reason:  eventReasonSubjectMissing,
+		Message: message,
+	})
+	if r.Recorder != nil {
+		r.Recorder.Event(zenlock, corev1.EventTypeWarning, eventReasonSubjectMissing, message)
+	}
+}
+
+// checkOpenAccess sets the OpenAccess condition while the ZenLock has no allowedSubjects
+// Any ServiceAccount in the namespace can then inject it; the condition is cleared once subjects are added.
+func checkOpenAccess(zenlock *securityv1alpha1.ZenLock) {
+	if len(zenlock.Spec.AllowedSubjects) == 0 {
+		setCondition(zenlock, securityv1alpha1.ZenLockCondition{
+			Type:    conditionTypeOpenAccess,
+			Status:  "True",
+			Reason:  "NoAllowedSubjects",
+			Message: "No allowedSubjects configured: any ServiceAccount in the namespace can inject this ZenLock",
+		})
+		return
+	}
+	if c := findCondition(zenlock, conditionTypeOpenAccess); c != nil && c.Status != "False" {
+		setCondition(zenlock, securityv1alpha1.ZenLockCondition{
+			Type:    conditionTypeOpenAccess,
+			Status:  "False",
+			Reason:  "SubjectsRestricted",
+			Message: "Injection is restricted to the allowedSubjects",
+		})
+	}
+}
+
+// isPaused reports whether the ZenLock carries the zen-lock/paused=true annotation
+func isPaused(zenlock *securityv1alpha1.ZenLock) bool {
+	return zenlock.GetAnnotations()[config.AnnotationPaused] == "true"
+}
+
+// findCondition returns the condition with the given type, or nil if absent
+func findCondition(zenlock *securityv1alpha1.ZenLock, conditionType string) *securityv1alpha1.ZenLockCondition {
+	for i := range zenlock.Status.Conditions {
+		if zenlock.Status.Conditions[i].Type == conditionType {
+			return &zenlock.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// setCondition adds or replaces a condition, only moving LastTransitionTime when the status changes
+func setCondition(zenlock *securityv1alpha1.ZenLock, condition securityv1alpha1.ZenLockCondition) {
+	now := metav1.Now()
+	if existing := findCondition(zenlock, condition.Type); existing != nil {
+		if existing.Status != condition.Status {
+			condition.LastTransitionTime = &now
+		} else {
+			condition.LastTransitionTime = existing.LastTransitionTime
+		}
+		*existing = condition
+		return
+	}
+
+	// New condition - set transition time
+	condition.LastTransitionTime = &now
+	zenlock.Status.Conditions = append(zenlock.Status.Conditions, condition)
+}
+
 // updateStatus updates the ZenLock status
 func (r *ZenLockReconciler) updateStatus(ctx context.Context, zenlock *securityv1alpha1.ZenLock, phase, reason, message string) {
 	zenlock.Status.Phase = phase
+	message = common.RedactMessage(message)
 
-	now := metav1.Now()
 	conditionStatus := "True"
 	if phase == "Error" {
 		conditionStatus = "False"
+		zenlock.Status.DecryptedByKeyFingerprint = ""
 	}
 
-	// Update or create condition
-	condition := securityv1alpha1.ZenLockCondition{
-		Type:    "Decryptable",
+	setCondition(zenlock, securityv1alpha1.ZenLockCondition{
+		Type:    conditionTypeDecryptable,
 		Status:  conditionStatus,
 		Reason:  reason,
 		Message: message,
+	})
+	if r.mirrorReadyCondition {
+		setCondition(zenlock, securityv1alpha1.ZenLockCondition{
+			Type:    conditionTypeReady,
+			Status:  conditionStatus,
+			Reason:  reason,
+			Message: message,
+		})
 	}
 
-	// Find existing condition
-	found := false
-	var existingIndex int
-	for i, c := range zenlock.Status.Conditions {
-		if c.Type == condition.Type {
-			existingIndex = i
-			found = true
-			// Only update LastTransitionTime if status changed
-			if c.Status != condition.Status {
-				condition.LastTransitionTime = &now
-			} else {
-				condition.LastTransitionTime = c.LastTransitionTime
-			}
-			break
-		}
-	}
-
-	if found {
-		zenlock.Status.Conditions[existingIndex] = condition
-	} else {
-		// New condition - set transition time
-		condition.LastTransitionTime = &now
-		zenlock.Status.Conditions = append(zenlock.Status.Conditions, condition)
-	}
+	r.writeStatus(ctx, zenlock)
+}
 
+// writeStatus persists the ZenLock status subresource
+func (r *ZenLockReconciler) writeStatus(ctx context.Context, zenlock *securityv1alpha1.ZenLock) {
 	// Retry status update with exponential backoff for transient errors
-	retryConfig := retry.DefaultConfig()
-	retryConfig.MaxAttempts = config.DefaultRetryMaxAttempts
-	retryConfig.InitialDelay = config.DefaultRetryInitialDelay
-	retryConfig.MaxDelay = config.DefaultRetryMaxDelay
+	retryConfig := common.RetryConfig(config.DefaultRetryMaxAttempts, config.DefaultRetryInitialDelay, config.DefaultRetryMaxDelay)
 
 	if err := retry.Do(ctx, retryConfig, func() error {
 		return r.Status().Update(ctx, zenlock)
@@ -233,9 +705,30 @@ func (r *ZenLockReconciler) updateStatus(ctx context.Context, zenlock *securityv
 	}
 }
 
+// releaseUnselected removes this instance's finalizer from a ZenLock it no longer selects
+func (r *ZenLockReconciler) releaseUnselected(ctx context.Context, zenlock *securityv1alpha1.ZenLock, logger interface {
+	Info(string, ...interface{})
+	Error(error, string, ...interface{})
+}) (ctrl.Result, error) {
+	if !lifecycle.HasFinalizer(zenlock, r.finalizerName()) {
+		return ctrl.Result{}, nil
+	}
+	if err := lifecycle.RemoveFinalizerAndUpdate(ctx, r.Client, zenlock, r.finalizerName()); err != nil {
+		logger.Error(err, "Failed to remove finalizer from unselected ZenLock")
+		return ctrl.Result{}, err
+	}
+	logger.Info("ZenLock no longer matches ZEN_LOCK_CONTROLLER_SELECTOR, released", "name", zenlock.Name)
+	return ctrl.Result{}, nil
+}
+
 // SetupWithManager sets up the controller with the Manager
+// With ZEN_LOCK_CONTROLLER_SELECTOR, only matching ZenLocks, and those still carrying this
+// instance's finalizer, are queued.
 func (r *ZenLockReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&securityv1alpha1.ZenLock{}).
+		For(&securityv1alpha1.ZenLock{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			return r.selects(obj) || lifecycle.HasFinalizer(obj, r.finalizerName())
+		}))).
+		WithOptions(controllerOptions(r.maxConcurrentReconciles)).
 		Complete(r)
 }
@@ -0,0 +1,87 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"filippo.io/age"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/crypto"
+)
+
+func reconcileWithoutAlgorithm(t *testing.T) *securityv1alpha1.ZenLock {
+	reconciler, clientBuilder := setupTestReconciler(t)
+
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	ciphertext, err := crypto.NewAgeEncryptor().Encrypt([]byte("value"), []string{identity.Recipient().String()})
+	if err != nil {
+		t.Fatalf("Failed to encrypt: %v", err)
+	}
+	reconciler.privateKey = identity.String()
+
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test-zenlock",
+			Namespace:  "default",
+			Finalizers: []string{zenLockFinalizer},
+		},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{"key": base64.StdEncoding.EncodeToString(ciphertext)},
+		},
+	}
+	client := clientBuilder.WithObjects(zenlock).WithStatusSubresource(zenlock).Build()
+	reconciler.Client = client
+
+	key := types.NamespacedName{Name: "test-zenlock", Namespace: "default"}
+	if _, err := reconciler.Reconcile(context.Background(), reconcile.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+	updated := &securityv1alpha1.ZenLock{}
+	if err := client.Get(context.Background(), key, updated); err != nil {
+		t.Fatalf("Failed to get ZenLock: %v", err)
+	}
+	return updated
+}
+
+func TestZenLockReconciler_Reconcile_EmptyAlgorithmDefaultsToAge(t *testing.T) {
+	t.Setenv("ZEN_LOCK_DEFAULT_ALGORITHM", "")
+	if updated := reconcileWithoutAlgorithm(t); updated.Status.Phase != "Ready" {
+		t.Errorf("Expected phase Ready, got %q", updated.Status.Phase)
+	}
+}
+
+func TestZenLockReconciler_Reconcile_EmptyAlgorithmUsesGlobalDefault(t *testing.T) {
+	t.Setenv("ZEN_LOCK_DEFAULT_ALGORITHM", "rot13")
+	updated := reconcileWithoutAlgorithm(t)
+	if updated.Status.Phase != "Error" {
+		t.Errorf("Expected phase Error, got %q", updated.Status.Phase)
+	}
+	condition := findCondition(updated, conditionTypeDecryptable)
+	if condition == nil || condition.Reason != "UnsupportedAlgorithm" {
+		t.Errorf("Expected reason UnsupportedAlgorithm, got %+v", condition)
+	}
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"filippo.io/age"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
+	"github.com/kube-zen/zen-lock/pkg/crypto"
+)
+
+func TestFailureBackoff_GrowsToCapAndResets(t *testing.T) {
+	var backoff failureBackoff
+	key := types.NamespacedName{Name: "test-zenlock", Namespace: "default"}
+	other := types.NamespacedName{Name: "other", Namespace: "default"}
+
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, expected := range want {
+		if got := backoff.next(key, time.Second, 5*time.Second); got != expected {
+			t.Errorf("failure %d: expected %v, got %v", i+1, expected, got)
+		}
+	}
+
+	// Failures are tracked per object
+	if got := backoff.next(other, time.Second, 5*time.Second); got != time.Second {
+		t.Errorf("Expected independent backoff for another object, got %v", got)
+	}
+
+	backoff.reset(key)
+	if got := backoff.next(key, time.Second, 5*time.Second); got != time.Second {
+		t.Errorf("Expected backoff to restart at base after reset, got %v", got)
+	}
+}
+
+func TestZenLockReconciler_Reconcile_FailureBackoff(t *testing.T) {
+	reconciler, clientBuilder := setupTestReconciler(t)
+	reconciler.failureRequeueBase = time.Second
+	reconciler.failureRequeueMax = 4 * time.Second
+
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	ciphertext, err := crypto.NewAgeEncryptor().Encrypt([]byte("value"), []string{identity.Recipient().String()})
+	if err != nil {
+		t.Fatalf("Failed to encrypt: %v", err)
+	}
+
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test-zenlock",
+			Namespace:  "default",
+			Finalizers: []string{zenLockFinalizer},
+		},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{
+				"key": base64.StdEncoding.EncodeToString(ciphertext),
+			},
+		},
+	}
+	reconciler.Client = clientBuilder.WithObjects(zenlock).WithStatusSubresource(zenlock).Build()
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-zenlock", Namespace: "default"}}
+	ctx := context.Background()
+
+	// The reconciler still holds the placeholder key, so every attempt fails to decrypt
+	for _, expected := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second} {
+		result, err := reconciler.Reconcile(ctx, req)
+		if err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+		if result.RequeueAfter != expected {
+			t.Errorf("Expected requeue after %v, got %v", expected, result.RequeueAfter)
+		}
+	}
+
+	// A successful decryption stops requeueing and resets the backoff
+	reconciler.privateKey = identity.String()
+	result, err := reconciler.Reconcile(ctx, req)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if result.RequeueAfter != 0 {
+		t.Errorf("Expected no requeue after success, got %v", result.RequeueAfter)
+	}
+
+	reconciler.privateKey = "AGE-SECRET-1EXAMPLEEXAMPLEEXAMPLEEXAMPLEEXAMPLEEXAMPLEEXAMPLEEXAMPLEEXAMPLE"
+	result, err = reconciler.Reconcile(ctx, req)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if result.RequeueAfter != time.Second {
+		t.Errorf("Expected backoff to restart at base after success, got %v", result.RequeueAfter)
+	}
+}
+
+func TestZenLockReconciler_Reconcile_KeyMissingRequeue(t *testing.T) {
+	reconciler, clientBuilder := setupTestReconciler(t)
+	t.Setenv("ZEN_LOCK_PRIVATE_KEY", "")
+	t.Setenv("ZEN_LOCK_IDENTITIES_DIR", "")
+
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test-zenlock",
+			Namespace:  "default",
+			Finalizers: []string{zenLockFinalizer},
+		},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{"key": "dGVzdA=="},
+		},
+	}
+	reconciler.Client = clientBuilder.WithObjects(zenlock).WithStatusSubresource(zenlock).Build()
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-zenlock", Namespace: "default"}}
+
+	tests := []struct {
+		name     string
+		setting  time.Duration
+		expected time.Duration
+	}{
+		{name: "default", expected: config.DefaultKeyMissingRequeue},
+		{name: "configured", setting: 5 * time.Second, expected: 5 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reconciler.privateKey = ""
+			reconciler.keyMissingRequeue = tt.setting
+			result, err := reconciler.Reconcile(context.Background(), req)
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+			if result.RequeueAfter != tt.expected {
+				t.Errorf("Expected requeue after %v, got %v", tt.expected, result.RequeueAfter)
+			}
+		})
+	}
+}
+
+func TestNewZenLockReconciler_RequeueEnv(t *testing.T) {
+	t.Setenv("ZEN_LOCK_PRIVATE_KEY", "AGE-SECRET-1EXAMPLEEXAMPLEEXAMPLEEXAMPLEEXAMPLEEXAMPLEEXAMPLEEXAMPLEEXAMPLE")
+	t.Setenv("ZEN_LOCK_KEY_MISSING_REQUEUE", "1m")
+	t.Setenv("ZEN_LOCK_FAILURE_REQUEUE_BASE", "invalid")
+	t.Setenv("ZEN_LOCK_FAILURE_REQUEUE_MAX", "1h")
+
+	reconciler, err := NewZenLockReconciler(nil, nil)
+	if err != nil {
+		t.Fatalf("NewZenLockReconciler() error = %v", err)
+	}
+	if reconciler.keyMissingRequeue != time.Minute {
+		t.Errorf("Expected keyMissingRequeue 1m, got %v", reconciler.keyMissingRequeue)
+	}
+	if reconciler.failureRequeueBase != config.DefaultFailureRequeueBase {
+		t.Errorf("Expected invalid base to fall back to default, got %v", reconciler.failureRequeueBase)
+	}
+	if reconciler.failureRequeueMax != time.Hour {
+		t.Errorf("Expected failureRequeueMax 1h, got %v", reconciler.failureRequeueMax)
+	}
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/controller/metrics"
+	"github.com/kube-zen/zen-lock/pkg/crypto"
+)
+
+func reconcileWithChecksums(t *testing.T, checksums map[string]string) *securityv1alpha1.ZenLock {
+	reconciler, clientBuilder := setupTestReconciler(t)
+
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	ciphertext, err := crypto.NewAgeEncryptor().Encrypt([]byte("value"), []string{identity.Recipient().String()})
+	if err != nil {
+		t.Fatalf("Failed to encrypt: %v", err)
+	}
+	reconciler.privateKey = identity.String()
+
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test-zenlock",
+			Namespace:  "default",
+			Finalizers: []string{zenLockFinalizer},
+		},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{
+				"key": base64.StdEncoding.EncodeToString(ciphertext),
+			},
+			Checksums: checksums,
+		},
+	}
+
+	client := clientBuilder.WithObjects(zenlock).WithStatusSubresource(zenlock).Build()
+	reconciler.Client = client
+
+	key := types.NamespacedName{Name: "test-zenlock", Namespace: "default"}
+	ctx := context.Background()
+	if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+
+	updated := &securityv1alpha1.ZenLock{}
+	if err := client.Get(ctx, key, updated); err != nil {
+		t.Fatalf("Failed to get ZenLock: %v", err)
+	}
+	return updated
+}
+
+func TestZenLockReconciler_Reconcile_ChecksumMatch(t *testing.T) {
+	updated := reconcileWithChecksums(t, map[string]string{"key": crypto.Checksum([]byte("value"))})
+	if updated.Status.Phase != "Ready" {
+		t.Errorf("Expected phase Ready, got %q", updated.Status.Phase)
+	}
+}
+
+func TestZenLockReconciler_Reconcile_ChecksumMismatch(t *testing.T) {
+	updated := reconcileWithChecksums(t, map[string]string{"key": crypto.Checksum([]byte("other"))})
+	if updated.Status.Phase != "Error" {
+		t.Errorf("Expected phase Error, got %q", updated.Status.Phase)
+	}
+	condition := findCondition(updated, conditionTypeDecryptable)
+	if condition == nil || condition.Reason != "ChecksumMismatch" {
+		t.Errorf("Expected Decryptable condition with reason ChecksumMismatch, got %+v", condition)
+	}
+}
+
+func TestZenLockReconciler_Reconcile_RecordsKeyUse(t *testing.T) {
+	controllerBefore := testutil.ToFloat64(metrics.PrivateKeyUseTotal.WithLabelValues(metrics.ComponentController))
+	webhookBefore := testutil.ToFloat64(metrics.PrivateKeyUseTotal.WithLabelValues(metrics.ComponentWebhook))
+
+	reconcileWithChecksums(t, nil)
+
+	if got := testutil.ToFloat64(metrics.PrivateKeyUseTotal.WithLabelValues(metrics.ComponentController)) - controllerBefore; got != 1 {
+		t.Errorf("Expected controller key use to increase by 1, got %f", got)
+	}
+	if got := testutil.ToFloat64(metrics.PrivateKeyUseTotal.WithLabelValues(metrics.ComponentWebhook)) - webhookBefore; got != 0 {
+		t.Errorf("Expected webhook key use to be unchanged, got %f", got)
+	}
+}
+
+func TestZenLockReconciler_Reconcile_NoChecksums(t *testing.T) {
+	updated := reconcileWithChecksums(t, nil)
+	if updated.Status.Phase != "Ready" {
+		t.Errorf("Expected phase Ready without checksums, got %q", updated.Status.Phase)
+	}
+}
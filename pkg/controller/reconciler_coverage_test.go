@@ -43,7 +43,8 @@ func TestZenLockReconciler_Reconcile_SuccessfulDecryption(t *testing.T) {
 	privateKey := identity.String()
 	publicKey := identity.Recipient().String()
 
-	// Set private key
+	// Set private key (the reconciler caches the key loaded at construction)
+	reconciler.privateKey = privateKey
 	originalKey := os.Getenv("ZEN_LOCK_PRIVATE_KEY")
 	os.Setenv("ZEN_LOCK_PRIVATE_KEY", privateKey)
 	defer func() {
@@ -18,13 +18,20 @@ package controller
 
 import (
 	"context"
+	"encoding/base64"
+	"strings"
 	"testing"
 
+	"filippo.io/age"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
+	"github.com/kube-zen/zen-lock/pkg/controller/metrics"
+	"github.com/kube-zen/zen-lock/pkg/crypto"
 )
 
 func TestZenLockReconciler_Reconcile_DecryptionFailure(t *testing.T) {
@@ -61,8 +68,8 @@ func TestZenLockReconciler_Reconcile_DecryptionFailure(t *testing.T) {
 	if err != nil {
 		t.Errorf("Reconcile() error = %v, want no error", err)
 	}
-	if result.RequeueAfter > 0 {
-		t.Error("Reconcile() should not requeue after decryption failure")
+	if result.RequeueAfter != config.DefaultFailureRequeueBase {
+		t.Errorf("Expected first decryption failure to requeue after %v, got %v", config.DefaultFailureRequeueBase, result.RequeueAfter)
 	}
 
 	// Verify status was updated to Error
@@ -121,3 +128,73 @@ func TestZenLockReconciler_Reconcile_UpdateFinalizerError(t *testing.T) {
 
 // TestZenLockReconciler_Reconcile_NotFound is defined in reconciler_test.go
 // This test file focuses on decryption-specific scenarios
+
+func TestZenLockReconciler_Reconcile_DecryptionFailureNamesKey(t *testing.T) {
+	reconciler, clientBuilder := setupTestReconciler(t)
+
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	other, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	reconciler.privateKey = identity.String()
+
+	encrypt := func(plaintext, recipient string) string {
+		ciphertext, err := crypto.NewAgeEncryptor().Encrypt([]byte(plaintext), []string{recipient})
+		if err != nil {
+			t.Fatalf("Failed to encrypt: %v", err)
+		}
+		return base64.StdEncoding.EncodeToString(ciphertext)
+	}
+
+	// One value encrypted to a different key among several good ones
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test-zenlock",
+			Namespace:  "default",
+			Finalizers: []string{zenLockFinalizer},
+		},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{
+				"API_KEY":  encrypt("key", identity.Recipient().String()),
+				"PASSWORD": encrypt("password", other.Recipient().String()),
+				"USERNAME": encrypt("admin", identity.Recipient().String()),
+				"ZONE":     encrypt("eu", identity.Recipient().String()),
+			},
+		},
+	}
+	client := clientBuilder.WithObjects(zenlock).WithStatusSubresource(zenlock).Build()
+	reconciler.Client = client
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-zenlock", Namespace: "default"}}
+	ctx := context.Background()
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	updated := &securityv1alpha1.ZenLock{}
+	if err := client.Get(ctx, req.NamespacedName, updated); err != nil {
+		t.Fatalf("Failed to get ZenLock: %v", err)
+	}
+	condition := findCondition(updated, conditionTypeDecryptable)
+	if condition == nil {
+		t.Fatal("Expected Decryptable condition")
+	}
+	if condition.Reason != "DecryptionFailed" {
+		t.Errorf("Expected reason DecryptionFailed, got %q", condition.Reason)
+	}
+	if !strings.Contains(condition.Message, `key "PASSWORD"`) {
+		t.Errorf("Expected message to name the failing key PASSWORD, got %q", condition.Message)
+	}
+	for _, good := range []string{"API_KEY", "USERNAME", "ZONE"} {
+		if strings.Contains(condition.Message, good) {
+			t.Errorf("Expected message not to mention decryptable key %s, got %q", good, condition.Message)
+		}
+	}
+	if got := testutil.ToFloat64(metrics.DecryptionKeyFailures.WithLabelValues("default", "test-zenlock", "PASSWORD")); got != 1 {
+		t.Errorf("Expected one key failure recorded for PASSWORD, got %v", got)
+	}
+}
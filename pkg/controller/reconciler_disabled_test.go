@@ -0,0 +1,95 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
+	"github.com/kube-zen/zen-lock/pkg/webhook"
+)
+
+func TestZenLockReconciler_Reconcile_DisabledAndReenabled(t *testing.T) {
+	reconciler, clientBuilder := setupTestReconciler(t)
+
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-zenlock",
+			Namespace:   "default",
+			Finalizers:  []string{zenLockFinalizer},
+			Annotations: map[string]string{config.AnnotationDisabled: "true"},
+		},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{"key": "invalid-encrypted-data"},
+		},
+	}
+
+	client := clientBuilder.WithObjects(zenlock).WithStatusSubresource(zenlock).Build()
+	reconciler.Client = client
+
+	key := types.NamespacedName{Name: "test-zenlock", Namespace: "default"}
+	cache := webhook.NewZenLockCache(5 * time.Minute)
+	defer cache.Stop()
+	webhook.RegisterCache(cache)
+	defer webhook.UnregisterCache(cache)
+	cache.Set(key, zenlock)
+
+	ctx := context.Background()
+	if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+
+	updated := &securityv1alpha1.ZenLock{}
+	if err := client.Get(ctx, key, updated); err != nil {
+		t.Fatalf("Failed to get ZenLock: %v", err)
+	}
+	disabled := findCondition(updated, conditionTypeDisabled)
+	if disabled == nil || disabled.Status != "True" || disabled.Reason != "DisabledByAnnotation" {
+		t.Fatalf("Expected Disabled=True condition, got %+v", disabled)
+	}
+	if _, hit := cache.Get(key); hit {
+		t.Error("Expected the webhook cache entry to be invalidated when disabled")
+	}
+
+	// Re-enable
+	cache.Set(key, updated)
+	delete(updated.Annotations, config.AnnotationDisabled)
+	if err := client.Update(ctx, updated); err != nil {
+		t.Fatalf("Failed to remove disabled annotation: %v", err)
+	}
+	if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+
+	if err := client.Get(ctx, key, updated); err != nil {
+		t.Fatalf("Failed to get ZenLock: %v", err)
+	}
+	disabled = findCondition(updated, conditionTypeDisabled)
+	if disabled == nil || disabled.Status != "False" || disabled.Reason != "Enabled" {
+		t.Errorf("Expected Disabled=False condition after re-enabling, got %+v", disabled)
+	}
+	if _, hit := cache.Get(key); hit {
+		t.Error("Expected the webhook cache entry to be invalidated when re-enabled")
+	}
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/common"
+	"github.com/kube-zen/zen-sdk/pkg/lifecycle"
+)
+
+const testCustomFinalizer = "team-a.example.com/zenlock-finalizer"
+
+func TestNewZenLockReconciler_Finalizer(t *testing.T) {
+	t.Setenv("ZEN_LOCK_PRIVATE_KEY", "AGE-SECRET-1EXAMPLEEXAMPLEEXAMPLEEXAMPLEEXAMPLEEXAMPLEEXAMPLEEXAMPLEEXAMPLE")
+
+	t.Setenv("ZEN_LOCK_FINALIZER", "")
+	reconciler, err := NewZenLockReconciler(nil, nil)
+	if err != nil {
+		t.Fatalf("NewZenLockReconciler() error = %v", err)
+	}
+	if reconciler.finalizerName() != zenLockFinalizer {
+		t.Errorf("Expected default finalizer, got %q", reconciler.finalizerName())
+	}
+
+	t.Setenv("ZEN_LOCK_FINALIZER", testCustomFinalizer)
+	reconciler, err = NewZenLockReconciler(nil, nil)
+	if err != nil {
+		t.Fatalf("NewZenLockReconciler() error = %v", err)
+	}
+	if reconciler.finalizerName() != testCustomFinalizer {
+		t.Errorf("Expected %q, got %q", testCustomFinalizer, reconciler.finalizerName())
+	}
+
+	for _, invalid := range []string{"no-domain", "bad domain/finalizer", "example.com/"} {
+		t.Setenv("ZEN_LOCK_FINALIZER", invalid)
+		if _, err := NewZenLockReconciler(nil, nil); err == nil {
+			t.Errorf("Expected error for invalid finalizer %q", invalid)
+		}
+	}
+}
+
+func TestZenLockReconciler_CustomFinalizerAdded(t *testing.T) {
+	reconciler, clientBuilder := setupTestReconciler(t)
+	reconciler.finalizer = testCustomFinalizer
+
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-zenlock", Namespace: "default"},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{"key": "dGVzdA=="},
+		},
+	}
+	client := clientBuilder.WithObjects(zenlock).WithStatusSubresource(zenlock).Build()
+	reconciler.Client = client
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-zenlock", Namespace: "default"}}
+	ctx := context.Background()
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	updated := &securityv1alpha1.ZenLock{}
+	if err := client.Get(ctx, req.NamespacedName, updated); err != nil {
+		t.Fatalf("Failed to get ZenLock: %v", err)
+	}
+	if !lifecycle.HasFinalizer(updated, testCustomFinalizer) {
+		t.Errorf("Expected custom finalizer, got %v", updated.Finalizers)
+	}
+	if lifecycle.HasFinalizer(updated, zenLockFinalizer) {
+		t.Errorf("Expected default finalizer not to be added, got %v", updated.Finalizers)
+	}
+}
+
+func TestZenLockReconciler_CustomFinalizerRemovedOnDeletion(t *testing.T) {
+	reconciler, clientBuilder := setupTestReconciler(t)
+	reconciler.finalizer = testCustomFinalizer
+	if err := corev1.AddToScheme(reconciler.Scheme); err != nil {
+		t.Fatalf("Failed to add corev1 to scheme: %v", err)
+	}
+
+	now := metav1.Now()
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "test-zenlock",
+			Namespace:         "default",
+			DeletionTimestamp: &now,
+			// Another instance's finalizer must be left in place
+			Finalizers: []string{testCustomFinalizer, zenLockFinalizer},
+		},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{"key": "dGVzdA=="},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "zen-lock-inject-default-pod",
+			Namespace: "default",
+			Labels:    map[string]string{common.LabelZenLockName: "test-zenlock"},
+		},
+	}
+	client := clientBuilder.WithObjects(zenlock, secret).Build()
+	reconciler.Client = client
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-zenlock", Namespace: "default"}}
+	ctx := context.Background()
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	if err := client.Get(ctx, types.NamespacedName{Name: secret.Name, Namespace: "default"}, &corev1.Secret{}); err == nil {
+		t.Error("Expected associated Secret to be deleted")
+	}
+	updated := &securityv1alpha1.ZenLock{}
+	if err := client.Get(ctx, req.NamespacedName, updated); err != nil {
+		t.Fatalf("Expected ZenLock to remain while another finalizer is set: %v", err)
+	}
+	if lifecycle.HasFinalizer(updated, testCustomFinalizer) {
+		t.Error("Expected custom finalizer to be removed")
+	}
+	if !lifecycle.HasFinalizer(updated, zenLockFinalizer) {
+		t.Error("Expected the other instance's finalizer to be kept")
+	}
+}
+
+func TestZenLockReconciler_DeletionIgnoresOtherFinalizers(t *testing.T) {
+	reconciler, clientBuilder := setupTestReconciler(t)
+	reconciler.finalizer = testCustomFinalizer
+	if err := corev1.AddToScheme(reconciler.Scheme); err != nil {
+		t.Fatalf("Failed to add corev1 to scheme: %v", err)
+	}
+
+	now := metav1.Now()
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "test-zenlock",
+			Namespace:         "default",
+			DeletionTimestamp: &now,
+			Finalizers:        []string{zenLockFinalizer},
+		},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{"key": "dGVzdA=="},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "zen-lock-inject-default-pod",
+			Namespace: "default",
+			Labels:    map[string]string{common.LabelZenLockName: "test-zenlock"},
+		},
+	}
+	client := clientBuilder.WithObjects(zenlock, secret).Build()
+	reconciler.Client = client
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-zenlock", Namespace: "default"}}
+	ctx := context.Background()
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	// The ZenLock belongs to another instance: no cleanup and its finalizer is untouched
+	if err := client.Get(ctx, types.NamespacedName{Name: secret.Name, Namespace: "default"}, &corev1.Secret{}); err != nil {
+		t.Errorf("Expected Secret to be kept, got %v", err)
+	}
+	updated := &securityv1alpha1.ZenLock{}
+	if err := client.Get(ctx, req.NamespacedName, updated); err != nil {
+		t.Fatalf("Failed to get ZenLock: %v", err)
+	}
+	if !lifecycle.HasFinalizer(updated, zenLockFinalizer) {
+		t.Errorf("Expected other finalizer to be kept, got %v", updated.Finalizers)
+	}
+}
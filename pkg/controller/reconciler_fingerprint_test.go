@@ -0,0 +1,101 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/crypto"
+)
+
+func TestZenLockReconciler_Reconcile_DecryptedByKeyFingerprint(t *testing.T) {
+	reconciler, clientBuilder := setupTestReconciler(t)
+
+	oldIdentity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	newIdentity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	reconciler.privateKey = oldIdentity.String() + "\n" + newIdentity.String()
+
+	newZenLock := func(name string, identity *age.X25519Identity) *securityv1alpha1.ZenLock {
+		ciphertext, err := crypto.NewAgeEncryptor().Encrypt([]byte("value"), []string{identity.Recipient().String()})
+		if err != nil {
+			t.Fatalf("Failed to encrypt: %v", err)
+		}
+		return &securityv1alpha1.ZenLock{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Finalizers: []string{zenLockFinalizer}},
+			Spec: securityv1alpha1.ZenLockSpec{
+				EncryptedData: map[string]string{"key": base64.StdEncoding.EncodeToString(ciphertext)},
+			},
+		}
+	}
+	broken := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{Name: "broken", Namespace: "default", Finalizers: []string{zenLockFinalizer}},
+		Spec:       securityv1alpha1.ZenLockSpec{EncryptedData: map[string]string{"key": "aW52YWxpZA=="}},
+		Status:     securityv1alpha1.ZenLockStatus{DecryptedByKeyFingerprint: "SHA256:stale"},
+	}
+
+	c := clientBuilder.WithObjects(newZenLock("on-old", oldIdentity), newZenLock("on-new", newIdentity), broken).
+		WithStatusSubresource(&securityv1alpha1.ZenLock{}).Build()
+	reconciler.Client = c
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "on-old", want: crypto.KeyFingerprint(oldIdentity.Recipient().String())},
+		{name: "on-new", want: crypto.KeyFingerprint(newIdentity.Recipient().String())},
+		{name: "broken", want: ""},
+	}
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := types.NamespacedName{Name: tt.name, Namespace: "default"}
+			if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key}); err != nil {
+				t.Fatalf("Reconcile returned error: %v", err)
+			}
+			updated := &securityv1alpha1.ZenLock{}
+			if err := c.Get(ctx, key, updated); err != nil {
+				t.Fatalf("Failed to get ZenLock: %v", err)
+			}
+			got := updated.Status.DecryptedByKeyFingerprint
+			if got != tt.want {
+				t.Errorf("DecryptedByKeyFingerprint = %q, want %q", got, tt.want)
+			}
+			if strings.Contains(got, "AGE-SECRET-KEY") || strings.Contains(got, "age1") {
+				t.Errorf("Fingerprint %q exposes a key", got)
+			}
+		})
+	}
+
+	if crypto.KeyFingerprint(oldIdentity.Recipient().String()) == crypto.KeyFingerprint(newIdentity.Recipient().String()) {
+		t.Error("Expected distinct identities to have distinct fingerprints")
+	}
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"filippo.io/age"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
+	"github.com/kube-zen/zen-lock/pkg/crypto"
+	"github.com/kube-zen/zen-lock/pkg/webhook"
+)
+
+func TestZenLockReconciler_Reconcile_PausedSkipsWork(t *testing.T) {
+	reconciler, clientBuilder := setupTestReconciler(t)
+
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-zenlock",
+			Namespace:   "default",
+			Finalizers:  []string{zenLockFinalizer},
+			Annotations: map[string]string{config.AnnotationPaused: "true"},
+		},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{
+				"key": "invalid-encrypted-data",
+			},
+		},
+	}
+
+	client := clientBuilder.WithObjects(zenlock).WithStatusSubresource(zenlock).Build()
+	reconciler.Client = client
+
+	// A cached entry must survive a paused reconcile
+	key := types.NamespacedName{Name: "test-zenlock", Namespace: "default"}
+	cache := webhook.NewZenLockCache(5 * time.Minute)
+	defer cache.Stop()
+	webhook.RegisterCache(cache)
+	defer webhook.UnregisterCache(cache)
+	cache.Set(key, zenlock)
+
+	ctx := context.Background()
+	if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+
+	updated := &securityv1alpha1.ZenLock{}
+	if err := client.Get(ctx, key, updated); err != nil {
+		t.Fatalf("Failed to get ZenLock: %v", err)
+	}
+
+	if updated.Status.Phase != "" {
+		t.Errorf("Expected phase to be untouched while paused, got %q", updated.Status.Phase)
+	}
+	if c := findCondition(updated, conditionTypeDecryptable); c != nil {
+		t.Errorf("Expected no Decryptable condition while paused, got %+v", c)
+	}
+	paused := findCondition(updated, conditionTypePaused)
+	if paused == nil || paused.Status != "True" {
+		t.Fatalf("Expected Paused=True condition, got %+v", paused)
+	}
+	if _, hit := cache.Get(key); !hit {
+		t.Error("Expected cache entry to survive a paused reconcile")
+	}
+}
+
+func TestZenLockReconciler_Reconcile_ResumeAfterUnpause(t *testing.T) {
+	reconciler, clientBuilder := setupTestReconciler(t)
+
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	ciphertext, err := crypto.NewAgeEncryptor().Encrypt([]byte("value"), []string{identity.Recipient().String()})
+	if err != nil {
+		t.Fatalf("Failed to encrypt: %v", err)
+	}
+	reconciler.privateKey = identity.String()
+
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-zenlock",
+			Namespace:   "default",
+			Finalizers:  []string{zenLockFinalizer},
+			Annotations: map[string]string{config.AnnotationPaused: "true"},
+		},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{
+				"key": base64.StdEncoding.EncodeToString(ciphertext),
+			},
+		},
+	}
+
+	client := clientBuilder.WithObjects(zenlock).WithStatusSubresource(zenlock).Build()
+	reconciler.Client = client
+
+	key := types.NamespacedName{Name: "test-zenlock", Namespace: "default"}
+	ctx := context.Background()
+	if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+
+	// Remove the annotation and reconcile again
+	current := &securityv1alpha1.ZenLock{}
+	if err := client.Get(ctx, key, current); err != nil {
+		t.Fatalf("Failed to get ZenLock: %v", err)
+	}
+	delete(current.Annotations, config.AnnotationPaused)
+	if err := client.Update(ctx, current); err != nil {
+		t.Fatalf("Failed to remove paused annotation: %v", err)
+	}
+
+	if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+
+	updated := &securityv1alpha1.ZenLock{}
+	if err := client.Get(ctx, key, updated); err != nil {
+		t.Fatalf("Failed to get ZenLock: %v", err)
+	}
+	if updated.Status.Phase != "Ready" {
+		t.Errorf("Expected phase Ready after resume, got %q", updated.Status.Phase)
+	}
+	paused := findCondition(updated, conditionTypePaused)
+	if paused == nil || paused.Status != "False" || paused.Reason != "Resumed" {
+		t.Errorf("Expected Paused=False/Resumed condition, got %+v", paused)
+	}
+	if c := findCondition(updated, conditionTypeDecryptable); c == nil || c.Status != "True" {
+		t.Errorf("Expected Decryptable=True condition after resume, got %+v", c)
+	}
+}
+
+func TestZenLockReconciler_Reconcile_PausedLeavesCacheAndMetrics(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		conditions  []securityv1alpha1.ZenLockCondition
+	}{
+		{
+			name:        "disabled while paused",
+			annotations: map[string]string{config.AnnotationPaused: "true", config.AnnotationDisabled: "true"},
+		},
+		{
+			name:        "re-enabled while paused",
+			annotations: map[string]string{config.AnnotationPaused: "true"},
+			conditions:  []securityv1alpha1.ZenLockCondition{{Type: conditionTypeDisabled, Status: "True", Reason: "DisabledByAnnotation"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reconciler, clientBuilder := setupTestReconciler(t)
+			zenlock := &securityv1alpha1.ZenLock{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "paused-zenlock",
+					Namespace:   "default",
+					Generation:  1,
+					Finalizers:  []string{zenLockFinalizer},
+					Annotations: tt.annotations,
+				},
+				Spec:   securityv1alpha1.ZenLockSpec{EncryptedData: map[string]string{"key": "aW52YWxpZA=="}},
+				Status: securityv1alpha1.ZenLockStatus{Conditions: tt.conditions},
+			}
+			reconciler.Client = clientBuilder.WithObjects(zenlock).WithStatusSubresource(zenlock).Build()
+
+			key := types.NamespacedName{Name: "paused-zenlock", Namespace: "default"}
+			cache := webhook.NewZenLockCache(5 * time.Minute)
+			defer cache.Stop()
+			webhook.RegisterCache(cache)
+			defer webhook.UnregisterCache(cache)
+			cache.Set(key, zenlock)
+			countBefore, _ := keyCountBuckets(t)
+
+			if _, err := reconciler.Reconcile(context.Background(), reconcile.Request{NamespacedName: key}); err != nil {
+				t.Fatalf("Reconcile returned error: %v", err)
+			}
+
+			if _, hit := cache.Get(key); !hit {
+				t.Error("Expected the cache entry to survive a paused reconcile")
+			}
+			if count, _ := keyCountBuckets(t); count != countBefore {
+				t.Errorf("Expected no spec size observation while paused, got %d new", count-countBefore)
+			}
+		})
+	}
+}
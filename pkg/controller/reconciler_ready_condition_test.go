@@ -0,0 +1,124 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+)
+
+func getConditionFromList(conditions []securityv1alpha1.ZenLockCondition, conditionType string) *securityv1alpha1.ZenLockCondition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+func TestZenLockReconciler_UpdateStatus_MirrorReadyCondition(t *testing.T) {
+	reconciler, clientBuilder := setupTestReconciler(t)
+	reconciler.mirrorReadyCondition = true
+
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-zenlock",
+			Namespace: "default",
+		},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{
+				"key": "encrypted-value",
+			},
+		},
+	}
+
+	client := clientBuilder.WithObjects(zenlock).WithStatusSubresource(zenlock).Build()
+	reconciler.Client = client
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "test-zenlock", Namespace: "default"}
+
+	reconciler.updateStatus(ctx, zenlock, "Ready", "Decrypted", "Successfully decrypted")
+
+	updated := &securityv1alpha1.ZenLock{}
+	if err := client.Get(ctx, key, updated); err != nil {
+		t.Fatalf("Failed to get updated ZenLock: %v", err)
+	}
+	decryptable := getConditionFromList(updated.Status.Conditions, "Decryptable")
+	ready := getConditionFromList(updated.Status.Conditions, "Ready")
+	if decryptable == nil || ready == nil {
+		t.Fatalf("Expected both Decryptable and Ready conditions, got %+v", updated.Status.Conditions)
+	}
+	if decryptable.Status != "True" || ready.Status != "True" {
+		t.Errorf("Expected both conditions True, got Decryptable=%s Ready=%s", decryptable.Status, ready.Status)
+	}
+	if ready.Reason != decryptable.Reason || ready.Message != decryptable.Message {
+		t.Errorf("Expected Ready to mirror Decryptable, got %+v vs %+v", ready, decryptable)
+	}
+
+	// Both transition together
+	reconciler.updateStatus(ctx, updated, "Error", "DecryptionFailed", "Failed to decrypt")
+
+	if err := client.Get(ctx, key, updated); err != nil {
+		t.Fatalf("Failed to get updated ZenLock: %v", err)
+	}
+	decryptable = getConditionFromList(updated.Status.Conditions, "Decryptable")
+	ready = getConditionFromList(updated.Status.Conditions, "Ready")
+	if decryptable == nil || ready == nil {
+		t.Fatalf("Expected both Decryptable and Ready conditions, got %+v", updated.Status.Conditions)
+	}
+	if decryptable.Status != "False" || ready.Status != "False" {
+		t.Errorf("Expected both conditions False, got Decryptable=%s Ready=%s", decryptable.Status, ready.Status)
+	}
+	if ready.Reason != "DecryptionFailed" {
+		t.Errorf("Expected Ready reason 'DecryptionFailed', got %q", ready.Reason)
+	}
+}
+
+func TestZenLockReconciler_UpdateStatus_NoReadyConditionByDefault(t *testing.T) {
+	reconciler, clientBuilder := setupTestReconciler(t)
+
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-zenlock",
+			Namespace: "default",
+		},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{
+				"key": "encrypted-value",
+			},
+		},
+	}
+
+	client := clientBuilder.WithObjects(zenlock).WithStatusSubresource(zenlock).Build()
+	reconciler.Client = client
+	ctx := context.Background()
+
+	reconciler.updateStatus(ctx, zenlock, "Ready", "Decrypted", "Successfully decrypted")
+
+	updated := &securityv1alpha1.ZenLock{}
+	if err := client.Get(ctx, types.NamespacedName{Name: "test-zenlock", Namespace: "default"}, updated); err != nil {
+		t.Fatalf("Failed to get updated ZenLock: %v", err)
+	}
+	if len(updated.Status.Conditions) != 1 || updated.Status.Conditions[0].Type != "Decryptable" {
+		t.Errorf("Expected only the Decryptable condition, got %+v", updated.Status.Conditions)
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/common"
+	"github.com/kube-zen/zen-lock/pkg/crypto"
+)
+
+// reconcileUndecryptableKey reconciles a ZenLock whose key cannot be decrypted and returns its Decryptable message
+func reconcileUndecryptableKey(t *testing.T, key string) string {
+	reconciler, clientBuilder := setupTestReconciler(t)
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	other, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	reconciler.privateKey = identity.String()
+
+	ciphertext, err := crypto.NewAgeEncryptor().Encrypt([]byte("value"), []string{other.Recipient().String()})
+	if err != nil {
+		t.Fatalf("Failed to encrypt: %v", err)
+	}
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-zenlock", Namespace: "default", Finalizers: []string{zenLockFinalizer}},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{key: base64.StdEncoding.EncodeToString(ciphertext)},
+		},
+	}
+	client := clientBuilder.WithObjects(zenlock).WithStatusSubresource(zenlock).Build()
+	reconciler.Client = client
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-zenlock", Namespace: "default"}}
+	if _, err := reconciler.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	updated := &securityv1alpha1.ZenLock{}
+	if err := client.Get(context.Background(), req.NamespacedName, updated); err != nil {
+		t.Fatalf("Failed to get ZenLock: %v", err)
+	}
+	condition := findCondition(updated, conditionTypeDecryptable)
+	if condition == nil {
+		t.Fatal("Expected Decryptable condition")
+	}
+	return condition.Message
+}
+
+func TestZenLockReconciler_Reconcile_RedactsKeyNames(t *testing.T) {
+	t.Setenv("ZEN_LOCK_REDACT_KEYS", "oauth-*")
+
+	message := reconcileUndecryptableKey(t, "oauth-client-id")
+	if strings.Contains(message, "oauth-client-id") || !strings.Contains(message, common.RedactedKey) {
+		t.Errorf("Expected the key name to be redacted, got %q", message)
+	}
+
+	message = reconcileUndecryptableKey(t, "PASSWORD")
+	if !strings.Contains(message, `key "PASSWORD"`) {
+		t.Errorf("Expected a non-matching key name to appear, got %q", message)
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/common"
+	"github.com/kube-zen/zen-lock/pkg/crypto"
+	"github.com/kube-zen/zen-lock/pkg/webhook"
+)
+
+// reconcileRemovedKeys reconciles a ZenLock holding only USERNAME next to the given Secrets, with pruning enabled
+func reconcileRemovedKeys(t *testing.T, secrets ...client.Object) (client.Client, *securityv1alpha1.ZenLock) {
+	reconciler, clientBuilder := setupTestReconciler(t)
+	if err := corev1.AddToScheme(reconciler.Scheme); err != nil {
+		t.Fatalf("Failed to add corev1 to scheme: %v", err)
+	}
+	reconciler.pruneRemovedKeys = true
+
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	reconciler.privateKey = identity.String()
+	ciphertext, err := crypto.NewAgeEncryptor().Encrypt([]byte("admin"), []string{identity.Recipient().String()})
+	if err != nil {
+		t.Fatalf("Failed to encrypt: %v", err)
+	}
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-zenlock", Namespace: "default", Finalizers: []string{zenLockFinalizer}},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{"USERNAME": base64.StdEncoding.EncodeToString(ciphertext)},
+		},
+	}
+
+	c := clientBuilder.WithObjects(append(secrets, zenlock)...).WithStatusSubresource(zenlock).Build()
+	reconciler.Client = c
+
+	key := types.NamespacedName{Name: "test-zenlock", Namespace: "default"}
+	ctx := context.Background()
+	if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+	updated := &securityv1alpha1.ZenLock{}
+	if err := c.Get(ctx, key, updated); err != nil {
+		t.Fatalf("Failed to get ZenLock: %v", err)
+	}
+	return c, updated
+}
+
+// staleKeysSecret returns a Secret of test-zenlock holding USERNAME and the since removed PASSWORD
+func staleKeysSecret(name, podName string) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{common.LabelZenLockName: "test-zenlock"},
+		},
+		Data: map[string][]byte{"USERNAME": []byte("admin"), "PASSWORD": []byte("secret")},
+	}
+	if podName != "" {
+		secret.Labels[common.LabelPodName] = podName
+		secret.Labels[common.LabelPodNamespace] = "default"
+	}
+	return secret
+}
+
+func TestZenLockReconciler_Reconcile_RemovedKeysPrunedFromSharedSecret(t *testing.T) {
+	sharedName := webhook.GenerateZenLockSecretName("test-zenlock")
+	c, zenlock := reconcileRemovedKeys(t, staleKeysSecret(sharedName, ""))
+
+	secret := &corev1.Secret{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: sharedName, Namespace: "default"}, secret); err != nil {
+		t.Fatalf("Failed to get shared Secret: %v", err)
+	}
+	if _, ok := secret.Data["PASSWORD"]; ok {
+		t.Error("Expected the removed key to be pruned from the shared Secret")
+	}
+	if string(secret.Data["USERNAME"]) != "admin" {
+		t.Errorf("Expected the remaining key to be kept, got %v", secret.Data)
+	}
+	if c := findCondition(zenlock, conditionTypeStaleKeys); c != nil {
+		t.Errorf("Expected no StaleKeys condition once the shared Secret is pruned, got %+v", c)
+	}
+}
+
+func TestZenLockReconciler_Reconcile_RemovedKeysFlagPerPodSecrets(t *testing.T) {
+	c, zenlock := reconcileRemovedKeys(t, staleKeysSecret("zen-lock-inject-default-app-1", "app-1"))
+
+	// Per-Pod Secrets are left as their Pod was admitted
+	secret := &corev1.Secret{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "zen-lock-inject-default-app-1", Namespace: "default"}, secret); err != nil {
+		t.Fatalf("Failed to get per-Pod Secret: %v", err)
+	}
+	if _, ok := secret.Data["PASSWORD"]; !ok {
+		t.Error("Expected the per-Pod Secret to be left unchanged")
+	}
+
+	condition := findCondition(zenlock, conditionTypeStaleKeys)
+	if condition == nil || condition.Status != "True" || condition.Reason != "PodRecreationRequired" {
+		t.Fatalf("Expected a StaleKeys condition asking for Pod recreation, got %+v", condition)
+	}
+	if !strings.Contains(condition.Message, "zen-lock-inject-default-app-1") {
+		t.Errorf("Expected the condition to name the stale Secret, got %q", condition.Message)
+	}
+}
+
+func TestNewZenLockReconciler_PruneRemovedKeys(t *testing.T) {
+	reconciler, _ := setupTestReconciler(t)
+	if reconciler.pruneRemovedKeys {
+		t.Error("Expected removed keys pruning to be off by default")
+	}
+
+	t.Setenv("ZEN_LOCK_PRUNE_REMOVED_KEYS", "true")
+	reconciler, _ = setupTestReconciler(t)
+	if !reconciler.pruneRemovedKeys {
+		t.Error("Expected ZEN_LOCK_PRUNE_REMOVED_KEYS=true to enable removed keys pruning")
+	}
+}
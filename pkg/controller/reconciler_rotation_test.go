@@ -0,0 +1,131 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/controller/metrics"
+	"github.com/kube-zen/zen-lock/pkg/crypto"
+)
+
+func TestZenLockReconciler_RotationPending(t *testing.T) {
+	oldIdentity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	newIdentity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	t.Setenv("ZEN_LOCK_PRIVATE_KEY", oldIdentity.String()+"\n"+newIdentity.String())
+	t.Setenv("ZEN_LOCK_IDENTITIES_DIR", "")
+	t.Setenv("ZEN_LOCK_ROTATION_RECIPIENT", newIdentity.Recipient().String())
+
+	encrypt := func(recipient *age.X25519Recipient) map[string]string {
+		ciphertext, err := crypto.NewAgeEncryptor().Encrypt([]byte("value"), []string{recipient.String()})
+		if err != nil {
+			t.Fatalf("Failed to encrypt: %v", err)
+		}
+		return map[string]string{"key": base64.StdEncoding.EncodeToString(ciphertext)}
+	}
+
+	const namespace = "rotation"
+	zenlocks := []*securityv1alpha1.ZenLock{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "old", Namespace: namespace, Finalizers: []string{zenLockFinalizer}},
+			Spec:       securityv1alpha1.ZenLockSpec{EncryptedData: encrypt(oldIdentity.Recipient())},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "new", Namespace: namespace, Finalizers: []string{zenLockFinalizer}},
+			Spec:       securityv1alpha1.ZenLockSpec{EncryptedData: encrypt(newIdentity.Recipient())},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	if err := securityv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Failed to add securityv1alpha1 to scheme: %v", err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(zenlocks[0], zenlocks[1]).WithStatusSubresource(zenlocks[0], zenlocks[1]).Build()
+	reconciler, err := NewZenLockReconciler(c, scheme)
+	if err != nil {
+		t.Fatalf("Failed to create reconciler: %v", err)
+	}
+
+	ctx := context.Background()
+	reconcileAll := func() {
+		for _, zl := range zenlocks {
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: zl.Name, Namespace: namespace}}
+			if _, err := reconciler.Reconcile(ctx, req); err != nil {
+				t.Fatalf("Reconcile(%s) error = %v", zl.Name, err)
+			}
+		}
+	}
+	pending := func() float64 {
+		return testutil.ToFloat64(metrics.RotationPending.WithLabelValues(namespace))
+	}
+
+	reconcileAll()
+	if got := pending(); got != 1 {
+		t.Fatalf("Expected the ZenLock encrypted to the old key to be pending, got %v", got)
+	}
+
+	// Re-encrypt to the new identity: the ZenLock drops off the burn-down
+	reencrypted := &securityv1alpha1.ZenLock{}
+	if err := c.Get(ctx, types.NamespacedName{Name: "old", Namespace: namespace}, reencrypted); err != nil {
+		t.Fatalf("Failed to get ZenLock: %v", err)
+	}
+	reencrypted.Spec.EncryptedData = encrypt(newIdentity.Recipient())
+	if err := c.Update(ctx, reencrypted); err != nil {
+		t.Fatalf("Failed to update ZenLock: %v", err)
+	}
+	reconcileAll()
+	if got := pending(); got != 0 {
+		t.Errorf("Expected no pending ZenLocks after re-encryption, got %v", got)
+	}
+}
+
+func TestNewZenLockReconciler_UnknownRotationRecipient(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	other, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	t.Setenv("ZEN_LOCK_PRIVATE_KEY", identity.String())
+	t.Setenv("ZEN_LOCK_IDENTITIES_DIR", "")
+	t.Setenv("ZEN_LOCK_ROTATION_RECIPIENT", other.Recipient().String())
+
+	scheme := runtime.NewScheme()
+	if _, err := NewZenLockReconciler(fake.NewClientBuilder().WithScheme(scheme).Build(), scheme); err == nil {
+		t.Error("Expected a rotation recipient without a loaded identity to be rejected")
+	}
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-sdk/pkg/lifecycle"
+)
+
+func TestZenLockReconciler_Reconcile_ControllerSelector(t *testing.T) {
+	t.Setenv("ZEN_LOCK_PRIVATE_KEY", "AGE-SECRET-1EXAMPLEEXAMPLEEXAMPLEEXAMPLEEXAMPLEEXAMPLEEXAMPLEEXAMPLEEXAMPLE")
+	t.Setenv("ZEN_LOCK_CONTROLLER_SELECTOR", "zen-lock/shard=a")
+
+	scheme := runtime.NewScheme()
+	if err := securityv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Failed to add securityv1alpha1 to scheme: %v", err)
+	}
+
+	newZenLock := func(name, shard string, finalizers ...string) *securityv1alpha1.ZenLock {
+		return &securityv1alpha1.ZenLock{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       name,
+				Namespace:  "default",
+				Labels:     map[string]string{"zen-lock/shard": shard},
+				Finalizers: finalizers,
+			},
+			Spec: securityv1alpha1.ZenLockSpec{
+				EncryptedData: map[string]string{"key": "invalid-encrypted-data"},
+			},
+		}
+	}
+	matching := newZenLock("matching", "a")
+	other := newZenLock("other", "b")
+	released := newZenLock("released", "b", zenLockFinalizer)
+
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(matching, other, released).
+		WithStatusSubresource(&securityv1alpha1.ZenLock{}).Build()
+	reconciler, err := NewZenLockReconciler(c, scheme)
+	if err != nil {
+		t.Fatalf("Failed to create reconciler: %v", err)
+	}
+
+	if !reconciler.selects(matching) || reconciler.selects(other) {
+		t.Fatal("Expected the selector to match shard a only")
+	}
+
+	ctx := context.Background()
+	for _, name := range []string{"matching", "other", "released"} {
+		key := types.NamespacedName{Name: name, Namespace: "default"}
+		if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key}); err != nil {
+			t.Fatalf("Reconcile(%s) returned error: %v", name, err)
+		}
+	}
+
+	// The matching ZenLock is processed: it gets the finalizer
+	got := &securityv1alpha1.ZenLock{}
+	if err := c.Get(ctx, types.NamespacedName{Name: "matching", Namespace: "default"}, got); err != nil {
+		t.Fatalf("Failed to get ZenLock: %v", err)
+	}
+	if !lifecycle.HasFinalizer(got, zenLockFinalizer) {
+		t.Error("Expected the matching ZenLock to be processed")
+	}
+
+	// The other ZenLock is skipped: no finalizer, no status
+	if err := c.Get(ctx, types.NamespacedName{Name: "other", Namespace: "default"}, got); err != nil {
+		t.Fatalf("Failed to get ZenLock: %v", err)
+	}
+	if lifecycle.HasFinalizer(got, zenLockFinalizer) || got.Status.Phase != "" || len(got.Status.Conditions) != 0 {
+		t.Errorf("Expected the unselected ZenLock to be skipped, got finalizers %v and status %+v", got.Finalizers, got.Status)
+	}
+
+	// A ZenLock relabeled away from this instance has its finalizer released
+	if err := c.Get(ctx, types.NamespacedName{Name: "released", Namespace: "default"}, got); err != nil {
+		t.Fatalf("Failed to get ZenLock: %v", err)
+	}
+	if lifecycle.HasFinalizer(got, zenLockFinalizer) {
+		t.Error("Expected the finalizer to be removed from a ZenLock no longer selected")
+	}
+}
+
+func TestNewZenLockReconciler_InvalidControllerSelector(t *testing.T) {
+	t.Setenv("ZEN_LOCK_PRIVATE_KEY", "AGE-SECRET-1EXAMPLEEXAMPLEEXAMPLEEXAMPLEEXAMPLEEXAMPLEEXAMPLEEXAMPLEEXAMPLE")
+	t.Setenv("ZEN_LOCK_CONTROLLER_SELECTOR", "shard in (")
+
+	scheme := runtime.NewScheme()
+	if err := securityv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Failed to add securityv1alpha1 to scheme: %v", err)
+	}
+	if _, err := NewZenLockReconciler(fake.NewClientBuilder().WithScheme(scheme).Build(), scheme); err == nil {
+		t.Error("Expected an invalid selector to be rejected")
+	}
+}
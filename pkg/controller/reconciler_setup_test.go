@@ -57,3 +57,42 @@ func TestZenLockReconciler_SetupWithManager(t *testing.T) {
 	// Real integration tests should cover SetupWithManager functionality
 	t.Skip("SetupWithManager requires envtest - covered in integration tests")
 }
+
+func TestMaxConcurrentReconciles(t *testing.T) {
+	t.Setenv("ZEN_LOCK_PRIVATE_KEY", "AGE-SECRET-1EXAMPLEEXAMPLEEXAMPLEEXAMPLEEXAMPLEEXAMPLEEXAMPLEEXAMPLEEXAMPLE")
+
+	scheme := runtime.NewScheme()
+	if err := securityv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Failed to add securityv1alpha1 to scheme: %v", err)
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	tests := []struct {
+		name  string
+		value string
+		want  int
+	}{
+		{name: "unset", value: "", want: 1},
+		{name: "configured", value: "4", want: 4},
+		{name: "zero", value: "0", want: 1},
+		{name: "invalid", value: "many", want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("ZEN_LOCK_MAX_CONCURRENT_RECONCILES", tt.value)
+
+			zenlockReconciler, err := NewZenLockReconciler(client, scheme)
+			if err != nil {
+				t.Fatalf("Failed to create reconciler: %v", err)
+			}
+			if got := controllerOptions(zenlockReconciler.maxConcurrentReconciles).MaxConcurrentReconciles; got != tt.want {
+				t.Errorf("ZenLock reconciler MaxConcurrentReconciles = %d, want %d", got, tt.want)
+			}
+
+			secretReconciler := NewSecretReconciler(client, scheme)
+			if got := controllerOptions(secretReconciler.maxConcurrentReconciles).MaxConcurrentReconciles; got != tt.want {
+				t.Errorf("Secret reconciler MaxConcurrentReconciles = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/controller/metrics"
+)
+
+// keyCountBuckets returns the sample count and cumulative bucket counts by upper bound of zenlock_spec_keys
+func keyCountBuckets(t *testing.T) (uint64, map[float64]uint64) {
+	m := &dto.Metric{}
+	if err := metrics.ZenLockKeyCount.Write(m); err != nil {
+		t.Fatalf("Failed to write histogram: %v", err)
+	}
+	buckets := make(map[float64]uint64)
+	for _, bucket := range m.GetHistogram().GetBucket() {
+		buckets[bucket.GetUpperBound()] = bucket.GetCumulativeCount()
+	}
+	return m.GetHistogram().GetSampleCount(), buckets
+}
+
+func TestZenLockReconciler_Reconcile_SpecSizeMetrics(t *testing.T) {
+	reconciler, clientBuilder := setupTestReconciler(t)
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{Name: "sized", Namespace: "default", Generation: 1, Finalizers: []string{zenLockFinalizer}},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{"a": "aW52YWxpZA==", "b": "aW52YWxpZA==", "c": "aW52YWxpZA=="},
+		},
+	}
+	c := clientBuilder.WithObjects(zenlock).WithStatusSubresource(&securityv1alpha1.ZenLock{}).Build()
+	reconciler.Client = c
+
+	countBefore, bucketsBefore := keyCountBuckets(t)
+	bytesBefore := &dto.Metric{}
+	if err := metrics.ZenLockByteSize.Write(bytesBefore); err != nil {
+		t.Fatalf("Failed to write histogram: %v", err)
+	}
+
+	ctx := context.Background()
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "sized", Namespace: "default"}}
+	for i := 0; i < 2; i++ {
+		if _, err := reconciler.Reconcile(ctx, req); err != nil {
+			t.Fatalf("Reconcile returned error: %v", err)
+		}
+	}
+
+	// Reconciling the same generation twice records a single observation, in the bucket for 3 keys
+	count, buckets := keyCountBuckets(t)
+	if got := count - countBefore; got != 1 {
+		t.Fatalf("Expected one key count observation, got %d", got)
+	}
+	if got := buckets[4] - bucketsBefore[4]; got != 1 {
+		t.Errorf("Expected the observation in the le=4 bucket, got %d", got)
+	}
+	if got := buckets[2] - bucketsBefore[2]; got != 0 {
+		t.Errorf("Expected no observation in the le=2 bucket, got %d", got)
+	}
+	bytesAfter := &dto.Metric{}
+	if err := metrics.ZenLockByteSize.Write(bytesAfter); err != nil {
+		t.Fatalf("Failed to write histogram: %v", err)
+	}
+	if got := bytesAfter.GetHistogram().GetSampleSum() - bytesBefore.GetHistogram().GetSampleSum(); got != 3*(1+12) {
+		t.Errorf("Expected %d bytes to be observed, got %v", 3*(1+12), got)
+	}
+
+	// A new generation is observed again
+	updated := &securityv1alpha1.ZenLock{}
+	if err := c.Get(ctx, req.NamespacedName, updated); err != nil {
+		t.Fatalf("Failed to get ZenLock: %v", err)
+	}
+	updated.Generation = 2
+	if err := c.Update(ctx, updated); err != nil {
+		t.Fatalf("Failed to update ZenLock: %v", err)
+	}
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+	if count, _ := keyCountBuckets(t); count-countBefore != 2 {
+		t.Errorf("Expected a new generation to be observed, got %d observations", count-countBefore)
+	}
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+)
+
+func reconcileWithSubjects(t *testing.T, recorder *record.FakeRecorder, objs ...client.Object) *securityv1alpha1.ZenLock {
+	reconciler, clientBuilder := setupTestReconciler(t)
+	if err := corev1.AddToScheme(reconciler.Scheme); err != nil {
+		t.Fatalf("Failed to add corev1 to scheme: %v", err)
+	}
+	reconciler.Recorder = recorder
+
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test-zenlock",
+			Namespace:  "default",
+			Finalizers: []string{zenLockFinalizer},
+		},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{"key": "encrypted-value"},
+			AllowedSubjects: []securityv1alpha1.SubjectReference{
+				{Kind: "ServiceAccount", Name: "app", Namespace: "default"},
+			},
+		},
+	}
+
+	client := clientBuilder.WithObjects(append(objs, zenlock)...).WithStatusSubresource(zenlock).Build()
+	reconciler.Client = client
+
+	key := types.NamespacedName{Name: "test-zenlock", Namespace: "default"}
+	ctx := context.Background()
+	if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+
+	updated := &securityv1alpha1.ZenLock{}
+	if err := client.Get(ctx, key, updated); err != nil {
+		t.Fatalf("Failed to get ZenLock: %v", err)
+	}
+	return updated
+}
+
+func TestZenLockReconciler_Reconcile_SubjectMissing(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	updated := reconcileWithSubjects(t, recorder)
+
+	condition := findCondition(updated, conditionTypeSubjectsResolved)
+	if condition == nil || condition.Status != "False" || condition.Reason != "SubjectMissing" {
+		t.Fatalf("Expected SubjectsResolved=False with reason SubjectMissing, got %+v", condition)
+	}
+	if !strings.Contains(condition.Message, "default/app") {
+		t.Errorf("Expected message to name the missing ServiceAccount, got %q", condition.Message)
+	}
+
+	select {
+	case event := <-recorder.Events:
+		if !strings.HasPrefix(event, "Warning SubjectMissing") {
+			t.Errorf("Expected Warning SubjectMissing event, got %q", event)
+		}
+	default:
+		t.Error("Expected a SubjectMissing event")
+	}
+
+	// Advisory only: the phase is still driven by decryption (undecryptable test data here)
+	if decryptable := findCondition(updated, conditionTypeDecryptable); decryptable == nil || decryptable.Reason == "SubjectMissing" {
+		t.Errorf("Expected Decryptable condition unaffected by missing subjects, got %+v", decryptable)
+	}
+}
+
+func TestZenLockReconciler_Reconcile_SubjectExists(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	sa := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+	}
+	updated := reconcileWithSubjects(t, recorder, sa)
+
+	condition := findCondition(updated, conditionTypeSubjectsResolved)
+	if condition == nil || condition.Status != "True" {
+		t.Fatalf("Expected SubjectsResolved=True, got %+v", condition)
+	}
+
+	select {
+	case event := <-recorder.Events:
+		t.Errorf("Expected no event, got %q", event)
+	default:
+	}
+}
+
+func TestZenLockReconciler_CheckSubjects_Clears(t *testing.T) {
+	reconciler, clientBuilder := setupTestReconciler(t)
+	if err := corev1.AddToScheme(reconciler.Scheme); err != nil {
+		t.Fatalf("Failed to add corev1 to scheme: %v", err)
+	}
+	reconciler.Recorder = record.NewFakeRecorder(10)
+	reconciler.Client = clientBuilder.Build()
+
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-zenlock", Namespace: "default"},
+		Spec: securityv1alpha1.ZenLockSpec{
+			AllowedSubjects: []securityv1alpha1.SubjectReference{
+				{Kind: "ServiceAccount", Name: "app"},
+			},
+		},
+	}
+	ctx := context.Background()
+
+	reconciler.checkSubjects(ctx, zenlock)
+	if c := findCondition(zenlock, conditionTypeSubjectsResolved); c == nil || c.Status != "False" {
+		t.Fatalf("Expected SubjectsResolved=False, got %+v", c)
+	}
+
+	// Creating the ServiceAccount clears the condition on the next check
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"}}
+	if err := reconciler.Create(ctx, sa); err != nil {
+		t.Fatalf("Failed to create ServiceAccount: %v", err)
+	}
+	reconciler.checkSubjects(ctx, zenlock)
+	if c := findCondition(zenlock, conditionTypeSubjectsResolved); c == nil || c.Status != "True" || c.Reason != "SubjectsFound" {
+		t.Errorf("Expected SubjectsResolved=True after ServiceAccount creation, got %+v", c)
+	}
+}
+
+func TestZenLockReconciler_Reconcile_OpenAccess(t *testing.T) {
+	reconciler, clientBuilder := setupTestReconciler(t)
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test-zenlock",
+			Namespace:  "default",
+			Finalizers: []string{zenLockFinalizer},
+		},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{"key": "encrypted-value"},
+		},
+	}
+	client := clientBuilder.WithObjects(zenlock).WithStatusSubresource(zenlock).Build()
+	reconciler.Client = client
+
+	key := types.NamespacedName{Name: "test-zenlock", Namespace: "default"}
+	ctx := context.Background()
+	reconcileAndGet := func() *securityv1alpha1.ZenLock {
+		if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key}); err != nil {
+			t.Fatalf("Reconcile returned error: %v", err)
+		}
+		updated := &securityv1alpha1.ZenLock{}
+		if err := client.Get(ctx, key, updated); err != nil {
+			t.Fatalf("Failed to get ZenLock: %v", err)
+		}
+		return updated
+	}
+
+	updated := reconcileAndGet()
+	condition := findCondition(updated, conditionTypeOpenAccess)
+	if condition == nil || condition.Status != "True" || condition.Reason != "NoAllowedSubjects" {
+		t.Fatalf("Expected OpenAccess=True with reason NoAllowedSubjects, got %+v", condition)
+	}
+
+	// Adding subjects clears the warning
+	updated.Spec.AllowedSubjects = []securityv1alpha1.SubjectReference{{Kind: "ServiceAccount", Name: "app", Namespace: "default"}}
+	if err := client.Update(ctx, updated); err != nil {
+		t.Fatalf("Failed to update ZenLock: %v", err)
+	}
+	condition = findCondition(reconcileAndGet(), conditionTypeOpenAccess)
+	if condition == nil || condition.Status != "False" {
+		t.Errorf("Expected OpenAccess=False once subjects are set, got %+v", condition)
+	}
+}
+
+func TestZenLockReconciler_Reconcile_NoOpenAccessWithSubjects(t *testing.T) {
+	updated := reconcileWithSubjects(t, record.NewFakeRecorder(10))
+	if condition := findCondition(updated, conditionTypeOpenAccess); condition != nil {
+		t.Errorf("Expected no OpenAccess condition for a ZenLock with subjects, got %+v", condition)
+	}
+}
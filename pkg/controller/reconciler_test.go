@@ -18,9 +18,11 @@ package controller
 
 import (
 	"context"
+	"encoding/base64"
 	"os"
 	"testing"
 
+	"filippo.io/age"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -28,6 +30,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/crypto"
 )
 
 func setupTestReconciler(t *testing.T) (*ZenLockReconciler, *fake.ClientBuilder) {
@@ -82,6 +85,16 @@ func TestZenLockReconciler_Reconcile_NotFound(t *testing.T) {
 func TestZenLockReconciler_Reconcile_ValidZenLock(t *testing.T) {
 	reconciler, clientBuilder := setupTestReconciler(t)
 
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	ciphertext, err := crypto.NewAgeEncryptor().Encrypt([]byte("test-value"), []string{identity.Recipient().String()})
+	if err != nil {
+		t.Fatalf("Failed to encrypt: %v", err)
+	}
+	reconciler.privateKey = identity.String()
+
 	zenlock := &securityv1alpha1.ZenLock{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "test-secret",
@@ -91,7 +104,7 @@ func TestZenLockReconciler_Reconcile_ValidZenLock(t *testing.T) {
 		},
 		Spec: securityv1alpha1.ZenLockSpec{
 			EncryptedData: map[string]string{
-				"key1": "dGVzdC12YWx1ZQ==", // base64 encoded test value
+				"key1": base64.StdEncoding.EncodeToString(ciphertext),
 			},
 		},
 	}
@@ -0,0 +1,143 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/go-logr/logr/funcr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/crypto"
+)
+
+// traceLine is a log line written by funcr.NewJSON holding a reconcile summary
+type traceLine struct {
+	Msg     string         `json:"msg"`
+	Summary reconcileTrace `json:"summary"`
+}
+
+// reconcileTraced reconciles the named ZenLock and returns the raw summary lines logged
+func reconcileTraced(t *testing.T, reconciler *ZenLockReconciler, name string) []string {
+	t.Helper()
+	var lines []string
+	logger := funcr.NewJSON(func(obj string) {
+		if strings.Contains(obj, `"Reconcile summary"`) {
+			lines = append(lines, obj)
+		}
+	}, funcr.Options{})
+	ctx := log.IntoContext(context.Background(), logger)
+	key := types.NamespacedName{Name: name, Namespace: "default"}
+	if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+	return lines
+}
+
+func TestZenLockReconciler_Reconcile_Trace(t *testing.T) {
+	reconciler, clientBuilder := setupTestReconciler(t)
+	reconciler.traceReconciles = true
+
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	reconciler.privateKey = identity.String()
+	ciphertext, err := crypto.NewAgeEncryptor().Encrypt([]byte("plaintext-admin"), []string{identity.Recipient().String()})
+	if err != nil {
+		t.Fatalf("Failed to encrypt: %v", err)
+	}
+	valid := base64.StdEncoding.EncodeToString(ciphertext)
+
+	newZenLock := func(name string, data map[string]string) *securityv1alpha1.ZenLock {
+		return &securityv1alpha1.ZenLock{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Generation: 3, Finalizers: []string{zenLockFinalizer}},
+			Spec:       securityv1alpha1.ZenLockSpec{EncryptedData: data},
+		}
+	}
+	ready := newZenLock("ready", map[string]string{"USERNAME": valid})
+	broken := newZenLock("broken", map[string]string{"USERNAME": valid, "PASSWORD": "aW52YWxpZA=="})
+	reconciler.Client = clientBuilder.WithObjects(ready, broken).WithStatusSubresource(&securityv1alpha1.ZenLock{}).Build()
+
+	tests := []struct {
+		name        string
+		wantDecrypt map[string]bool
+		wantPhase   string
+	}{
+		{name: "ready", wantDecrypt: map[string]bool{"USERNAME": true}, wantPhase: "Ready"},
+		{name: "broken", wantDecrypt: map[string]bool{"USERNAME": true, "PASSWORD": false}, wantPhase: "Error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := reconcileTraced(t, reconciler, tt.name)
+			if len(lines) != 1 {
+				t.Fatalf("Expected a single summary line, got %d: %v", len(lines), lines)
+			}
+			if strings.Contains(lines[0], "plaintext-admin") {
+				t.Fatalf("Summary leaks plaintext: %s", lines[0])
+			}
+
+			var line traceLine
+			if err := json.Unmarshal([]byte(lines[0]), &line); err != nil {
+				t.Fatalf("Summary is not a JSON line: %v: %s", err, lines[0])
+			}
+			got := line.Summary
+			if got.Object != "default/"+tt.name || got.Generation != 3 {
+				t.Errorf("Expected object default/%s at generation 3, got %q at %d", tt.name, got.Object, got.Generation)
+			}
+			if got.PhaseFrom != "" || got.PhaseTo != tt.wantPhase {
+				t.Errorf("Expected the phase to move from \"\" to %q, got %q to %q", tt.wantPhase, got.PhaseFrom, got.PhaseTo)
+			}
+			if len(got.Decrypt) != len(tt.wantDecrypt) {
+				t.Fatalf("Decrypt = %v, want %v", got.Decrypt, tt.wantDecrypt)
+			}
+			for key, want := range tt.wantDecrypt {
+				if got.Decrypt[key] != want {
+					t.Errorf("Decrypt[%q] = %v, want %v", key, got.Decrypt[key], want)
+				}
+			}
+			// Only a successful decryption refreshes the webhook cache
+			if got.CacheInvalidated != (tt.wantPhase == "Ready") {
+				t.Errorf("CacheInvalidated = %v for phase %s", got.CacheInvalidated, tt.wantPhase)
+			}
+			if !strings.Contains(lines[0], `"durationMs"`) {
+				t.Errorf("Expected the summary to carry the duration: %s", lines[0])
+			}
+		})
+	}
+}
+
+func TestZenLockReconciler_Reconcile_TraceDisabled(t *testing.T) {
+	reconciler, clientBuilder := setupTestReconciler(t)
+	reconciler.Client = clientBuilder.WithObjects(&securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-zenlock", Namespace: "default", Finalizers: []string{zenLockFinalizer}},
+		Spec:       securityv1alpha1.ZenLockSpec{EncryptedData: map[string]string{"key": "aW52YWxpZA=="}},
+	}).WithStatusSubresource(&securityv1alpha1.ZenLock{}).Build()
+
+	if lines := reconcileTraced(t, reconciler, "test-zenlock"); len(lines) != 0 {
+		t.Errorf("Expected no summary without ZEN_LOCK_RECONCILE_TRACE, got %v", lines)
+	}
+}
@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/common"
+	"github.com/kube-zen/zen-lock/pkg/webhook"
+)
+
+// injectedKeyNames returns the Secret keys a Pod injecting the ZenLock receives, given its decrypted data
+// Key names go through the same concatenation, extension and case rules as in the webhook.
+func injectedKeyNames(zenlock *securityv1alpha1.ZenLock, decrypted map[string][]byte) (map[string]bool, error) {
+	data, err := webhook.ApplyConcatenation(zenlock, decrypted)
+	if err != nil {
+		return nil, err
+	}
+	if data, err = webhook.ApplyKeyExtensions(zenlock, data); err != nil {
+		return nil, err
+	}
+	if data, err = webhook.ApplyKeyCase(zenlock, data); err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool, len(data))
+	for key := range data {
+		names[key] = true
+	}
+	return names, nil
+}
+
+// checkRemovedKeys handles injected Secrets still carrying keys the ZenLock no longer has (ZEN_LOCK_PRUNE_REMOVED_KEYS)
+// Shared Secrets are mounted by every Pod injecting the ZenLock and are updated in place: the stale keys are dropped.
+// Per-Pod Secrets match the volumes and env vars their Pod was admitted with, so they are left alone and the
+// StaleKeys condition tells which Pods to recreate. Immutable Secrets cannot be updated and are reported the same way.
+func (r *ZenLockReconciler) checkRemovedKeys(ctx context.Context, zenlock *securityv1alpha1.ZenLock, decrypted map[string][]byte) {
+	logger := log.FromContext(ctx)
+
+	current, err := injectedKeyNames(zenlock, decrypted)
+	if err != nil {
+		// The webhook denies injections of such a ZenLock: nothing to compare against
+		logger.V(4).Info("Cannot resolve injected key names, skipping removed keys check", "name", zenlock.Name, "error", err)
+		return
+	}
+
+	secrets := &corev1.SecretList{}
+	if err := r.List(ctx, secrets, client.InNamespace(zenlock.Namespace), client.MatchingLabels{common.ZenLockNameLabel(): zenlock.Name}); err != nil {
+		// Transient lookup failure - keep the previous condition rather than guessing
+		logger.Error(err, "Failed to list injected secrets for removed keys check", "name", zenlock.Name)
+		return
+	}
+
+	var stale []string
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		if !secret.DeletionTimestamp.IsZero() {
+			continue
+		}
+		var removed []string
+		for key := range secret.Data {
+			if !current[key] {
+				removed = append(removed, key)
+			}
+		}
+		if len(removed) == 0 {
+			continue
+		}
+
+		_, perPod := secret.Labels[common.PodNameLabel()]
+		immutable := secret.Immutable != nil && *secret.Immutable
+		if perPod || immutable {
+			stale = append(stale, secret.Name)
+			continue
+		}
+		for _, key := range removed {
+			delete(secret.Data, key)
+		}
+		if err := r.Update(ctx, secret); err != nil {
+			logger.Error(err, "Failed to remove stale keys from shared secret", "secret", secret.Name)
+			stale = append(stale, secret.Name)
+			continue
+		}
+		logger.Info("Removed stale keys from shared secret", "secret", secret.Name, "keys", common.RedactKeyNames(removed))
+	}
+
+	if len(stale) == 0 {
+		if c := findCondition(zenlock, conditionTypeStaleKeys); c != nil && c.Status != "False" {
+			setCondition(zenlock, securityv1alpha1.ZenLockCondition{
+				Type:    conditionTypeStaleKeys,
+				Status:  "False",
+				Reason:  "NoStaleKeys",
+				Message: "No injected Secret carries keys removed from the ZenLock",
+			})
+		}
+		return
+	}
+	sort.Strings(stale)
+	setCondition(zenlock, securityv1alpha1.ZenLockCondition{
+		Type:    conditionTypeStaleKeys,
+		Status:  "True",
+		Reason:  "PodRecreationRequired",
+		Message: fmt.Sprintf("Injected Secret(s) still carry keys removed from the ZenLock; recreate the Pods using them: %s", strings.Join(stale, ", ")),
+	})
+}
@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kube-zen/zen-lock/pkg/controller/metrics"
+)
+
+// rotationTracker tracks the ZenLocks the rotation target identity cannot decrypt yet
+// and publishes their number per namespace (zenlock_rotation_pending).
+type rotationTracker struct {
+	mu      sync.Mutex
+	pending map[types.NamespacedName]bool
+}
+
+// set records whether key is still pending re-encryption to the rotation target
+func (t *rotationTracker) set(key types.NamespacedName, pending bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.pending == nil {
+		t.pending = make(map[types.NamespacedName]bool)
+	}
+	if pending {
+		t.pending[key] = true
+	} else {
+		delete(t.pending, key)
+	}
+
+	count := 0
+	for k := range t.pending {
+		if k.Namespace == key.Namespace {
+			count++
+		}
+	}
+	metrics.SetRotationPending(key.Namespace, count)
+}
+
+// forget drops key, e.g. once the ZenLock is deleted or no longer decryptable at all
+func (t *rotationTracker) forget(key types.NamespacedName) {
+	t.set(key, false)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -11,9 +12,15 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/kube-zen/zen-lock/pkg/common"
 	"github.com/kube-zen/zen-lock/pkg/config"
@@ -32,6 +39,15 @@ type SecretReconciler struct {
 	client.Client
 	Scheme    *runtime.Scheme
 	OrphanTTL time.Duration // Time after which orphaned Secrets are deleted
+
+	// blockOwnerDeletion sets blockOwnerDeletion on the Pod owner reference (ZEN_LOCK_BLOCK_OWNER_DELETION)
+	blockOwnerDeletion bool
+	// ownerRefGrace is the minimum Pod age before the owner reference is set (ZEN_LOCK_OWNERREF_GRACE)
+	ownerRefGrace time.Duration
+	// maxConcurrentReconciles is the number of Secrets reconciled in parallel (ZEN_LOCK_MAX_CONCURRENT_RECONCILES)
+	maxConcurrentReconciles int
+	// startupPrune enqueues every zen-lock Secret once the controller starts (ZEN_LOCK_STARTUP_PRUNE)
+	startupPrune bool
 }
 
 // NewSecretReconciler creates a new SecretReconciler
@@ -42,10 +58,23 @@ func NewSecretReconciler(client client.Client, scheme *runtime.Scheme) *SecretRe
 			orphanTTL = parsedTTL
 		}
 	}
+	var ownerRefGrace time.Duration
+	if graceStr := os.Getenv("ZEN_LOCK_OWNERREF_GRACE"); graceStr != "" {
+		if parsedGrace, err := time.ParseDuration(graceStr); err == nil && parsedGrace > 0 {
+			ownerRefGrace = parsedGrace
+		}
+	}
+	startupPrune, _ := strconv.ParseBool(os.Getenv("ZEN_LOCK_STARTUP_PRUNE"))
 	return &SecretReconciler{
 		Client:    client,
 		Scheme:    scheme,
 		OrphanTTL: orphanTTL,
+
+		blockOwnerDeletion: common.BlockOwnerDeletion(),
+		ownerRefGrace:      ownerRefGrace,
+
+		maxConcurrentReconciles: maxConcurrentReconcilesFromEnv(),
+		startupPrune:            startupPrune,
 	}
 }
 
@@ -63,26 +92,38 @@ func (r *SecretReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	}
 
 	// Only process Secrets with zen-lock labels
-	podName, hasPodName := secret.Labels[common.LabelPodName]
-	podNamespace, hasPodNamespace := secret.Labels[common.LabelPodNamespace]
+	podName, hasPodName := secret.Labels[common.PodNameLabel()]
+	podNamespace, hasPodNamespace := secret.Labels[common.PodNamespaceLabel()]
 	if !hasPodName || !hasPodNamespace {
 		// Not a zen-lock Secret, ignore
 		return ctrl.Result{}, nil
 	}
 
-	// Check if OwnerReference already exists
-	if len(secret.OwnerReferences) > 0 {
-		// Already has OwnerReference, nothing to do
-		return ctrl.Result{}, nil
-	}
-
 	// Fetch the Pod
 	pod := &corev1.Pod{}
 	podKey := types.NamespacedName{
 		Name:      podName,
 		Namespace: podNamespace,
 	}
-	if err := r.Get(ctx, podKey, pod); err != nil {
+	err := r.Get(ctx, podKey, pod)
+
+	// A terminal Pod never restarts its containers: delete its Secret now rather than when the Pod is deleted
+	if err == nil && podTerminated(pod) {
+		logger.Info("Deleting zen-lock secret of terminated Pod", "secret", req.NamespacedName, "pod", podKey, "phase", pod.Status.Phase)
+		if err := r.Delete(ctx, secret); err != nil && !k8serrors.IsNotFound(err) {
+			logger.Error(err, "Failed to delete zen-lock secret of terminated Pod", "secret", req.NamespacedName)
+			return ctrl.Result{}, fmt.Errorf("failed to delete secret of terminated Pod: %w", err)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Check if OwnerReference already exists
+	if len(secret.OwnerReferences) > 0 {
+		// Already has OwnerReference, nothing to do
+		return ctrl.Result{}, nil
+	}
+
+	if err != nil {
 		// Pod doesn't exist - check if this is a stale/orphaned secret
 		if k8serrors.IsNotFound(err) {
 			// Check if Secret has been orphaned for a while (no OwnerReference means Pod was never created or was deleted)
@@ -111,12 +152,15 @@ func (r *SecretReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		return ctrl.Result{RequeueAfter: config.RequeueDelayPodNoUID}, nil
 	}
 
+	// Let a freshly created Pod settle before referencing it, in environments that need it
+	if remaining := r.ownerRefGrace - time.Since(pod.CreationTimestamp.Time); r.ownerRefGrace > 0 && remaining > 0 {
+		logger.V(4).Info("Pod within owner reference grace period, will retry", "pod", podKey, "remaining", remaining)
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
 	// Set owner reference using zen-sdk/pkg/k8s/metadata
 	// This ensures proper scheme handling and garbage collection
-	retryConfig := retry.DefaultConfig()
-	retryConfig.MaxAttempts = config.DefaultRetryMaxAttempts
-	retryConfig.InitialDelay = config.DefaultRetryInitialDelay
-	retryConfig.MaxDelay = config.DefaultRetryMaxDelay
+	retryConfig := common.RetryConfig(config.DefaultRetryMaxAttempts, config.DefaultRetryInitialDelay, config.DefaultRetryMaxDelay)
 
 	if err := retry.Do(ctx, retryConfig, func() error {
 		// Re-fetch secret to get latest version (for conflict resolution)
@@ -127,7 +171,7 @@ func (r *SecretReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		// Set controller reference using controllerutil
 		// This ensures proper scheme handling and garbage collection
 		// Use SetControllerReference to set Controller=true for proper cleanup
-		if err := controllerutil.SetControllerReference(pod, currentSecret, r.Scheme); err != nil {
+		if err := controllerutil.SetControllerReference(pod, currentSecret, r.Scheme, controllerutil.WithBlockOwnerDeletion(r.blockOwnerDeletion)); err != nil {
 			return fmt.Errorf("failed to set controller reference: %w", err)
 		}
 		return r.Update(ctx, currentSecret)
@@ -140,9 +184,48 @@ func (r *SecretReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	return ctrl.Result{}, nil
 }
 
+// podTerminated reports whether the Pod has reached a terminal phase, after which its containers never run again
+func podTerminated(pod *corev1.Pod) bool {
+	return pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+}
+
+// secretsForTerminatedPod maps a terminated Pod to the zen-lock Secrets created for it
+func (r *SecretReconciler) secretsForTerminatedPod(ctx context.Context, obj client.Object) []reconcile.Request {
+	secrets := &corev1.SecretList{}
+	if err := r.List(ctx, secrets, client.InNamespace(obj.GetNamespace()), client.MatchingLabels{
+		common.PodNameLabel():      obj.GetName(),
+		common.PodNamespaceLabel(): obj.GetNamespace(),
+	}); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list zen-lock secrets of terminated Pod", "pod", client.ObjectKeyFromObject(obj))
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(secrets.Items))
+	for i := range secrets.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&secrets.Items[i])})
+	}
+	return requests
+}
+
 // SetupWithManager sets up the controller with the Manager
+// Pods are watched so their Secrets are deleted as soon as they terminate.
 func (r *SecretReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	if err := mgr.Add(newOrphanSecretsGauge(mgr.GetClient(), r.OrphanTTL, config.DefaultOrphanGaugeInterval)); err != nil {
+		return err
+	}
+	terminated := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		pod, ok := obj.(*corev1.Pod)
+		return ok && podTerminated(pod)
+	})
+	bldr := ctrl.NewControllerManagedBy(mgr).
 		For(&corev1.Secret{}).
-		Complete(r)
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(r.secretsForTerminatedPod), builder.WithPredicates(terminated)).
+		WithOptions(controllerOptions(r.maxConcurrentReconciles))
+	if r.startupPrune {
+		events := make(chan event.GenericEvent)
+		if err := mgr.Add(newStartupPruneSweep(r.Client, events, config.DefaultStartupPruneInterval)); err != nil {
+			return err
+		}
+		bldr = bldr.WatchesRawSource(source.Channel(events, &handler.EnqueueRequestForObject{}))
+	}
+	return bldr.Complete(r)
 }
@@ -0,0 +1,93 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kube-zen/zen-lock/pkg/common"
+)
+
+func TestSecretReconciler_CustomLabelPrefix(t *testing.T) {
+	t.Setenv("ZEN_LOCK_LABEL_PREFIX", "secrets.example.com")
+	reconciler, clientBuilder := setupSecretReconciler(t)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "default", UID: types.UID("test-pod-uid")},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "test-container", Image: "nginx"}}},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "zen-lock-secret",
+			Namespace: "default",
+			Labels: map[string]string{
+				"secrets.example.com/pod-name":      "test-pod",
+				"secrets.example.com/pod-namespace": "default",
+				"secrets.example.com/zenlock-name":  "test-zenlock",
+			},
+		},
+	}
+	c := clientBuilder.WithObjects(pod, secret).Build()
+	reconciler.Client = c
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "zen-lock-secret", Namespace: "default"}}
+	if _, err := reconciler.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	updated := &corev1.Secret{}
+	if err := c.Get(context.Background(), req.NamespacedName, updated); err != nil {
+		t.Fatalf("Failed to get Secret: %v", err)
+	}
+	if len(updated.OwnerReferences) != 1 || updated.OwnerReferences[0].UID != pod.UID {
+		t.Errorf("Expected the Secret labeled with the custom prefix to be owned by the Pod, got %+v", updated.OwnerReferences)
+	}
+}
+
+func TestSecretReconciler_CustomLabelPrefix_IgnoresDefaultLabels(t *testing.T) {
+	t.Setenv("ZEN_LOCK_LABEL_PREFIX", "secrets.example.com")
+	reconciler, clientBuilder := setupSecretReconciler(t)
+
+	// Default-prefix labels are foreign once a custom prefix is configured: the orphan is left alone
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "other-secret",
+			Namespace: "default",
+			Labels: map[string]string{
+				common.LabelPodName:      "missing-pod",
+				common.LabelPodNamespace: "default",
+			},
+		},
+	}
+	c := clientBuilder.WithObjects(secret).Build()
+	reconciler.Client = c
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "other-secret", Namespace: "default"}}
+	if _, err := reconciler.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if err := c.Get(context.Background(), req.NamespacedName, &corev1.Secret{}); k8serrors.IsNotFound(err) {
+		t.Error("Expected a Secret without the configured labels to be left alone")
+	}
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kube-zen/zen-lock/pkg/common"
+)
+
+func TestSecretReconciler_BlockOwnerDeletion(t *testing.T) {
+	for _, tt := range []struct {
+		env  string
+		want bool
+	}{
+		{env: "", want: false},
+		{env: "false", want: false},
+		{env: "true", want: true},
+	} {
+		t.Run("env="+tt.env, func(t *testing.T) {
+			t.Setenv("ZEN_LOCK_BLOCK_OWNER_DELETION", tt.env)
+			reconciler, clientBuilder := setupSecretReconciler(t)
+
+			pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "default", UID: types.UID("test-pod-uid")}}
+			secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+				Name:      "zen-lock-secret",
+				Namespace: "default",
+				Labels: map[string]string{
+					common.LabelPodName:      "test-pod",
+					common.LabelPodNamespace: "default",
+					common.LabelZenLockName:  "test-zenlock",
+				},
+			}}
+			client := clientBuilder.WithObjects(pod, secret).Build()
+			reconciler.Client = client
+
+			key := types.NamespacedName{Name: "zen-lock-secret", Namespace: "default"}
+			ctx := context.Background()
+			if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key}); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			updated := &corev1.Secret{}
+			if err := client.Get(ctx, key, updated); err != nil {
+				t.Fatalf("Failed to get Secret: %v", err)
+			}
+			if len(updated.OwnerReferences) != 1 {
+				t.Fatalf("Expected one owner reference, got %+v", updated.OwnerReferences)
+			}
+			ref := updated.OwnerReferences[0]
+			if got := ref.BlockOwnerDeletion != nil && *ref.BlockOwnerDeletion; got != tt.want {
+				t.Errorf("Expected blockOwnerDeletion %v, got %v", tt.want, got)
+			}
+			if ref.Controller == nil || !*ref.Controller {
+				t.Error("Expected a controller owner reference")
+			}
+		})
+	}
+}
+
+func TestSecretReconciler_OwnerRefGrace(t *testing.T) {
+	t.Setenv("ZEN_LOCK_OWNERREF_GRACE", "1m")
+	reconciler, clientBuilder := setupSecretReconciler(t)
+
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:              "test-pod",
+		Namespace:         "default",
+		UID:               types.UID("test-pod-uid"),
+		CreationTimestamp: metav1.Now(),
+	}}
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Name:      "zen-lock-secret",
+		Namespace: "default",
+		Labels: map[string]string{
+			common.LabelPodName:      "test-pod",
+			common.LabelPodNamespace: "default",
+			common.LabelZenLockName:  "test-zenlock",
+		},
+	}}
+	client := clientBuilder.WithObjects(pod, secret).Build()
+	reconciler.Client = client
+
+	key := types.NamespacedName{Name: "zen-lock-secret", Namespace: "default"}
+	ctx := context.Background()
+	ownerRefs := func() int {
+		updated := &corev1.Secret{}
+		if err := client.Get(ctx, key, updated); err != nil {
+			t.Fatalf("Failed to get Secret: %v", err)
+		}
+		return len(updated.OwnerReferences)
+	}
+
+	result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if result.RequeueAfter <= 0 || result.RequeueAfter > time.Minute {
+		t.Errorf("Expected a requeue within the grace period, got %v", result.RequeueAfter)
+	}
+	if n := ownerRefs(); n != 0 {
+		t.Fatalf("Expected no owner reference within the grace period, got %d", n)
+	}
+
+	// Let the grace period elapse by backdating the Pod
+	current := &corev1.Pod{}
+	if err := client.Get(ctx, types.NamespacedName{Name: "test-pod", Namespace: "default"}, current); err != nil {
+		t.Fatalf("Failed to get Pod: %v", err)
+	}
+	current.CreationTimestamp = metav1.NewTime(time.Now().Add(-2 * time.Minute))
+	if err := client.Update(ctx, current); err != nil {
+		t.Fatalf("Failed to update Pod: %v", err)
+	}
+	if result, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if result.RequeueAfter != 0 {
+		t.Errorf("Expected no requeue after the grace period, got %v", result.RequeueAfter)
+	}
+	if n := ownerRefs(); n != 1 {
+		t.Errorf("Expected the owner reference after the grace period, got %d", n)
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kube-zen/zen-lock/pkg/common"
+	"github.com/kube-zen/zen-lock/pkg/webhook"
+)
+
+// perKeySecrets returns the Secrets the webhook creates for a Pod with zen-lock/per-key-secrets=true
+func perKeySecrets(podName string, created time.Time, keys ...string) []client.Object {
+	base := webhook.GenerateSecretName("default", podName)
+	objects := make([]client.Object, 0, len(keys))
+	for _, key := range keys {
+		objects = append(objects, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              webhook.GenerateKeySecretName(base, key),
+				Namespace:         "default",
+				CreationTimestamp: metav1.NewTime(created),
+				Labels: map[string]string{
+					common.LabelPodName:      podName,
+					common.LabelPodNamespace: "default",
+					common.LabelZenLockName:  "test-zenlock",
+				},
+			},
+			Data: map[string][]byte{key: []byte("value")},
+		})
+	}
+	return objects
+}
+
+func TestSecretReconciler_PerKeySecrets(t *testing.T) {
+	reconciler, clientBuilder := setupSecretReconciler(t)
+	reconciler.OrphanTTL = time.Second
+
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "running", Namespace: "default", UID: types.UID("running-uid")}}
+	owned := perKeySecrets("running", time.Now(), "USERNAME", "PASSWORD", "tls.crt")
+	orphaned := perKeySecrets("deleted", time.Now().Add(-time.Minute), "USERNAME", "PASSWORD")
+	c := clientBuilder.WithObjects(pod).WithObjects(owned...).WithObjects(orphaned...).Build()
+	reconciler.Client = c
+
+	ctx := context.Background()
+	for _, obj := range append(owned, orphaned...) {
+		key := client.ObjectKeyFromObject(obj)
+		if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key}); err != nil {
+			t.Fatalf("Reconcile(%s) error = %v", key, err)
+		}
+	}
+
+	// Each Secret of a running Pod is owned by it, so all are garbage collected with the Pod
+	for _, obj := range owned {
+		secret := &corev1.Secret{}
+		if err := c.Get(ctx, client.ObjectKeyFromObject(obj), secret); err != nil {
+			t.Fatalf("Failed to get Secret: %v", err)
+		}
+		if len(secret.OwnerReferences) != 1 || secret.OwnerReferences[0].UID != pod.UID {
+			t.Errorf("Expected Secret %s to be owned by the Pod, got %+v", secret.Name, secret.OwnerReferences)
+		}
+	}
+
+	// Every Secret of a deleted Pod is cleaned up
+	for _, obj := range orphaned {
+		if err := c.Get(ctx, client.ObjectKeyFromObject(obj), &corev1.Secret{}); err == nil {
+			t.Errorf("Expected orphaned Secret %s to be deleted", obj.GetName())
+		}
+	}
+}
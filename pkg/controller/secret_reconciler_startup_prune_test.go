@@ -0,0 +1,100 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/kube-zen/zen-lock/pkg/common"
+)
+
+func TestStartupPruneSweep_EnqueuesLabeledSecrets(t *testing.T) {
+	_, clientBuilder := setupSecretReconciler(t)
+
+	labeled := func(name, podName string) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels: map[string]string{
+				common.LabelPodName:      podName,
+				common.LabelPodNamespace: "default",
+				common.LabelZenLockName:  "test-zenlock",
+			},
+		}}
+	}
+	unlabeled := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "user-secret", Namespace: "default"}}
+	c := clientBuilder.WithObjects(labeled("zen-lock-a", "pod-a"), labeled("zen-lock-b", "pod-b"), unlabeled).Build()
+
+	events := make(chan event.GenericEvent, 10)
+	if err := newStartupPruneSweep(c, events, time.Millisecond).Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	close(events)
+
+	var enqueued []string
+	for e := range events {
+		enqueued = append(enqueued, e.Object.GetName())
+	}
+	sort.Strings(enqueued)
+	if len(enqueued) != 2 || enqueued[0] != "zen-lock-a" || enqueued[1] != "zen-lock-b" {
+		t.Errorf("Expected only the zen-lock Secrets to be enqueued, got %v", enqueued)
+	}
+}
+
+func TestStartupPruneSweep_StopsOnCancel(t *testing.T) {
+	_, clientBuilder := setupSecretReconciler(t)
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Name:      "zen-lock-a",
+		Namespace: "default",
+		Labels:    map[string]string{common.LabelPodName: "pod-a", common.LabelPodNamespace: "default"},
+	}}
+	c := clientBuilder.WithObjects(secret).Build()
+
+	// Nobody receives: the sweep must give up when the manager stops
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	done := make(chan error, 1)
+	go func() { done <- newStartupPruneSweep(c, make(chan event.GenericEvent), time.Millisecond).Start(ctx) }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Start() error = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the sweep to stop when its context is cancelled")
+	}
+}
+
+func TestNewSecretReconciler_StartupPrune(t *testing.T) {
+	reconciler, _ := setupSecretReconciler(t)
+	if reconciler.startupPrune {
+		t.Error("Expected the startup prune sweep to be off by default")
+	}
+
+	t.Setenv("ZEN_LOCK_STARTUP_PRUNE", "true")
+	reconciler, _ = setupSecretReconciler(t)
+	if !reconciler.startupPrune {
+		t.Error("Expected ZEN_LOCK_STARTUP_PRUNE=true to enable the startup prune sweep")
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kube-zen/zen-lock/pkg/common"
+)
+
+func TestSecretReconciler_TerminatedPodSecretDeleted(t *testing.T) {
+	tests := []struct {
+		name        string
+		phase       corev1.PodPhase
+		wantDeleted bool
+	}{
+		{name: "succeeded", phase: corev1.PodSucceeded, wantDeleted: true},
+		{name: "failed", phase: corev1.PodFailed, wantDeleted: true},
+		{name: "running", phase: corev1.PodRunning, wantDeleted: false},
+		{name: "pending", phase: corev1.PodPending, wantDeleted: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reconciler, clientBuilder := setupSecretReconciler(t)
+
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "job-pod", Namespace: "default", UID: types.UID("job-pod-uid")},
+				Status:     corev1.PodStatus{Phase: tt.phase},
+			}
+			controller := true
+			secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+				Name:      "zen-lock-secret",
+				Namespace: "default",
+				Labels: map[string]string{
+					common.LabelPodName:      "job-pod",
+					common.LabelPodNamespace: "default",
+					common.LabelZenLockName:  "test-zenlock",
+				},
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: "v1", Kind: "Pod", Name: "job-pod", UID: pod.UID, Controller: &controller,
+				}},
+			}}
+			c := clientBuilder.WithObjects(pod, secret).Build()
+			reconciler.Client = c
+
+			// The Pod watch maps a terminated Pod to its Secret
+			requests := reconciler.secretsForTerminatedPod(context.Background(), pod)
+			if len(requests) != 1 || requests[0].Name != "zen-lock-secret" {
+				t.Fatalf("Expected the Pod to map to its Secret, got %v", requests)
+			}
+
+			key := types.NamespacedName{Name: "zen-lock-secret", Namespace: "default"}
+			if _, err := reconciler.Reconcile(context.Background(), reconcile.Request{NamespacedName: key}); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			err := c.Get(context.Background(), key, &corev1.Secret{})
+			if deleted := k8serrors.IsNotFound(err); deleted != tt.wantDeleted {
+				t.Errorf("Secret deleted = %v, want %v (err %v)", deleted, tt.wantDeleted, err)
+			}
+		})
+	}
+}
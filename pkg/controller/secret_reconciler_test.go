@@ -26,7 +26,11 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
 	"github.com/kube-zen/zen-lock/pkg/common"
+	"github.com/kube-zen/zen-lock/pkg/controller/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -395,3 +399,99 @@ func TestSecretReconciler_SkipsWhenOwnerReferenceExists(t *testing.T) {
 		t.Errorf("Expected 1 OwnerReference, got %d", len(updatedSecret.OwnerReferences))
 	}
 }
+
+func TestSecretReconciler_IgnoresSharedSecrets(t *testing.T) {
+	reconciler, clientBuilder := setupSecretReconciler(t)
+
+	// Shared (ZenLock-named) Secrets carry only the ZenLock label and must never be treated as orphans
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "zen-lock-shared-test-zenlock",
+			Namespace: "default",
+			Labels: map[string]string{
+				common.LabelZenLockName: "test-zenlock",
+			},
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-20 * time.Minute)),
+		},
+	}
+
+	client := clientBuilder.WithObjects(secret).Build()
+	reconciler.Client = client
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: secret.Name, Namespace: "default"}}
+	ctx := context.Background()
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Errorf("Reconcile() error = %v", err)
+	}
+
+	if err := client.Get(ctx, req.NamespacedName, &corev1.Secret{}); err != nil {
+		t.Errorf("Expected shared Secret to be kept, got %v", err)
+	}
+}
+
+func TestOrphanSecretsGauge_CountsOrphansApproachingTTL(t *testing.T) {
+	reconciler, clientBuilder := setupSecretReconciler(t)
+	const namespace = "orphan-metric"
+
+	newSecret := func(name, podName string, age time.Duration) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels: map[string]string{
+					common.LabelPodName:      podName,
+					common.LabelPodNamespace: namespace,
+					common.LabelZenLockName:  "test-zenlock",
+				},
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-age)),
+			},
+		}
+	}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "live-pod", Namespace: namespace, UID: "live-uid"}}
+	objs := []client.Object{
+		newSecret("orphan-old-1", "gone-1", 20*time.Minute),
+		newSecret("orphan-old-2", "gone-2", 20*time.Minute),
+		newSecret("orphan-near-ttl", "gone-3", 10*time.Minute),
+		newSecret("orphan-new", "gone-4", time.Minute),
+		newSecret("live", "live-pod", 10*time.Minute),
+		pod,
+	}
+	c := clientBuilder.WithObjects(objs...).Build()
+	reconciler.Client = c
+	gauge := newOrphanSecretsGauge(c, reconciler.OrphanTTL, time.Minute)
+
+	ctx := context.Background()
+	reconcileSecret := func(name string) {
+		req := reconcile.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: namespace}}
+		if _, err := reconciler.Reconcile(ctx, req); err != nil {
+			t.Fatalf("Reconcile(%s) error = %v", name, err)
+		}
+	}
+	pending := func() float64 {
+		gauge.update(ctx)
+		return testutil.ToFloat64(metrics.OrphanSecretsPending.WithLabelValues(namespace))
+	}
+
+	// The orphans past half the 15m TTL are pending; the young one may still get its Pod
+	if got := pending(); got != 3 {
+		t.Fatalf("Expected 3 pending orphans, got %v", got)
+	}
+
+	// Each cleaned up orphan drops the gauge
+	reconcileSecret("orphan-old-1")
+	if got := pending(); got != 2 {
+		t.Errorf("Expected 2 pending orphans after first cleanup, got %v", got)
+	}
+	reconcileSecret("orphan-old-2")
+	if got := pending(); got != 1 {
+		t.Errorf("Expected 1 pending orphan after second cleanup, got %v", got)
+	}
+
+	// A namespace left without orphans is reported as zero
+	if err := c.Delete(ctx, newSecret("orphan-near-ttl", "gone-3", 0)); err != nil {
+		t.Fatalf("Failed to delete Secret: %v", err)
+	}
+	if got := pending(); got != 0 {
+		t.Errorf("Expected no pending orphans, got %v", got)
+	}
+}
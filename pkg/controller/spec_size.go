@@ -0,0 +1,45 @@
+package controller
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/controller/metrics"
+)
+
+// specSizeTracker records the size of each ZenLock (zenlock_spec_keys, zenlock_spec_bytes)
+// once per generation, so requeues and status-only updates do not skew the distribution.
+type specSizeTracker struct {
+	mu       sync.Mutex
+	observed map[types.NamespacedName]int64
+}
+
+// observe records the ZenLock's size unless its current generation was already recorded
+func (t *specSizeTracker) observe(key types.NamespacedName, zenlock *securityv1alpha1.ZenLock) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if generation, ok := t.observed[key]; ok && generation == zenlock.Generation {
+		return
+	}
+	if t.observed == nil {
+		t.observed = make(map[types.NamespacedName]int64)
+	}
+	t.observed[key] = zenlock.Generation
+
+	size := 0
+	for k, v := range zenlock.Spec.EncryptedData {
+		size += len(k) + len(v)
+	}
+	metrics.ObserveZenLockKeyCount(len(zenlock.Spec.EncryptedData))
+	metrics.ObserveZenLockByteSize(size)
+}
+
+// forget drops key, e.g. once the ZenLock is deleted
+func (t *specSizeTracker) forget(key types.NamespacedName) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.observed, key)
+}
@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kube-zen/zen-lock/pkg/common"
+)
+
+// startupPruneSweep enqueues every zen-lock Secret once, when the controller starts
+// Orphans left behind while the controller was down are otherwise only cleaned up when
+// something touches them. Secrets are sent one per interval so a large backlog does not
+// turn into a burst of Pod reads and deletes against the API server.
+type startupPruneSweep struct {
+	reader   client.Reader
+	events   chan<- event.GenericEvent
+	interval time.Duration
+}
+
+// newStartupPruneSweep creates a sweep sending the Secrets listed through reader to events, one per interval
+func newStartupPruneSweep(reader client.Reader, events chan<- event.GenericEvent, interval time.Duration) *startupPruneSweep {
+	return &startupPruneSweep{reader: reader, events: events, interval: interval}
+}
+
+// Start lists the zen-lock Secrets and enqueues them, then returns (manager.Runnable)
+// A failed list is logged rather than returned: the sweep is best effort and must not stop the manager.
+func (s *startupPruneSweep) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("startup-prune")
+
+	secrets := &corev1.SecretList{}
+	if err := s.reader.List(ctx, secrets, client.HasLabels{common.PodNameLabel(), common.PodNamespaceLabel()}); err != nil {
+		logger.Error(err, "Failed to list zen-lock secrets for the startup prune sweep")
+		return nil
+	}
+	logger.Info("Enqueueing zen-lock secrets for the startup prune sweep", "count", len(secrets.Items))
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for i := range secrets.Items {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case s.events <- event.GenericEvent{Object: &secrets.Items[i]}:
+		}
+	}
+	return nil
+}
+
+// NeedLeaderElection runs the sweep on the leader only, like the Secret controller it feeds
+func (s *startupPruneSweep) NeedLeaderElection() bool {
+	return true
+}
@@ -3,8 +3,11 @@ package crypto
 import (
 	"bytes"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"io"
+	"sort"
+	"strings"
 
 	"filippo.io/age"
 
@@ -13,11 +16,14 @@ import (
 )
 
 // AgeEncryptor implements Encryptor using age encryption
-type AgeEncryptor struct{}
+type AgeEncryptor struct {
+	// tolerantBase64 also accepts unpadded base64 in DecryptMap (ZEN_LOCK_BASE64_TOLERANT)
+	tolerantBase64 bool
+}
 
 // NewAgeEncryptor creates a new AgeEncryptor instance
 func NewAgeEncryptor() *AgeEncryptor {
-	return &AgeEncryptor{}
+	return &AgeEncryptor{tolerantBase64: Base64Tolerant()}
 }
 
 // Encrypt encrypts plaintext using age with the provided recipients (public keys)
@@ -61,51 +67,139 @@ func (a *AgeEncryptor) Encrypt(plaintext []byte, recipients []string) ([]byte, e
 }
 
 // Decrypt decrypts ciphertext using age with the provided identity (private key)
+// identity may hold several identities in age identity-file format (one per line, # comments);
+// each is tried in turn.
 func (a *AgeEncryptor) Decrypt(ciphertext []byte, identity string) ([]byte, error) {
 	if identity == "" {
 		return nil, fmt.Errorf("identity (private key) is required")
 	}
 
-	// Parse identity
-	id, err := age.ParseX25519Identity(identity)
+	r, err := decryptReader(bytes.NewReader(ciphertext), identity)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse identity: %w", err)
+		return nil, err
 	}
 
-	// Decrypt the data
-	r, err := age.Decrypt(bytes.NewReader(ciphertext), id)
+	decrypted, err := io.ReadAll(r)
 	if err != nil {
 		metrics.RecordAlgorithmError(config.DefaultAlgorithm, "decryption_failed")
-		return nil, fmt.Errorf("failed to create decrypt reader: %w", err)
+		return nil, fmt.Errorf("failed to read decrypted data: %w", err)
+	}
+	// An intentionally empty secret decrypts to an explicit empty value, never nil
+	if decrypted == nil {
+		decrypted = []byte{}
 	}
 
-	decrypted, err := io.ReadAll(r)
+	// Record successful decryption
+	metrics.RecordAlgorithmUsage(config.DefaultAlgorithm, "decrypt")
+
+	return decrypted, nil
+}
+
+// DecryptTo decrypts ciphertext with the provided identity, writing the plaintext to w as it is decrypted
+// Unlike Decrypt, neither the ciphertext nor the plaintext needs to be held in memory at once,
+// which keeps large values from multiplying buffers under concurrent admissions.
+func (a *AgeEncryptor) DecryptTo(w io.Writer, ciphertext io.Reader, identity string) error {
+	if identity == "" {
+		return fmt.Errorf("identity (private key) is required")
+	}
+
+	r, err := decryptReader(ciphertext, identity)
 	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(w, r); err != nil {
 		metrics.RecordAlgorithmError(config.DefaultAlgorithm, "decryption_failed")
-		return nil, fmt.Errorf("failed to read decrypted data: %w", err)
+		return fmt.Errorf("failed to read decrypted data: %w", err)
 	}
 
 	// Record successful decryption
 	metrics.RecordAlgorithmUsage(config.DefaultAlgorithm, "decrypt")
 
-	return decrypted, nil
+	return nil
+}
+
+// decryptReader parses identity and returns a reader over the plaintext of ciphertext
+func decryptReader(ciphertext io.Reader, identity string) (io.Reader, error) {
+	// Parse identities
+	ids, err := parseIdentities(identity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse identity: %w", err)
+	}
+
+	// Decrypt the data
+	r, err := age.Decrypt(ciphertext, ids...)
+	if err != nil {
+		metrics.RecordAlgorithmError(config.DefaultAlgorithm, "decryption_failed")
+		return nil, fmt.Errorf("failed to create decrypt reader: %w", err)
+	}
+	return r, nil
+}
+
+// KeyError reports which encryptedData key of a map failed to decode or decrypt
+type KeyError struct {
+	// Key is the encryptedData key that failed
+	Key string
+	// Op describes the failed step ("decode base64" or "decrypt")
+	Op  string
+	Err error
+}
+
+func (e *KeyError) Error() string {
+	if e.Op == "decode base64" {
+		return fmt.Sprintf("failed to decode base64 for key %q: %v", e.Key, e.Err)
+	}
+	return fmt.Sprintf("failed to %s key %q: %v", e.Op, e.Key, e.Err)
+}
+
+func (e *KeyError) Unwrap() error {
+	return e.Err
+}
+
+// Permanent reports that retrying cannot help: the ciphertext or identity is wrong, not the environment
+func (e *KeyError) Permanent() bool {
+	return true
+}
+
+// IsPermanent reports whether err wraps a decryption failure that must not be retried
+func IsPermanent(err error) bool {
+	var permanent interface{ Permanent() bool }
+	return errors.As(err, &permanent) && permanent.Permanent()
 }
 
 // DecryptMap decrypts a map of base64-encoded encrypted values
+// Every key is present in the result; empty plaintexts are returned as empty, non-nil slices.
+// Keys are processed in sorted order, so the first failing key is reported deterministically as a *KeyError.
 func (a *AgeEncryptor) DecryptMap(encryptedData map[string]string, identity string) (map[string][]byte, error) {
 	result := make(map[string][]byte, len(encryptedData))
 
-	for key, encryptedValue := range encryptedData {
+	keys := make([]string, 0, len(encryptedData))
+	for key := range encryptedData {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		// Stream large values straight from base64 into a buffer sized up front
+		if len(encryptedData[key]) > config.StreamingDecryptThreshold {
+			plaintext, err := a.decryptLarge(encryptedData[key], identity)
+			if err != nil {
+				return nil, &KeyError{Key: key, Op: keyErrorOp(err), Err: err}
+			}
+			result[key] = plaintext
+			continue
+		}
+
 		// Decode base64
-		ciphertext, err := base64.StdEncoding.DecodeString(encryptedValue)
+		ciphertext, err := DecodeBase64(encryptedData[key], a.tolerantBase64)
 		if err != nil {
-			return nil, fmt.Errorf("failed to decode base64 for key %q: %w", key, err)
+			return nil, &KeyError{Key: key, Op: "decode base64", Err: err}
 		}
 
 		// Decrypt
 		plaintext, err := a.Decrypt(ciphertext, identity)
 		if err != nil {
-			return nil, fmt.Errorf("failed to decrypt key %q: %w", key, err)
+			return nil, &KeyError{Key: key, Op: "decrypt", Err: err}
 		}
 
 		result[key] = plaintext
@@ -113,3 +207,28 @@ func (a *AgeEncryptor) DecryptMap(encryptedData map[string]string, identity stri
 
 	return result, nil
 }
+
+// decryptLarge decrypts one base64 value through DecryptTo
+// Plaintext is never longer than the ciphertext, so the buffer is allocated once.
+func (a *AgeEncryptor) decryptLarge(encoded, identity string) ([]byte, error) {
+	encoding, err := base64Encoding(encoded, a.tolerantBase64)
+	if err != nil {
+		return nil, err
+	}
+	var plaintext bytes.Buffer
+	plaintext.Grow(encoding.DecodedLen(len(encoded)))
+	ciphertext := base64.NewDecoder(encoding, strings.NewReader(encoded))
+	if err := a.DecryptTo(&plaintext, ciphertext, identity); err != nil {
+		return nil, err
+	}
+	return plaintext.Bytes(), nil
+}
+
+// keyErrorOp names the step a streamed value failed at: base64 errors surface while decrypting
+func keyErrorOp(err error) string {
+	var corrupt base64.CorruptInputError
+	if errors.As(err, &corrupt) || errors.Is(err, ErrBase64Padding) {
+		return "decode base64"
+	}
+	return "decrypt"
+}
@@ -18,6 +18,7 @@ package crypto
 
 import (
 	"encoding/base64"
+	"errors"
 	"testing"
 
 	"filippo.io/age"
@@ -156,3 +157,29 @@ func TestAgeEncryptor_DecryptMap_ValidBase64InvalidCiphertext(t *testing.T) {
 }
 
 // Note: mustEncrypt helper is already defined in age_decrypt_real_test.go
+
+func TestAgeEncryptor_DecryptMap_KeyError(t *testing.T) {
+	encryptor := NewAgeEncryptor()
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	ciphertext, err := encryptor.Encrypt([]byte("value"), []string{identity.Recipient().String()})
+	if err != nil {
+		t.Fatalf("Failed to encrypt: %v", err)
+	}
+	good := base64.StdEncoding.EncodeToString(ciphertext)
+
+	// Both b and c are bad; the first in sorted order is always reported
+	encryptedData := map[string]string{"a": good, "b": "not-base64!", "c": "also-bad!", "d": good}
+	for i := 0; i < 10; i++ {
+		_, err := encryptor.DecryptMap(encryptedData, identity.String())
+		var keyErr *KeyError
+		if !errors.As(err, &keyErr) {
+			t.Fatalf("Expected *KeyError, got %v", err)
+		}
+		if keyErr.Key != "b" {
+			t.Fatalf("Expected failing key b, got %q", keyErr.Key)
+		}
+	}
+}
@@ -118,3 +118,38 @@ func mustEncrypt(t *testing.T, encryptor *AgeEncryptor, plaintext []byte, public
 	}
 	return ciphertext
 }
+
+func TestAgeEncryptor_DecryptMap_EmptyValue(t *testing.T) {
+	encryptor := NewAgeEncryptor()
+
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+
+	ciphertext, err := encryptor.Encrypt([]byte(""), []string{identity.Recipient().String()})
+	if err != nil {
+		t.Fatalf("Failed to encrypt empty string: %v", err)
+	}
+
+	decrypted, err := encryptor.DecryptMap(map[string]string{
+		"EMPTY": base64.StdEncoding.EncodeToString(ciphertext),
+	}, identity.String())
+	if err != nil {
+		t.Fatalf("DecryptMap() error = %v", err)
+	}
+
+	value, ok := decrypted["EMPTY"]
+	if !ok {
+		t.Fatal("Expected key EMPTY to be present in decrypted map")
+	}
+	if value == nil {
+		t.Error("Expected an explicit empty value, got nil")
+	}
+	if len(value) != 0 {
+		t.Errorf("Expected empty value, got %q", string(value))
+	}
+	if _, ok := decrypted["MISSING"]; ok {
+		t.Error("Expected key MISSING to be absent")
+	}
+}
@@ -0,0 +1,120 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"filippo.io/age"
+
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+// largeTestValue encrypts size random bytes to a fresh identity
+// Returns the plaintext, its base64 ciphertext and the identity.
+func largeTestValue(tb testing.TB, size int) ([]byte, string, string) {
+	tb.Helper()
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		tb.Fatalf("Failed to generate identity: %v", err)
+	}
+	plaintext := make([]byte, size)
+	if _, err := rand.Read(plaintext); err != nil {
+		tb.Fatalf("Failed to generate plaintext: %v", err)
+	}
+	ciphertext, err := NewAgeEncryptor().Encrypt(plaintext, []string{identity.Recipient().String()})
+	if err != nil {
+		tb.Fatalf("Failed to encrypt: %v", err)
+	}
+	return plaintext, base64.StdEncoding.EncodeToString(ciphertext), identity.String()
+}
+
+func TestDecryptTo_MatchesDecrypt(t *testing.T) {
+	encryptor := NewAgeEncryptor()
+	for _, size := range []int{0, 1, 64 * 1024, 3*1024*1024 + 7} {
+		plaintext, encoded, identity := largeTestValue(t, size)
+		ciphertext, _ := base64.StdEncoding.DecodeString(encoded)
+
+		buffered, err := encryptor.Decrypt(ciphertext, identity)
+		if err != nil {
+			t.Fatalf("Decrypt(%d bytes) failed: %v", size, err)
+		}
+		var streamed bytes.Buffer
+		if err := encryptor.DecryptTo(&streamed, bytes.NewReader(ciphertext), identity); err != nil {
+			t.Fatalf("DecryptTo(%d bytes) failed: %v", size, err)
+		}
+		if !bytes.Equal(buffered, streamed.Bytes()) || !bytes.Equal(buffered, plaintext) {
+			t.Errorf("Streaming and buffered decryption of %d bytes differ", size)
+		}
+	}
+}
+
+func TestDecryptMap_StreamsLargeValues(t *testing.T) {
+	plaintext, encoded, identity := largeTestValue(t, 2*1024*1024)
+	if len(encoded) <= config.StreamingDecryptThreshold {
+		t.Fatalf("Expected the test value to exceed the streaming threshold")
+	}
+
+	decrypted, err := NewAgeEncryptor().DecryptMap(map[string]string{"large": encoded}, identity)
+	if err != nil {
+		t.Fatalf("DecryptMap failed: %v", err)
+	}
+	if !bytes.Equal(decrypted["large"], plaintext) {
+		t.Error("Expected the streamed value to match the plaintext")
+	}
+}
+
+func TestDecryptMap_LargeValueErrors(t *testing.T) {
+	_, encoded, identity := largeTestValue(t, 256*1024)
+	other, _ := age.GenerateX25519Identity()
+
+	tests := []struct {
+		name     string
+		value    string
+		identity string
+		wantOp   string
+	}{
+		{name: "corrupt base64", value: encoded[:100] + "!" + encoded[101:], identity: identity, wantOp: "decode base64"},
+		{name: "wrong identity", value: encoded, identity: other.String(), wantOp: "decrypt"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAgeEncryptor().DecryptMap(map[string]string{"large": tt.value}, tt.identity)
+			var keyErr *KeyError
+			if !errors.As(err, &keyErr) || keyErr.Key != "large" || keyErr.Op != tt.wantOp {
+				t.Fatalf("Expected a %q KeyError for key large, got %v", tt.wantOp, err)
+			}
+			if !IsPermanent(err) {
+				t.Error("Expected the error to be permanent")
+			}
+		})
+	}
+}
+
+func BenchmarkDecryptLargeValue(b *testing.B) {
+	_, encoded, identity := largeTestValue(b, 4*1024*1024)
+	encryptor := NewAgeEncryptor()
+
+	b.Run("buffered", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			ciphertext, err := base64.StdEncoding.DecodeString(encoded)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if _, err := encryptor.Decrypt(ciphertext, identity); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("streaming", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := encryptor.decryptLarge(encoded, identity); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,41 @@
+package crypto
+
+import (
+	"encoding/base64"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ErrBase64Padding reports an encryptedData value whose base64 padding is missing or wrong
+var ErrBase64Padding = errors.New("base64 padding is missing or incorrect: values must be standard base64 padded with '=' to a multiple of 4 characters (set ZEN_LOCK_BASE64_TOLERANT=true to also accept unpadded base64)")
+
+// Base64Tolerant reports whether encryptedData values may also be unpadded base64 (ZEN_LOCK_BASE64_TOLERANT=true)
+func Base64Tolerant() bool {
+	tolerant, _ := strconv.ParseBool(os.Getenv("ZEN_LOCK_BASE64_TOLERANT"))
+	return tolerant
+}
+
+// base64Encoding returns the encoding to decode an encryptedData value with
+// Values are standard padded base64; in tolerant mode a value without any padding is decoded as raw base64.
+// Only the length and trailing '=' are inspected, so the check is the same for buffered and streamed values.
+func base64Encoding(value string, tolerant bool) (*base64.Encoding, error) {
+	if len(value)%4 == 0 {
+		return base64.StdEncoding, nil
+	}
+	if tolerant && !strings.HasSuffix(value, "=") {
+		return base64.RawStdEncoding, nil
+	}
+	return nil, ErrBase64Padding
+}
+
+// DecodeBase64 decodes an encryptedData value exactly as decryption does
+// Validators use it so a value they accept is never rejected when it is decrypted.
+func DecodeBase64(value string, tolerant bool) ([]byte, error) {
+	encoding, err := base64Encoding(value, tolerant)
+	if err != nil {
+		return nil, err
+	}
+	return encoding.DecodeString(value)
+}
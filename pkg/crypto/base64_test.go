@@ -0,0 +1,79 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDecodeBase64(t *testing.T) {
+	plaintext := []byte("zen-lock") // 8 bytes: padded encoding ends in "="
+	padded := base64.StdEncoding.EncodeToString(plaintext)
+	raw := base64.RawStdEncoding.EncodeToString(plaintext)
+
+	tests := []struct {
+		name        string
+		value       string
+		tolerant    bool
+		wantPadding bool
+		wantErr     bool
+	}{
+		{name: "padded", value: padded},
+		{name: "padded tolerant", value: padded, tolerant: true},
+		{name: "unpadded", value: raw, wantErr: true, wantPadding: true},
+		{name: "unpadded tolerant", value: raw, tolerant: true},
+		{name: "wrong padding", value: padded + "=", wantErr: true, wantPadding: true},
+		{name: "wrong padding tolerant", value: padded + "=", tolerant: true, wantErr: true, wantPadding: true},
+		{name: "malformed", value: "!!!!", wantErr: true},
+		{name: "malformed tolerant", value: "!!!!!", tolerant: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoded, err := DecodeBase64(tt.value, tt.tolerant)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecodeBase64() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if errors.Is(err, ErrBase64Padding) != tt.wantPadding {
+				t.Errorf("Expected padding error %v, got %v", tt.wantPadding, err)
+			}
+			if err == nil && !bytes.Equal(decoded, plaintext) {
+				t.Errorf("Expected %q, got %q", plaintext, decoded)
+			}
+		})
+	}
+}
+
+func TestDecryptMap_Base64Padding(t *testing.T) {
+	// Of three consecutive sizes, two need padding; both the buffered and the streamed path are covered
+	for _, size := range []int{32, 33, 34, 256 * 1024, 256*1024 + 1, 256*1024 + 2} {
+		plaintext, encoded, identity := largeTestValue(t, size)
+		raw := strings.TrimRight(encoded, "=")
+		if raw == encoded {
+			continue
+		}
+
+		_, err := (&AgeEncryptor{}).DecryptMap(map[string]string{"key": raw}, identity)
+		var keyErr *KeyError
+		if !errors.As(err, &keyErr) || keyErr.Op != "decode base64" || !errors.Is(err, ErrBase64Padding) {
+			t.Errorf("Expected a padding KeyError for %d bytes in strict mode, got %v", size, err)
+		}
+
+		decrypted, err := (&AgeEncryptor{tolerantBase64: true}).DecryptMap(map[string]string{"key": raw}, identity)
+		if err != nil || !bytes.Equal(decrypted["key"], plaintext) {
+			t.Errorf("Expected unpadded %d bytes to decrypt in tolerant mode, got %v", size, err)
+		}
+	}
+}
+
+func TestNewAgeEncryptor_Base64Tolerant(t *testing.T) {
+	t.Setenv("ZEN_LOCK_BASE64_TOLERANT", "true")
+	if !NewAgeEncryptor().tolerantBase64 {
+		t.Error("Expected ZEN_LOCK_BASE64_TOLERANT=true to enable tolerant decoding")
+	}
+	t.Setenv("ZEN_LOCK_BASE64_TOLERANT", "")
+	if NewAgeEncryptor().tolerantBase64 {
+		t.Error("Expected strict decoding by default")
+	}
+}
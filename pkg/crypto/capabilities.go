@@ -0,0 +1,69 @@
+package crypto
+
+import (
+	"runtime/debug"
+)
+
+// ageModulePath is the module path of the age library
+const ageModulePath = "filippo.io/age"
+
+// RecipientType describes an age recipient type and whether this build supports it
+type RecipientType struct {
+	Name      string `json:"name"`
+	Supported bool   `json:"supported"`
+	// Reason explains why the recipient type is unsupported
+	Reason string `json:"reason,omitempty"`
+}
+
+// Capabilities summarizes the crypto support of this build for support cases
+// No key material is reported: only whether a valid identity is configured.
+type Capabilities struct {
+	Library        string          `json:"library"`
+	LibraryVersion string          `json:"libraryVersion"`
+	RecipientTypes []RecipientType `json:"recipientTypes"`
+	// IdentityLoaded reports whether ZEN_LOCK_PRIVATE_KEY or ZEN_LOCK_IDENTITIES_DIR yields a parseable identity
+	IdentityLoaded bool `json:"identityLoaded"`
+}
+
+// recipientTypes lists the recipient types this build can encrypt to and decrypt with
+var recipientTypes = []RecipientType{
+	{Name: "X25519", Supported: true},
+	{Name: "SSH", Supported: false, Reason: "not compiled in; use an age X25519 key (age1...)"},
+}
+
+// GetCapabilities reports the age library version, supported recipient types and identity status
+func GetCapabilities() Capabilities {
+	types := make([]RecipientType, len(recipientTypes))
+	copy(types, recipientTypes)
+
+	identityLoaded := false
+	if identity := ResolvePrivateKey(); identity != "" {
+		_, err := parseIdentities(identity)
+		identityLoaded = err == nil
+	}
+
+	return Capabilities{
+		Library:        ageModulePath,
+		LibraryVersion: ageLibraryVersion(),
+		RecipientTypes: types,
+		IdentityLoaded: identityLoaded,
+	}
+}
+
+// ageLibraryVersion returns the age module version recorded in the build info ("unknown" if unavailable)
+func ageLibraryVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	for _, dep := range info.Deps {
+		if dep.Path != ageModulePath {
+			continue
+		}
+		if dep.Replace != nil {
+			return dep.Replace.Version
+		}
+		return dep.Version
+	}
+	return "unknown"
+}
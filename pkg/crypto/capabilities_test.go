@@ -0,0 +1,70 @@
+package crypto
+
+import (
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+)
+
+func TestGetCapabilities_RecipientTypes(t *testing.T) {
+	caps := GetCapabilities()
+
+	if caps.Library != "filippo.io/age" {
+		t.Errorf("Expected the age library, got %q", caps.Library)
+	}
+	if caps.LibraryVersion != "unknown" && !strings.HasPrefix(caps.LibraryVersion, "v1.") {
+		t.Errorf("Unexpected age version %q", caps.LibraryVersion)
+	}
+
+	supported := make(map[string]bool)
+	for _, rt := range caps.RecipientTypes {
+		supported[rt.Name] = rt.Supported
+		if !rt.Supported && rt.Reason == "" {
+			t.Errorf("Expected a reason for unsupported recipient type %s", rt.Name)
+		}
+	}
+	if !supported["X25519"] {
+		t.Error("Expected X25519 recipients to be supported")
+	}
+	if supported["SSH"] {
+		t.Error("Expected SSH recipients to be reported unsupported")
+	}
+
+	// The report must match what Encrypt actually accepts
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	encryptor := NewAgeEncryptor()
+	if _, err := encryptor.Encrypt([]byte("data"), []string{identity.Recipient().String()}); err != nil {
+		t.Errorf("Expected an X25519 recipient to be accepted: %v", err)
+	}
+	sshKey := "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIHsKLqeplhpW+uObz5dvMgjz1OxfM/XXUB+VHtZ6isGN"
+	if _, err := encryptor.Encrypt([]byte("data"), []string{sshKey}); err == nil {
+		t.Error("Expected an SSH recipient to be rejected")
+	}
+}
+
+func TestGetCapabilities_IdentityLoaded(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+
+	t.Setenv("ZEN_LOCK_IDENTITIES_DIR", "")
+	t.Setenv("ZEN_LOCK_PRIVATE_KEY", "")
+	if GetCapabilities().IdentityLoaded {
+		t.Error("Expected no identity without a private key")
+	}
+
+	t.Setenv("ZEN_LOCK_PRIVATE_KEY", "AGE-SECRET-KEY-NOT-VALID")
+	if GetCapabilities().IdentityLoaded {
+		t.Error("Expected an invalid private key not to count as loaded")
+	}
+
+	t.Setenv("ZEN_LOCK_PRIVATE_KEY", identity.String())
+	if !GetCapabilities().IdentityLoaded {
+		t.Error("Expected a valid private key to be reported as loaded")
+	}
+}
@@ -0,0 +1,33 @@
+package crypto
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrChecksumMismatch is returned when decrypted data does not match its expected checksum
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// Checksum returns the hex-encoded SHA-256 checksum of a plaintext value
+func Checksum(plaintext []byte) string {
+	sum := sha256.Sum256(plaintext)
+	return hex.EncodeToString(sum[:])
+}
+
+// VerifyChecksums verifies decrypted values against their expected SHA-256 checksums
+// Keys without a checksum are not verified. Returns an error wrapping ErrChecksumMismatch on mismatch.
+func VerifyChecksums(decrypted map[string][]byte, checksums map[string]string) error {
+	for key, expected := range checksums {
+		value, ok := decrypted[key]
+		if !ok {
+			return fmt.Errorf("%w: checksum for key %q has no matching encrypted data", ErrChecksumMismatch, key)
+		}
+		if !strings.EqualFold(Checksum(value), expected) {
+			return fmt.Errorf("%w for key %q (data may be corrupted or encrypted with a different key)", ErrChecksumMismatch, key)
+		}
+	}
+	return nil
+}
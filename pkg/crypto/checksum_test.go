@@ -0,0 +1,87 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crypto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestChecksum(t *testing.T) {
+	// SHA-256 of "hello"
+	want := "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	if got := Checksum([]byte("hello")); got != want {
+		t.Errorf("Checksum() = %q, want %q", got, want)
+	}
+}
+
+func TestVerifyChecksums(t *testing.T) {
+	decrypted := map[string][]byte{
+		"USERNAME": []byte("admin"),
+		"PASSWORD": []byte("secret"),
+	}
+
+	tests := []struct {
+		name      string
+		checksums map[string]string
+		wantErr   bool
+	}{
+		{
+			name:      "no checksums skips verification",
+			checksums: nil,
+			wantErr:   false,
+		},
+		{
+			name:      "matching checksums",
+			checksums: map[string]string{"USERNAME": Checksum([]byte("admin")), "PASSWORD": Checksum([]byte("secret"))},
+			wantErr:   false,
+		},
+		{
+			name:      "partial checksums only verify listed keys",
+			checksums: map[string]string{"USERNAME": Checksum([]byte("admin"))},
+			wantErr:   false,
+		},
+		{
+			name:      "uppercase hex matches",
+			checksums: map[string]string{"USERNAME": strings.ToUpper(Checksum([]byte("admin")))},
+			wantErr:   false,
+		},
+		{
+			name:      "mismatching checksum",
+			checksums: map[string]string{"PASSWORD": Checksum([]byte("wrong"))},
+			wantErr:   true,
+		},
+		{
+			name:      "checksum for missing key",
+			checksums: map[string]string{"MISSING": Checksum([]byte("admin"))},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyChecksums(decrypted, tt.checksums)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("VerifyChecksums() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrChecksumMismatch) {
+				t.Errorf("Expected error to wrap ErrChecksumMismatch, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package crypto
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+
+	"filippo.io/age/armor"
+
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+// ResolveEncoding returns the encoding of encryptedData values, defaulting to base64
+func ResolveEncoding(encoding string) string {
+	if encoding == "" {
+		return config.EncodingBase64
+	}
+	return encoding
+}
+
+// EncryptedDataAsBase64 returns encryptedData with every value as base64 ciphertext, as decryption expects
+// Base64 data is returned unchanged; armored values are unwrapped and re-encoded as padded standard base64.
+func EncryptedDataAsBase64(encryptedData map[string]string, encoding string) (map[string]string, error) {
+	switch ResolveEncoding(encoding) {
+	case config.EncodingBase64:
+		return encryptedData, nil
+	case config.EncodingArmor:
+		converted := make(map[string]string, len(encryptedData))
+		for key, value := range encryptedData {
+			ciphertext, err := io.ReadAll(armor.NewReader(strings.NewReader(value)))
+			if err != nil {
+				return nil, fmt.Errorf("encryptedData[%q] is not valid age armor: %v", key, err)
+			}
+			converted[key] = base64.StdEncoding.EncodeToString(ciphertext)
+		}
+		return converted, nil
+	default:
+		return nil, fmt.Errorf("unsupported encoding %q, must be %q or %q", encoding, config.EncodingBase64, config.EncodingArmor)
+	}
+}
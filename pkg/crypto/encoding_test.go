@@ -0,0 +1,66 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+)
+
+// armorCiphertext wraps binary age ciphertext in ASCII armor, as `age --armor` writes it
+func armorCiphertext(t *testing.T, ciphertext []byte) string {
+	var buf bytes.Buffer
+	w := armor.NewWriter(&buf)
+	if _, err := w.Write(ciphertext); err != nil {
+		t.Fatalf("Failed to armor: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Failed to armor: %v", err)
+	}
+	return buf.String()
+}
+
+func TestEncryptedDataAsBase64(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	encryptor := NewAgeEncryptor()
+	ciphertext, err := encryptor.Encrypt([]byte("value"), []string{identity.Recipient().String()})
+	if err != nil {
+		t.Fatalf("Failed to encrypt: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		encoding string
+		value    string
+		wantErr  bool
+	}{
+		{name: "default", encoding: "", value: base64.StdEncoding.EncodeToString(ciphertext)},
+		{name: "base64", encoding: "base64", value: base64.StdEncoding.EncodeToString(ciphertext)},
+		{name: "armor", encoding: "armor", value: armorCiphertext(t, ciphertext)},
+		{name: "base64 value marked armor", encoding: "armor", value: base64.StdEncoding.EncodeToString(ciphertext), wantErr: true},
+		{name: "unknown encoding", encoding: "hex", value: "00", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := EncryptedDataAsBase64(map[string]string{"key": tt.value}, tt.encoding)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EncryptedDataAsBase64() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			decrypted, err := encryptor.DecryptMap(data, identity.String())
+			if err != nil {
+				t.Fatalf("DecryptMap() error = %v", err)
+			}
+			if string(decrypted["key"]) != "value" {
+				t.Errorf("Decrypted %q, want %q", decrypted["key"], "value")
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package crypto
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ageHeaderVersion is the first line of every binary age file
+const ageHeaderVersion = "age-encryption.org/v1"
+
+// StanzaTypes returns the stanza types in the header of a binary age file, e.g. "X25519" or "scrypt"
+// ok is false when ciphertext does not start with a complete age header.
+func StanzaTypes(ciphertext []byte) (types []string, ok bool) {
+	line, rest, found := bytes.Cut(ciphertext, []byte("\n"))
+	if !found || string(line) != ageHeaderVersion {
+		return nil, false
+	}
+	for len(rest) > 0 {
+		line, rest, found = bytes.Cut(rest, []byte("\n"))
+		if !found {
+			return nil, false
+		}
+		switch {
+		case bytes.HasPrefix(line, []byte("---")):
+			// The header MAC line ends the header
+			return types, true
+		case bytes.HasPrefix(line, []byte("-> ")):
+			fields := strings.Fields(string(line[len("-> "):]))
+			if len(fields) == 0 {
+				return nil, false
+			}
+			types = append(types, fields[0])
+		}
+	}
+	return nil, false
+}
+
+// CheckCiphertextFormat returns an error when an age ciphertext has no stanza the algorithm decrypts,
+// e.g. a passphrase-encrypted (scrypt) value in a ZenLock declaring the X25519-based "age" algorithm.
+// Data that is not a binary age file is left to decryption to reject.
+func CheckCiphertextFormat(algorithm string, ciphertext []byte) error {
+	alg, registered := registry[algorithm]
+	if !registered || len(alg.stanzaTypes) == 0 {
+		return nil
+	}
+	types, ok := StanzaTypes(ciphertext)
+	if !ok {
+		return nil
+	}
+	for _, stanzaType := range types {
+		if slices.Contains(alg.stanzaTypes, stanzaType) {
+			return nil
+		}
+	}
+	message := fmt.Sprintf("ciphertext is encrypted to %s stanza(s), but algorithm %q decrypts %s recipients",
+		strings.Join(types, ", "), algorithm, strings.Join(alg.stanzaTypes, ", "))
+	if slices.Contains(types, "scrypt") {
+		message += " (the value was encrypted with a passphrase, not to the zen-lock public key)"
+	}
+	return errors.New(message)
+}
@@ -0,0 +1,88 @@
+package crypto
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+)
+
+// encryptTo encrypts plaintext to the given age recipients
+func encryptTo(t *testing.T, recipients ...age.Recipient) []byte {
+	var buf bytes.Buffer
+	w, err := age.Encrypt(&buf, recipients...)
+	if err != nil {
+		t.Fatalf("Failed to encrypt: %v", err)
+	}
+	if _, err := io.WriteString(w, "value"); err != nil {
+		t.Fatalf("Failed to encrypt: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Failed to encrypt: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// scryptCiphertext returns a passphrase-encrypted value, as `age -p` writes it
+func scryptCiphertext(t *testing.T) []byte {
+	recipient, err := age.NewScryptRecipient("passphrase")
+	if err != nil {
+		t.Fatalf("Failed to create scrypt recipient: %v", err)
+	}
+	// Keep the test fast; the work factor does not change the header format
+	recipient.SetWorkFactor(10)
+	return encryptTo(t, recipient)
+}
+
+func TestStanzaTypes(t *testing.T) {
+	first, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	second, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		ciphertext []byte
+		want       []string
+		wantOK     bool
+	}{
+		{name: "X25519", ciphertext: encryptTo(t, first.Recipient()), want: []string{"X25519"}, wantOK: true},
+		{name: "two recipients", ciphertext: encryptTo(t, first.Recipient(), second.Recipient()), want: []string{"X25519", "X25519"}, wantOK: true},
+		{name: "scrypt", ciphertext: scryptCiphertext(t), want: []string{"scrypt"}, wantOK: true},
+		{name: "not age", ciphertext: []byte("invalid"), wantOK: false},
+		{name: "truncated header", ciphertext: []byte(ageHeaderVersion + "\n-> X25519 abc\n"), wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := StanzaTypes(tt.ciphertext)
+			if ok != tt.wantOK || strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("StanzaTypes() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestCheckCiphertextFormat(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+
+	if err := CheckCiphertextFormat("age", encryptTo(t, identity.Recipient())); err != nil {
+		t.Errorf("Expected X25519 ciphertext to match the age algorithm, got %v", err)
+	}
+	err = CheckCiphertextFormat("age", scryptCiphertext(t))
+	if err == nil || !strings.Contains(err.Error(), "scrypt") || !strings.Contains(err.Error(), "passphrase") {
+		t.Errorf("Expected scrypt ciphertext to be rejected with a clear message, got %v", err)
+	}
+	// Data that is not age at all is left to decryption
+	if err := CheckCiphertextFormat("age", []byte("invalid")); err != nil {
+		t.Errorf("Expected non-age data to pass the format check, got %v", err)
+	}
+}
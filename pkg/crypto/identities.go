@@ -0,0 +1,161 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"filippo.io/age"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// keyIDComment labels the identities that follow it with a key ID
+// Identity files allow comment lines, so labeled identities keep the age identity-file format.
+const keyIDComment = "# zen-lock key-id: "
+
+// parseIdentities parses one or more age X25519 identities in identity-file format
+func parseIdentities(identity string) ([]age.Identity, error) {
+	ids, err := age.ParseIdentities(strings.NewReader(identity))
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// LoadIdentitiesDir loads the age identities from every file in dir
+// Subdirectories, hidden files and files that do not hold age identities are skipped.
+// The identities of each file are labeled with the file name without extension as key ID.
+// Returns the identities in age identity-file format and how many were loaded.
+func LoadIdentitiesDir(dir string) (string, int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read identities directory: %w", err)
+	}
+
+	// Stable order so the first matching identity is deterministic
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	var identities []string
+	count := 0
+	for _, entry := range entries {
+		// Kubernetes Secret volumes expose files through hidden ..data symlinks
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		content := strings.TrimSpace(string(data))
+		ids, err := parseIdentities(content)
+		if err != nil {
+			// Not an identity file - skip without echoing its contents
+			continue
+		}
+		keyID := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		identities = append(identities, keyIDComment+keyID+"\n"+content)
+		count += len(ids)
+	}
+
+	return strings.Join(identities, "\n"), count, nil
+}
+
+// ResolvePrivateKey returns the configured decryption identities
+// It combines ZEN_LOCK_PRIVATE_KEY with any identities found in ZEN_LOCK_IDENTITIES_DIR.
+// Only the number of identities is logged, never their contents.
+func ResolvePrivateKey() string {
+	privateKey := strings.TrimSpace(os.Getenv("ZEN_LOCK_PRIVATE_KEY"))
+
+	dir := os.Getenv("ZEN_LOCK_IDENTITIES_DIR")
+	if dir == "" {
+		return privateKey
+	}
+
+	logger := log.Log.WithName("crypto")
+	identities, count, err := LoadIdentitiesDir(dir)
+	if err != nil {
+		logger.Error(err, "Failed to load identities directory", "dir", dir)
+		return privateKey
+	}
+	logger.Info("Loaded identities from directory", "dir", dir, "count", count)
+
+	if identities == "" {
+		return privateKey
+	}
+	if privateKey == "" {
+		return identities
+	}
+	return privateKey + "\n" + identities
+}
+
+// SelectIdentity returns the identity among identities whose recipient (public key) is recipient
+// identities is in age identity-file format, as returned by ResolvePrivateKey.
+func SelectIdentity(identities, recipient string) (string, error) {
+	ids, err := parseIdentities(identities)
+	if err != nil {
+		return "", err
+	}
+	for _, id := range ids {
+		if x, ok := id.(*age.X25519Identity); ok && x.Recipient().String() == recipient {
+			return x.String(), nil
+		}
+	}
+	return "", fmt.Errorf("no configured identity matches recipient %q", recipient)
+}
+
+// SelectKeyID returns the identities labeled with keyID by LoadIdentitiesDir
+// identities is in age identity-file format, as returned by ResolvePrivateKey.
+// An empty keyID, or one no identity is labeled with, returns all identities.
+func SelectKeyID(identities, keyID string) string {
+	if keyID == "" {
+		return identities
+	}
+	var selected []string
+	label := ""
+	for _, line := range strings.Split(identities, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, keyIDComment) {
+			label = strings.TrimPrefix(line, keyIDComment)
+			continue
+		}
+		if label == keyID && line != "" && !strings.HasPrefix(line, "#") {
+			selected = append(selected, line)
+		}
+	}
+	if len(selected) == 0 {
+		return identities
+	}
+	return strings.Join(selected, "\n")
+}
+
+// KeyFingerprint returns a short, non-reversible fingerprint of an age recipient (public key)
+// Format: "SHA256:" followed by the first 8 bytes of the SHA-256 of the recipient, hex-encoded.
+func KeyFingerprint(recipient string) string {
+	sum := sha256.Sum256([]byte(recipient))
+	return "SHA256:" + hex.EncodeToString(sum[:8])
+}
+
+// DecryptingKeyFingerprint returns the KeyFingerprint of the first identity that can decrypt ciphertext
+// identities is in age identity-file format. Only the age header is unwrapped, not the payload.
+// Returns "" when no X25519 identity matches.
+func DecryptingKeyFingerprint(ciphertext []byte, identities string) string {
+	ids, err := parseIdentities(identities)
+	if err != nil {
+		return ""
+	}
+	for _, id := range ids {
+		x, ok := id.(*age.X25519Identity)
+		if !ok {
+			continue
+		}
+		if _, err := age.Decrypt(bytes.NewReader(ciphertext), x); err == nil {
+			return KeyFingerprint(x.Recipient().String())
+		}
+	}
+	return ""
+}
@@ -0,0 +1,154 @@
+package crypto
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+)
+
+func TestLoadIdentitiesDir(t *testing.T) {
+	dir := t.TempDir()
+
+	id1, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	id2, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+
+	files := map[string]string{
+		"a.key":   "# created: 2025-01-01\n" + id1.String() + "\n",
+		"b.key":   id2.String(),
+		"README":  "not an identity",
+		".hidden": "AGE-SECRET-KEY-INVALID",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "subdir"), 0o700); err != nil {
+		t.Fatalf("Failed to create subdir: %v", err)
+	}
+
+	identities, count, err := LoadIdentitiesDir(dir)
+	if err != nil {
+		t.Fatalf("LoadIdentitiesDir() error = %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 identities, got %d", count)
+	}
+
+	// Both identities must decrypt
+	encryptor := NewAgeEncryptor()
+	for _, id := range []*age.X25519Identity{id1, id2} {
+		ciphertext, err := encryptor.Encrypt([]byte("secret"), []string{id.Recipient().String()})
+		if err != nil {
+			t.Fatalf("Encrypt() error = %v", err)
+		}
+		plaintext, err := encryptor.Decrypt(ciphertext, identities)
+		if err != nil {
+			t.Fatalf("Decrypt() error = %v", err)
+		}
+		if string(plaintext) != "secret" {
+			t.Errorf("Expected 'secret', got %q", plaintext)
+		}
+	}
+}
+
+func TestLoadIdentitiesDir_Missing(t *testing.T) {
+	if _, _, err := LoadIdentitiesDir(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("Expected error for missing directory")
+	}
+}
+
+func TestResolvePrivateKey(t *testing.T) {
+	dir := t.TempDir()
+	envID, _ := age.GenerateX25519Identity()
+	dirID, _ := age.GenerateX25519Identity()
+	if err := os.WriteFile(filepath.Join(dir, "key.txt"), []byte(dirID.String()), 0o600); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+
+	t.Setenv("ZEN_LOCK_PRIVATE_KEY", envID.String())
+	t.Setenv("ZEN_LOCK_IDENTITIES_DIR", dir)
+
+	identities := ResolvePrivateKey()
+	encryptor := NewAgeEncryptor()
+	for _, id := range []*age.X25519Identity{envID, dirID} {
+		ciphertext, err := encryptor.Encrypt([]byte("v"), []string{id.Recipient().String()})
+		if err != nil {
+			t.Fatalf("Encrypt() error = %v", err)
+		}
+		if _, err := encryptor.Decrypt(ciphertext, identities); err != nil {
+			t.Errorf("Expected resolved identities to decrypt, got %v", err)
+		}
+	}
+}
+
+func TestSelectIdentity(t *testing.T) {
+	first, _ := age.GenerateX25519Identity()
+	second, _ := age.GenerateX25519Identity()
+	identities := first.String() + "\n" + second.String()
+
+	got, err := SelectIdentity(identities, second.Recipient().String())
+	if err != nil || got != second.String() {
+		t.Errorf("Expected the second identity, got err %v", err)
+	}
+
+	other, _ := age.GenerateX25519Identity()
+	if _, err := SelectIdentity(identities, other.Recipient().String()); err == nil {
+		t.Error("Expected an error for a recipient without a matching identity")
+	}
+}
+
+func TestSelectKeyID(t *testing.T) {
+	dir := t.TempDir()
+	teamA, _ := age.GenerateX25519Identity()
+	teamB, _ := age.GenerateX25519Identity()
+	files := map[string]string{
+		"team-a.key": "# created: 2025-01-01\n" + teamA.String() + "\n",
+		"team-b.key": teamB.String(),
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	identities, _, err := LoadIdentitiesDir(dir)
+	if err != nil {
+		t.Fatalf("LoadIdentitiesDir() error = %v", err)
+	}
+
+	encryptor := NewAgeEncryptor()
+	encryptedA, err := encryptor.Encrypt([]byte("a"), []string{teamA.Recipient().String()})
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+
+	// A known key ID selects only its identity
+	selected := SelectKeyID(identities, "team-b")
+	if selected != teamB.String() {
+		t.Errorf("Expected only the team-b identity, got %q", selected)
+	}
+	if _, err := encryptor.Decrypt(encryptedA, selected); err == nil {
+		t.Error("Expected the team-b identity not to decrypt team-a data")
+	}
+	if plaintext, err := encryptor.Decrypt(encryptedA, SelectKeyID(identities, "team-a")); err != nil || string(plaintext) != "a" {
+		t.Errorf("Expected the team-a identity to decrypt, got %q, %v", plaintext, err)
+	}
+
+	// An unknown or empty key ID falls back to all identities
+	for _, keyID := range []string{"team-c", ""} {
+		if got := SelectKeyID(identities, keyID); got != identities {
+			t.Errorf("Expected key ID %q to fall back to all identities, got %q", keyID, got)
+		}
+	}
+	if _, err := encryptor.Decrypt(encryptedA, SelectKeyID(identities, "team-c")); err != nil {
+		t.Errorf("Expected the fallback to decrypt, got %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+package crypto
+
+import "sort"
+
+// DecryptMapOptional decrypts a map like DecryptMap, tolerating failures of optional keys
+// Required keys (all keys not listed in optionalKeys) are decrypted together and any failure is returned.
+// Optional keys are decrypted one by one; a value that fails to decode or decrypt is left out of the
+// result and its key is returned in omitted, sorted. Without optional keys this is DecryptMap.
+func DecryptMapOptional(e Encryptor, encryptedData map[string]string, identity string, optionalKeys []string) (decrypted map[string][]byte, omitted []string, err error) {
+	optional := make(map[string]string, len(optionalKeys))
+	for _, key := range optionalKeys {
+		if value, ok := encryptedData[key]; ok {
+			optional[key] = value
+		}
+	}
+	if len(optional) == 0 {
+		decrypted, err = e.DecryptMap(encryptedData, identity)
+		return decrypted, nil, err
+	}
+
+	required := make(map[string]string, len(encryptedData)-len(optional))
+	for key, value := range encryptedData {
+		if _, ok := optional[key]; !ok {
+			required[key] = value
+		}
+	}
+	decrypted, err = e.DecryptMap(required, identity)
+	if err != nil {
+		return nil, nil, err
+	}
+	if decrypted == nil {
+		decrypted = make(map[string][]byte, len(encryptedData))
+	}
+
+	keys := make([]string, 0, len(optional))
+	for key := range optional {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value, err := e.DecryptMap(map[string]string{key: optional[key]}, identity)
+		if err != nil {
+			omitted = append(omitted, key)
+			continue
+		}
+		decrypted[key] = value[key]
+	}
+	return decrypted, omitted, nil
+}
+
+// OmitKeys returns a copy of m without the given keys, e.g. the checksums of omitted optional keys
+func OmitKeys(m map[string]string, keys []string) map[string]string {
+	if len(keys) == 0 || len(m) == 0 {
+		return m
+	}
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		result[k] = v
+	}
+	for _, k := range keys {
+		delete(result, k)
+	}
+	return result
+}
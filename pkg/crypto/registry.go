@@ -0,0 +1,101 @@
+package crypto
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+// AlgorithmInfo describes an encryption algorithm compiled into this build
+type AlgorithmInfo struct {
+	Name string `json:"name"`
+	// Available reports whether ZenLocks using the algorithm can be decrypted with the current configuration
+	Available bool `json:"available"`
+	// Reason explains why the algorithm is unavailable
+	Reason string `json:"reason,omitempty"`
+}
+
+// algorithm is a registered Encryptor implementation
+type algorithm struct {
+	newEncryptor func() Encryptor
+	// available checks the configuration the algorithm needs to decrypt
+	available func() (bool, string)
+	// stanzaTypes are the age header stanza types the algorithm decrypts (see CheckCiphertextFormat)
+	stanzaTypes []string
+}
+
+// registry maps algorithm names to their implementations
+var registry = map[string]algorithm{
+	config.SupportedAlgorithm: {
+		newEncryptor: func() Encryptor { return NewAgeEncryptor() },
+		available: func() (bool, string) {
+			if ResolvePrivateKey() == "" {
+				return false, "no identity configured (set ZEN_LOCK_PRIVATE_KEY or ZEN_LOCK_IDENTITIES_DIR); encryption still works"
+			}
+			return true, ""
+		},
+		stanzaTypes: []string{"X25519"},
+	},
+}
+
+// Algorithms lists the registered algorithms, sorted by name, with their availability
+func Algorithms() []AlgorithmInfo {
+	infos := make([]AlgorithmInfo, 0, len(registry))
+	for name, alg := range registry {
+		available, reason := alg.available()
+		infos = append(infos, AlgorithmInfo{Name: name, Available: available, Reason: reason})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
+}
+
+// NewEncryptorFor returns the Encryptor of a registered algorithm
+func NewEncryptorFor(name string) (Encryptor, error) {
+	alg, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown algorithm %q", name)
+	}
+	return alg.newEncryptor(), nil
+}
+
+// AlgorithmNames lists the names of the registered algorithms, sorted
+func AlgorithmNames() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsRegistered reports whether an algorithm is compiled into this build
+func IsRegistered(name string) bool {
+	_, ok := registry[name]
+	return ok
+}
+
+// DefaultAlgorithm returns the algorithm of ZenLocks that leave spec.algorithm empty (ZEN_LOCK_DEFAULT_ALGORITHM)
+func DefaultAlgorithm() string {
+	if name := os.Getenv("ZEN_LOCK_DEFAULT_ALGORITHM"); name != "" {
+		return name
+	}
+	return config.DefaultAlgorithm
+}
+
+// ResolveAlgorithm returns the algorithm a ZenLock uses: its spec.algorithm, or the default when empty
+func ResolveAlgorithm(name string) string {
+	if name == "" {
+		return DefaultAlgorithm()
+	}
+	return name
+}
+
+// ValidateDefaultAlgorithm checks that ZEN_LOCK_DEFAULT_ALGORITHM names a registered algorithm
+func ValidateDefaultAlgorithm() error {
+	if name := DefaultAlgorithm(); !IsRegistered(name) {
+		return fmt.Errorf("ZEN_LOCK_DEFAULT_ALGORITHM %q is not a registered algorithm", name)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package crypto
+
+import (
+	"testing"
+
+	"filippo.io/age"
+)
+
+func findAlgorithm(t *testing.T, name string) AlgorithmInfo {
+	t.Helper()
+	for _, alg := range Algorithms() {
+		if alg.Name == name {
+			return alg
+		}
+	}
+	t.Fatalf("Algorithm %q not registered", name)
+	return AlgorithmInfo{}
+}
+
+func TestAlgorithms_Availability(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+
+	t.Setenv("ZEN_LOCK_IDENTITIES_DIR", "")
+	t.Setenv("ZEN_LOCK_PRIVATE_KEY", "")
+	if alg := findAlgorithm(t, "age"); alg.Available || alg.Reason == "" {
+		t.Errorf("Expected age unavailable with a reason without a private key, got %+v", alg)
+	}
+
+	t.Setenv("ZEN_LOCK_PRIVATE_KEY", identity.String())
+	if alg := findAlgorithm(t, "age"); !alg.Available || alg.Reason != "" {
+		t.Errorf("Expected age available with a private key, got %+v", alg)
+	}
+}
+
+func TestNewEncryptorFor(t *testing.T) {
+	if _, err := NewEncryptorFor("age"); err != nil {
+		t.Errorf("Expected age encryptor, got error: %v", err)
+	}
+	if _, err := NewEncryptorFor("rot13"); err == nil {
+		t.Error("Expected an error for an unknown algorithm")
+	}
+}
+
+func TestResolveAlgorithm(t *testing.T) {
+	t.Setenv("ZEN_LOCK_DEFAULT_ALGORITHM", "")
+	if got := ResolveAlgorithm(""); got != "age" {
+		t.Errorf("Expected an empty algorithm to default to age, got %q", got)
+	}
+	if err := ValidateDefaultAlgorithm(); err != nil {
+		t.Errorf("Expected the built-in default to be valid, got %v", err)
+	}
+
+	t.Setenv("ZEN_LOCK_DEFAULT_ALGORITHM", "rot13")
+	if got := ResolveAlgorithm(""); got != "rot13" {
+		t.Errorf("Expected an empty algorithm to use the configured default, got %q", got)
+	}
+	if got := ResolveAlgorithm("age"); got != "age" {
+		t.Errorf("Expected an explicit algorithm to win over the default, got %q", got)
+	}
+	if err := ValidateDefaultAlgorithm(); err == nil {
+		t.Error("Expected an unregistered default algorithm to be rejected")
+	}
+}
+
+func TestDefaultAlgorithm_AnyRegisteredAlgorithm(t *testing.T) {
+	// The default is checked against the registry, so it can name any algorithm compiled in
+	registry["test-alg"] = algorithm{
+		newEncryptor: func() Encryptor { return NewAgeEncryptor() },
+		available:    func() (bool, string) { return true, "" },
+	}
+	defer delete(registry, "test-alg")
+
+	if names := AlgorithmNames(); len(names) != 2 || names[0] != "age" || names[1] != "test-alg" {
+		t.Errorf("Expected the registered algorithms sorted by name, got %v", names)
+	}
+	t.Setenv("ZEN_LOCK_DEFAULT_ALGORITHM", "test-alg")
+	if err := ValidateDefaultAlgorithm(); err != nil {
+		t.Errorf("Expected a registered non-age default to be accepted, got %v", err)
+	}
+	if got := ResolveAlgorithm(""); got != "test-alg" {
+		t.Errorf("Expected an empty algorithm to resolve to test-alg, got %q", got)
+	}
+}
@@ -19,8 +19,11 @@ package validation
 
 import (
 	"fmt"
+	"strings"
 
 	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
+	"github.com/kube-zen/zen-lock/pkg/crypto"
 )
 
 // ValidateZenLock validates a ZenLock CRD.
@@ -33,9 +36,14 @@ func ValidateZenLock(zenlock *securityv1alpha1.ZenLock) error {
 		return fmt.Errorf("encryptedData cannot be empty")
 	}
 
-	// Validate algorithm
-	if zenlock.Spec.Algorithm != "" && zenlock.Spec.Algorithm != "age" {
-		return fmt.Errorf("unsupported algorithm: %s (only 'age' is supported)", zenlock.Spec.Algorithm)
+	// Validate algorithm (an empty algorithm resolves to the configured default)
+	if algorithm := crypto.ResolveAlgorithm(zenlock.Spec.Algorithm); !crypto.IsRegistered(algorithm) {
+		return fmt.Errorf("unsupported algorithm: %s (supported: %s)", algorithm, strings.Join(crypto.AlgorithmNames(), ", "))
+	}
+
+	// Validate encoding (an empty encoding resolves to base64)
+	if encoding := crypto.ResolveEncoding(zenlock.Spec.Encoding); encoding != config.EncodingBase64 && encoding != config.EncodingArmor {
+		return fmt.Errorf("unsupported encoding: %s (must be 'base64' or 'armor')", encoding)
 	}
 
 	// Validate encrypted data format (should be base64 strings)
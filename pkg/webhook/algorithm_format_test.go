@@ -0,0 +1,70 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+)
+
+func TestZenLockValidator_AlgorithmMatchesCiphertext(t *testing.T) {
+	handler, publicKey := subjectsTestValidator(t, "")
+
+	zenlock := createTestZenLock(t, map[string]string{"key": encryptTestData(t, "value", publicKey)}, "age", nil)
+	if resp := handler.Handle(context.Background(), zenlockRequest(t, zenlock)); !resp.Allowed {
+		t.Errorf("Expected X25519 ciphertext declared as age to be allowed, got %v", resp.Result)
+	}
+}
+
+func TestZenLockValidator_AlgorithmCiphertextMismatch(t *testing.T) {
+	handler, _ := subjectsTestValidator(t, "")
+
+	// A value encrypted with `age -p` carries a scrypt stanza instead of an X25519 one
+	recipient, err := age.NewScryptRecipient("passphrase")
+	if err != nil {
+		t.Fatalf("Failed to create scrypt recipient: %v", err)
+	}
+	recipient.SetWorkFactor(10)
+	var buf bytes.Buffer
+	w, err := age.Encrypt(&buf, recipient)
+	if err != nil {
+		t.Fatalf("Failed to encrypt: %v", err)
+	}
+	if _, err := io.WriteString(w, "value"); err != nil {
+		t.Fatalf("Failed to encrypt: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Failed to encrypt: %v", err)
+	}
+
+	zenlock := createTestZenLock(t, map[string]string{"key": base64.StdEncoding.EncodeToString(buf.Bytes())}, "age", nil)
+	resp := handler.Handle(context.Background(), zenlockRequest(t, zenlock))
+	if resp.Allowed {
+		t.Fatal("Expected scrypt ciphertext declared as age to be denied")
+	}
+	for _, want := range []string{`encryptedData["key"]`, "scrypt", `algorithm "age"`} {
+		if !strings.Contains(resp.Result.Message, want) {
+			t.Errorf("Expected the denial to mention %s, got %q", want, resp.Result.Message)
+		}
+	}
+}
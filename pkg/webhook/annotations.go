@@ -0,0 +1,65 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+// podAnnotations are the zen-lock annotations a Pod may carry, including those the webhook sets itself
+// (zen-lock/mount-path.<zenlock-name> is matched by prefix). ZenLock and Namespace annotations are not
+// among them: on a Pod they have no effect.
+var podAnnotations = map[string]bool{
+	config.AnnotationInject:           true,
+	config.AnnotationMountPath:        true,
+	config.AnnotationConfirmed:        true,
+	config.AnnotationInjectIf:         true,
+	config.AnnotationEnvPrefix:        true,
+	config.AnnotationMountWritable:    true,
+	config.AnnotationProjectMetadata:  true,
+	config.AnnotationInjectScope:      true,
+	config.AnnotationSecretNaming:     true,
+	config.AnnotationPerKeySecrets:    true,
+	config.AnnotationDelegatedSecrets: true,
+}
+
+// StrictAnnotationsEnabled reports whether Pods with unknown zen-lock annotations are denied (ZEN_LOCK_STRICT_ANNOTATIONS)
+func StrictAnnotationsEnabled() bool {
+	strict, _ := strconv.ParseBool(os.Getenv("ZEN_LOCK_STRICT_ANNOTATIONS"))
+	return strict
+}
+
+// UnknownPodAnnotations returns the zen-lock/* annotation keys of a Pod that zen-lock does not recognize, sorted
+func UnknownPodAnnotations(annotations map[string]string) []string {
+	var unknown []string
+	for key := range annotations {
+		if !strings.HasPrefix(key, config.AnnotationPrefix) || podAnnotations[key] {
+			continue
+		}
+		if name, ok := strings.CutPrefix(key, config.AnnotationMountPathPrefix); ok && name != "" {
+			continue
+		}
+		unknown = append(unknown, key)
+	}
+	sort.Strings(unknown)
+	return unknown
+}
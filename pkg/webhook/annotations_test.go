@@ -0,0 +1,106 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+func TestPodHandler_Handle_StrictAnnotations(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-zenlock", Namespace: "default"},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{"USERNAME": encryptTestData(t, "admin", identity.Recipient().String())},
+		},
+	}
+	handler, clientBuilder := setupTestPodHandlerWithKey(t, identity.String())
+	handler.Client = clientBuilder.WithObjects(zenlock).Build()
+	handler.strictAnnotations = true
+
+	known := map[string]string{
+		config.AnnotationInject:                           "test-zenlock",
+		config.AnnotationMountPathPrefix + "test-zenlock": "/etc/app",
+		"example.com/other":                               "ignored",
+	}
+	if resp := handler.Handle(context.Background(), selectorTestRequestWithAnnotations(t, nil, known)); !resp.Allowed {
+		t.Fatalf("Expected known annotations to be allowed, got %v", resp.Result)
+	}
+
+	misspelled := map[string]string{
+		config.AnnotationInject: "test-zenlock",
+		"zen-lock/mountpath":    "/etc/app",
+	}
+	resp := handler.Handle(context.Background(), selectorTestRequestWithAnnotations(t, nil, misspelled))
+	if resp.Allowed {
+		t.Fatal("Expected an unknown zen-lock annotation to be denied in strict mode")
+	}
+	if !strings.Contains(resp.Result.Message, "zen-lock/mountpath") {
+		t.Errorf("Expected the denial to name the annotation, got %q", resp.Result.Message)
+	}
+
+	handler.strictAnnotations = false
+	if resp := handler.Handle(context.Background(), selectorTestRequestWithAnnotations(t, nil, misspelled)); !resp.Allowed {
+		t.Errorf("Expected unknown annotations to be ignored without strict mode, got %v", resp.Result)
+	}
+}
+
+func TestUnknownPodAnnotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        []string
+	}{
+		{name: "none", annotations: nil, want: nil},
+		{name: "known", annotations: map[string]string{config.AnnotationInject: "a", config.AnnotationEnvPrefix: "APP_"}, want: nil},
+		{name: "mount path override", annotations: map[string]string{"zen-lock/mount-path.db": "/db"}, want: nil},
+		{name: "empty mount path override", annotations: map[string]string{"zen-lock/mount-path.": "/db"}, want: []string{"zen-lock/mount-path."}},
+		{name: "other prefixes", annotations: map[string]string{"zen-lockx/inject": "a", "example.com/zen-lock": "a"}, want: nil},
+		{name: "zenlock annotation on a pod", annotations: map[string]string{config.AnnotationKeyCase: "upper"}, want: []string{config.AnnotationKeyCase}},
+		{name: "sorted", annotations: map[string]string{"zen-lock/b": "", "zen-lock/a": ""}, want: []string{"zen-lock/a", "zen-lock/b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UnknownPodAnnotations(tt.annotations); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UnknownPodAnnotations() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStrictAnnotationsEnabled(t *testing.T) {
+	t.Setenv("ZEN_LOCK_STRICT_ANNOTATIONS", "")
+	if StrictAnnotationsEnabled() {
+		t.Error("Expected strict annotations to be off by default")
+	}
+	t.Setenv("ZEN_LOCK_STRICT_ANNOTATIONS", "true")
+	if !StrictAnnotationsEnabled() {
+		t.Error("Expected ZEN_LOCK_STRICT_ANNOTATIONS=true to enable strict annotations")
+	}
+}
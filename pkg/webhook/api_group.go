@@ -0,0 +1,49 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+)
+
+// checkZenLockGroup returns an error when a ZenLock admission request is for an API group other than the one
+// this build serves
+// The decoder otherwise fails with an opaque "no kind registered" error when a ZenLock from another group
+// (e.g. a stale manifest or webhook rule) reaches the webhook.
+func checkZenLockGroup(req admission.Request) error {
+	group := req.Kind.Group
+	if group == "" && len(req.Object.Raw) > 0 {
+		var typeMeta metav1.TypeMeta
+		if err := json.Unmarshal(req.Object.Raw, &typeMeta); err == nil && typeMeta.APIVersion != "" {
+			if gv, err := schema.ParseGroupVersion(typeMeta.APIVersion); err == nil {
+				group = gv.Group
+			}
+		}
+	}
+	if group == "" || group == securityv1alpha1.GroupVersion.Group {
+		return nil
+	}
+	return fmt.Errorf("ZenLock API group %q is not served by this zen-lock; use apiVersion %s and check the CRD and webhook configuration",
+		group, securityv1alpha1.GroupVersion.String())
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+)
+
+func TestZenLockValidator_APIGroup(t *testing.T) {
+	handler, publicKey := subjectsTestValidator(t, "")
+	defaulter := newTestDefaulter()
+
+	tests := []struct {
+		name       string
+		apiVersion string
+		kindGroup  string
+		wantAllow  bool
+	}{
+		{name: "served group", apiVersion: "security.kube-zen.io/v1alpha1", wantAllow: true},
+		{name: "served group from request kind", kindGroup: "security.kube-zen.io", wantAllow: true},
+		{name: "legacy group", apiVersion: "security.zen.io/v1alpha1", wantAllow: false},
+		{name: "legacy group from request kind", kindGroup: "security.zen.io", wantAllow: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			zenlock := createTestZenLock(t, map[string]string{"key": encryptTestData(t, "value", publicKey)}, "age", nil)
+			zenlock.TypeMeta = metav1.TypeMeta{APIVersion: tt.apiVersion, Kind: "ZenLock"}
+			req := zenlockRequest(t, zenlock)
+			req.Kind = metav1.GroupVersionKind{Group: tt.kindGroup, Version: "v1alpha1", Kind: "ZenLock"}
+
+			resp := handler.Handle(context.Background(), req)
+			if resp.Allowed != tt.wantAllow {
+				t.Fatalf("Allowed = %v, want %v (%v)", resp.Allowed, tt.wantAllow, resp.Result)
+			}
+			defaulted := defaulter.Handle(context.Background(), req)
+			if defaulted.Allowed != tt.wantAllow {
+				t.Fatalf("Defaulter Allowed = %v, want %v (%v)", defaulted.Allowed, tt.wantAllow, defaulted.Result)
+			}
+			if !tt.wantAllow && !strings.Contains(resp.Result.Message, securityv1alpha1.GroupVersion.String()) {
+				t.Errorf("Expected the error to name the served group, got %q", resp.Result.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientretry "k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kube-zen/zen-lock/pkg/common"
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+// Audit decisions
+const (
+	AuditDecisionInjected = "injected"
+	AuditDecisionSkipped  = "skipped"
+	AuditDecisionDenied   = "denied"
+	AuditDecisionError    = "error"
+)
+
+// AuditEntry is one injection event in the audit ConfigMap
+// It only names objects and the outcome: admission messages are left out so no secret material can leak.
+type AuditEntry struct {
+	Time     time.Time `json:"time"`
+	Pod      string    `json:"pod"`
+	ZenLocks []string  `json:"zenlocks,omitempty"`
+	Decision string    `json:"decision"`
+}
+
+// AuditConfigMapEnabled reports whether injection events are recorded in a per-namespace ConfigMap (ZEN_LOCK_AUDIT_CONFIGMAP=true)
+func AuditConfigMapEnabled() bool {
+	enabled, _ := strconv.ParseBool(os.Getenv("ZEN_LOCK_AUDIT_CONFIGMAP"))
+	return enabled
+}
+
+// AuditMaxEntries returns how many entries the audit ConfigMap keeps (ZEN_LOCK_AUDIT_MAX_ENTRIES)
+func AuditMaxEntries() int {
+	if maxStr := os.Getenv("ZEN_LOCK_AUDIT_MAX_ENTRIES"); maxStr != "" {
+		if parsedMax, err := strconv.Atoi(maxStr); err == nil && parsedMax > 0 {
+			return parsedMax
+		}
+	}
+	return config.DefaultAuditMaxEntries
+}
+
+// AuditLog appends injection events to the config.AuditConfigMapName ConfigMap of each namespace
+// Intended for clusters without a log or metrics pipeline. Writes are rate-limited per namespace and
+// best-effort: a failed or dropped write never affects the admission decision.
+type AuditLog struct {
+	client client.Client
+	// reader fetches the ConfigMap; pass an uncached reader so the webhook does not watch every ConfigMap
+	reader     client.Reader
+	maxEntries int
+	limiter    *RateLimiter
+}
+
+// NewAuditLog creates an AuditLog keeping at most maxEntries per namespace
+func NewAuditLog(c client.Client, reader client.Reader, maxEntries int) *AuditLog {
+	return &AuditLog{
+		client:     c,
+		reader:     reader,
+		maxEntries: maxEntries,
+		limiter:    NewRateLimiter(config.DefaultAuditBurst, config.DefaultAuditRefillInterval),
+	}
+}
+
+// Append adds an entry to the namespace's audit ConfigMap, dropping the oldest entries beyond the cap
+// Returns false without writing when the namespace exceeded its rate limit.
+func (a *AuditLog) Append(ctx context.Context, namespace string, entry AuditEntry) (bool, error) {
+	if !a.limiter.Allow(namespace) {
+		return false, nil
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+
+	key := types.NamespacedName{Name: config.AuditConfigMapName, Namespace: namespace}
+	err = clientretry.RetryOnConflict(clientretry.DefaultRetry, func() error {
+		cm := &corev1.ConfigMap{}
+		if err := a.reader.Get(ctx, key, cm); err != nil {
+			if !k8serrors.IsNotFound(err) {
+				return err
+			}
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+					Labels:    map[string]string{common.LabelManagedBy: common.ManagedByWebhook},
+				},
+				Data: map[string]string{config.AuditConfigMapKey: string(line)},
+			}
+			return a.client.Create(ctx, cm)
+		}
+
+		if cm.Data == nil {
+			cm.Data = make(map[string]string)
+		}
+		cm.Data[config.AuditConfigMapKey] = appendAuditLine(cm.Data[config.AuditConfigMapKey], string(line), a.maxEntries)
+		return a.client.Update(ctx, cm)
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to write audit ConfigMap %s: %w", key, err)
+	}
+	return true, nil
+}
+
+// appendAuditLine appends line to the newline-separated entries, keeping the newest maxEntries
+func appendAuditLine(entries, line string, maxEntries int) string {
+	var lines []string
+	if entries != "" {
+		lines = strings.Split(entries, "\n")
+	}
+	lines = append(lines, line)
+	if len(lines) > maxEntries {
+		lines = lines[len(lines)-maxEntries:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// auditContextKey carries the names of the ZenLocks an admission materialized
+type auditContextKey struct{}
+
+// withAuditZenLocks returns a context collecting the ZenLocks materialized while handling a request
+func withAuditZenLocks(ctx context.Context) (context.Context, *[]string) {
+	zenlocks := &[]string{}
+	return context.WithValue(ctx, auditContextKey{}, zenlocks), zenlocks
+}
+
+// recordAuditZenLock notes that the admission materialized a ZenLock (no-op when auditing is off)
+func recordAuditZenLock(ctx context.Context, zenlockName string) {
+	if zenlocks, ok := ctx.Value(auditContextKey{}).(*[]string); ok {
+		*zenlocks = append(*zenlocks, zenlockName)
+	}
+}
+
+// auditDecision maps an admission response to an audit decision
+func auditDecision(resp admission.Response) string {
+	switch {
+	case !resp.Allowed && resp.Result != nil && resp.Result.Code == http.StatusForbidden:
+		return AuditDecisionDenied
+	case !resp.Allowed:
+		return AuditDecisionError
+	case len(resp.Patches) > 0:
+		return AuditDecisionInjected
+	default:
+		return AuditDecisionSkipped
+	}
+}
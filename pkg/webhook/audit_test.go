@@ -0,0 +1,182 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"filippo.io/age"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+func readAuditEntries(t *testing.T, c client.Client, namespace string) []AuditEntry {
+	t.Helper()
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: config.AuditConfigMapName, Namespace: namespace}, cm); err != nil {
+		t.Fatalf("Failed to get audit ConfigMap: %v", err)
+	}
+	var entries []AuditEntry
+	for _, line := range strings.Split(cm.Data[config.AuditConfigMapKey], "\n") {
+		var entry AuditEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("Failed to decode audit entry %q: %v", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestAuditLog_AppendsEntries(t *testing.T) {
+	_, clientBuilder := setupTestPodHandler(t)
+	c := clientBuilder.Build()
+	auditLog := NewAuditLog(c, c, 10)
+	defer auditLog.limiter.Stop()
+
+	for _, pod := range []string{"app-1", "app-2"} {
+		entry := AuditEntry{Time: time.Now().UTC(), Pod: pod, ZenLocks: []string{"db-creds"}, Decision: AuditDecisionInjected}
+		written, err := auditLog.Append(context.Background(), "default", entry)
+		if err != nil || !written {
+			t.Fatalf("Append(%s) = %v, %v", pod, written, err)
+		}
+	}
+
+	entries := readAuditEntries(t, c, "default")
+	if len(entries) != 2 || entries[0].Pod != "app-1" || entries[1].Pod != "app-2" {
+		t.Fatalf("Expected entries for app-1 then app-2, got %+v", entries)
+	}
+	if entries[1].ZenLocks[0] != "db-creds" || entries[1].Decision != AuditDecisionInjected {
+		t.Errorf("Unexpected entry: %+v", entries[1])
+	}
+}
+
+func TestAuditLog_TrimsOldestEntries(t *testing.T) {
+	_, clientBuilder := setupTestPodHandler(t)
+	c := clientBuilder.Build()
+	auditLog := NewAuditLog(c, c, 3)
+	defer auditLog.limiter.Stop()
+
+	for i := 1; i <= 5; i++ {
+		entry := AuditEntry{Time: time.Now().UTC(), Pod: fmt.Sprintf("app-%d", i), Decision: AuditDecisionDenied}
+		if _, err := auditLog.Append(context.Background(), "default", entry); err != nil {
+			t.Fatalf("Append returned error: %v", err)
+		}
+	}
+
+	entries := readAuditEntries(t, c, "default")
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries after trimming, got %d", len(entries))
+	}
+	for i, want := range []string{"app-3", "app-4", "app-5"} {
+		if entries[i].Pod != want {
+			t.Errorf("Entry %d: expected pod %s, got %s", i, want, entries[i].Pod)
+		}
+	}
+}
+
+func TestAuditLog_RateLimited(t *testing.T) {
+	_, clientBuilder := setupTestPodHandler(t)
+	c := clientBuilder.Build()
+	auditLog := NewAuditLog(c, c, 100)
+	defer auditLog.limiter.Stop()
+
+	dropped := 0
+	for i := 0; i < config.DefaultAuditBurst+5; i++ {
+		written, err := auditLog.Append(context.Background(), "default", AuditEntry{Pod: "app", Decision: AuditDecisionInjected})
+		if err != nil {
+			t.Fatalf("Append returned error: %v", err)
+		}
+		if !written {
+			dropped++
+		}
+	}
+	if dropped == 0 {
+		t.Error("Expected writes beyond the burst to be dropped")
+	}
+}
+
+func TestPodHandler_Handle_AuditRecordsNoSecretData(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	const plaintext = "s3cr3t-password"
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-zenlock", Namespace: "default"},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{"PASSWORD": encryptTestData(t, plaintext, identity.Recipient().String())},
+		},
+	}
+
+	handler, clientBuilder := setupTestPodHandlerWithKey(t, identity.String())
+	c := clientBuilder.WithObjects(zenlock).Build()
+	handler.Client = c
+	handler.Auditor = NewAuditLog(c, c, 10)
+	defer handler.Auditor.limiter.Stop()
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "app",
+			Namespace:   "default",
+			Annotations: map[string]string{config.AnnotationInject: "test-zenlock"},
+		},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "test-container", Image: "nginx"}}},
+	}
+	podRaw, _ := json.Marshal(pod)
+	req := admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			Object:    runtime.RawExtension{Raw: podRaw},
+			Namespace: "default",
+		},
+	}
+
+	if resp := handler.Handle(context.Background(), req); !resp.Allowed {
+		t.Fatalf("Expected injection to be allowed, got: %v", resp.Result)
+	}
+
+	entries := readAuditEntries(t, c, "default")
+	if len(entries) != 1 {
+		t.Fatalf("Expected one audit entry, got %+v", entries)
+	}
+	if entries[0].Pod != "app" || len(entries[0].ZenLocks) != 1 || entries[0].ZenLocks[0] != "test-zenlock" || entries[0].Decision != AuditDecisionInjected {
+		t.Errorf("Unexpected audit entry: %+v", entries[0])
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: config.AuditConfigMapName, Namespace: "default"}, cm); err != nil {
+		t.Fatalf("Failed to get audit ConfigMap: %v", err)
+	}
+	for _, value := range cm.Data {
+		if strings.Contains(value, plaintext) || strings.Contains(value, "PASSWORD") || strings.Contains(value, zenlock.Spec.EncryptedData["PASSWORD"]) {
+			t.Errorf("Audit ConfigMap leaks secret data: %q", value)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"filippo.io/age"
+
+	"github.com/kube-zen/zen-lock/pkg/config"
+	"github.com/kube-zen/zen-lock/pkg/crypto"
+)
+
+// DebugBenchmarkPath is the path of the decryption benchmark endpoint on the metrics server
+const DebugBenchmarkPath = "/debug/zenlock-benchmark"
+
+// maxBenchmarkRequestBytes bounds the request body: a supplied ZenLock's encryptedData, base64-encoded
+const maxBenchmarkRequestBytes = 1 << 20
+
+// BenchmarkToken returns the bearer token guarding the benchmark endpoint (ZEN_LOCK_BENCHMARK_TOKEN)
+// The endpoint is only served when it is set.
+func BenchmarkToken() string {
+	return os.Getenv("ZEN_LOCK_BENCHMARK_TOKEN")
+}
+
+// BenchmarkRequest configures a benchmark run; every field is optional
+type BenchmarkRequest struct {
+	// Iterations is how many times the ZenLock is decrypted
+	Iterations int `json:"iterations,omitempty"`
+	// EncryptedData is a ZenLock's encryptedData, decrypted with the webhook's key.
+	// When empty, a synthetic ZenLock is encrypted to an ephemeral key.
+	EncryptedData map[string]string `json:"encryptedData,omitempty"`
+	// Keys and ValueBytes shape the synthetic ZenLock
+	Keys       int `json:"keys,omitempty"`
+	ValueBytes int `json:"valueBytes,omitempty"`
+}
+
+// BenchmarkResult reports the latency of decrypting a whole ZenLock, as the admission path does
+type BenchmarkResult struct {
+	Synthetic  bool    `json:"synthetic"`
+	Keys       int     `json:"keys"`
+	Iterations int     `json:"iterations"`
+	P50Ms      float64 `json:"p50Ms"`
+	P99Ms      float64 `json:"p99Ms"`
+	MaxMs      float64 `json:"maxMs"`
+}
+
+// NewDebugBenchmarkHandler returns a handler running an in-process decryption benchmark
+// Requests must carry "Authorization: Bearer <token>". Real ZenLocks are never read, only runs are
+// serialized, and decrypted values are discarded: the response holds timings only.
+func NewDebugBenchmarkHandler(token string) http.Handler {
+	var running sync.Mutex
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !benchmarkAuthorized(r, token) {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var req BenchmarkRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBenchmarkRequestBytes)).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+			http.Error(w, fmt.Sprintf("invalid benchmark request: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		// One run at a time so concurrent benchmarks neither skew each other nor starve admissions
+		if !running.TryLock() {
+			http.Error(w, "a benchmark is already running", http.StatusTooManyRequests)
+			return
+		}
+		defer running.Unlock()
+
+		result, err := runDecryptBenchmark(req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(result)
+	})
+}
+
+// benchmarkAuthorized reports whether the request carries the benchmark token
+func benchmarkAuthorized(r *http.Request, token string) bool {
+	presented, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && token != "" && subtle.ConstantTimeCompare([]byte(presented), []byte(token)) == 1
+}
+
+// runDecryptBenchmark decrypts the requested ZenLock req.Iterations times through the admission crypto path
+func runDecryptBenchmark(req BenchmarkRequest) (BenchmarkResult, error) {
+	iterations := req.Iterations
+	if iterations <= 0 {
+		iterations = config.DefaultBenchmarkIterations
+	}
+	if iterations > config.MaxBenchmarkIterations {
+		return BenchmarkResult{}, fmt.Errorf("iterations must be at most %d", config.MaxBenchmarkIterations)
+	}
+
+	encryptor := crypto.NewAgeEncryptor()
+	encryptedData, identity := req.EncryptedData, crypto.ResolvePrivateKey()
+	synthetic := len(encryptedData) == 0
+	if synthetic {
+		var err error
+		encryptedData, identity, err = syntheticBenchmarkZenLock(encryptor, req.Keys, req.ValueBytes)
+		if err != nil {
+			return BenchmarkResult{}, err
+		}
+	} else if identity == "" {
+		return BenchmarkResult{}, fmt.Errorf("no private key configured to decrypt the supplied encryptedData")
+	}
+
+	latencies := make([]time.Duration, iterations)
+	for i := range latencies {
+		start := time.Now()
+		if _, err := encryptor.DecryptMap(encryptedData, identity); err != nil {
+			return BenchmarkResult{}, SanitizeError(err, "decrypt benchmark ZenLock")
+		}
+		latencies[i] = time.Since(start)
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+	return BenchmarkResult{
+		Synthetic:  synthetic,
+		Keys:       len(encryptedData),
+		Iterations: iterations,
+		P50Ms:      durationMs(percentile(latencies, 50)),
+		P99Ms:      durationMs(percentile(latencies, 99)),
+		MaxMs:      durationMs(latencies[len(latencies)-1]),
+	}, nil
+}
+
+// syntheticBenchmarkZenLock returns random values encrypted to a fresh identity, and that identity
+func syntheticBenchmarkZenLock(encryptor crypto.Encryptor, keys, valueBytes int) (map[string]string, string, error) {
+	if keys <= 0 {
+		keys = config.DefaultBenchmarkKeys
+	}
+	if valueBytes <= 0 {
+		valueBytes = config.DefaultBenchmarkValueBytes
+	}
+	if keys > config.MaxBenchmarkKeys || valueBytes > config.MaxBenchmarkValueBytes {
+		return nil, "", fmt.Errorf("synthetic ZenLocks are limited to %d keys of %d bytes", config.MaxBenchmarkKeys, config.MaxBenchmarkValueBytes)
+	}
+
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate benchmark identity: %w", err)
+	}
+	encryptedData := make(map[string]string, keys)
+	value := make([]byte, valueBytes)
+	for i := 0; i < keys; i++ {
+		if _, err := rand.Read(value); err != nil {
+			return nil, "", fmt.Errorf("failed to generate benchmark value: %w", err)
+		}
+		ciphertext, err := encryptor.Encrypt(value, []string{identity.Recipient().String()})
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to encrypt benchmark value: %w", err)
+		}
+		encryptedData[fmt.Sprintf("KEY_%d", i)] = base64.StdEncoding.EncodeToString(ciphertext)
+	}
+	return encryptedData, identity.String(), nil
+}
+
+// percentile returns the p-th percentile of sorted latencies (nearest rank)
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// durationMs converts d to fractional milliseconds
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"filippo.io/age"
+)
+
+const testBenchmarkToken = "s3cr3t-admin-token"
+
+func serveBenchmark(t *testing.T, authorization, method, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, DebugBenchmarkPath, strings.NewReader(body))
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	rec := httptest.NewRecorder()
+	NewDebugBenchmarkHandler(testBenchmarkToken).ServeHTTP(rec, req)
+	return rec
+}
+
+func TestDebugBenchmarkHandler_Synthetic(t *testing.T) {
+	rec := serveBenchmark(t, "Bearer "+testBenchmarkToken, http.MethodPost, `{"iterations": 20, "keys": 3, "valueBytes": 32}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var result BenchmarkResult
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if !result.Synthetic || result.Keys != 3 || result.Iterations != 20 {
+		t.Errorf("Unexpected benchmark shape: %+v", result)
+	}
+	if result.P50Ms <= 0 || result.P50Ms > result.P99Ms || result.P99Ms > result.MaxMs {
+		t.Errorf("Expected 0 < p50 <= p99 <= max, got %+v", result)
+	}
+	if result.MaxMs > float64(time.Minute/time.Millisecond) {
+		t.Errorf("Implausible decryption latency: %+v", result)
+	}
+}
+
+func TestDebugBenchmarkHandler_SuppliedZenLock(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	t.Setenv("ZEN_LOCK_IDENTITIES_DIR", "")
+	t.Setenv("ZEN_LOCK_PRIVATE_KEY", identity.String())
+
+	body, _ := json.Marshal(BenchmarkRequest{
+		Iterations:    5,
+		EncryptedData: map[string]string{"PASSWORD": encryptTestData(t, "s3cr3t-plaintext", identity.Recipient().String())},
+	})
+	rec := serveBenchmark(t, "Bearer "+testBenchmarkToken, http.MethodPost, string(body))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if strings.Contains(rec.Body.String(), "s3cr3t-plaintext") {
+		t.Errorf("Expected response to exclude plaintext, got %s", rec.Body.String())
+	}
+
+	var result BenchmarkResult
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if result.Synthetic || result.Keys != 1 || result.Iterations != 5 {
+		t.Errorf("Unexpected benchmark shape: %+v", result)
+	}
+}
+
+func TestDebugBenchmarkHandler_RequiresToken(t *testing.T) {
+	for _, authorization := range []string{"", "Bearer wrong-token", testBenchmarkToken} {
+		if rec := serveBenchmark(t, authorization, http.MethodPost, `{}`); rec.Code != http.StatusUnauthorized {
+			t.Errorf("Authorization %q: expected 401, got %d", authorization, rec.Code)
+		}
+	}
+
+	// An empty configured token never authorizes
+	req := httptest.NewRequest(http.MethodPost, DebugBenchmarkPath, strings.NewReader(`{}`))
+	req.Header.Set("Authorization", "Bearer ")
+	rec := httptest.NewRecorder()
+	NewDebugBenchmarkHandler("").ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 with no configured token, got %d", rec.Code)
+	}
+}
+
+func TestDebugBenchmarkHandler_Limits(t *testing.T) {
+	if rec := serveBenchmark(t, "Bearer "+testBenchmarkToken, http.MethodGet, ""); rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405 for GET, got %d", rec.Code)
+	}
+	if rec := serveBenchmark(t, "Bearer "+testBenchmarkToken, http.MethodPost, `{"iterations": 1000000}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for too many iterations, got %d", rec.Code)
+	}
+	if rec := serveBenchmark(t, "Bearer "+testBenchmarkToken, http.MethodPost, `{"keys": 1000000}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for too many keys, got %d", rec.Code)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	latencies := make([]time.Duration, 100)
+	for i := range latencies {
+		latencies[i] = time.Duration(i+1) * time.Millisecond
+	}
+	if got := percentile(latencies, 50); got != 50*time.Millisecond {
+		t.Errorf("Expected p50 of 50ms, got %s", got)
+	}
+	if got := percentile(latencies, 99); got != 99*time.Millisecond {
+		t.Errorf("Expected p99 of 99ms, got %s", got)
+	}
+	if got := percentile(latencies[:1], 99); got != time.Millisecond {
+		t.Errorf("Expected a single sample to be every percentile, got %s", got)
+	}
+}
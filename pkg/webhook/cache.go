@@ -17,12 +17,17 @@ limitations under the License.
 package webhook
 
 import (
+	"context"
+	"sort"
 	"sync"
 	"time"
 
 	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
 	"github.com/kube-zen/zen-lock/pkg/controller/metrics"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // ZenLockCache provides thread-safe caching for ZenLock CRDs
@@ -31,6 +36,7 @@ type ZenLockCache struct {
 	cache      map[types.NamespacedName]*cacheEntry
 	mu         sync.RWMutex
 	ttl        time.Duration
+	maxAge     time.Duration // Absolute lifetime of an entry across Set calls (0 = unlimited)
 	cleanupInt time.Duration
 	stopCh     chan struct{}
 	hits       int64         // Cache hit counter
@@ -40,15 +46,43 @@ type ZenLockCache struct {
 
 type cacheEntry struct {
 	zenlock    *securityv1alpha1.ZenLock
+	insertedAt time.Time
 	expiresAt  time.Time
 	lastAccess time.Time
+	// firstSetAt is kept when Set refreshes the entry, so maxAge bounds how long a key
+	// can stay cached without a miss, however often it is refreshed
+	firstSetAt time.Time
+}
+
+// stale reports whether the entry has outlived its TTL or the cache's max age
+func (e *cacheEntry) stale(now time.Time, maxAge time.Duration) bool {
+	if now.After(e.expiresAt) {
+		return true
+	}
+	return maxAge > 0 && now.After(e.firstSetAt.Add(maxAge))
+}
+
+// CacheEntryInfo describes a cached ZenLock without exposing any of its data
+type CacheEntryInfo struct {
+	Namespace    string    `json:"namespace"`
+	Name         string    `json:"name"`
+	InsertedAt   time.Time `json:"insertedAt"`
+	TTLRemaining string    `json:"ttlRemaining"`
 }
 
 // NewZenLockCache creates a new ZenLock cache with the specified TTL
 func NewZenLockCache(ttl time.Duration) *ZenLockCache {
+	return NewZenLockCacheWithMaxAge(ttl, 0)
+}
+
+// NewZenLockCacheWithMaxAge creates a new ZenLock cache with the specified TTL and max age
+// An entry is treated as a miss once maxAge has passed since it was first cached, even if Set
+// refreshed it in the meantime, forcing a refetch. A maxAge of 0 disables the limit.
+func NewZenLockCacheWithMaxAge(ttl, maxAge time.Duration) *ZenLockCache {
 	cache := &ZenLockCache{
 		cache:      make(map[types.NamespacedName]*cacheEntry),
 		ttl:        ttl,
+		maxAge:     maxAge,
 		cleanupInt: ttl / 2, // Cleanup every half TTL
 		stopCh:     make(chan struct{}),
 		metricsCh:  make(chan struct{}, 1), // Buffered channel for metrics updates
@@ -84,6 +118,17 @@ func (c *ZenLockCache) Get(key types.NamespacedName) (*securityv1alpha1.ZenLock,
 		c.recordMiss()
 		return nil, false
 	}
+	if entry.stale(now, c.maxAge) {
+		c.mu.RUnlock()
+		// Drop the entry so the refetched ZenLock starts a new lifetime
+		c.mu.Lock()
+		if current, stillExists := c.cache[key]; stillExists && current == entry {
+			delete(c.cache, key)
+		}
+		c.mu.Unlock()
+		c.recordMiss()
+		return nil, false
+	}
 	c.mu.RUnlock()
 
 	// Update lastAccess with write lock (race condition fix)
@@ -99,13 +144,30 @@ func (c *ZenLockCache) Get(key types.NamespacedName) (*securityv1alpha1.ZenLock,
 
 // Set stores a ZenLock in the cache
 func (c *ZenLockCache) Set(key types.NamespacedName, zenlock *securityv1alpha1.ZenLock) {
+	c.SetWithTTL(key, zenlock, 0)
+}
+
+// SetWithTTL stores a ZenLock in the cache with its own TTL (<= 0 = the cache's TTL)
+// The max age still applies on top of the entry's TTL.
+func (c *ZenLockCache) SetWithTTL(key types.NamespacedName, zenlock *securityv1alpha1.ZenLock, ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = c.ttl
+	}
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	now := time.Now()
+	firstSetAt := now
+	if existing, exists := c.cache[key]; exists && !existing.stale(now, c.maxAge) {
+		firstSetAt = existing.firstSetAt
+	}
 	c.cache[key] = &cacheEntry{
 		zenlock:    zenlock.DeepCopy(),
-		expiresAt:  time.Now().Add(c.ttl),
-		lastAccess: time.Now(),
+		insertedAt: now,
+		expiresAt:  now.Add(ttl),
+		lastAccess: now,
+		firstSetAt: firstSetAt,
 	}
 }
 
@@ -125,6 +187,33 @@ func (c *ZenLockCache) InvalidateAll() {
 	c.cache = make(map[types.NamespacedName]*cacheEntry)
 }
 
+// Resync refreshes every live entry from current, the full set of ZenLocks, and drops entries for ZenLocks
+// no longer present. Expiry times are kept, so TTL and max age still force a refetch, and the insertion time
+// only moves when the ZenLock changed; ZenLocks not already cached are not added.
+func (c *ZenLockCache) Resync(current map[types.NamespacedName]*securityv1alpha1.ZenLock) (refreshed, dropped int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for key, entry := range c.cache {
+		zenlock, ok := current[key]
+		if !ok {
+			delete(c.cache, key)
+			dropped++
+			continue
+		}
+		if entry.stale(now, c.maxAge) {
+			continue
+		}
+		if entry.zenlock.ResourceVersion != zenlock.ResourceVersion {
+			entry.insertedAt = now
+		}
+		entry.zenlock = zenlock.DeepCopy()
+		refreshed++
+	}
+	return refreshed, dropped
+}
+
 // cleanup periodically removes expired entries
 // Optimized for Go 1.25: collect expired keys first, then delete in batch
 func (c *ZenLockCache) cleanup() {
@@ -139,7 +228,7 @@ func (c *ZenLockCache) cleanup() {
 			// Collect expired keys first (more efficient than deleting during iteration)
 			expiredKeys := make([]types.NamespacedName, 0)
 			for key, entry := range c.cache {
-				if now.After(entry.expiresAt) {
+				if entry.stale(now, c.maxAge) {
 					expiredKeys = append(expiredKeys, key)
 				}
 			}
@@ -171,6 +260,33 @@ func (c *ZenLockCache) Size() int {
 	return len(c.cache)
 }
 
+// Entries returns metadata for the unexpired cache entries, sorted by namespace and name
+func (c *ZenLockCache) Entries() []CacheEntryInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	now := time.Now()
+	entries := make([]CacheEntryInfo, 0, len(c.cache))
+	for key, entry := range c.cache {
+		if entry.stale(now, c.maxAge) {
+			continue
+		}
+		entries = append(entries, CacheEntryInfo{
+			Namespace:    key.Namespace,
+			Name:         key.Name,
+			InsertedAt:   entry.insertedAt,
+			TTLRemaining: entry.expiresAt.Sub(now).Round(time.Second).String(),
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Namespace != entries[j].Namespace {
+			return entries[i].Namespace < entries[j].Namespace
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return entries
+}
+
 // recordHit increments the hit counter and triggers metrics update
 func (c *ZenLockCache) recordHit() {
 	c.mu.Lock()
@@ -228,3 +344,16 @@ func (c *ZenLockCache) updateMetrics() {
 
 	metrics.UpdateCacheMetrics(size, hits, misses)
 }
+
+// NamespaceCacheTTL returns the namespace's zen-lock/cache-ttl override (0 when unset, invalid or unreadable)
+func NamespaceCacheTTL(ctx context.Context, c client.Reader, namespace string) time.Duration {
+	ns := &corev1.Namespace{}
+	if err := c.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		return 0
+	}
+	ttl, err := time.ParseDuration(ns.Annotations[config.AnnotationCacheTTL])
+	if err != nil || ttl <= 0 {
+		return 0
+	}
+	return ttl
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+// InformerResyncFromEnv returns ZEN_LOCK_INFORMER_RESYNC, or the default when unset or invalid
+// It is the interval of the ZenLock cache resync.
+func InformerResyncFromEnv() time.Duration {
+	if period := InformerSyncPeriodFromEnv(); period != nil {
+		return *period
+	}
+	return config.DefaultInformerResync
+}
+
+// InformerSyncPeriodFromEnv returns ZEN_LOCK_INFORMER_RESYNC as the manager's informer resync period
+// Unset or invalid returns nil, keeping controller-runtime's default: the period applies to every
+// controller of the manager, and each resync re-decrypts every ZenLock.
+func InformerSyncPeriodFromEnv() *time.Duration {
+	if resyncStr := os.Getenv("ZEN_LOCK_INFORMER_RESYNC"); resyncStr != "" {
+		if parsedResync, err := time.ParseDuration(resyncStr); err == nil && parsedResync > 0 {
+			return &parsedResync
+		}
+	}
+	return nil
+}
+
+// CacheResync periodically refreshes a ZenLockCache from the manager's informer cache
+// Invalidations can be missed, e.g. when the controller runs in another process, leaving an entry stale
+// until its TTL expires. A resync catches such changes without a request to the API server.
+type CacheResync struct {
+	reader   client.Reader
+	cache    *ZenLockCache
+	interval time.Duration
+}
+
+// NewCacheResync creates a CacheResync refreshing cache from reader every interval
+func NewCacheResync(reader client.Reader, cache *ZenLockCache, interval time.Duration) *CacheResync {
+	return &CacheResync{reader: reader, cache: cache, interval: interval}
+}
+
+// Start resyncs the cache every interval until ctx is done (manager.Runnable)
+func (r *CacheResync) Start(ctx context.Context) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			r.Resync(ctx)
+		}
+	}
+}
+
+// NeedLeaderElection returns false: every webhook replica has its own cache
+func (r *CacheResync) NeedLeaderElection() bool {
+	return false
+}
+
+// Resync lists every ZenLock and refreshes the cache from the list
+// When the list fails the cache is left as is: its entries still expire with their TTL.
+func (r *CacheResync) Resync(ctx context.Context) {
+	logger := log.FromContext(ctx)
+	zenlocks := &securityv1alpha1.ZenLockList{}
+	if err := r.reader.List(ctx, zenlocks); err != nil {
+		logger.Error(err, "Failed to list ZenLocks for cache resync")
+		return
+	}
+	current := make(map[types.NamespacedName]*securityv1alpha1.ZenLock, len(zenlocks.Items))
+	for i := range zenlocks.Items {
+		zenlock := &zenlocks.Items[i]
+		current[types.NamespacedName{Namespace: zenlock.Namespace, Name: zenlock.Name}] = zenlock
+	}
+	refreshed, dropped := r.cache.Resync(current)
+	logger.V(4).Info("Resynced ZenLock cache", "refreshed", refreshed, "dropped", dropped)
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+func resyncTestZenLock(name, value string) *securityv1alpha1.ZenLock {
+	return &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       securityv1alpha1.ZenLockSpec{EncryptedData: map[string]string{"key": value}},
+	}
+}
+
+func TestCacheResync_RefreshesFromLister(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(securityv1alpha1.AddToScheme(scheme))
+	updated := resyncTestZenLock("updated", "v2")
+	uncached := resyncTestZenLock("uncached", "v1")
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(updated, uncached).Build()
+
+	cache := NewZenLockCache(time.Minute)
+	defer cache.Stop()
+	updatedKey := types.NamespacedName{Name: "updated", Namespace: "default"}
+	deletedKey := types.NamespacedName{Name: "deleted", Namespace: "default"}
+	cache.Set(updatedKey, resyncTestZenLock("updated", "v1"))
+	cache.Set(deletedKey, resyncTestZenLock("deleted", "v1"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- NewCacheResync(c, cache, 20*time.Millisecond).Start(ctx) }()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		cached, ok := cache.Get(updatedKey)
+		if ok && cached.Spec.EncryptedData["key"] == "v2" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the cached ZenLock to be refreshed from the lister, got %v", cached)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if _, ok := cache.Get(deletedKey); ok {
+		t.Error("Expected the entry of a deleted ZenLock to be dropped")
+	}
+	if _, ok := cache.Get(types.NamespacedName{Name: "uncached", Namespace: "default"}); ok {
+		t.Error("Expected the resync not to add ZenLocks that were not cached")
+	}
+	if cache.Size() != 1 {
+		t.Errorf("Expected the valid entry to be kept, got %d entries", cache.Size())
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Start returned error: %v", err)
+	}
+}
+
+func TestZenLockCache_ResyncKeepsExpiry(t *testing.T) {
+	cache := NewZenLockCacheWithMaxAge(100*time.Millisecond, time.Minute)
+	defer cache.Stop()
+	key := types.NamespacedName{Name: "test-zenlock", Namespace: "default"}
+	cached := resyncTestZenLock("test-zenlock", "v1")
+	cached.ResourceVersion = "1"
+	cache.Set(key, cached)
+	insertedAt := cache.Entries()[0].InsertedAt
+
+	// An unchanged ZenLock keeps its insertion time
+	time.Sleep(10 * time.Millisecond)
+	if refreshed, _ := cache.Resync(map[types.NamespacedName]*securityv1alpha1.ZenLock{key: cached}); refreshed != 1 {
+		t.Fatalf("Expected the entry to be refreshed, got %d", refreshed)
+	}
+	if got := cache.Entries()[0].InsertedAt; !got.Equal(insertedAt) {
+		t.Errorf("Expected insertion time %v to be kept, got %v", insertedAt, got)
+	}
+
+	// A changed ZenLock is recorded as inserted now
+	changed := resyncTestZenLock("test-zenlock", "v2")
+	changed.ResourceVersion = "2"
+	cache.Resync(map[types.NamespacedName]*securityv1alpha1.ZenLock{key: changed})
+	if got := cache.Entries()[0].InsertedAt; !got.After(insertedAt) {
+		t.Errorf("Expected insertion time to move past %v, got %v", insertedAt, got)
+	}
+
+	// Resyncing never extends the TTL
+	deadline := time.Now().Add(time.Second)
+	for {
+		cache.Resync(map[types.NamespacedName]*securityv1alpha1.ZenLock{key: changed})
+		if _, ok := cache.Get(key); !ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the entry to expire despite resyncs")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestInformerResyncFromEnv(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{value: "", want: config.DefaultInformerResync},
+		{value: "30s", want: 30 * time.Second},
+		{value: "0s", want: config.DefaultInformerResync},
+		{value: "soon", want: config.DefaultInformerResync},
+	}
+	for _, tt := range tests {
+		t.Setenv("ZEN_LOCK_INFORMER_RESYNC", tt.value)
+		if got := InformerResyncFromEnv(); got != tt.want {
+			t.Errorf("InformerResyncFromEnv() with %q = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestInformerSyncPeriodFromEnv(t *testing.T) {
+	// Unset or invalid keeps controller-runtime's default resync period for every controller
+	for _, value := range []string{"", "0s", "soon"} {
+		t.Setenv("ZEN_LOCK_INFORMER_RESYNC", value)
+		if got := InformerSyncPeriodFromEnv(); got != nil {
+			t.Errorf("InformerSyncPeriodFromEnv() with %q = %v, want nil", value, *got)
+		}
+	}
+
+	t.Setenv("ZEN_LOCK_INFORMER_RESYNC", "30s")
+	if got := InformerSyncPeriodFromEnv(); got == nil || *got != 30*time.Second {
+		t.Errorf("InformerSyncPeriodFromEnv() with 30s = %v, want 30s", got)
+	}
+}
@@ -17,13 +17,16 @@ limitations under the License.
 package webhook
 
 import (
+	"context"
 	"testing"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
 	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
 )
 
 func TestZenLockCache_Get(t *testing.T) {
@@ -280,3 +283,146 @@ func TestZenLockCache_Expiration(t *testing.T) {
 		t.Error("Expected cache miss after expiration")
 	}
 }
+
+func TestZenLockCache_MaxAge(t *testing.T) {
+	cache := NewZenLockCacheWithMaxAge(5*time.Minute, 100*time.Millisecond)
+	defer cache.Stop()
+
+	key := types.NamespacedName{Namespace: "default", Name: "test-zenlock"}
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-zenlock", Namespace: "default"},
+		Spec:       securityv1alpha1.ZenLockSpec{EncryptedData: map[string]string{"key1": "value1"}},
+	}
+
+	cache.Set(key, zenlock)
+
+	// Refreshing and reading the entry keeps it well within its TTL, but not past its max age
+	time.Sleep(60 * time.Millisecond)
+	cache.Set(key, zenlock)
+	if _, found := cache.Get(key); !found {
+		t.Fatal("Expected cache hit before max age")
+	}
+	time.Sleep(60 * time.Millisecond)
+
+	if _, found := cache.Get(key); found {
+		t.Error("Expected cache miss past max age even though the entry was recently refreshed and accessed")
+	}
+	if cache.Size() != 0 {
+		t.Errorf("Expected entry past max age to be dropped, got size %d", cache.Size())
+	}
+
+	// A fresh Set after the miss starts a new lifetime
+	cache.Set(key, zenlock)
+	if _, found := cache.Get(key); !found {
+		t.Error("Expected cache hit after refetch")
+	}
+}
+
+func TestZenLockCache_NoMaxAge(t *testing.T) {
+	cache := NewZenLockCache(5 * time.Minute)
+	defer cache.Stop()
+
+	key := types.NamespacedName{Namespace: "default", Name: "test-zenlock"}
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-zenlock", Namespace: "default"},
+	}
+
+	cache.Set(key, zenlock)
+	time.Sleep(20 * time.Millisecond)
+	cache.Set(key, zenlock)
+	if _, found := cache.Get(key); !found {
+		t.Error("Expected cache hit without a max age")
+	}
+}
+
+func TestZenLockCache_SetWithTTL(t *testing.T) {
+	cache := NewZenLockCache(100 * time.Millisecond)
+	defer cache.Stop()
+
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-zenlock", Namespace: "default"},
+		Spec:       securityv1alpha1.ZenLockSpec{EncryptedData: map[string]string{"key1": "value1"}},
+	}
+	shortKey := types.NamespacedName{Namespace: "high-churn", Name: "test-zenlock"}
+	longKey := types.NamespacedName{Namespace: "stable", Name: "test-zenlock"}
+	defaultKey := types.NamespacedName{Namespace: "default", Name: "test-zenlock"}
+
+	cache.SetWithTTL(shortKey, zenlock, 20*time.Millisecond)
+	cache.SetWithTTL(longKey, zenlock, 5*time.Minute)
+	cache.SetWithTTL(defaultKey, zenlock, 0)
+
+	time.Sleep(50 * time.Millisecond)
+	if _, found := cache.Get(shortKey); found {
+		t.Error("Expected the shorter namespace TTL to expire before the default")
+	}
+	if _, found := cache.Get(defaultKey); !found {
+		t.Error("Expected the default TTL entry to still be cached")
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if _, found := cache.Get(defaultKey); found {
+		t.Error("Expected the default TTL entry to have expired")
+	}
+	if _, found := cache.Get(longKey); !found {
+		t.Error("Expected the longer namespace TTL to outlive the default")
+	}
+}
+
+func TestNamespaceCacheTTL(t *testing.T) {
+	tests := []struct {
+		name       string
+		annotation string
+		want       time.Duration
+	}{
+		{name: "override", annotation: "30s", want: 30 * time.Second},
+		{name: "unset"},
+		{name: "invalid", annotation: "soon"},
+		{name: "negative", annotation: "-1m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}}
+			if tt.annotation != "" {
+				ns.Annotations = map[string]string{config.AnnotationCacheTTL: tt.annotation}
+			}
+			_, clientBuilder := setupTestPodHandler(t)
+			c := clientBuilder.WithObjects(ns).Build()
+
+			if got := NamespaceCacheTTL(context.Background(), c, "team-a"); got != tt.want {
+				t.Errorf("NamespaceCacheTTL() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	_, clientBuilder := setupTestPodHandler(t)
+	if got := NamespaceCacheTTL(context.Background(), clientBuilder.Build(), "missing"); got != 0 {
+		t.Errorf("Expected no override for a missing namespace, got %s", got)
+	}
+}
+
+func TestPodHandler_FetchZenLock_NamespaceCacheTTL(t *testing.T) {
+	handler, clientBuilder := setupTestPodHandler(t)
+	defer handler.cache.Stop()
+
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:        "high-churn",
+		Annotations: map[string]string{config.AnnotationCacheTTL: "20ms"},
+	}}
+	zenlock := &securityv1alpha1.ZenLock{ObjectMeta: metav1.ObjectMeta{Name: "test-zenlock", Namespace: "high-churn"}}
+	handler.Client = clientBuilder.WithObjects(ns, zenlock).Build()
+
+	key := types.NamespacedName{Namespace: "high-churn", Name: "test-zenlock"}
+	if _, resp := handler.fetchZenLock(context.Background(), key, "high-churn", "test-zenlock", time.Now()); resp.Result != nil {
+		t.Fatalf("fetchZenLock failed: %v", resp.Result)
+	}
+	if _, found := handler.cache.Get(key); !found {
+		t.Fatal("Expected the ZenLock to be cached")
+	}
+
+	// The handler's 5m default would keep it; the namespace's 20ms override does not
+	time.Sleep(50 * time.Millisecond)
+	if _, found := handler.cache.Get(key); found {
+		t.Error("Expected the entry to expire with the namespace TTL")
+	}
+}
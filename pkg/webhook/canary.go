@@ -0,0 +1,56 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// CanaryNamespacesFromEnv returns the namespaces listed in ZEN_LOCK_CANARY_NAMESPACES (comma-separated; nil when unset)
+func CanaryNamespacesFromEnv() map[string]bool {
+	var namespaces map[string]bool
+	for _, namespace := range strings.Split(os.Getenv("ZEN_LOCK_CANARY_NAMESPACES"), ",") {
+		if namespace = strings.TrimSpace(namespace); namespace == "" {
+			continue
+		}
+		if namespaces == nil {
+			namespaces = make(map[string]bool)
+		}
+		namespaces[namespace] = true
+	}
+	return namespaces
+}
+
+// downgradeCanaryDenial admits a Pod denied in a canary namespace, turning the denial into a warning
+// This lets a new restrictive policy, e.g. allowedSubjects, be tried out in a few namespaces first.
+// Only policy denials are downgraded: errors still fail the request. The Pod is admitted without
+// injection; Events, denial counts and audit entries still record what would have been blocked.
+func (h *PodHandler) downgradeCanaryDenial(ctx context.Context, req admission.Request, resp admission.Response) admission.Response {
+	if resp.Allowed || !h.canaryNamespaces[req.Namespace] || resp.Result == nil || resp.Result.Code != http.StatusForbidden {
+		return resp
+	}
+	message := resp.Result.Message
+	log.FromContext(ctx).Info("Canary namespace: admitting Pod that would be denied", "namespace", req.Namespace, "name", req.Name, "reason", message)
+	warnings := append(resp.Warnings, "zen-lock canary namespace: Pod admitted without injection, would be denied: "+message)
+	return admission.Allowed("").WithWarnings(warnings...)
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+)
+
+// canaryTestHandler returns a handler serving a ZenLock the default ServiceAccount may not inject
+func canaryTestHandler(t *testing.T, canaryNamespaces map[string]bool) *PodHandler {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-zenlock", Namespace: "default"},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData:   map[string]string{"USERNAME": encryptTestData(t, "admin", identity.Recipient().String())},
+			AllowedSubjects: []securityv1alpha1.SubjectReference{{Kind: "ServiceAccount", Name: "backend", Namespace: "default"}},
+		},
+	}
+
+	handler, clientBuilder := setupTestPodHandlerWithKey(t, identity.String())
+	handler.Client = clientBuilder.WithObjects(zenlock).Build()
+	handler.canaryNamespaces = canaryNamespaces
+	return handler
+}
+
+func TestPodHandler_Handle_CanaryNamespaceDowngradesDenial(t *testing.T) {
+	handler := canaryTestHandler(t, map[string]bool{"default": true})
+
+	resp := handler.Handle(context.Background(), sharedNamingRequest("app-1", ""))
+	if !resp.Allowed {
+		t.Fatalf("Expected the denial to be downgraded in a canary namespace, got %v", resp.Result)
+	}
+	if len(resp.Patches) != 0 {
+		t.Errorf("Expected the Pod to be admitted without injection, got %d patches", len(resp.Patches))
+	}
+	if len(resp.Warnings) != 1 || !strings.Contains(resp.Warnings[0], "would be denied") || !strings.Contains(resp.Warnings[0], "not allowed") {
+		t.Errorf("Expected a warning carrying the denial, got %v", resp.Warnings)
+	}
+
+	secrets := &corev1.SecretList{}
+	if err := handler.Client.List(context.Background(), secrets); err != nil {
+		t.Fatalf("Failed to list secrets: %v", err)
+	}
+	if len(secrets.Items) != 0 {
+		t.Errorf("Expected no Secret for a downgraded denial, got %d", len(secrets.Items))
+	}
+}
+
+func TestPodHandler_Handle_DenialOutsideCanaryNamespace(t *testing.T) {
+	handler := canaryTestHandler(t, map[string]bool{"staging": true})
+
+	resp := handler.Handle(context.Background(), sharedNamingRequest("app-1", ""))
+	if resp.Allowed {
+		t.Fatal("Expected the denial to stand outside the canary namespaces")
+	}
+	if !strings.Contains(resp.Result.Message, "not allowed") {
+		t.Errorf("Expected the ServiceAccount denial, got %q", resp.Result.Message)
+	}
+}
+
+func TestCanaryNamespacesFromEnv(t *testing.T) {
+	t.Setenv("ZEN_LOCK_CANARY_NAMESPACES", "")
+	if got := CanaryNamespacesFromEnv(); got != nil {
+		t.Errorf("Expected no canary namespaces when unset, got %v", got)
+	}
+
+	t.Setenv("ZEN_LOCK_CANARY_NAMESPACES", " staging, team-a ,,")
+	got := CanaryNamespacesFromEnv()
+	if len(got) != 2 || !got["staging"] || !got["team-a"] {
+		t.Errorf("Expected staging and team-a, got %v", got)
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+// servingCertName is the certificate file the webhook server reads from its cert dir (controller-runtime default)
+const servingCertName = "tls.crt"
+
+// CertExpiryWindowFromEnv returns ZEN_LOCK_CERT_EXPIRY_WINDOW, or the default when unset or invalid
+// A window of 0 disables the check.
+func CertExpiryWindowFromEnv() time.Duration {
+	if windowStr := os.Getenv("ZEN_LOCK_CERT_EXPIRY_WINDOW"); windowStr != "" {
+		if parsedWindow, err := time.ParseDuration(windowStr); err == nil && parsedWindow >= 0 {
+			return parsedWindow
+		}
+	}
+	return config.DefaultCertExpiryWindow
+}
+
+// CertExpiryCheck returns a readiness check that fails once the serving certificate in certDir
+// expires within window, so a stalled rotation is noticed before admissions start failing
+// The certificate is re-read on every probe, as cert-manager replaces it in place.
+func CertExpiryCheck(certDir string, window time.Duration) healthz.Checker {
+	certPath := filepath.Join(certDir, servingCertName)
+	return func(_ *http.Request) error {
+		return checkCertExpiry(certPath, window, time.Now())
+	}
+}
+
+// checkCertExpiry returns an error when the first certificate in certPath expires within window of now
+func checkCertExpiry(certPath string, window time.Duration, now time.Time) error {
+	data, err := os.ReadFile(certPath)
+	if err != nil {
+		return fmt.Errorf("failed to read serving certificate: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return fmt.Errorf("serving certificate %s contains no PEM certificate", certPath)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("failed to parse serving certificate: %w", err)
+	}
+	if remaining := cert.NotAfter.Sub(now); remaining < window {
+		return fmt.Errorf("serving certificate expires at %s (in %s), within the %s expiry window; check certificate rotation",
+			cert.NotAfter.UTC().Format(time.RFC3339), remaining.Round(time.Second), window)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeServingCert writes a self-signed serving certificate expiring at notAfter to a new cert dir
+func writeServingCert(t *testing.T, notAfter time.Time) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "zen-lock-webhook.zen-lock-system.svc"},
+		NotBefore:    notAfter.Add(-90 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	dir := t.TempDir()
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	if err := os.WriteFile(filepath.Join(dir, "tls.crt"), certPEM, 0o600); err != nil {
+		t.Fatalf("Failed to write certificate: %v", err)
+	}
+	return dir
+}
+
+func TestCertExpiryCheck(t *testing.T) {
+	tests := []struct {
+		name      string
+		expiresIn time.Duration
+		wantErr   bool
+	}{
+		{name: "healthy", expiresIn: 30 * 24 * time.Hour},
+		{name: "near expiry", expiresIn: time.Hour, wantErr: true},
+		{name: "expired", expiresIn: -time.Hour, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeServingCert(t, time.Now().Add(tt.expiresIn))
+			err := CertExpiryCheck(dir, 24*time.Hour)(nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CertExpiryCheck() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !strings.Contains(err.Error(), "expiry window") {
+				t.Errorf("Expected the error to mention the expiry window, got %q", err)
+			}
+		})
+	}
+}
+
+func TestCertExpiryCheck_MissingCert(t *testing.T) {
+	if err := CertExpiryCheck(t.TempDir(), 24*time.Hour)(nil); err == nil {
+		t.Error("Expected a missing certificate to fail the check")
+	}
+}
+
+func TestCertExpiryWindowFromEnv(t *testing.T) {
+	t.Setenv("ZEN_LOCK_CERT_EXPIRY_WINDOW", "72h")
+	if got := CertExpiryWindowFromEnv(); got != 72*time.Hour {
+		t.Errorf("CertExpiryWindowFromEnv() = %v, want 72h", got)
+	}
+	t.Setenv("ZEN_LOCK_CERT_EXPIRY_WINDOW", "invalid")
+	if got := CertExpiryWindowFromEnv(); got != 24*time.Hour {
+		t.Errorf("CertExpiryWindowFromEnv() = %v, want the default", got)
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+// Concatenation parses the ZenLock's zen-lock/concatenate annotation
+// Value: <name>=<key>,<key>,... Returns an empty name when the annotation is unset.
+func Concatenation(zenlock *securityv1alpha1.ZenLock) (name string, keys []string, err error) {
+	value := strings.TrimSpace(zenlock.GetAnnotations()[config.AnnotationConcatenate])
+	if value == "" {
+		return "", nil, nil
+	}
+	name, list, ok := strings.Cut(value, "=")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" {
+		return "", nil, fmt.Errorf("invalid %s %q: must be <name>=<key>,<key>,...", config.AnnotationConcatenate, value)
+	}
+	if errs := validation.IsConfigMapKey(name); len(errs) > 0 {
+		return "", nil, fmt.Errorf("invalid %s name %q: %s", config.AnnotationConcatenate, name, strings.Join(errs, "; "))
+	}
+	for _, key := range strings.Split(list, ",") {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return "", nil, fmt.Errorf("invalid %s %q: empty key", config.AnnotationConcatenate, value)
+		}
+		if slices.Contains(keys, key) {
+			return "", nil, fmt.Errorf("invalid %s: key %q is listed more than once", config.AnnotationConcatenate, key)
+		}
+		keys = append(keys, key)
+	}
+	return name, keys, nil
+}
+
+// ApplyConcatenation adds the ZenLock's zen-lock/concatenate entry to decrypted data
+// The listed values are joined in order, each followed by a newline unless it already ends with one,
+// e.g. to assemble a PEM bundle. The listed keys are kept. Every listed key must be present, and the
+// name must not already be a key. The data is returned unchanged when the annotation is unset.
+func ApplyConcatenation(zenlock *securityv1alpha1.ZenLock, data map[string][]byte) (map[string][]byte, error) {
+	name, keys, err := Concatenation(zenlock)
+	if err != nil || name == "" {
+		return data, err
+	}
+	if _, exists := data[name]; exists {
+		return nil, fmt.Errorf("%s name %q is already a key", config.AnnotationConcatenate, name)
+	}
+
+	var joined bytes.Buffer
+	for _, key := range keys {
+		value, ok := data[key]
+		if !ok {
+			return nil, fmt.Errorf("%s lists key %q, which is not in the decrypted data", config.AnnotationConcatenate, key)
+		}
+		joined.Write(value)
+		if len(value) > 0 && value[len(value)-1] != '\n' {
+			joined.WriteByte('\n')
+		}
+	}
+
+	result := make(map[string][]byte, len(data)+1)
+	for key, value := range data {
+		result[key] = value
+	}
+	result[name] = joined.Bytes()
+	return result, nil
+}
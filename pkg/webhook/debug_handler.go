@@ -0,0 +1,78 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/kube-zen/zen-lock/pkg/crypto"
+)
+
+// DebugCachePath is the path of the cache debug endpoint on the metrics server
+const DebugCachePath = "/debug/zenlock-cache"
+
+// DebugCryptoPath is the path of the crypto capabilities debug endpoint on the metrics server
+const DebugCryptoPath = "/debug/zenlock-crypto"
+
+// DebugEndpointEnabled reports whether the debug endpoints are enabled (ZEN_LOCK_DEBUG_ENDPOINT=true)
+func DebugEndpointEnabled() bool {
+	enabled, _ := strconv.ParseBool(os.Getenv("ZEN_LOCK_DEBUG_ENDPOINT"))
+	return enabled
+}
+
+// debugCacheResponse is the body served by the cache debug endpoint
+type debugCacheResponse struct {
+	Entries []CacheEntryInfo `json:"entries"`
+}
+
+// NewDebugCacheHandler returns a handler listing cached ZenLocks across all registered caches
+// Only names, insertion times and remaining TTLs are exposed - never encrypted or decrypted data.
+func NewDebugCacheHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		resp := debugCacheResponse{Entries: []CacheEntryInfo{}}
+		globalCacheManager.mu.RLock()
+		for _, cache := range globalCacheManager.caches {
+			resp.Entries = append(resp.Entries, cache.Entries()...)
+		}
+		globalCacheManager.mu.RUnlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+}
+
+// NewDebugCryptoHandler returns a handler reporting the age library version and crypto capabilities
+// Whether an identity is loaded is reported as a boolean; key material is never exposed.
+func NewDebugCryptoHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(crypto.GetCapabilities())
+	})
+}
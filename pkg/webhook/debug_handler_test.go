@@ -0,0 +1,121 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"filippo.io/age"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/crypto"
+)
+
+func TestDebugCacheHandler(t *testing.T) {
+	cache := NewZenLockCache(time.Minute)
+	defer cache.Stop()
+	RegisterCache(cache)
+	defer UnregisterCache(cache)
+
+	cache.Set(types.NamespacedName{Namespace: "debug-ns", Name: "debug-zenlock"}, &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{Name: "debug-zenlock", Namespace: "debug-ns"},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{"PASSWORD": "c2Vuc2l0aXZlLWNpcGhlcnRleHQ="},
+		},
+	})
+
+	rec := httptest.NewRecorder()
+	NewDebugCacheHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, DebugCachePath, nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", rec.Code)
+	}
+	body := rec.Body.String()
+	for _, sensitive := range []string{"PASSWORD", "c2Vuc2l0aXZlLWNpcGhlcnRleHQ=", "encryptedData"} {
+		if strings.Contains(body, sensitive) {
+			t.Errorf("Expected response to exclude %q, got %s", sensitive, body)
+		}
+	}
+
+	var resp debugCacheResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	var found *CacheEntryInfo
+	for i := range resp.Entries {
+		if resp.Entries[i].Namespace == "debug-ns" && resp.Entries[i].Name == "debug-zenlock" {
+			found = &resp.Entries[i]
+		}
+	}
+	if found == nil {
+		t.Fatalf("Expected cached entry in response, got %+v", resp.Entries)
+	}
+	if found.InsertedAt.IsZero() {
+		t.Error("Expected insertion time to be set")
+	}
+	if remaining, err := time.ParseDuration(found.TTLRemaining); err != nil || remaining <= 0 || remaining > time.Minute {
+		t.Errorf("Expected remaining TTL within (0, 1m], got %q", found.TTLRemaining)
+	}
+}
+
+func TestDebugCacheHandler_MethodNotAllowed(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewDebugCacheHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, DebugCachePath, nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405, got %d", rec.Code)
+	}
+}
+
+func TestDebugCryptoHandler(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	t.Setenv("ZEN_LOCK_IDENTITIES_DIR", "")
+	t.Setenv("ZEN_LOCK_PRIVATE_KEY", identity.String())
+
+	rec := httptest.NewRecorder()
+	NewDebugCryptoHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, DebugCryptoPath, nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", rec.Code)
+	}
+	if body := rec.Body.String(); strings.Contains(body, identity.String()) || strings.Contains(body, "AGE-SECRET-KEY") {
+		t.Errorf("Expected response to exclude key material, got %s", body)
+	}
+
+	var caps crypto.Capabilities
+	if err := json.Unmarshal(rec.Body.Bytes(), &caps); err != nil {
+		t.Fatalf("Response is not JSON: %v", err)
+	}
+	if !caps.IdentityLoaded || len(caps.RecipientTypes) == 0 {
+		t.Errorf("Expected a loaded identity and recipient types, got %+v", caps)
+	}
+
+	rec = httptest.NewRecorder()
+	NewDebugCryptoHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, DebugCryptoPath, nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405 for POST, got %d", rec.Code)
+	}
+}
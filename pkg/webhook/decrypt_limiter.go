@@ -0,0 +1,80 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"strconv"
+	"sync"
+)
+
+// decryptLimiter bounds the number of concurrent decryptions
+// A nil limiter imposes no limit.
+type decryptLimiter struct {
+	slots chan struct{}
+}
+
+// newDecryptLimiter creates a limiter allowing limit concurrent decryptions (<= 0 = GOMAXPROCS)
+func newDecryptLimiter(limit int) *decryptLimiter {
+	if limit <= 0 {
+		limit = runtime.GOMAXPROCS(0)
+	}
+	return &decryptLimiter{slots: make(chan struct{}, limit)}
+}
+
+// acquire blocks until a decryption slot is free or ctx is done
+func (l *decryptLimiter) acquire(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release frees a slot taken by acquire
+func (l *decryptLimiter) release() {
+	if l == nil {
+		return
+	}
+	<-l.slots
+}
+
+var (
+	sharedDecryptLimiter     *decryptLimiter
+	sharedDecryptLimiterOnce sync.Once
+)
+
+// getSharedDecryptLimiter returns the limiter shared by every admission handler in the process
+// Sized via ZEN_LOCK_MAX_CONCURRENT_DECRYPTS (default: GOMAXPROCS).
+func getSharedDecryptLimiter() *decryptLimiter {
+	sharedDecryptLimiterOnce.Do(func() {
+		limit := 0
+		if limitStr := os.Getenv("ZEN_LOCK_MAX_CONCURRENT_DECRYPTS"); limitStr != "" {
+			if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit > 0 {
+				limit = parsedLimit
+			}
+		}
+		sharedDecryptLimiter = newDecryptLimiter(limit)
+	})
+	return sharedDecryptLimiter
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+func TestDecryptLimiter_NeverExceedsLimit(t *testing.T) {
+	const limit = 3
+	limiter := newDecryptLimiter(limit)
+
+	var active, peak int32
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := limiter.acquire(context.Background()); err != nil {
+				t.Errorf("acquire() error = %v", err)
+				return
+			}
+			defer limiter.release()
+
+			current := atomic.AddInt32(&active, 1)
+			for {
+				observed := atomic.LoadInt32(&peak)
+				if current <= observed || atomic.CompareAndSwapInt32(&peak, observed, current) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&active, -1)
+		}()
+	}
+	wg.Wait()
+
+	if peak > limit {
+		t.Errorf("Expected at most %d concurrent decryptions, observed %d", limit, peak)
+	}
+	if peak == 0 {
+		t.Error("Expected some decryptions to run")
+	}
+}
+
+func TestDecryptLimiter_SaturatedRespectsCancellation(t *testing.T) {
+	limiter := newDecryptLimiter(1)
+	if err := limiter.acquire(context.Background()); err != nil {
+		t.Fatalf("acquire() error = %v", err)
+	}
+	defer limiter.release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := limiter.acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded from saturated limiter, got %v", err)
+	}
+}
+
+func TestDecryptLimiter_DefaultsToGOMAXPROCS(t *testing.T) {
+	if got := cap(newDecryptLimiter(0).slots); got != runtime.GOMAXPROCS(0) {
+		t.Errorf("Expected default limit %d, got %d", runtime.GOMAXPROCS(0), got)
+	}
+}
+
+func TestPodHandler_Handle_DecryptLimiterSaturated(t *testing.T) {
+	handler, clientBuilder := setupTestPodHandler(t)
+	handler.decryptLimiter = newDecryptLimiter(1)
+	if err := handler.decryptLimiter.acquire(context.Background()); err != nil {
+		t.Fatalf("acquire() error = %v", err)
+	}
+	defer handler.decryptLimiter.release()
+
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-zenlock", Namespace: "default"},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{"key": "ZW5jcnlwdGVk"},
+		},
+	}
+	handler.Client = clientBuilder.WithObjects(zenlock).Build()
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-pod",
+			Namespace:   "default",
+			Annotations: map[string]string{config.AnnotationInject: "test-zenlock"},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "test-container", Image: "nginx"}},
+		},
+	}
+	podRaw, _ := json.Marshal(pod)
+	req := admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Object:    k8sruntime.RawExtension{Raw: podRaw},
+			Namespace: "default",
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	resp := handler.Handle(ctx, req)
+	if resp.Allowed {
+		t.Fatal("Expected request to fail while decryption capacity is exhausted")
+	}
+	if resp.Result == nil || resp.Result.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503, got %+v", resp.Result)
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kube-zen/zen-lock/pkg/config"
+	"github.com/kube-zen/zen-lock/pkg/crypto"
+)
+
+// ErrDecryptTimeout is returned when a decryption exceeds the decryption timeout
+var ErrDecryptTimeout = errors.New("decryption timed out")
+
+// getDecryptTimeout returns the per-decryption timeout (ZEN_LOCK_DECRYPT_TIMEOUT, default 5s)
+func getDecryptTimeout() time.Duration {
+	if timeoutStr := os.Getenv("ZEN_LOCK_DECRYPT_TIMEOUT"); timeoutStr != "" {
+		if parsedTimeout, err := time.ParseDuration(timeoutStr); err == nil && parsedTimeout > 0 {
+			return parsedTimeout
+		}
+	}
+	return config.DefaultDecryptTimeout
+}
+
+// decryptMapWithTimeout runs DecryptMapOptional in a goroutine and gives up after timeout (<= 0 = default) or when ctx is done
+// A stuck decryption cannot be interrupted: its goroutine is abandoned and its result discarded,
+// so the admission fails quickly instead of consuming the whole webhook budget.
+// Optional keys that fail to decrypt are left out of the result and returned in omitted.
+func decryptMapWithTimeout(ctx context.Context, encryptor crypto.Encryptor, encryptedData map[string]string, optionalKeys []string, privateKey string, timeout time.Duration) (decrypted map[string][]byte, omitted []string, err error) {
+	if timeout <= 0 {
+		timeout = config.DefaultDecryptTimeout
+	}
+
+	type result struct {
+		data    map[string][]byte
+		omitted []string
+		err     error
+	}
+	// Buffered so an abandoned decryption can still deliver its result and exit
+	done := make(chan result, 1)
+	go func() {
+		data, omitted, err := crypto.DecryptMapOptional(encryptor, encryptedData, privateKey, optionalKeys)
+		done <- result{data: data, omitted: omitted, err: err}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case r := <-done:
+		return r.data, r.omitted, r.err
+	case <-timer.C:
+		return nil, nil, fmt.Errorf("%w after %s", ErrDecryptTimeout, timeout)
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	}
+}
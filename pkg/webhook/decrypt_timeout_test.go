@@ -0,0 +1,140 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
+	"github.com/kube-zen/zen-lock/pkg/controller/metrics"
+	"github.com/kube-zen/zen-lock/pkg/crypto"
+)
+
+// slowEncryptor blocks DecryptMap until unblock is closed, simulating a hung decryption
+type slowEncryptor struct {
+	crypto.Encryptor
+	unblock chan struct{}
+}
+
+func (s *slowEncryptor) DecryptMap(encryptedData map[string]string, identity string) (map[string][]byte, error) {
+	<-s.unblock
+	return map[string][]byte{"key": []byte("value")}, nil
+}
+
+func newSlowEncryptor(t *testing.T) *slowEncryptor {
+	s := &slowEncryptor{Encryptor: crypto.NewAgeEncryptor(), unblock: make(chan struct{})}
+	t.Cleanup(func() { close(s.unblock) })
+	return s
+}
+
+func TestDecryptMapWithTimeout_Fires(t *testing.T) {
+	start := time.Now()
+	_, _, err := decryptMapWithTimeout(context.Background(), newSlowEncryptor(t), map[string]string{"key": "ZW5jcnlwdGVk"}, nil, "identity", 20*time.Millisecond)
+	if !errors.Is(err, ErrDecryptTimeout) {
+		t.Fatalf("Expected ErrDecryptTimeout, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected timeout to fire promptly, took %s", elapsed)
+	}
+}
+
+func TestDecryptMapWithTimeout_Completes(t *testing.T) {
+	slow := &slowEncryptor{Encryptor: crypto.NewAgeEncryptor(), unblock: make(chan struct{})}
+	close(slow.unblock)
+
+	data, _, err := decryptMapWithTimeout(context.Background(), slow, map[string]string{"key": "ZW5jcnlwdGVk"}, nil, "identity", time.Second)
+	if err != nil {
+		t.Fatalf("Expected decryption to succeed, got %v", err)
+	}
+	if string(data["key"]) != "value" {
+		t.Errorf("Unexpected decrypted data: %v", data)
+	}
+}
+
+func TestGetDecryptTimeout(t *testing.T) {
+	t.Setenv("ZEN_LOCK_DECRYPT_TIMEOUT", "")
+	if got := getDecryptTimeout(); got != config.DefaultDecryptTimeout {
+		t.Errorf("Expected default %s, got %s", config.DefaultDecryptTimeout, got)
+	}
+	t.Setenv("ZEN_LOCK_DECRYPT_TIMEOUT", "2s")
+	if got := getDecryptTimeout(); got != 2*time.Second {
+		t.Errorf("Expected 2s, got %s", got)
+	}
+	t.Setenv("ZEN_LOCK_DECRYPT_TIMEOUT", "bogus")
+	if got := getDecryptTimeout(); got != config.DefaultDecryptTimeout {
+		t.Errorf("Expected default for invalid value, got %s", got)
+	}
+}
+
+func TestPodHandler_Handle_DecryptTimeout(t *testing.T) {
+	handler, clientBuilder := setupTestPodHandler(t)
+	handler.crypto = newSlowEncryptor(t)
+	handler.decryptTimeout = 20 * time.Millisecond
+
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{Name: "slow-zenlock", Namespace: "default"},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{"key": "ZW5jcnlwdGVk"},
+		},
+	}
+	handler.Client = clientBuilder.WithObjects(zenlock).Build()
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-pod",
+			Namespace:   "default",
+			Annotations: map[string]string{config.AnnotationInject: "slow-zenlock"},
+		},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "test-container", Image: "nginx"}}},
+	}
+	podRaw, _ := json.Marshal(pod)
+	req := admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			Object:    runtime.RawExtension{Raw: podRaw},
+			Namespace: "default",
+		},
+	}
+
+	before := testutil.ToFloat64(metrics.DecryptionTimeoutsTotal.WithLabelValues(metrics.ComponentWebhook, "default", "slow-zenlock"))
+
+	resp := handler.Handle(context.Background(), req)
+	if resp.Allowed {
+		t.Fatal("Expected a timed-out decryption to fail the admission")
+	}
+	if resp.Result.Code != http.StatusServiceUnavailable || !strings.Contains(resp.Result.Message, "decryption timed out") {
+		t.Errorf("Expected a 503 naming the decryption timeout, got %d: %s", resp.Result.Code, resp.Result.Message)
+	}
+
+	if got := testutil.ToFloat64(metrics.DecryptionTimeoutsTotal.WithLabelValues(metrics.ComponentWebhook, "default", "slow-zenlock")) - before; got != 1 {
+		t.Errorf("Expected decryption timeouts to increase by 1, got %f", got)
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"sort"
+	"strings"
+
+	"gomodules.xyz/jsonpatch/v2"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+// alreadyInjectedMessage admits a Pod whose injection, including its delegated-secrets annotation, is in place
+const alreadyInjectedMessage = "zen-lock injection already present"
+
+// delegatedSecretsPatchPath is the JSON patch path of the delegated-secrets annotation
+var delegatedSecretsPatchPath = "/metadata/annotations/" + strings.ReplaceAll(config.AnnotationDelegatedSecrets, "/", "~1")
+
+// stripSuppliedDelegation removes a delegated-secrets annotation the Pod was submitted with
+// Only zen-lock may set it. Mutation responses already replace or drop it, and an already-injected Pod
+// was checked to carry the expected value; any other admitted Pod gets it removed.
+func (h *PodHandler) stripSuppliedDelegation(req admission.Request, resp admission.Response) admission.Response {
+	if !resp.Allowed || len(resp.Patches) > 0 {
+		return resp
+	}
+	// A nil Result is a mutation response that found nothing to change
+	if resp.Result == nil || resp.Result.Message == alreadyInjectedMessage {
+		return resp
+	}
+	pod := &corev1.Pod{}
+	if err := h.decoder.Decode(req, pod); err != nil {
+		return resp
+	}
+	if _, ok := pod.Annotations[config.AnnotationDelegatedSecrets]; !ok {
+		return resp
+	}
+	resp.Patches = []jsonpatch.JsonPatchOperation{{Operation: "remove", Path: delegatedSecretsPatchPath}}
+	patchType := admissionv1.PatchTypeJSONPatch
+	resp.PatchType = &patchType
+	return resp
+}
+
+// DelegatedSecretNames returns the Secret name the webhook gives each ZenLock injected into the Pod
+// The controller derives the names itself rather than trusting the delegated-secrets annotation, which
+// anyone able to annotate the Pod can edit. Naming follows selectorTargets and applySecretNaming.
+func DelegatedSecretNames(pod *corev1.Pod, zenlockNames []string) map[string]string {
+	names := make(map[string]string, len(zenlockNames))
+	podName := admittedPodName(pod)
+	shared := pod.Annotations[config.AnnotationSecretNaming] == config.SecretNamingZenLock
+	for _, zenlockName := range zenlockNames {
+		switch {
+		case shared:
+			names[zenlockName] = GenerateZenLockSecretName(zenlockName)
+		case len(zenlockNames) == 1:
+			names[zenlockName] = GenerateSecretName(pod.Namespace, podName)
+		default:
+			names[zenlockName] = GenerateSecretName(pod.Namespace, podName+"-"+zenlockName)
+		}
+	}
+	return names
+}
+
+// DelegatedZenLocks returns the sorted ZenLock names listed in the delegated-secrets annotation
+func DelegatedZenLocks(pod *corev1.Pod) []string {
+	secrets := ParseDelegatedSecrets(pod.Annotations[config.AnnotationDelegatedSecrets])
+	zenlockNames := make([]string, 0, len(secrets))
+	for zenlockName := range secrets {
+		zenlockNames = append(zenlockNames, zenlockName)
+	}
+	sort.Strings(zenlockNames)
+	return zenlockNames
+}
+
+// admittedPodName returns the Pod's name as the webhook saw it at admission
+// The API server names Pods created with generateName only after admission, so the webhook saw none.
+func admittedPodName(pod *corev1.Pod) string {
+	if pod.GenerateName != "" && strings.HasPrefix(pod.Name, pod.GenerateName) {
+		return ""
+	}
+	return pod.Name
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	clientretry "k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+// pendingDenials is the coalesced denial update of one ZenLock
+type pendingDenials struct {
+	count      int
+	lastReason string
+}
+
+// DenialQueue coalesces injection denials per ZenLock until they are written to its status
+// Recording is a map update, so the admission path never waits on the API server.
+type DenialQueue struct {
+	mu      sync.Mutex
+	pending map[types.NamespacedName]pendingDenials
+}
+
+// NewDenialQueue creates an empty DenialQueue
+func NewDenialQueue() *DenialQueue {
+	return &DenialQueue{pending: make(map[types.NamespacedName]pendingDenials)}
+}
+
+// Record counts one denied injection for the ZenLock
+func (q *DenialQueue) Record(zenlock types.NamespacedName, reason string) {
+	if len(reason) > config.MaxDenialReasonLength {
+		reason = reason[:config.MaxDenialReasonLength]
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	p := q.pending[zenlock]
+	p.count++
+	p.lastReason = reason
+	q.pending[zenlock] = p
+}
+
+// drain returns and clears the pending updates
+func (q *DenialQueue) drain() map[types.NamespacedName]pendingDenials {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	drained := q.pending
+	q.pending = make(map[types.NamespacedName]pendingDenials)
+	return drained
+}
+
+// requeue puts back an update that could not be written, ahead of denials recorded since
+func (q *DenialQueue) requeue(zenlock types.NamespacedName, update pendingDenials) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	p, ok := q.pending[zenlock]
+	p.count += update.count
+	if !ok {
+		p.lastReason = update.lastReason
+	}
+	q.pending[zenlock] = p
+}
+
+// DenialStatusWriter drains a DenialQueue into ZenLock statuses (DenialCount, LastDenialReason)
+// It runs in every webhook replica: each adds its own denials, and conflicts are retried on fresh reads.
+type DenialStatusWriter struct {
+	client   client.Client
+	queue    *DenialQueue
+	interval time.Duration
+}
+
+// NewDenialStatusWriter creates a DenialStatusWriter flushing queue every interval
+func NewDenialStatusWriter(c client.Client, queue *DenialQueue, interval time.Duration) *DenialStatusWriter {
+	return &DenialStatusWriter{client: c, queue: queue, interval: interval}
+}
+
+// Start flushes the queue every interval until ctx is done, then flushes once more (manager.Runnable)
+func (w *DenialStatusWriter) Start(ctx context.Context) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			// Best-effort final flush on shutdown with a short, fresh deadline
+			flushCtx, cancel := context.WithTimeout(context.Background(), w.interval)
+			w.Flush(flushCtx)
+			cancel()
+			return nil
+		case <-ticker.C:
+			w.Flush(ctx)
+		}
+	}
+}
+
+// NeedLeaderElection returns false: every webhook replica flushes the denials it recorded
+func (w *DenialStatusWriter) NeedLeaderElection() bool {
+	return false
+}
+
+// Flush writes every pending update; failed writes are requeued, deleted ZenLocks are dropped
+func (w *DenialStatusWriter) Flush(ctx context.Context) {
+	logger := log.FromContext(ctx)
+	for key, update := range w.queue.drain() {
+		err := clientretry.RetryOnConflict(clientretry.DefaultRetry, func() error {
+			zenlock := &securityv1alpha1.ZenLock{}
+			if err := w.client.Get(ctx, key, zenlock); err != nil {
+				return err
+			}
+			zenlock.Status.DenialCount += update.count
+			zenlock.Status.LastDenialReason = update.lastReason
+			return w.client.Status().Update(ctx, zenlock)
+		})
+		if k8serrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			logger.Error(err, "Failed to record injection denials in ZenLock status, will retry", "zenlock", key)
+			w.queue.requeue(key, update)
+		}
+	}
+}
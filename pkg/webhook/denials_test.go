@@ -0,0 +1,173 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+var deniedZenLockKey = types.NamespacedName{Name: "test-zenlock", Namespace: "default"}
+
+func deniedZenLock() *securityv1alpha1.ZenLock {
+	return &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{Name: deniedZenLockKey.Name, Namespace: deniedZenLockKey.Namespace},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{"key": "ZW5jcnlwdGVk"},
+			AllowedSubjects: []securityv1alpha1.SubjectReference{
+				{Kind: "ServiceAccount", Name: "backend", Namespace: "default"},
+			},
+		},
+	}
+}
+
+func serviceAccountRequest(serviceAccount string, dryRun bool) admission.Request {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-pod",
+			Namespace:   "default",
+			Annotations: map[string]string{config.AnnotationInject: deniedZenLockKey.Name},
+		},
+		Spec: corev1.PodSpec{
+			ServiceAccountName: serviceAccount,
+			Containers:         []corev1.Container{{Name: "test-container", Image: "nginx"}},
+		},
+	}
+	podRaw, _ := json.Marshal(pod)
+	return admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			Object:    runtime.RawExtension{Raw: podRaw},
+			Namespace: "default",
+			DryRun:    &dryRun,
+		},
+	}
+}
+
+func getDenialStatus(t *testing.T, c client.Client) securityv1alpha1.ZenLockStatus {
+	t.Helper()
+	zenlock := &securityv1alpha1.ZenLock{}
+	if err := c.Get(context.Background(), deniedZenLockKey, zenlock); err != nil {
+		t.Fatalf("Failed to get ZenLock: %v", err)
+	}
+	return zenlock.Status
+}
+
+func TestPodHandler_DenialsCountedInStatus(t *testing.T) {
+	handler, clientBuilder := setupTestPodHandler(t)
+	handler.delegateSecretCreation = true
+	handler.Denials = NewDenialQueue()
+	handler.Client = clientBuilder.WithObjects(deniedZenLock()).WithStatusSubresource(&securityv1alpha1.ZenLock{}).Build()
+	writer := NewDenialStatusWriter(handler.Client, handler.Denials, time.Minute)
+
+	for _, serviceAccount := range []string{"frontend", "batch"} {
+		if resp := handler.Handle(context.Background(), serviceAccountRequest(serviceAccount, false)); resp.Allowed {
+			t.Fatalf("Expected ServiceAccount %s to be denied", serviceAccount)
+		}
+	}
+	// Neither an allowed injection nor a dry-run denial is counted
+	if resp := handler.Handle(context.Background(), serviceAccountRequest("backend", false)); !resp.Allowed {
+		t.Fatalf("Expected ServiceAccount backend to be allowed, got %v", resp.Result)
+	}
+	if resp := handler.Handle(context.Background(), serviceAccountRequest("frontend", true)); resp.Allowed {
+		t.Fatal("Expected the dry-run request to be denied")
+	}
+
+	if status := getDenialStatus(t, handler.Client); status.DenialCount != 0 {
+		t.Fatalf("Expected no status write before the flush, got %d", status.DenialCount)
+	}
+
+	writer.Flush(context.Background())
+	status := getDenialStatus(t, handler.Client)
+	if status.DenialCount != 2 {
+		t.Errorf("Expected a denial count of 2, got %d", status.DenialCount)
+	}
+	if !strings.Contains(status.LastDenialReason, `"batch"`) {
+		t.Errorf("Expected the last reason to name the batch ServiceAccount, got %q", status.LastDenialReason)
+	}
+
+	// Later denials add to the persisted count
+	handler.Handle(context.Background(), serviceAccountRequest("frontend", false))
+	writer.Flush(context.Background())
+	status = getDenialStatus(t, handler.Client)
+	if status.DenialCount != 3 || !strings.Contains(status.LastDenialReason, `"frontend"`) {
+		t.Errorf("Expected 3 denials, the last for frontend, got %d: %q", status.DenialCount, status.LastDenialReason)
+	}
+}
+
+func TestDenialStatusWriter_RequeuesFailedWrites(t *testing.T) {
+	_, clientBuilder := setupTestPodHandler(t)
+	failing := true
+	c := clientBuilder.WithObjects(deniedZenLock()).WithStatusSubresource(&securityv1alpha1.ZenLock{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+				if failing {
+					return errors.New("api server unavailable")
+				}
+				return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+			},
+		}).Build()
+
+	queue := NewDenialQueue()
+	writer := NewDenialStatusWriter(c, queue, time.Minute)
+	queue.Record(deniedZenLockKey, "first")
+	writer.Flush(context.Background())
+
+	failing = false
+	queue.Record(deniedZenLockKey, "second")
+	writer.Flush(context.Background())
+
+	if status := getDenialStatus(t, c); status.DenialCount != 2 || status.LastDenialReason != "second" {
+		t.Errorf("Expected the failed update to be retried with the next one, got %d: %q", status.DenialCount, status.LastDenialReason)
+	}
+}
+
+func TestDenialQueue_TruncatesReason(t *testing.T) {
+	queue := NewDenialQueue()
+	queue.Record(deniedZenLockKey, strings.Repeat("x", 2*config.MaxDenialReasonLength))
+	if got := queue.drain()[deniedZenLockKey].lastReason; len(got) != config.MaxDenialReasonLength {
+		t.Errorf("Expected the reason truncated to %d bytes, got %d", config.MaxDenialReasonLength, len(got))
+	}
+}
+
+func TestDenialStatusWriter_DropsDeletedZenLocks(t *testing.T) {
+	_, clientBuilder := setupTestPodHandler(t)
+	queue := NewDenialQueue()
+	writer := NewDenialStatusWriter(clientBuilder.Build(), queue, time.Minute)
+
+	queue.Record(deniedZenLockKey, "denied")
+	writer.Flush(context.Background())
+	if pending := queue.drain(); len(pending) != 0 {
+		t.Errorf("Expected updates for a missing ZenLock to be dropped, got %+v", pending)
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+)
+
+// armorTestData returns a base64 value from encryptTestData as ASCII-armored age ciphertext
+func armorTestData(t *testing.T, encoded string) string {
+	ciphertext, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		t.Fatalf("Failed to decode: %v", err)
+	}
+	var buf bytes.Buffer
+	w := armor.NewWriter(&buf)
+	if _, err := w.Write(ciphertext); err != nil {
+		t.Fatalf("Failed to armor: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Failed to armor: %v", err)
+	}
+	return buf.String()
+}
+
+func TestPodHandler_Handle_Encoding(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	encoded := encryptTestData(t, "admin", identity.Recipient().String())
+
+	tests := []struct {
+		name     string
+		encoding string
+		value    string
+	}{
+		{name: "base64 default", encoding: "", value: encoded},
+		{name: "armor", encoding: "armor", value: armorTestData(t, encoded)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			zenlock := &securityv1alpha1.ZenLock{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-zenlock", Namespace: "default"},
+				Spec: securityv1alpha1.ZenLockSpec{
+					EncryptedData: map[string]string{"USERNAME": tt.value},
+					Encoding:      tt.encoding,
+				},
+			}
+			handler, clientBuilder := setupTestPodHandlerWithKey(t, identity.String())
+			handler.Client = clientBuilder.WithObjects(zenlock).Build()
+
+			if resp := handler.Handle(context.Background(), sharedNamingRequest("app-1", "")); !resp.Allowed {
+				t.Fatalf("Expected the Pod to be injected, got %v", resp.Result)
+			}
+			secret := &corev1.Secret{}
+			key := types.NamespacedName{Name: GenerateSecretName("default", "app-1"), Namespace: "default"}
+			if err := handler.Client.Get(context.Background(), key, secret); err != nil {
+				t.Fatalf("Expected the Secret to be created: %v", err)
+			}
+			if string(secret.Data["USERNAME"]) != "admin" {
+				t.Errorf("Expected USERNAME=admin, got %q", secret.Data["USERNAME"])
+			}
+		})
+	}
+}
+
+func TestZenLockValidator_Encoding(t *testing.T) {
+	handler, publicKey := subjectsTestValidator(t, "")
+	encoded := encryptTestData(t, "value", publicKey)
+
+	tests := []struct {
+		name      string
+		encoding  string
+		value     string
+		wantAllow bool
+	}{
+		{name: "base64 default", value: encoded, wantAllow: true},
+		{name: "base64", encoding: "base64", value: encoded, wantAllow: true},
+		{name: "armor", encoding: "armor", value: armorTestData(t, encoded), wantAllow: true},
+		{name: "base64 value marked armor", encoding: "armor", value: encoded, wantAllow: false},
+		{name: "armored value marked base64", encoding: "base64", value: armorTestData(t, encoded), wantAllow: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			zenlock := createTestZenLock(t, map[string]string{"key": tt.value}, "age", nil)
+			zenlock.Spec.Encoding = tt.encoding
+			resp := handler.Handle(context.Background(), zenlockRequest(t, zenlock))
+			if resp.Allowed != tt.wantAllow {
+				t.Errorf("Allowed = %v, want %v (%v)", resp.Allowed, tt.wantAllow, resp.Result)
+			}
+		})
+	}
+}
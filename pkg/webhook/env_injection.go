@@ -0,0 +1,102 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+)
+
+// envVarNamePattern matches the environment variable names zen-lock generates
+var envVarNamePattern = regexp.MustCompile(`^[A-Z_][A-Z0-9_]*$`)
+
+// envVarInvalidChars matches characters replaced by an underscore in generated names
+var envVarInvalidChars = regexp.MustCompile(`[^A-Z0-9_]`)
+
+// ValidateEnvPrefix validates the zen-lock/env-prefix annotation value (empty means no prefix)
+func ValidateEnvPrefix(prefix string) error {
+	if prefix != "" && !envVarNamePattern.MatchString(prefix) {
+		return fmt.Errorf("prefix %q must consist of uppercase letters, digits and underscores and not start with a digit", prefix)
+	}
+	return nil
+}
+
+// EnvVarName returns the environment variable exposing a ZenLock key: the prefix followed by the key
+// uppercased, with every character other than letters, digits and underscores replaced by an underscore
+func EnvVarName(prefix, key string) string {
+	return prefix + envVarInvalidChars.ReplaceAllString(strings.ToUpper(key), "_")
+}
+
+// applyEnvPrefix exposes every key of each target's ZenLock as an environment variable read from its Secret
+// Keys that cannot form a valid name, or that collide once transformed, are rejected rather than dropped.
+func applyEnvPrefix(targets []injectionTarget, zenlocks []*securityv1alpha1.ZenLock, prefix string) error {
+	if err := ValidateEnvPrefix(prefix); err != nil {
+		return err
+	}
+
+	sources := make(map[string]string)
+	for i := range targets {
+		keys := make([]string, 0, len(zenlocks[i].Spec.EncryptedData))
+		for key := range zenlocks[i].Spec.EncryptedData {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		env := make([]corev1.EnvVar, 0, len(keys))
+		for _, key := range keys {
+			name := EnvVarName(prefix, key)
+			if !envVarNamePattern.MatchString(name) {
+				return fmt.Errorf("key %q of ZenLock %q gives invalid environment variable name %q", key, targets[i].zenlockName, name)
+			}
+			source := targets[i].zenlockName + "/" + key
+			if other, ok := sources[name]; ok {
+				return fmt.Errorf("keys %q and %q both map to environment variable %q", other, source, name)
+			}
+			sources[name] = source
+			env = append(env, corev1.EnvVar{
+				Name: name,
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: targets[i].secretName},
+						Key:                  key,
+					},
+				},
+			})
+		}
+		targets[i].env = env
+	}
+	return nil
+}
+
+// addEnvVars appends the environment variables to a container, keeping any it already defines
+func addEnvVars(container *corev1.Container, env []corev1.EnvVar) {
+	existing := make(map[string]bool, len(container.Env))
+	for _, e := range container.Env {
+		existing[e.Name] = true
+	}
+	for _, e := range env {
+		if !existing[e.Name] {
+			container.Env = append(container.Env, e)
+		}
+	}
+}
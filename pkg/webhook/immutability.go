@@ -0,0 +1,72 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"os"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+// SecretsImmutableByDefault reports the operator-wide immutability default (ZEN_LOCK_IMMUTABLE_SECRETS=true)
+func SecretsImmutableByDefault() bool {
+	immutable, _ := strconv.ParseBool(os.Getenv("ZEN_LOCK_IMMUTABLE_SECRETS"))
+	return immutable
+}
+
+// ResolveSecretImmutability reports whether the Secrets injected for a ZenLock are immutable
+// Precedence: spec.immutable, then the namespace's zen-lock/default-immutable annotation, then globalDefault.
+// The Namespace is only read when the ZenLock leaves it unset; on error globalDefault is returned with the error.
+func ResolveSecretImmutability(ctx context.Context, c client.Reader, zenlock *securityv1alpha1.ZenLock, globalDefault bool) (bool, error) {
+	if zenlock.Spec.Immutable != nil {
+		return *zenlock.Spec.Immutable, nil
+	}
+
+	namespace := &corev1.Namespace{}
+	if err := c.Get(ctx, types.NamespacedName{Name: zenlock.Namespace}, namespace); err != nil {
+		return globalDefault, client.IgnoreNotFound(err)
+	}
+	if value, ok := namespace.Annotations[config.AnnotationDefaultImmutable]; ok {
+		if immutable, err := strconv.ParseBool(value); err == nil {
+			return immutable, nil
+		}
+	}
+	return globalDefault, nil
+}
+
+// SecretImmutable reports whether a Secret is marked immutable
+func SecretImmutable(secret *corev1.Secret) bool {
+	return secret.Immutable != nil && *secret.Immutable
+}
+
+// ReplaceSecret deletes existing and creates desired in its place
+// The API server rejects any change to an immutable Secret, so refreshing one means recreating it.
+func ReplaceSecret(ctx context.Context, c client.Client, existing, desired *corev1.Secret) error {
+	if err := c.Delete(ctx, existing); client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	replacement := desired.DeepCopy()
+	replacement.ResourceVersion = ""
+	return c.Create(ctx, replacement)
+}
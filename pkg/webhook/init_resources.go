@@ -0,0 +1,102 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"fmt"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+// initResourceSetting is one configurable resource quantity of injected init containers
+type initResourceSetting struct {
+	env          string
+	name         corev1.ResourceName
+	limit        bool
+	defaultValue string
+}
+
+var initResourceSettings = []initResourceSetting{
+	{env: "ZEN_LOCK_INIT_CPU_REQUEST", name: corev1.ResourceCPU, defaultValue: config.DefaultInitCPURequest},
+	{env: "ZEN_LOCK_INIT_MEMORY_REQUEST", name: corev1.ResourceMemory, defaultValue: config.DefaultInitMemoryRequest},
+	{env: "ZEN_LOCK_INIT_CPU_LIMIT", name: corev1.ResourceCPU, limit: true, defaultValue: config.DefaultInitCPULimit},
+	{env: "ZEN_LOCK_INIT_MEMORY_LIMIT", name: corev1.ResourceMemory, limit: true, defaultValue: config.DefaultInitMemoryLimit},
+}
+
+// parseInitResources returns the resources of injected init containers, from the environment or the defaults
+// A value of "0" leaves that request or limit unset.
+func parseInitResources() (corev1.ResourceRequirements, error) {
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{},
+		Limits:   corev1.ResourceList{},
+	}
+	for _, setting := range initResourceSettings {
+		value := os.Getenv(setting.env)
+		if value == "" {
+			value = setting.defaultValue
+		}
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return corev1.ResourceRequirements{}, fmt.Errorf("%s %q is invalid: %v", setting.env, value, err)
+		}
+		if quantity.IsZero() {
+			continue
+		}
+		if setting.limit {
+			resources.Limits[setting.name] = quantity
+		} else {
+			resources.Requests[setting.name] = quantity
+		}
+	}
+
+	// The API server rejects Pods whose requests exceed their limits
+	for name, request := range resources.Requests {
+		if limit, ok := resources.Limits[name]; ok && request.Cmp(limit) > 0 {
+			return corev1.ResourceRequirements{}, fmt.Errorf("init container %s request %s exceeds its limit %s", name, request.String(), limit.String())
+		}
+	}
+	return resources, nil
+}
+
+// ValidateInitResources rejects invalid ZEN_LOCK_INIT_* resource settings, so injected Pods are never rejected for them
+func ValidateInitResources() error {
+	_, err := parseInitResources()
+	return err
+}
+
+// getInitResources returns the resources of injected init containers
+// Settings are checked at startup; should they be invalid anyway, the defaults apply.
+func getInitResources() corev1.ResourceRequirements {
+	resources, err := parseInitResources()
+	if err != nil {
+		return corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(config.DefaultInitCPURequest),
+				corev1.ResourceMemory: resource.MustParse(config.DefaultInitMemoryRequest),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(config.DefaultInitCPULimit),
+				corev1.ResourceMemory: resource.MustParse(config.DefaultInitMemoryLimit),
+			},
+		}
+	}
+	return resources
+}
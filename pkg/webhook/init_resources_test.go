@@ -0,0 +1,112 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+// injectInitContainer mutates a single-container Pod for target and returns the injected init container
+func injectInitContainer(t *testing.T, target injectionTarget) corev1.Container {
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "nginx"}}}}
+	if err := (&PodHandler{}).mutatePodForTarget(pod, target); err != nil {
+		t.Fatalf("mutatePodForTarget failed: %v", err)
+	}
+	if len(pod.Spec.InitContainers) != 1 {
+		t.Fatalf("Expected one injected init container, got %+v", pod.Spec.InitContainers)
+	}
+	return pod.Spec.InitContainers[0]
+}
+
+func assertQuantity(t *testing.T, list corev1.ResourceList, name corev1.ResourceName, want string) {
+	t.Helper()
+	got, ok := list[name]
+	if want == "" {
+		if ok {
+			t.Errorf("Expected no %s, got %s", name, got.String())
+		}
+		return
+	}
+	if !ok || got.String() != want {
+		t.Errorf("Expected %s %s, got %s", name, want, got.String())
+	}
+}
+
+func TestInitContainerResources_Defaults(t *testing.T) {
+	targets := []injectionTarget{{
+		zenlockName: "db-credentials",
+		secretName:  "zen-lock-inject-default-app",
+		volumeName:  config.DefaultVolumeName,
+		mountPath:   config.DefaultMountPath,
+	}}
+	applyMountWritable(targets)
+	initContainer := injectInitContainer(t, targets[0])
+
+	assertQuantity(t, initContainer.Resources.Requests, corev1.ResourceCPU, config.DefaultInitCPURequest)
+	assertQuantity(t, initContainer.Resources.Requests, corev1.ResourceMemory, config.DefaultInitMemoryRequest)
+	assertQuantity(t, initContainer.Resources.Limits, corev1.ResourceCPU, config.DefaultInitCPULimit)
+	assertQuantity(t, initContainer.Resources.Limits, corev1.ResourceMemory, config.DefaultInitMemoryLimit)
+}
+
+func TestInitContainerResources_Configured(t *testing.T) {
+	t.Setenv("ZEN_LOCK_INIT_CPU_REQUEST", "50m")
+	t.Setenv("ZEN_LOCK_INIT_MEMORY_REQUEST", "32Mi")
+	t.Setenv("ZEN_LOCK_INIT_CPU_LIMIT", "0")
+	t.Setenv("ZEN_LOCK_INIT_MEMORY_LIMIT", "128Mi")
+
+	targets := []injectionTarget{{
+		zenlockName: "db-credentials",
+		secretName:  "zen-lock-inject-default-app",
+		volumeName:  config.DefaultVolumeName,
+		mountPath:   config.DefaultMountPath,
+	}}
+	applyMountWritable(targets)
+	copier := injectInitContainer(t, targets[0])
+
+	assertQuantity(t, copier.Resources.Requests, corev1.ResourceCPU, "50m")
+	assertQuantity(t, copier.Resources.Requests, corev1.ResourceMemory, "32Mi")
+	assertQuantity(t, copier.Resources.Limits, corev1.ResourceCPU, "")
+	assertQuantity(t, copier.Resources.Limits, corev1.ResourceMemory, "128Mi")
+}
+
+func TestValidateInitResources(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr bool
+	}{
+		{name: "defaults", wantErr: false},
+		{name: "valid override", env: map[string]string{"ZEN_LOCK_INIT_MEMORY_LIMIT": "1Gi"}, wantErr: false},
+		{name: "unparsable quantity", env: map[string]string{"ZEN_LOCK_INIT_CPU_REQUEST": "lots"}, wantErr: true},
+		{name: "request above limit", env: map[string]string{"ZEN_LOCK_INIT_MEMORY_REQUEST": "1Gi"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			if err := ValidateInitResources(); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateInitResources() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
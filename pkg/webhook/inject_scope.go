@@ -0,0 +1,63 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+// isNativeSidecar reports whether an init container is a native sidecar (restartPolicy Always)
+// Native sidecars start with the init containers but keep running alongside the main containers.
+func isNativeSidecar(container *corev1.Container) bool {
+	return container.RestartPolicy != nil && *container.RestartPolicy == corev1.ContainerRestartPolicyAlways
+}
+
+// scopedContainers returns the Pod's containers that a zen-lock/inject-scope value selects
+func scopedContainers(pod *corev1.Pod, scope string) []*corev1.Container {
+	var containers []*corev1.Container
+	if scope == "" || scope == config.InjectScopeAll || scope == config.InjectScopeMainOnly {
+		for i := range pod.Spec.Containers {
+			containers = append(containers, &pod.Spec.Containers[i])
+		}
+	}
+	for i := range pod.Spec.InitContainers {
+		c := &pod.Spec.InitContainers[i]
+		switch {
+		case scope == "" || scope == config.InjectScopeAll,
+			scope == config.InjectScopeInitOnly && !isNativeSidecar(c),
+			scope == config.InjectScopeSidecarOnly && isNativeSidecar(c):
+			containers = append(containers, c)
+		}
+	}
+	return containers
+}
+
+// applyInjectScope restricts every target to the containers selected by scope
+// A scope selecting none of the Pod's containers is rejected rather than injecting nothing.
+func applyInjectScope(targets []injectionTarget, pod *corev1.Pod, scope string) error {
+	if len(scopedContainers(pod, scope)) == 0 {
+		return fmt.Errorf("the Pod has no containers in scope %q", scope)
+	}
+	for i := range targets {
+		targets[i].scope = scope
+	}
+	return nil
+}
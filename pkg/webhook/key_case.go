@@ -0,0 +1,86 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+// KeyCase returns the ZenLock's zen-lock/key-case, or KeyCaseNone when the annotation is unset
+func KeyCase(zenlock *securityv1alpha1.ZenLock) (string, error) {
+	value := strings.ToLower(strings.TrimSpace(zenlock.GetAnnotations()[config.AnnotationKeyCase]))
+	switch value {
+	case "", config.KeyCaseNone:
+		return config.KeyCaseNone, nil
+	case config.KeyCaseUpper, config.KeyCaseLower:
+		return value, nil
+	default:
+		return "", fmt.Errorf("invalid %s %q: must be %q, %q or %q", config.AnnotationKeyCase, value, config.KeyCaseUpper, config.KeyCaseLower, config.KeyCaseNone)
+	}
+}
+
+// KeyCaseNames returns the name of each key after case normalization
+// Keys that normalize to the same name are an error, as one would silently overwrite the other.
+func KeyCaseNames(keys []string, keyCase string) (map[string]string, error) {
+	sorted := append([]string(nil), keys...)
+	sort.Strings(sorted)
+
+	names := make(map[string]string, len(sorted))
+	used := make(map[string]string, len(sorted))
+	for _, key := range sorted {
+		name := key
+		switch keyCase {
+		case config.KeyCaseUpper:
+			name = strings.ToUpper(key)
+		case config.KeyCaseLower:
+			name = strings.ToLower(key)
+		}
+		if other, taken := used[name]; taken {
+			return nil, fmt.Errorf("keys %q and %q both normalize to %q", other, key, name)
+		}
+		used[name] = key
+		names[key] = name
+	}
+	return names, nil
+}
+
+// ApplyKeyCase renames the keys of decrypted data according to the ZenLock's zen-lock/key-case
+// The data is returned unchanged when the annotation is unset or "none".
+func ApplyKeyCase(zenlock *securityv1alpha1.ZenLock, data map[string][]byte) (map[string][]byte, error) {
+	keyCase, err := KeyCase(zenlock)
+	if err != nil || keyCase == config.KeyCaseNone {
+		return data, err
+	}
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	names, err := KeyCaseNames(keys, keyCase)
+	if err != nil {
+		return nil, err
+	}
+	renamed := make(map[string][]byte, len(data))
+	for key, value := range data {
+		renamed[names[key]] = value
+	}
+	return renamed, nil
+}
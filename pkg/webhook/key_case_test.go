@@ -0,0 +1,131 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+// keyCaseTestHandler returns a handler for a ZenLock with the given plaintext data and zen-lock/key-case annotation
+func keyCaseTestHandler(t *testing.T, keyCase string, plain map[string]string) *PodHandler {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	data := make(map[string]string, len(plain))
+	for key, value := range plain {
+		data[key] = encryptTestData(t, value, identity.Recipient().String())
+	}
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-zenlock", Namespace: "default"},
+		Spec:       securityv1alpha1.ZenLockSpec{EncryptedData: data},
+	}
+	if keyCase != "" {
+		zenlock.Annotations = map[string]string{config.AnnotationKeyCase: keyCase}
+	}
+
+	handler, clientBuilder := setupTestPodHandlerWithKey(t, identity.String())
+	handler.Client = clientBuilder.WithObjects(zenlock).Build()
+	return handler
+}
+
+func TestPodHandler_Handle_KeyCase(t *testing.T) {
+	tests := []struct {
+		name     string
+		keyCase  string
+		wantKeys []string
+	}{
+		{name: "upper", keyCase: "upper", wantKeys: []string{"DB_USER", "API.KEY"}},
+		{name: "lower", keyCase: "lower", wantKeys: []string{"db_user", "api.key"}},
+		{name: "default", keyCase: "", wantKeys: []string{"db_user", "Api.Key"}},
+		{name: "none", keyCase: "none", wantKeys: []string{"db_user", "Api.Key"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := keyCaseTestHandler(t, tt.keyCase, map[string]string{"db_user": "admin", "Api.Key": "secret"})
+
+			resp := handler.Handle(context.Background(), sharedNamingRequest("app-1", ""))
+			if !resp.Allowed {
+				t.Fatalf("Expected the Pod to be injected, got %v", resp.Result)
+			}
+
+			secret := &corev1.Secret{}
+			key := types.NamespacedName{Name: GenerateSecretName("default", "app-1"), Namespace: "default"}
+			if err := handler.Client.Get(context.Background(), key, secret); err != nil {
+				t.Fatalf("Expected the Secret to be created: %v", err)
+			}
+			if len(secret.Data) != len(tt.wantKeys) {
+				t.Fatalf("Expected keys %v, got %v", tt.wantKeys, secret.Data)
+			}
+			for _, want := range tt.wantKeys {
+				if _, ok := secret.Data[want]; !ok {
+					t.Errorf("Expected key %q, got %v", want, secret.Data)
+				}
+			}
+		})
+	}
+}
+
+func TestPodHandler_Handle_KeyCaseCollision(t *testing.T) {
+	handler := keyCaseTestHandler(t, "upper", map[string]string{"Key": "a", "key": "b"})
+
+	resp := handler.Handle(context.Background(), sharedNamingRequest("app-1", ""))
+	if resp.Allowed {
+		t.Fatal("Expected keys colliding after normalization to be denied")
+	}
+	if !strings.Contains(resp.Result.Message, `both normalize to "KEY"`) {
+		t.Errorf("Expected the denial to explain the collision, got %q", resp.Result.Message)
+	}
+}
+
+func TestZenLockValidator_KeyCase(t *testing.T) {
+	handler, publicKey := subjectsTestValidator(t, "")
+	data := map[string]string{
+		"Key": encryptTestData(t, "a", publicKey),
+		"key": encryptTestData(t, "b", publicKey),
+	}
+
+	tests := []struct {
+		name      string
+		keyCase   string
+		wantAllow bool
+	}{
+		{name: "none", keyCase: "none", wantAllow: true},
+		{name: "collision", keyCase: "upper", wantAllow: false},
+		{name: "invalid value", keyCase: "title", wantAllow: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			zenlock := createTestZenLock(t, data, "age", nil)
+			zenlock.Annotations = map[string]string{config.AnnotationKeyCase: tt.keyCase}
+			resp := handler.Handle(context.Background(), zenlockRequest(t, zenlock))
+			if resp.Allowed != tt.wantAllow {
+				t.Errorf("Allowed = %v, want %v (%v)", resp.Allowed, tt.wantAllow, resp.Result)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+// KeyExtensions parses the ZenLock's zen-lock/key-extensions annotation
+// Value: comma-separated <key>=<extension> pairs, e.g. "tls.crt=.pem,settings=.json".
+// Returns nil when the annotation is unset.
+func KeyExtensions(zenlock *securityv1alpha1.ZenLock) (map[string]string, error) {
+	value := strings.TrimSpace(zenlock.GetAnnotations()[config.AnnotationKeyExtensions])
+	if value == "" {
+		return nil, nil
+	}
+	extensions := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		key, extension, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		key = strings.TrimSpace(key)
+		extension = strings.TrimSpace(extension)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid %s entry %q: must be <key>=<extension>", config.AnnotationKeyExtensions, pair)
+		}
+		if len(extension) < 2 || !strings.HasPrefix(extension, ".") {
+			return nil, fmt.Errorf("invalid %s extension %q for key %q: must start with a dot", config.AnnotationKeyExtensions, extension, key)
+		}
+		if _, dup := extensions[key]; dup {
+			return nil, fmt.Errorf("invalid %s: key %q is listed more than once", config.AnnotationKeyExtensions, key)
+		}
+		extensions[key] = extension
+	}
+	return extensions, nil
+}
+
+// KeyExtensionNames returns the Secret key (mounted file name) of each key after appending its extension
+// Keys without an extension keep their name. Resulting names must be valid Secret keys and distinct.
+func KeyExtensionNames(keys []string, extensions map[string]string) (map[string]string, error) {
+	sorted := append([]string(nil), keys...)
+	sort.Strings(sorted)
+
+	names := make(map[string]string, len(sorted))
+	used := make(map[string]string, len(sorted))
+	for _, key := range sorted {
+		name := key + extensions[key]
+		if errs := validation.IsConfigMapKey(name); len(errs) > 0 {
+			return nil, fmt.Errorf("key %q with extension %q is not a valid file name: %s", key, extensions[key], strings.Join(errs, "; "))
+		}
+		if other, taken := used[name]; taken {
+			return nil, fmt.Errorf("keys %q and %q both map to file name %q", other, key, name)
+		}
+		used[name] = key
+		names[key] = name
+	}
+	return names, nil
+}
+
+// ApplyKeyExtensions renames the keys of decrypted data according to the ZenLock's zen-lock/key-extensions
+// The data is returned unchanged when the annotation is unset.
+func ApplyKeyExtensions(zenlock *securityv1alpha1.ZenLock, data map[string][]byte) (map[string][]byte, error) {
+	extensions, err := KeyExtensions(zenlock)
+	if err != nil || len(extensions) == 0 {
+		return data, err
+	}
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	names, err := KeyExtensionNames(keys, extensions)
+	if err != nil {
+		return nil, err
+	}
+	renamed := make(map[string][]byte, len(data))
+	for key, value := range data {
+		renamed[names[key]] = value
+	}
+	return renamed, nil
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+// keyExtensionsTestHandler returns a handler for a ZenLock with tls.crt and USERNAME and the given key-extensions annotation
+func keyExtensionsTestHandler(t *testing.T, extensions string) *PodHandler {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-zenlock",
+			Namespace:   "default",
+			Annotations: map[string]string{config.AnnotationKeyExtensions: extensions},
+		},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{
+				"tls.crt":  encryptTestData(t, "cert", identity.Recipient().String()),
+				"USERNAME": encryptTestData(t, "admin", identity.Recipient().String()),
+			},
+		},
+	}
+
+	handler, clientBuilder := setupTestPodHandlerWithKey(t, identity.String())
+	handler.Client = clientBuilder.WithObjects(zenlock).Build()
+	return handler
+}
+
+func TestPodHandler_Handle_KeyExtensions(t *testing.T) {
+	handler := keyExtensionsTestHandler(t, "tls.crt=.pem")
+
+	resp := handler.Handle(context.Background(), sharedNamingRequest("app-1", ""))
+	if !resp.Allowed {
+		t.Fatalf("Expected the Pod to be injected, got %v", resp.Result)
+	}
+
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Name: GenerateSecretName("default", "app-1"), Namespace: "default"}
+	if err := handler.Client.Get(context.Background(), key, secret); err != nil {
+		t.Fatalf("Expected the Secret to be created: %v", err)
+	}
+	if string(secret.Data["tls.crt.pem"]) != "cert" {
+		t.Errorf("Expected tls.crt to be written as tls.crt.pem, got keys %v", secret.Data)
+	}
+	if _, ok := secret.Data["tls.crt"]; ok {
+		t.Error("Expected tls.crt to be renamed")
+	}
+	if string(secret.Data["USERNAME"]) != "admin" {
+		t.Errorf("Expected USERNAME without an extension to be left as is, got keys %v", secret.Data)
+	}
+}
+
+func TestPodHandler_Handle_KeyExtensionsInvalidFileName(t *testing.T) {
+	handler := keyExtensionsTestHandler(t, "USERNAME=./passwd")
+
+	resp := handler.Handle(context.Background(), sharedNamingRequest("app-1", ""))
+	if resp.Allowed {
+		t.Fatal("Expected an invalid resulting file name to be denied")
+	}
+	if !strings.Contains(resp.Result.Message, "not a valid file name") {
+		t.Errorf("Expected the denial to explain the file name, got %q", resp.Result.Message)
+	}
+}
+
+func TestKeyExtensions(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    map[string]string
+		wantErr bool
+	}{
+		{name: "unset", value: "", want: nil},
+		{name: "pairs", value: "tls.crt=.pem, settings=.json", want: map[string]string{"tls.crt": ".pem", "settings": ".json"}},
+		{name: "missing dot", value: "tls.crt=pem", wantErr: true},
+		{name: "missing extension", value: "tls.crt", wantErr: true},
+		{name: "duplicate key", value: "a=.pem,a=.json", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			zenlock := &securityv1alpha1.ZenLock{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{config.AnnotationKeyExtensions: tt.value},
+			}}
+			got, err := KeyExtensions(zenlock)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("KeyExtensions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("KeyExtensions() = %v, want %v", got, tt.want)
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Errorf("KeyExtensions()[%q] = %q, want %q", k, got[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestKeyExtensionNames_Collision(t *testing.T) {
+	if _, err := KeyExtensionNames([]string{"cert", "cert.pem"}, map[string]string{"cert": ".pem"}); err == nil {
+		t.Error("Expected an error when two keys map to the same file name")
+	}
+}
+
+func TestZenLockValidator_KeyExtensions(t *testing.T) {
+	handler, publicKey := subjectsTestValidator(t, "")
+	data := map[string]string{"tls.crt": encryptTestData(t, "cert", publicKey)}
+
+	zenlock := createTestZenLock(t, data, "age", nil)
+	zenlock.Annotations = map[string]string{config.AnnotationKeyExtensions: "tls.crt=.pem"}
+	if resp := handler.Handle(context.Background(), zenlockRequest(t, zenlock)); !resp.Allowed {
+		t.Errorf("Expected valid key extensions to be allowed, got %v", resp.Result)
+	}
+
+	zenlock.Annotations = map[string]string{config.AnnotationKeyExtensions: "tls.crt=./x"}
+	if resp := handler.Handle(context.Background(), zenlockRequest(t, zenlock)); resp.Allowed {
+		t.Error("Expected an invalid resulting file name to be denied")
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+// applyProjectMetadata switches every target to a projected volume that adds the Pod's name and namespace
+// A ZenLock key named like a metadata file would make the kubelet fail to mount the volume, so it is rejected.
+func applyProjectMetadata(targets []injectionTarget, zenlocks []*securityv1alpha1.ZenLock) error {
+	for i := range targets {
+		for _, file := range []string{config.MetadataFilePodName, config.MetadataFilePodNamespace} {
+			if _, ok := zenlocks[i].Spec.EncryptedData[file]; ok {
+				return fmt.Errorf("key %q of ZenLock %q collides with the projected Pod metadata", file, targets[i].zenlockName)
+			}
+		}
+		targets[i].projectMetadata = true
+	}
+	return nil
+}
+
+// projectedMetadataVolumeSource projects the Secret's keys and the Pod's name and namespace into one volume
+func projectedMetadataVolumeSource(secretName string) corev1.VolumeSource {
+	return corev1.VolumeSource{
+		Projected: &corev1.ProjectedVolumeSource{
+			Sources: []corev1.VolumeProjection{
+				{
+					Secret: &corev1.SecretProjection{
+						LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+					},
+				},
+				podMetadataProjection(),
+			},
+		},
+	}
+}
+
+// podMetadataProjection projects the Pod's name and namespace as files
+func podMetadataProjection() corev1.VolumeProjection {
+	return corev1.VolumeProjection{
+		DownwardAPI: &corev1.DownwardAPIProjection{
+			Items: []corev1.DownwardAPIVolumeFile{
+				{Path: config.MetadataFilePodName, FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"}},
+				{Path: config.MetadataFilePodNamespace, FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"}},
+			},
+		},
+	}
+}
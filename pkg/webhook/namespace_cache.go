@@ -0,0 +1,116 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+// NamespaceCache caches Namespace objects for a short TTL
+// Admissions read the Pod's Namespace for its zen-lock annotations; the cache saves an API
+// request per admission when many Pods are created in the same namespace.
+type NamespaceCache struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]namespaceCacheEntry
+	hits    int64
+	misses  int64
+}
+
+type namespaceCacheEntry struct {
+	namespace *corev1.Namespace
+	expiresAt time.Time
+}
+
+// NamespaceCacheTTLFromEnv returns ZEN_LOCK_NAMESPACE_CACHE_TTL, or the default when unset or invalid
+// A TTL of 0 disables the cache.
+func NamespaceCacheTTLFromEnv() time.Duration {
+	if ttlStr := os.Getenv("ZEN_LOCK_NAMESPACE_CACHE_TTL"); ttlStr != "" {
+		if parsedTTL, err := time.ParseDuration(ttlStr); err == nil && parsedTTL >= 0 {
+			return parsedTTL
+		}
+	}
+	return config.DefaultNamespaceCacheTTL
+}
+
+// NewNamespaceCache creates a Namespace cache with the given TTL
+func NewNamespaceCache(ttl time.Duration) *NamespaceCache {
+	return &NamespaceCache{
+		ttl:     ttl,
+		entries: make(map[string]namespaceCacheEntry),
+	}
+}
+
+// Get returns the named Namespace, reading it through reader when it is not cached or has expired
+// Errors are not cached. The returned object is a copy.
+func (c *NamespaceCache) Get(ctx context.Context, reader client.Reader, name string) (*corev1.Namespace, error) {
+	now := time.Now()
+	c.mu.Lock()
+	if entry, ok := c.entries[name]; ok && now.Before(entry.expiresAt) {
+		c.hits++
+		c.mu.Unlock()
+		return entry.namespace.DeepCopy(), nil
+	}
+	c.misses++
+	c.mu.Unlock()
+
+	namespace := &corev1.Namespace{}
+	if err := reader.Get(ctx, types.NamespacedName{Name: name}, namespace); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.entries[name] = namespaceCacheEntry{namespace: namespace.DeepCopy(), expiresAt: now.Add(c.ttl)}
+	c.mu.Unlock()
+	return namespace, nil
+}
+
+// Stats returns the number of cache hits and misses
+func (c *NamespaceCache) Stats() (hits, misses int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits, c.misses
+}
+
+// cachedNamespaceReader serves Namespace gets from a NamespaceCache and delegates everything else
+type cachedNamespaceReader struct {
+	client.Reader
+	cache *NamespaceCache
+}
+
+// Get implements client.Reader
+func (r cachedNamespaceReader) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	namespace, ok := obj.(*corev1.Namespace)
+	if !ok || len(opts) > 0 {
+		return r.Reader.Get(ctx, key, obj, opts...)
+	}
+	cached, err := r.cache.Get(ctx, r.Reader, key.Name)
+	if err != nil {
+		return err
+	}
+	cached.DeepCopyInto(namespace)
+	return nil
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"filippo.io/age"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+)
+
+func TestPodHandler_Handle_NamespaceCacheHit(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-zenlock", Namespace: "default"},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{"USERNAME": encryptTestData(t, "admin", identity.Recipient().String())},
+		},
+	}
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
+
+	namespaceGets := 0
+	handler, clientBuilder := setupTestPodHandlerWithKey(t, identity.String())
+	handler.Client = clientBuilder.WithObjects(zenlock, namespace).WithInterceptorFuncs(interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if _, ok := obj.(*corev1.Namespace); ok {
+				namespaceGets++
+			}
+			return c.Get(ctx, key, obj, opts...)
+		},
+	}).Build()
+	handler.namespaces = NewNamespaceCache(time.Minute)
+
+	for _, podName := range []string{"app-1", "app-2"} {
+		if resp := handler.Handle(context.Background(), sharedNamingRequest(podName, "")); !resp.Allowed {
+			t.Fatalf("Expected %s to be injected, got %v", podName, resp.Result)
+		}
+	}
+
+	if namespaceGets != 1 {
+		t.Errorf("Expected the Namespace to be fetched once, got %d gets", namespaceGets)
+	}
+	if hits, _ := handler.namespaces.Stats(); hits == 0 {
+		t.Error("Expected the second admission to hit the Namespace cache")
+	}
+}
+
+func TestNamespaceCache_Expires(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Failed to add corev1 to scheme: %v", err)
+	}
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:        "team-a",
+		Annotations: map[string]string{"example": "v1"},
+	}}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(namespace).Build()
+
+	cache := NewNamespaceCache(50 * time.Millisecond)
+	ctx := context.Background()
+	if _, err := cache.Get(ctx, c, "team-a"); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+
+	// Changes are not seen until the entry expires
+	namespace.Annotations["example"] = "v2"
+	if err := c.Update(ctx, namespace); err != nil {
+		t.Fatalf("Failed to update Namespace: %v", err)
+	}
+	cached, err := cache.Get(ctx, c, "team-a")
+	if err != nil || cached.Annotations["example"] != "v1" {
+		t.Fatalf("Expected the cached Namespace, got %v, %v", cached, err)
+	}
+	if hits, misses := cache.Stats(); hits != 1 || misses != 1 {
+		t.Errorf("Expected 1 hit and 1 miss, got %d and %d", hits, misses)
+	}
+
+	time.Sleep(80 * time.Millisecond)
+	refreshed, err := cache.Get(ctx, c, "team-a")
+	if err != nil || refreshed.Annotations["example"] != "v2" {
+		t.Fatalf("Expected the Namespace to be refetched after the TTL, got %v, %v", refreshed, err)
+	}
+	if _, misses := cache.Stats(); misses != 2 {
+		t.Errorf("Expected the expired entry to count as a miss, got %d misses", misses)
+	}
+
+	// Errors are not cached
+	if _, err := cache.Get(ctx, c, "missing"); err == nil {
+		t.Error("Expected an error for a missing Namespace")
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kube-zen/zen-lock/pkg/common"
+	"github.com/kube-zen/zen-lock/pkg/config"
+	"github.com/kube-zen/zen-lock/pkg/controller/metrics"
+)
+
+// Decisions recorded by zenlock_webhook_observed_total
+const (
+	observedDecisionInject = "inject"
+	observedDecisionDeny   = "deny"
+	observedDecisionSkip   = "skip"
+)
+
+// WebhookMode returns the configured webhook mode (ZEN_LOCK_MODE, enforce by default)
+func WebhookMode() string {
+	if mode := strings.ToLower(strings.TrimSpace(os.Getenv("ZEN_LOCK_MODE"))); mode != "" {
+		return mode
+	}
+	return config.WebhookModeEnforce
+}
+
+// ValidateWebhookMode rejects an unknown ZEN_LOCK_MODE, so a typo never silently enforces
+func ValidateWebhookMode() error {
+	switch mode := WebhookMode(); mode {
+	case config.WebhookModeEnforce, config.WebhookModeObserve:
+		return nil
+	default:
+		return fmt.Errorf("ZEN_LOCK_MODE %q is invalid: must be %q or %q", mode, config.WebhookModeEnforce, config.WebhookModeObserve)
+	}
+}
+
+// handleObserve evaluates a request as a dry run and admits the Pod unchanged
+// Decryption and the would-be mutation are computed as usual, but dry-run skips every side effect
+// (Secrets, Events, denial counts, audit entries); only logs and metrics record the decision.
+func (h *PodHandler) handleObserve(ctx context.Context, req admission.Request) admission.Response {
+	dryRun := true
+	req.DryRun = &dryRun
+	resp := h.handle(ctx, req)
+
+	decision := observedDecisionSkip
+	message := ""
+	switch {
+	case !resp.Allowed:
+		decision = observedDecisionDeny
+		if resp.Result != nil {
+			message = common.RedactMessage(resp.Result.Message)
+		}
+	case len(resp.Patches) > 0:
+		decision = observedDecisionInject
+	}
+	metrics.RecordWebhookObserved(req.Namespace, decision)
+	if decision != observedDecisionSkip {
+		log.FromContext(ctx).Info("Observe mode: admitting Pod unchanged", "namespace", req.Namespace, "name", req.Name, "decision", decision, "reason", message)
+	}
+
+	return admission.Allowed(fmt.Sprintf("zen-lock observe mode: would %s", decision))
+}
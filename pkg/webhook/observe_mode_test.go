@@ -0,0 +1,117 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
+	"github.com/kube-zen/zen-lock/pkg/controller/metrics"
+)
+
+// observeTestHandler returns an observe-mode handler serving a decryptable ZenLock restricted to subjects
+func observeTestHandler(t *testing.T, subjects []securityv1alpha1.SubjectReference) (*PodHandler, *record.FakeRecorder) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-zenlock", Namespace: "default"},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData:   map[string]string{"USERNAME": encryptTestData(t, "admin", identity.Recipient().String())},
+			AllowedSubjects: subjects,
+		},
+	}
+
+	handler, clientBuilder := setupTestPodHandlerWithKey(t, identity.String())
+	handler.Client = clientBuilder.WithObjects(zenlock).Build()
+	handler.observe = true
+	recorder := record.NewFakeRecorder(10)
+	handler.Recorder = recorder
+	handler.Denials = NewDenialQueue()
+	return handler, recorder
+}
+
+// assertNoSideEffects fails if the handler wrote a Secret, an Event or a denial
+func assertNoSideEffects(t *testing.T, handler *PodHandler, recorder *record.FakeRecorder) {
+	t.Helper()
+	secrets := &corev1.SecretList{}
+	if err := handler.Client.List(context.Background(), secrets); err != nil {
+		t.Fatalf("Failed to list secrets: %v", err)
+	}
+	if len(secrets.Items) != 0 {
+		t.Errorf("Expected no Secret in observe mode, got %d", len(secrets.Items))
+	}
+	if len(recorder.Events) != 0 {
+		t.Errorf("Expected no Events in observe mode, got %q", <-recorder.Events)
+	}
+	if pending := handler.Denials.drain(); len(pending) != 0 {
+		t.Errorf("Expected no recorded denials in observe mode, got %+v", pending)
+	}
+}
+
+func TestPodHandler_Handle_ObserveInject(t *testing.T) {
+	handler, recorder := observeTestHandler(t, nil)
+	before := testutil.ToFloat64(metrics.WebhookObservedTotal.WithLabelValues("default", config.WebhookModeObserve, observedDecisionInject))
+
+	resp := handler.Handle(context.Background(), sharedNamingRequest("app-1", ""))
+	if !resp.Allowed {
+		t.Fatalf("Expected observe mode to admit the Pod, got %v", resp.Result)
+	}
+	if len(resp.Patches) != 0 || resp.Patch != nil {
+		t.Errorf("Expected no patch in observe mode, got %+v", resp.Patches)
+	}
+	assertNoSideEffects(t, handler, recorder)
+
+	if got := testutil.ToFloat64(metrics.WebhookObservedTotal.WithLabelValues("default", config.WebhookModeObserve, observedDecisionInject)) - before; got != 1 {
+		t.Errorf("Expected one observed injection, got %v", got)
+	}
+}
+
+func TestPodHandler_Handle_ObserveDeny(t *testing.T) {
+	handler, recorder := observeTestHandler(t, []securityv1alpha1.SubjectReference{
+		{Kind: "ServiceAccount", Name: "other", Namespace: "default"},
+	})
+	before := testutil.ToFloat64(metrics.WebhookObservedTotal.WithLabelValues("default", config.WebhookModeObserve, observedDecisionDeny))
+
+	resp := handler.Handle(context.Background(), sharedNamingRequest("app-1", ""))
+	if !resp.Allowed || len(resp.Patches) != 0 {
+		t.Fatalf("Expected observe mode to admit a Pod it would deny, unchanged, got %v", resp.Result)
+	}
+	assertNoSideEffects(t, handler, recorder)
+
+	if got := testutil.ToFloat64(metrics.WebhookObservedTotal.WithLabelValues("default", config.WebhookModeObserve, observedDecisionDeny)) - before; got != 1 {
+		t.Errorf("Expected one observed denial, got %v", got)
+	}
+}
+
+func TestValidateWebhookMode(t *testing.T) {
+	for mode, wantErr := range map[string]bool{"": false, "enforce": false, "Observe": false, "audit": true} {
+		t.Setenv("ZEN_LOCK_MODE", mode)
+		if err := ValidateWebhookMode(); (err != nil) != wantErr {
+			t.Errorf("ValidateWebhookMode(%q) error = %v, wantErr %v", mode, err, wantErr)
+		}
+	}
+}
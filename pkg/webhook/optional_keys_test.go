@@ -0,0 +1,135 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/crypto"
+)
+
+// optionalKeysTestHandler returns a handler for a ZenLock whose DEBUG_TOKEN is encrypted to another identity
+func optionalKeysTestHandler(t *testing.T, optionalKeys []string) (*PodHandler, *record.FakeRecorder) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	other, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-zenlock", Namespace: "default"},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{
+				"USERNAME":    encryptTestData(t, "admin", identity.Recipient().String()),
+				"DEBUG_TOKEN": encryptTestData(t, "token", other.Recipient().String()),
+			},
+			Checksums:    map[string]string{"DEBUG_TOKEN": crypto.Checksum([]byte("token"))},
+			OptionalKeys: optionalKeys,
+		},
+	}
+
+	handler, clientBuilder := setupTestPodHandlerWithKey(t, identity.String())
+	handler.Client = clientBuilder.WithObjects(zenlock).Build()
+	recorder := record.NewFakeRecorder(10)
+	handler.Recorder = recorder
+	return handler, recorder
+}
+
+func TestPodHandler_Handle_OptionalKeyFailureIsOmitted(t *testing.T) {
+	handler, recorder := optionalKeysTestHandler(t, []string{"DEBUG_TOKEN"})
+
+	resp := handler.Handle(context.Background(), sharedNamingRequest("app-1", ""))
+	if !resp.Allowed || len(resp.Patches) == 0 {
+		t.Fatalf("Expected the Pod to be injected, got %v", resp.Result)
+	}
+	if len(resp.Warnings) != 1 || !strings.Contains(resp.Warnings[0], `"DEBUG_TOKEN"`) {
+		t.Errorf("Expected a warning naming the omitted key, got %v", resp.Warnings)
+	}
+
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Name: GenerateSecretName("default", "app-1"), Namespace: "default"}
+	if err := handler.Client.Get(context.Background(), key, secret); err != nil {
+		t.Fatalf("Expected the Secret to be created: %v", err)
+	}
+	if string(secret.Data["USERNAME"]) != "admin" {
+		t.Errorf("Expected USERNAME to be injected, got %q", secret.Data["USERNAME"])
+	}
+	if _, ok := secret.Data["DEBUG_TOKEN"]; ok {
+		t.Error("Expected DEBUG_TOKEN to be omitted from the Secret")
+	}
+
+	select {
+	case event := <-recorder.Events:
+		if !strings.Contains(event, EventReasonOptionalKeysOmitted) || !strings.Contains(event, "DEBUG_TOKEN") {
+			t.Errorf("Unexpected event: %s", event)
+		}
+	default:
+		t.Error("Expected an OptionalKeysOmitted event")
+	}
+}
+
+func TestPodHandler_Handle_RequiredKeyFailureDenies(t *testing.T) {
+	handler, _ := optionalKeysTestHandler(t, nil)
+
+	resp := handler.Handle(context.Background(), sharedNamingRequest("app-1", ""))
+	if resp.Allowed {
+		t.Fatal("Expected injection to fail when a required key cannot be decrypted")
+	}
+
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Name: GenerateSecretName("default", "app-1"), Namespace: "default"}
+	if err := handler.Client.Get(context.Background(), key, secret); err == nil {
+		t.Error("Expected no Secret to be created")
+	}
+}
+
+func TestDecryptMapOptional(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	encryptedData := map[string]string{
+		"USERNAME": encryptTestData(t, "admin", identity.Recipient().String()),
+		"BROKEN":   "not-base64!",
+	}
+
+	decrypted, omitted, err := crypto.DecryptMapOptional(crypto.NewAgeEncryptor(), encryptedData, identity.String(), []string{"BROKEN", "ABSENT"})
+	if err != nil {
+		t.Fatalf("Expected optional failures to be tolerated, got %v", err)
+	}
+	if string(decrypted["USERNAME"]) != "admin" || len(decrypted) != 1 {
+		t.Errorf("Unexpected decrypted data: %v", decrypted)
+	}
+	if len(omitted) != 1 || omitted[0] != "BROKEN" {
+		t.Errorf("Expected BROKEN to be omitted, got %v", omitted)
+	}
+
+	if _, _, err := crypto.DecryptMapOptional(crypto.NewAgeEncryptor(), encryptedData, identity.String(), []string{"USERNAME"}); err == nil {
+		t.Error("Expected a required key failure to be returned")
+	}
+}
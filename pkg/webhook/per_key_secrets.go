@@ -0,0 +1,177 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+// keySecret is the Secret holding a single injected key (zen-lock/per-key-secrets)
+type keySecret struct {
+	key        string
+	secretName string
+	// optional keys may be omitted when they fail to decrypt, so their Secret may not exist
+	optional bool
+}
+
+// GenerateKeySecretName generates the name of the Secret holding one key of a per-Pod Secret
+// Key names may contain characters not allowed in resource names, so a hash of the key is appended.
+func GenerateKeySecretName(secretName, key string) string {
+	const maxLength = 253
+	hash := sha256.Sum256([]byte(key))
+	suffix := "-" + hex.EncodeToString(hash[:4])
+	if len(secretName)+len(suffix) > maxLength {
+		// A name must not end in "-" or "." ahead of the suffix
+		secretName = strings.TrimRight(secretName[:maxLength-len(suffix)], "-.")
+	}
+	return secretName + suffix
+}
+
+// injectedKeys returns the Secret keys a ZenLock is injected as, sorted, without decrypting it
+// Key names go through the concatenation, extension and case rules; values do not affect them.
+func injectedKeys(zenlock *securityv1alpha1.ZenLock, skip []string) ([]string, error) {
+	data := make(map[string][]byte, len(zenlock.Spec.EncryptedData))
+	for key := range zenlock.Spec.EncryptedData {
+		data[key] = nil
+	}
+	for _, key := range skip {
+		delete(data, key)
+	}
+	data, err := ApplyConcatenation(zenlock, data)
+	if err != nil {
+		return nil, err
+	}
+	if data, err = ApplyKeyExtensions(zenlock, data); err != nil {
+		return nil, err
+	}
+	if data, err = ApplyKeyCase(zenlock, data); err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// applyPerKeySecrets splits every target's Secret into one Secret per key (zen-lock/per-key-secrets=true)
+// The Secrets are projected into the target's volume, so the mounted files are the same as with one Secret.
+func (h *PodHandler) applyPerKeySecrets(targets []injectionTarget, zenlocks []*securityv1alpha1.ZenLock) error {
+	if h.delegateSecretCreation {
+		return fmt.Errorf("%s is not supported when the controller creates Secrets (ZEN_LOCK_WEBHOOK_CREATE_SECRET=false)", config.AnnotationPerKeySecrets)
+	}
+	for i := range targets {
+		switch {
+		case targets[i].shared:
+			return fmt.Errorf("%s cannot be combined with shared Secrets (%s)", config.AnnotationPerKeySecrets, config.AnnotationSecretNaming)
+		case len(targets[i].env) > 0:
+			return fmt.Errorf("%s cannot be combined with %s", config.AnnotationPerKeySecrets, config.AnnotationEnvPrefix)
+		case secretType(zenlocks[i]) != corev1.SecretTypeOpaque:
+			return fmt.Errorf("%s requires ZenLock %q to use secretType %s, got %s", config.AnnotationPerKeySecrets, targets[i].zenlockName, corev1.SecretTypeOpaque, secretType(zenlocks[i]))
+		}
+
+		keys, err := injectedKeys(zenlocks[i], nil)
+		if err != nil {
+			return err
+		}
+		// Keys still present when every optional key is omitted are always created
+		required, err := injectedKeys(zenlocks[i], zenlocks[i].Spec.OptionalKeys)
+		if err != nil {
+			return err
+		}
+		requiredSet := make(map[string]bool, len(required))
+		for _, key := range required {
+			requiredSet[key] = true
+		}
+
+		targets[i].keySecrets = make([]keySecret, 0, len(keys))
+		for _, key := range keys {
+			targets[i].keySecrets = append(targets[i].keySecrets, keySecret{
+				key:        key,
+				secretName: GenerateKeySecretName(targets[i].secretName, key),
+				optional:   !requiredSet[key],
+			})
+		}
+	}
+	return nil
+}
+
+// perKeyVolumeSource projects every per-key Secret, and the Pod's metadata when requested, into one volume
+func perKeyVolumeSource(target injectionTarget) corev1.VolumeSource {
+	sources := make([]corev1.VolumeProjection, 0, len(target.keySecrets)+1)
+	for _, ks := range target.keySecrets {
+		projection := &corev1.SecretProjection{
+			LocalObjectReference: corev1.LocalObjectReference{Name: ks.secretName},
+		}
+		if ks.optional {
+			optional := true
+			projection.Optional = &optional
+		}
+		sources = append(sources, corev1.VolumeProjection{Secret: projection})
+	}
+	if target.projectMetadata {
+		sources = append(sources, podMetadataProjection())
+	}
+	return corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{Sources: sources}}
+}
+
+// requiredSecretNames returns the Secrets a target's volume cannot be mounted without
+func (t injectionTarget) requiredSecretNames() []string {
+	if len(t.keySecrets) == 0 {
+		return []string{t.secretName}
+	}
+	names := make([]string, 0, len(t.keySecrets))
+	for _, ks := range t.keySecrets {
+		if !ks.optional {
+			names = append(names, ks.secretName)
+		}
+	}
+	return names
+}
+
+// secretWrites returns the Secrets to write for a target with their data
+// Without per-key Secrets this is the target's Secret holding every key.
+func secretWrites(target injectionTarget, data map[string][]byte) (map[string]map[string][]byte, error) {
+	if len(target.keySecrets) == 0 {
+		return map[string]map[string][]byte{target.secretName: data}, nil
+	}
+	writes := make(map[string]map[string][]byte, len(target.keySecrets))
+	for _, ks := range target.keySecrets {
+		value, ok := data[ks.key]
+		if !ok {
+			if ks.optional {
+				continue
+			}
+			return nil, fmt.Errorf("key %q is missing from the decrypted data", ks.key)
+		}
+		writes[ks.secretName] = map[string][]byte{ks.key: value}
+	}
+	if len(writes) != len(data) {
+		return nil, fmt.Errorf("decrypted data has %d keys, expected %d", len(data), len(writes))
+	}
+	return writes, nil
+}
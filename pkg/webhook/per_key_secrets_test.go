@@ -0,0 +1,162 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/common"
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+func TestPodHandler_Handle_PerKeySecrets(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	values := map[string]string{"USERNAME": "admin", "PASSWORD": "s3cret", "tls.crt": "cert"}
+	encrypted := make(map[string]string, len(values))
+	for key, value := range values {
+		encrypted[key] = encryptTestData(t, value, identity.Recipient().String())
+	}
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-zenlock", Namespace: "default"},
+		Spec:       securityv1alpha1.ZenLockSpec{EncryptedData: encrypted},
+	}
+	handler, clientBuilder := setupTestPodHandlerWithKey(t, identity.String())
+	handler.Client = clientBuilder.WithObjects(zenlock).Build()
+
+	annotations := map[string]string{config.AnnotationInject: "test-zenlock", config.AnnotationPerKeySecrets: "true"}
+	resp := handler.Handle(context.Background(), selectorTestRequestWithAnnotations(t, nil, annotations))
+	if !resp.Allowed {
+		t.Fatalf("Expected injection to be allowed, got %v", resp.Result)
+	}
+
+	// One Secret per key, each carrying the Pod labels the cleanup relies on
+	secrets := &corev1.SecretList{}
+	if err := handler.Client.List(context.Background(), secrets, client.InNamespace("default")); err != nil {
+		t.Fatalf("Failed to list Secrets: %v", err)
+	}
+	if len(secrets.Items) != len(values) {
+		t.Fatalf("Expected %d Secrets, got %d", len(values), len(secrets.Items))
+	}
+	base := GenerateSecretName("default", "test-pod")
+	for _, secret := range secrets.Items {
+		if len(secret.Data) != 1 {
+			t.Errorf("Expected Secret %s to hold one key, got %d", secret.Name, len(secret.Data))
+		}
+		for key, value := range secret.Data {
+			if secret.Name != GenerateKeySecretName(base, key) || string(value) != values[key] {
+				t.Errorf("Unexpected Secret %s holding %s=%q", secret.Name, key, value)
+			}
+		}
+		if secret.Labels[common.PodNameLabel()] != "test-pod" || secret.Labels[common.ZenLockNameLabel()] != "test-zenlock" {
+			t.Errorf("Expected Secret %s to carry the Pod and ZenLock labels, got %v", secret.Name, secret.Labels)
+		}
+	}
+
+	// Every Secret is projected into the one mounted volume
+	patches, err := json.Marshal(resp.Patches)
+	if err != nil {
+		t.Fatalf("Failed to marshal patches: %v", err)
+	}
+	if !strings.Contains(string(patches), `"projected"`) {
+		t.Errorf("Expected a projected volume, got %s", patches)
+	}
+	for key := range values {
+		if name := GenerateKeySecretName(base, key); !strings.Contains(string(patches), name) {
+			t.Errorf("Expected the volume to project Secret %s, got %s", name, patches)
+		}
+	}
+	if strings.Contains(string(patches), `"`+base+`"`) {
+		t.Errorf("Expected no combined Secret to be mounted, got %s", patches)
+	}
+}
+
+func TestApplyPerKeySecrets(t *testing.T) {
+	handler := &PodHandler{}
+	target := injectionTarget{zenlockName: "test-zenlock", secretName: "zen-lock-inject-default-app"}
+
+	zenlock := envTestZenLock("API_KEY", "DEBUG_TOKEN")
+	zenlock.Spec.OptionalKeys = []string{"DEBUG_TOKEN"}
+	targets := []injectionTarget{target}
+	if err := handler.applyPerKeySecrets(targets, []*securityv1alpha1.ZenLock{zenlock}); err != nil {
+		t.Fatalf("applyPerKeySecrets failed: %v", err)
+	}
+	if len(targets[0].keySecrets) != 2 {
+		t.Fatalf("Expected two per-key Secrets, got %+v", targets[0].keySecrets)
+	}
+	for _, ks := range targets[0].keySecrets {
+		if ks.optional != (ks.key == "DEBUG_TOKEN") {
+			t.Errorf("Key %s: optional = %v", ks.key, ks.optional)
+		}
+	}
+	if names := targets[0].requiredSecretNames(); len(names) != 1 || names[0] != GenerateKeySecretName(target.secretName, "API_KEY") {
+		t.Errorf("Expected only the API_KEY Secret to be required, got %v", names)
+	}
+
+	shared := target
+	shared.shared = true
+	withEnv := target
+	withEnv.env = []corev1.EnvVar{{Name: "APP_API_KEY"}}
+	for name, bad := range map[string]injectionTarget{"shared": shared, "env prefix": withEnv} {
+		if err := handler.applyPerKeySecrets([]injectionTarget{bad}, []*securityv1alpha1.ZenLock{envTestZenLock("API_KEY")}); err == nil {
+			t.Errorf("Expected %s to be rejected", name)
+		}
+	}
+
+	tls := envTestZenLock("tls.crt", "tls.key")
+	tls.Spec.SecretType = corev1.SecretTypeTLS
+	if err := handler.applyPerKeySecrets([]injectionTarget{target}, []*securityv1alpha1.ZenLock{tls}); err == nil {
+		t.Error("Expected a typed Secret to be rejected")
+	}
+}
+
+func TestGenerateKeySecretName(t *testing.T) {
+	name := GenerateKeySecretName("zen-lock-inject-default-app", "DB_PASSWORD")
+	if name != GenerateKeySecretName("zen-lock-inject-default-app", "DB_PASSWORD") {
+		t.Error("Expected key Secret names to be deterministic")
+	}
+	if name == GenerateKeySecretName("zen-lock-inject-default-app", "DB_USER") {
+		t.Error("Expected distinct keys to get distinct Secret names")
+	}
+	if long := GenerateKeySecretName(strings.Repeat("a", 253), "key"); len(long) > 253 {
+		t.Errorf("Expected names within 253 characters, got %d", len(long))
+	}
+
+	// Truncation cuts right after "-." here; the separators must not be left ahead of the hash
+	truncated := GenerateKeySecretName(strings.Repeat("a", 242)+"-.-"+strings.Repeat("b", 20), "key")
+	if len(truncated) > 253 {
+		t.Errorf("Expected names within 253 characters, got %d", len(truncated))
+	}
+	if errs := validation.IsDNS1123Subdomain(truncated); len(errs) > 0 {
+		t.Errorf("Expected a valid Secret name, got %q: %v", truncated, errs)
+	}
+	if want := strings.Repeat("a", 242) + "-"; !strings.HasPrefix(truncated, want) || strings.Contains(truncated, "-.") {
+		t.Errorf("Expected trailing separators to be trimmed, got %q", truncated)
+	}
+}
@@ -6,19 +6,28 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	"github.com/kube-zen/zen-sdk/pkg/leader"
 	"github.com/kube-zen/zen-sdk/pkg/retry"
 
 	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
@@ -54,6 +63,78 @@ func GenerateSecretName(namespace, podName string) string {
 	return fmt.Sprintf("%s-%s", truncated, hashSuffix)
 }
 
+// GenerateZenLockSecretName generates the shared secret name for a ZenLock (secret-naming=zenlock)
+// The name depends only on the ZenLock, so every Pod injecting it mounts the same Secret.
+func GenerateZenLockSecretName(zenlockName string) string {
+	name := fmt.Sprintf("zen-lock-shared-%s", zenlockName)
+
+	// ZenLock names are <= 253 characters; keep the prefixed name within the limit
+	const maxLength = 253
+	const hashLength = 8
+	if len(name) <= maxLength {
+		return name
+	}
+	hash := sha256.Sum256([]byte(zenlockName))
+	return fmt.Sprintf("%s-%s", name[:maxLength-hashLength-1], hex.EncodeToString(hash[:4]))
+}
+
+// GenerateVolumeName generates a valid volume name for a ZenLock injected alongside others
+func GenerateVolumeName(zenlockName string) string {
+	// Volume names must be DNS-1123 labels: <= 63 characters and no dots
+	const maxLength = 63
+	const hashLength = 8
+
+	name := fmt.Sprintf("%s-%s", config.DefaultVolumeName, zenlockName)
+	if len(name) <= maxLength && !strings.Contains(name, ".") {
+		return name
+	}
+
+	hash := sha256.Sum256([]byte(zenlockName))
+	hashSuffix := hex.EncodeToString(hash[:4])
+	prefix := strings.ReplaceAll(name, ".", "-")
+	if len(prefix) > maxLength-hashLength-1 {
+		prefix = strings.TrimRight(prefix[:maxLength-hashLength-1], "-")
+	}
+	return fmt.Sprintf("%s-%s", prefix, hashSuffix)
+}
+
+// EventReasonInjectionFailed is the Warning Event reason recorded on a ZenLock when injecting it fails
+const EventReasonInjectionFailed = "InjectionFailed"
+
+// EventReasonOptionalKeysOmitted is the Warning Event reason recorded on a ZenLock when optional keys fail to decrypt
+const EventReasonOptionalKeysOmitted = "OptionalKeysOmitted"
+
+// injectionTarget describes one ZenLock materialized into a Pod as a Secret volume
+type injectionTarget struct {
+	zenlockName string
+	secretName  string
+	volumeName  string
+	mountPath   string
+	// shared marks a ZenLock-named Secret used by every Pod injecting the ZenLock (owned by the ZenLock)
+	shared bool
+	// env exposes the Secret's keys as environment variables (zen-lock/env-prefix)
+	env []corev1.EnvVar
+	// projectMetadata mounts a projected volume adding the Pod's name and namespace (zen-lock/project-metadata)
+	projectMetadata bool
+	// writable mounts the data read-write; Secret-mode data is copied into an emptyDir (zen-lock/mount-writable)
+	writable bool
+	// scope selects the containers receiving the mount and env vars (zen-lock/inject-scope, all by default)
+	scope string
+	// keySecrets materializes each key as its own Secret, projected into the volume (zen-lock/per-key-secrets)
+	keySecrets []keySecret
+}
+
+// applySecretNaming switches Secret-mode targets to shared ZenLock-named Secrets when requested
+func applySecretNaming(targets []injectionTarget, naming string) {
+	if naming != config.SecretNamingZenLock {
+		return
+	}
+	for i := range targets {
+		targets[i].secretName = GenerateZenLockSecretName(targets[i].zenlockName)
+		targets[i].shared = true
+	}
+}
+
 // PodHandler handles mutating admission webhook requests for Pods
 type PodHandler struct {
 	Client     client.Client
@@ -61,6 +142,111 @@ type PodHandler struct {
 	crypto     crypto.Encryptor
 	privateKey string
 	cache      *ZenLockCache
+	// namespaces caches the Namespaces read during admission (nil reads them from the API every time)
+	namespaces *NamespaceCache
+
+	// maxSelectorZenLocks bounds how many selector-based ZenLocks are evaluated per namespace (0 = default)
+	maxSelectorZenLocks int
+
+	// delegateSecretCreation leaves Secret creation to the controller; the webhook only mutates the Pod
+	delegateSecretCreation bool
+
+	// systemNamespace is the webhook's own namespace, excluded from injection ("" disables the guard)
+	systemNamespace string
+	// allowSelfNamespace permits injection into systemNamespace (ZEN_LOCK_ALLOW_SELF_NAMESPACE=true)
+	allowSelfNamespace bool
+
+	// requireConfirmation only honors zen-lock/inject on Pods also marked zen-lock/confirmed=true
+	requireConfirmation bool
+	// strictAnnotations denies Pods carrying zen-lock/* annotations the webhook does not know (ZEN_LOCK_STRICT_ANNOTATIONS)
+	strictAnnotations bool
+
+	// immutableByDefault makes injected Secrets immutable unless the ZenLock or its namespace says otherwise (ZEN_LOCK_IMMUTABLE_SECRETS)
+	immutableByDefault bool
+	// blockOwnerDeletion sets blockOwnerDeletion on the ZenLock owner reference of shared Secrets (ZEN_LOCK_BLOCK_OWNER_DELETION)
+	blockOwnerDeletion bool
+	// observe evaluates every request without mutating Pods or writing anything (ZEN_LOCK_MODE=observe)
+	observe bool
+	// policyHook consults an external policy endpoint before injecting (ZEN_LOCK_POLICY_URL, nil = disabled)
+	policyHook *PolicyHook
+	// canaryNamespaces admit Pods that would be denied, with the denial as a warning (ZEN_LOCK_CANARY_NAMESPACES)
+	canaryNamespaces map[string]bool
+
+	// adoptUnmanagedSecrets lets zen-lock overwrite an unlabeled Secret at its target name (ZEN_LOCK_ADOPT_UNMANAGED_SECRETS)
+	adoptUnmanagedSecrets bool
+	// secretSyncAttempts bounds the create/read/update rounds against concurrent writers (ZEN_LOCK_SECRET_SYNC_ATTEMPTS, 0 = default)
+	secretSyncAttempts int
+
+	// messageSuffix is appended to denial and warning messages, e.g. a runbook URL (ZEN_LOCK_DENIAL_MESSAGE_SUFFIX)
+	messageSuffix string
+
+	// hostPathPolicy is config.HostPathPolicyDeny or config.HostPathPolicyWarn for Pods with hostPath volumes ("" allows them)
+	hostPathPolicy string
+
+	// decryptLimiter bounds concurrent decryptions across all admissions (nil = unlimited)
+	decryptLimiter *decryptLimiter
+	// decryptTimeout bounds a single decryption (ZEN_LOCK_DECRYPT_TIMEOUT, 0 = default)
+	decryptTimeout time.Duration
+
+	// Recorder emits Events on ZenLocks whose injection failed (optional; nil disables events)
+	Recorder record.EventRecorder
+
+	// Auditor records injection events in a per-namespace ConfigMap (optional; nil disables the audit trail)
+	Auditor *AuditLog
+
+	// Denials collects denied injections for the ZenLock status counter (optional; nil disables counting)
+	Denials *DenialQueue
+}
+
+// namespaceReader returns the client to read Namespaces with, through the Namespace cache when enabled
+func (h *PodHandler) namespaceReader() client.Reader {
+	if h.namespaces == nil {
+		return h.Client
+	}
+	return cachedNamespaceReader{Reader: h.Client, cache: h.namespaces}
+}
+
+// SecretCreationDelegated reports whether Secrets are created by the controller instead of the webhook
+// Set ZEN_LOCK_WEBHOOK_CREATE_SECRET=false to delegate.
+func SecretCreationDelegated() bool {
+	createSecret, err := strconv.ParseBool(os.Getenv("ZEN_LOCK_WEBHOOK_CREATE_SECRET"))
+	return err == nil && !createSecret
+}
+
+// FormatDelegatedSecrets encodes ZenLock -> Secret name pairs for the delegated-secrets annotation
+func FormatDelegatedSecrets(secrets map[string]string) string {
+	pairs := make([]string, 0, len(secrets))
+	for zenlockName, secretName := range secrets {
+		pairs = append(pairs, zenlockName+"="+secretName)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// ParseDelegatedSecrets decodes the delegated-secrets annotation into ZenLock -> Secret name pairs
+func ParseDelegatedSecrets(value string) map[string]string {
+	secrets := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		zenlockName, secretName, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || zenlockName == "" || secretName == "" {
+			continue
+		}
+		secrets[zenlockName] = secretName
+	}
+	return secrets
+}
+
+// HostPathPolicy returns the policy for injecting into Pods with hostPath volumes
+// ZEN_LOCK_DENY_HOSTPATH_PODS=true (or deny) denies them, warn admits them with a warning; anything else allows them.
+func HostPathPolicy() string {
+	value := strings.ToLower(strings.TrimSpace(os.Getenv("ZEN_LOCK_DENY_HOSTPATH_PODS")))
+	if value == config.HostPathPolicyWarn {
+		return config.HostPathPolicyWarn
+	}
+	if deny, err := strconv.ParseBool(value); (err == nil && deny) || value == config.HostPathPolicyDeny {
+		return config.HostPathPolicyDeny
+	}
+	return ""
 }
 
 // NewPodHandler creates a new PodHandler
@@ -68,9 +254,9 @@ func NewPodHandler(client client.Client, scheme *runtime.Scheme) (*PodHandler, e
 	decoder := admission.NewDecoder(scheme)
 
 	// Load private key from environment (cached in handler)
-	privateKey := os.Getenv("ZEN_LOCK_PRIVATE_KEY")
+	privateKey := crypto.ResolvePrivateKey()
 	if privateKey == "" {
-		return nil, fmt.Errorf("ZEN_LOCK_PRIVATE_KEY environment variable is not set")
+		return nil, fmt.Errorf("ZEN_LOCK_PRIVATE_KEY environment variable is not set and ZEN_LOCK_IDENTITIES_DIR provided no identities")
 	}
 
 	// Initialize crypto
@@ -83,16 +269,79 @@ func NewPodHandler(client client.Client, scheme *runtime.Scheme) (*PodHandler, e
 			cacheTTL = parsedTTL
 		}
 	}
-	cache := NewZenLockCache(cacheTTL)
+	// Bound staleness of refreshed entries (ZEN_LOCK_CACHE_MAX_AGE, disabled by default)
+	var cacheMaxAge time.Duration
+	if maxAgeStr := os.Getenv("ZEN_LOCK_CACHE_MAX_AGE"); maxAgeStr != "" {
+		if parsedMaxAge, err := time.ParseDuration(maxAgeStr); err == nil && parsedMaxAge > 0 {
+			cacheMaxAge = parsedMaxAge
+		}
+	}
+	cache := NewZenLockCacheWithMaxAge(cacheTTL, cacheMaxAge)
 	// Register cache for invalidation
 	RegisterCache(cache)
 
+	// Cache Namespace reads for a short time (ZEN_LOCK_NAMESPACE_CACHE_TTL, 0 disables)
+	var namespaces *NamespaceCache
+	if namespaceTTL := NamespaceCacheTTLFromEnv(); namespaceTTL > 0 {
+		namespaces = NewNamespaceCache(namespaceTTL)
+	}
+
+	// Bound selector evaluation per namespace (configurable via ZEN_LOCK_MAX_SELECTOR_ZENLOCKS env var)
+	maxSelectorZenLocks := config.DefaultMaxSelectorZenLocks
+	if maxStr := os.Getenv("ZEN_LOCK_MAX_SELECTOR_ZENLOCKS"); maxStr != "" {
+		if parsedMax, err := strconv.Atoi(maxStr); err == nil && parsedMax > 0 {
+			maxSelectorZenLocks = parsedMax
+		}
+	}
+
+	// Never inject into our own control-plane Pods unless explicitly allowed (avoids dependency loops)
+	systemNamespace, _ := leader.RequirePodNamespace()
+	allowSelfNamespace, _ := strconv.ParseBool(os.Getenv("ZEN_LOCK_ALLOW_SELF_NAMESPACE"))
+
+	// Guard against injection from copied manifests (ZEN_LOCK_REQUIRE_OPT_IN_LABEL=true)
+	requireConfirmation, _ := strconv.ParseBool(os.Getenv("ZEN_LOCK_REQUIRE_OPT_IN_LABEL"))
+
+	// Take over hand-made Secrets at zen-lock's target names instead of refusing (ZEN_LOCK_ADOPT_UNMANAGED_SECRETS=true)
+	adoptUnmanagedSecrets, _ := strconv.ParseBool(os.Getenv("ZEN_LOCK_ADOPT_UNMANAGED_SECRETS"))
+
+	// Rounds of re-reading a Secret another replica changed concurrently (ZEN_LOCK_SECRET_SYNC_ATTEMPTS)
+	secretSyncAttempts := config.DefaultSecretSyncAttempts
+	if attemptsStr := os.Getenv("ZEN_LOCK_SECRET_SYNC_ATTEMPTS"); attemptsStr != "" {
+		if parsedAttempts, err := strconv.Atoi(attemptsStr); err == nil && parsedAttempts > 0 {
+			secretSyncAttempts = parsedAttempts
+		}
+	}
+
+	// Consult an external policy engine before injecting (ZEN_LOCK_POLICY_URL)
+	policyHook, err := PolicyHookFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
 	return &PodHandler{
-		Client:     client,
-		decoder:    decoder,
-		crypto:     encryptor,
-		privateKey: privateKey,
-		cache:      cache,
+		Client:                 client,
+		decoder:                decoder,
+		crypto:                 encryptor,
+		privateKey:             privateKey,
+		cache:                  cache,
+		namespaces:             namespaces,
+		maxSelectorZenLocks:    maxSelectorZenLocks,
+		delegateSecretCreation: SecretCreationDelegated(),
+		systemNamespace:        systemNamespace,
+		allowSelfNamespace:     allowSelfNamespace,
+		requireConfirmation:    requireConfirmation,
+		strictAnnotations:      StrictAnnotationsEnabled(),
+		hostPathPolicy:         HostPathPolicy(),
+		immutableByDefault:     SecretsImmutableByDefault(),
+		blockOwnerDeletion:     common.BlockOwnerDeletion(),
+		observe:                WebhookMode() == config.WebhookModeObserve,
+		canaryNamespaces:       CanaryNamespacesFromEnv(),
+		policyHook:             policyHook,
+		adoptUnmanagedSecrets:  adoptUnmanagedSecrets,
+		secretSyncAttempts:     secretSyncAttempts,
+		messageSuffix:          strings.TrimSpace(os.Getenv("ZEN_LOCK_DENIAL_MESSAGE_SUFFIX")),
+		decryptLimiter:         getSharedDecryptLimiter(),
+		decryptTimeout:         getDecryptTimeout(),
 	}, nil
 }
 
@@ -128,6 +377,13 @@ func (h *PodHandler) validateInjectionRequest(pod *corev1.Pod, injectName, mount
 		}
 	}
 
+	// A Pod without containers has nothing to mount the secret into; catch the bad manifest early
+	if resp := denyPodWithoutContainers(pod, namespace); resp.Result != nil {
+		duration := time.Since(startTime).Seconds()
+		metrics.RecordWebhookInjection(namespace, injectName, "error", duration)
+		return resp
+	}
+
 	// SECURITY: Validate that zen-lock injector is available
 	// If annotation is present, the webhook must be able to process it
 	// This check ensures the webhook is deployed and operational
@@ -143,6 +399,15 @@ func (h *PodHandler) validateInjectionRequest(pod *corev1.Pod, injectName, mount
 	return admission.Response{} // Valid
 }
 
+// denyPodWithoutContainers denies injection into a Pod with neither containers nor init containers
+func denyPodWithoutContainers(pod *corev1.Pod, namespace string) admission.Response {
+	if len(pod.Spec.Containers) > 0 || len(pod.Spec.InitContainers) > 0 {
+		return admission.Response{}
+	}
+	metrics.RecordValidationFailure(namespace, "no_containers")
+	return admission.Denied(fmt.Sprintf("zen-lock cannot inject into Pod %q: it has no containers or init containers to mount the secret into", pod.Name))
+}
+
 // fetchZenLock fetches the ZenLock CRD with caching
 func (h *PodHandler) fetchZenLock(ctx context.Context, zenlockKey types.NamespacedName, namespace, injectName string, startTime time.Time) (*securityv1alpha1.ZenLock, admission.Response) {
 	// Try cache first
@@ -162,39 +427,64 @@ func (h *PodHandler) fetchZenLock(ctx context.Context, zenlockKey types.Namespac
 		sanitizedErr := SanitizeError(err, "fetch ZenLock")
 		return nil, admission.Errored(http.StatusInternalServerError, sanitizedErr)
 	}
-	// Cache the result
-	h.cache.Set(zenlockKey, zenlock)
+	// Cache the result, honoring the namespace's zen-lock/cache-ttl override
+	h.cache.SetWithTTL(zenlockKey, zenlock, NamespaceCacheTTL(ctx, h.namespaceReader(), namespace))
 	return zenlock, admission.Response{}
 }
 
 // handleDryRun handles dry-run mode by mutating the pod without creating secrets
 func (h *PodHandler) handleDryRun(ctx context.Context, pod *corev1.Pod, secretName, mountPath, injectName, namespace string, startTime time.Time, originalObject []byte) admission.Response {
-	mutatedPod := pod.DeepCopy()
-	if err := h.mutatePod(mutatedPod, secretName, mountPath); err != nil {
-		duration := time.Since(startTime).Seconds()
-		metrics.RecordWebhookInjection(namespace, injectName, "error", duration)
-		sanitizedErr := SanitizeError(err, "mutate pod (dry-run)")
-		return admission.Errored(http.StatusInternalServerError, sanitizedErr)
-	}
-	mutatedPodBytes, err := json.Marshal(mutatedPod)
-	if err != nil {
-		duration := time.Since(startTime).Seconds()
-		metrics.RecordWebhookInjection(namespace, injectName, "error", duration)
-		sanitizedErr := SanitizeError(err, "marshal mutated pod (dry-run)")
-		return admission.Errored(http.StatusInternalServerError, sanitizedErr)
-	}
-	duration := time.Since(startTime).Seconds()
-	metrics.RecordWebhookInjection(namespace, injectName, "success", duration)
-	return admission.PatchResponseFromRaw(originalObject, mutatedPodBytes)
+	targets := []injectionTarget{{
+		zenlockName: injectName,
+		secretName:  secretName,
+		volumeName:  config.DefaultVolumeName,
+		mountPath:   mountPath,
+	}}
+	return h.createTargetsMutationResponse(pod, targets, namespace, startTime, originalObject, " (dry-run)")
 }
 
 // ensureSecretExists ensures the secret exists and is up-to-date, handling conflicts and stale data
+// Webhook replicas admitting Pods at once race on the same Secret: a write based on a Secret that changed
+// since it was read fails with a conflict (or the Secret is gone, or was recreated meanwhile), and the whole
+// create/read/compare/update round is repeated on fresh data, up to secretSyncAttempts times.
 func (h *PodHandler) ensureSecretExists(ctx context.Context, secret *corev1.Secret, secretName, injectName, namespace, podName string, secretData map[string][]byte, startTime time.Time, retryConfig retry.Config, isDryRun bool) error {
 	// Skip secret creation/update in dry-run mode
 	if isDryRun {
 		return nil
 	}
 
+	// Resending a write based on a stale read cannot succeed: leave conflicts to the loop below
+	retryable := retryConfig.RetryableErrors
+	if retryable == nil {
+		retryable = common.IsRetryable
+	}
+	roundConfig := retryConfig
+	roundConfig.RetryableErrors = func(err error) bool {
+		return !k8serrors.IsConflict(err) && retryable(err)
+	}
+
+	attempts := h.secretSyncAttempts
+	if attempts <= 0 {
+		attempts = config.DefaultSecretSyncAttempts
+	}
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		err = h.syncSecret(ctx, secret, secretName, injectName, namespace, podName, secretData, roundConfig)
+		if !secretRaced(err) {
+			return err
+		}
+		log.FromContext(ctx).V(4).Info("Secret changed concurrently, retrying", "secret", secretName, "namespace", namespace, "attempt", attempt)
+	}
+	return err
+}
+
+// secretRaced reports whether a Secret write failed because another writer changed the Secret meanwhile
+func secretRaced(err error) bool {
+	return k8serrors.IsConflict(err) || k8serrors.IsNotFound(err) || k8serrors.IsAlreadyExists(err)
+}
+
+// syncSecret runs one round of ensureSecretExists: create the Secret, or bring the existing one up to date
+func (h *PodHandler) syncSecret(ctx context.Context, secret *corev1.Secret, secretName, injectName, namespace, podName string, secretData map[string][]byte, retryConfig retry.Config) error {
 	createErr := retry.Do(ctx, retryConfig, func() error {
 		return h.Client.Create(ctx, secret)
 	})
@@ -215,6 +505,11 @@ func (h *PodHandler) ensureSecretExists(ctx context.Context, secret *corev1.Secr
 		return err
 	}
 
+	// Never overwrite a Secret someone created by hand at the name zen-lock would use
+	if !h.adoptUnmanagedSecrets && IsUserManagedSecret(existingSecret) {
+		return &SecretCollisionError{Name: secretName}
+	}
+
 	// Ensure labels map is initialized
 	if existingSecret.Labels == nil {
 		// Pre-allocate labels map with estimated size (Go 1.25 optimization)
@@ -222,39 +517,62 @@ func (h *PodHandler) ensureSecretExists(ctx context.Context, secret *corev1.Secr
 	}
 
 	// Check if existing secret matches current ZenLock
-	existingZenLockName, hasZenLockLabel := existingSecret.Labels[common.LabelZenLockName]
+	existingZenLockName, hasZenLockLabel := existingSecret.Labels[common.ZenLockNameLabel()]
+
+	// Immutable Secrets cannot be updated: recreate them when stale or when they must become mutable
+	if SecretImmutable(existingSecret) {
+		if existingZenLockName == injectName && h.secretDataMatches(existingSecret.Data, secretData) && SecretImmutable(secret) {
+			return nil
+		}
+		return retry.Do(ctx, retryConfig, func() error {
+			return ReplaceSecret(ctx, h.Client, existingSecret, secret)
+		})
+	}
+
 	if !hasZenLockLabel || existingZenLockName != injectName {
 		// Secret exists but is for a different ZenLock - update it
-		if !isDryRun {
-			existingSecret.Data = secretData
-			existingSecret.Labels[common.LabelZenLockName] = injectName
-			existingSecret.Labels[common.LabelPodName] = podName
-			existingSecret.Labels[common.LabelPodNamespace] = namespace
-			if err := retry.Do(ctx, retryConfig, func() error {
-				return h.Client.Update(ctx, existingSecret)
-			}); err != nil {
-				return err
-			}
+		existingSecret.Data = secretData
+		existingSecret.Immutable = secret.Immutable
+		existingSecret.Labels[common.ZenLockNameLabel()] = injectName
+		// Shared Secrets (empty podName) carry no Pod labels
+		if podName != "" {
+			existingSecret.Labels[common.PodNameLabel()] = podName
+			existingSecret.Labels[common.PodNamespaceLabel()] = namespace
 		}
-		return nil
+		return retry.Do(ctx, retryConfig, func() error {
+			return h.Client.Update(ctx, existingSecret)
+		})
 	}
 
-	// Secret exists and matches current ZenLock - verify data matches
-	if !h.secretDataMatches(existingSecret.Data, secretData) {
+	// Secret exists and matches current ZenLock - verify data matches (and apply immutability)
+	if !h.secretDataMatches(existingSecret.Data, secretData) || SecretImmutable(secret) {
 		// Data doesn't match - update secret with fresh data
-		if !isDryRun {
-			existingSecret.Data = secretData
-			if err := retry.Do(ctx, retryConfig, func() error {
-				return h.Client.Update(ctx, existingSecret)
-			}); err != nil {
-				return err
-			}
-		}
+		existingSecret.Data = secretData
+		existingSecret.Immutable = secret.Immutable
+		return retry.Do(ctx, retryConfig, func() error {
+			return h.Client.Update(ctx, existingSecret)
+		})
 	}
 
 	return nil
 }
 
+// SecretCollisionError reports a user-managed Secret at the name zen-lock would inject into
+type SecretCollisionError struct {
+	Name string
+}
+
+func (e *SecretCollisionError) Error() string {
+	return fmt.Sprintf("Secret %q already exists and is not managed by zen-lock; rename or delete it, or set ZEN_LOCK_ADOPT_UNMANAGED_SECRETS=true to let zen-lock take it over", e.Name)
+}
+
+// IsUserManagedSecret reports whether a Secret carries none of zen-lock's ZenLock or Pod labels
+func IsUserManagedSecret(secret *corev1.Secret) bool {
+	_, hasZenLock := secret.Labels[common.ZenLockNameLabel()]
+	_, hasPod := secret.Labels[common.PodNameLabel()]
+	return !hasZenLock && !hasPod
+}
+
 // secretDataMatches checks if two secret data maps are equal
 func (h *PodHandler) secretDataMatches(existing, expected map[string][]byte) bool {
 	if len(existing) != len(expected) {
@@ -270,6 +588,79 @@ func (h *PodHandler) secretDataMatches(existing, expected map[string][]byte) boo
 
 // Handle processes admission requests
 func (h *PodHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if h.observe {
+		return h.stripSuppliedDelegation(req, h.handleObserve(ctx, req))
+	}
+	if h.Auditor == nil || (req.DryRun != nil && *req.DryRun) {
+		return h.appendMessageSuffix(redactResponse(h.stripSuppliedDelegation(req, h.downgradeCanaryDenial(ctx, req, h.handle(ctx, req)))))
+	}
+
+	ctx, zenlocks := withAuditZenLocks(ctx)
+	resp := h.handle(ctx, req)
+	// The audit trail records the decision itself, also when a canary namespace downgrades it
+	h.recordAudit(ctx, req, *zenlocks, resp)
+	return h.appendMessageSuffix(redactResponse(h.stripSuppliedDelegation(req, h.downgradeCanaryDenial(ctx, req, resp))))
+}
+
+// recordAudit appends the outcome of an injection to the namespace's audit ConfigMap
+// Pods that requested no injection and were left alone are not recorded.
+func (h *PodHandler) recordAudit(ctx context.Context, req admission.Request, zenlocks []string, resp admission.Response) {
+	pod := &corev1.Pod{}
+	if err := h.decoder.Decode(req, pod); err != nil {
+		return
+	}
+	if len(zenlocks) == 0 {
+		if injectName := pod.GetAnnotations()[config.AnnotationInject]; injectName != "" {
+			zenlocks = []string{injectName}
+		}
+	}
+	decision := auditDecision(resp)
+	if decision == AuditDecisionSkipped && len(zenlocks) == 0 {
+		return
+	}
+
+	entry := AuditEntry{
+		Time:     time.Now().UTC(),
+		Pod:      admissionPodName(pod),
+		ZenLocks: zenlocks,
+		Decision: decision,
+	}
+	written, err := h.Auditor.Append(ctx, req.Namespace, entry)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to record injection audit entry", "namespace", req.Namespace)
+	} else if !written {
+		log.FromContext(ctx).V(1).Info("Injection audit entry dropped by rate limit", "namespace", req.Namespace)
+	}
+}
+
+// appendMessageSuffix adds messageSuffix to the denial message and every warning of a response
+// The suffix follows the original text, so the reason stays at the start for machine parsing.
+func (h *PodHandler) appendMessageSuffix(resp admission.Response) admission.Response {
+	if h.messageSuffix == "" {
+		return resp
+	}
+	if !resp.Allowed && resp.Result != nil && resp.Result.Message != "" {
+		resp.Result.Message += " " + h.messageSuffix
+	}
+	for i := range resp.Warnings {
+		resp.Warnings[i] += " " + h.messageSuffix
+	}
+	return resp
+}
+
+// redactResponse redacts key names matching ZEN_LOCK_REDACT_KEYS in the denial message and warnings of a response
+func redactResponse(resp admission.Response) admission.Response {
+	if resp.Result != nil {
+		resp.Result.Message = common.RedactMessage(resp.Result.Message)
+	}
+	for i := range resp.Warnings {
+		resp.Warnings[i] = common.RedactMessage(resp.Warnings[i])
+	}
+	return resp
+}
+
+// handle processes an admission request (see Handle)
+func (h *PodHandler) handle(ctx context.Context, req admission.Request) admission.Response {
 	// Add timeout to context (configurable via ZEN_LOCK_WEBHOOK_TIMEOUT env var)
 	ctx, cancel := context.WithTimeout(ctx, getWebhookTimeout())
 	defer cancel()
@@ -286,10 +677,53 @@ func (h *PodHandler) Handle(ctx context.Context, req admission.Request) admissio
 	// If this Pod creation request reached here, it means zen-lead has allowed it (it's the leader)
 	// No need to check leader status here - zen-lead blocks non-leader Pod creation at the API level
 
+	// Skip the webhook's own namespace so zen-lock never depends on itself to start
+	if h.systemNamespace != "" && req.Namespace == h.systemNamespace && !h.allowSelfNamespace {
+		return admission.Allowed("zen-lock does not inject into its own namespace")
+	}
+
+	// Catch misspelled annotations, which would otherwise be silently ignored, when configured
+	if h.strictAnnotations {
+		if unknown := UnknownPodAnnotations(pod.GetAnnotations()); len(unknown) > 0 {
+			metrics.RecordValidationFailure(req.Namespace, "unknown_annotation")
+			return admission.Denied(fmt.Sprintf("unknown zen-lock annotations: %s (ZEN_LOCK_STRICT_ANNOTATIONS is enabled)", strings.Join(unknown, ", ")))
+		}
+	}
+
 	// Check if injection is requested
 	injectName := pod.GetAnnotations()[config.AnnotationInject]
 	if injectName == "" {
-		return admission.Allowed("no zen-lock injection requested")
+		// No explicit request - fall back to ZenLocks selecting this pod via InjectionSelector
+		return h.handleSelectorInjection(ctx, req, pod, startTime)
+	}
+
+	// Skip unconfirmed requests when confirmation is required (e.g. annotation copied from another manifest)
+	if h.requireConfirmation && !isInjectionConfirmed(pod) {
+		metrics.RecordValidationFailure(req.Namespace, "injection_unconfirmed")
+		return admission.Allowed("zen-lock injection not confirmed").WithWarnings(
+			fmt.Sprintf("zen-lock: %s=%q ignored: Pod must also carry the %s=true label or annotation", config.AnnotationInject, injectName, config.AnnotationConfirmed))
+	}
+
+	// Honor zen-lock/inject-if so one template can be reused across environments
+	if expr, ok := pod.GetAnnotations()[config.AnnotationInjectIf]; ok {
+		cond, err := ParseInjectCondition(expr)
+		if err != nil {
+			duration := time.Since(startTime).Seconds()
+			metrics.RecordWebhookInjection(req.Namespace, injectName, "error", duration)
+			metrics.RecordValidationFailure(req.Namespace, "invalid_inject_condition")
+			return admission.Denied(fmt.Sprintf("invalid inject condition: %v", err))
+		}
+		if !cond.Matches(pod.GetAnnotations()) {
+			return admission.Allowed(fmt.Sprintf("zen-lock injection skipped: %s=%q not met", config.AnnotationInjectIf, expr))
+		}
+	}
+
+	// Keep plaintext out of Pods with host filesystem access when configured
+	hostPathWarnings, resp := h.checkHostPathVolumes(pod, req.Namespace)
+	if resp.Result != nil {
+		duration := time.Since(startTime).Seconds()
+		metrics.RecordWebhookInjection(req.Namespace, injectName, "error", duration)
+		return resp
 	}
 
 	// Get mount path from annotation or use default
@@ -303,6 +737,24 @@ func (h *PodHandler) Handle(ctx context.Context, req admission.Request) admissio
 		return resp
 	}
 
+	// Get the containers to inject into (all by default)
+	injectScope := pod.GetAnnotations()[config.AnnotationInjectScope]
+	if err := ValidateInjectScope(injectScope); err != nil {
+		duration := time.Since(startTime).Seconds()
+		metrics.RecordWebhookInjection(req.Namespace, injectName, "error", duration)
+		metrics.RecordValidationFailure(req.Namespace, "invalid_inject_scope")
+		return admission.Denied(fmt.Sprintf("invalid inject scope: %v", err))
+	}
+
+	// Get Secret naming (per-Pod by default)
+	secretNaming := pod.GetAnnotations()[config.AnnotationSecretNaming]
+	if err := ValidateSecretNaming(secretNaming); err != nil {
+		duration := time.Since(startTime).Seconds()
+		metrics.RecordWebhookInjection(req.Namespace, injectName, "error", duration)
+		metrics.RecordValidationFailure(req.Namespace, "invalid_secret_naming")
+		return admission.Denied(fmt.Sprintf("invalid secret naming: %v", err))
+	}
+
 	// Fetch ZenLock CRD (with caching)
 	zenlockKey := types.NamespacedName{
 		Name:      injectName,
@@ -314,23 +766,190 @@ func (h *PodHandler) Handle(ctx context.Context, req admission.Request) admissio
 		return resp
 	}
 
-	// Validate AllowedSubjects if specified
-	if len(zenlock.Spec.AllowedSubjects) > 0 {
-		if err := h.validateAllowedSubjects(ctx, pod, zenlock.Spec.AllowedSubjects); err != nil {
+	// Generate stable secret name from namespace and pod name (available at admission time)
+	secretName := GenerateSecretName(req.Namespace, pod.Name)
+	target := injectionTarget{
+		zenlockName: injectName,
+		secretName:  secretName,
+		volumeName:  config.DefaultVolumeName,
+		mountPath:   mountPath,
+	}
+	targets := []injectionTarget{target}
+	applySecretNaming(targets, secretNaming)
+
+	// Expose keys as prefixed environment variables when requested
+	if prefix, ok := pod.GetAnnotations()[config.AnnotationEnvPrefix]; ok {
+		if err := applyEnvPrefix(targets, []*securityv1alpha1.ZenLock{zenlock}, prefix); err != nil {
 			duration := time.Since(startTime).Seconds()
-			metrics.RecordWebhookInjection(req.Namespace, injectName, "denied", duration)
-			return admission.Denied(fmt.Sprintf("Pod ServiceAccount not allowed to use ZenLock %q: %v", injectName, err))
+			metrics.RecordWebhookInjection(req.Namespace, injectName, "error", duration)
+			metrics.RecordValidationFailure(req.Namespace, "invalid_env_prefix")
+			return admission.Denied(fmt.Sprintf("invalid env prefix: %v", err))
+		}
+	}
+	if pod.GetAnnotations()[config.AnnotationProjectMetadata] == "true" {
+		if err := applyProjectMetadata(targets, []*securityv1alpha1.ZenLock{zenlock}); err != nil {
+			duration := time.Since(startTime).Seconds()
+			metrics.RecordWebhookInjection(req.Namespace, injectName, "error", duration)
+			metrics.RecordValidationFailure(req.Namespace, "invalid_project_metadata")
+			return admission.Denied(fmt.Sprintf("invalid metadata projection: %v", err))
+		}
+	}
+	if pod.GetAnnotations()[config.AnnotationPerKeySecrets] == "true" {
+		if err := h.applyPerKeySecrets(targets, []*securityv1alpha1.ZenLock{zenlock}); err != nil {
+			duration := time.Since(startTime).Seconds()
+			metrics.RecordWebhookInjection(req.Namespace, injectName, "error", duration)
+			metrics.RecordValidationFailure(req.Namespace, "invalid_per_key_secrets")
+			return admission.Denied(fmt.Sprintf("invalid per-key secrets: %v", err))
 		}
 	}
+	if pod.GetAnnotations()[config.AnnotationMountWritable] == "true" {
+		applyMountWritable(targets)
+	}
+	if err := applyInjectScope(targets, pod, injectScope); err != nil {
+		duration := time.Since(startTime).Seconds()
+		metrics.RecordWebhookInjection(req.Namespace, injectName, "error", duration)
+		metrics.RecordValidationFailure(req.Namespace, "invalid_inject_scope")
+		return admission.Denied(fmt.Sprintf("invalid inject scope: %v", err))
+	}
+	target = targets[0]
+
+	// A Pod admitted again after injection (e.g. webhook reinvocation) is left as is, without decrypting
+	if h.alreadyInjected(ctx, req.Namespace, pod, targets) {
+		return h.noopResponse(ctx, req, pod, []*securityv1alpha1.ZenLock{zenlock}, startTime).WithWarnings(hostPathWarnings...)
+	}
+
+	// Decrypt and materialize the Secret (the write is skipped in dry-run mode)
+	resp = h.materializeTarget(ctx, req, pod, zenlock, target, startTime)
+	if resp.Result != nil {
+		h.recordInjectionFailure(req, pod, zenlock, resp)
+		return resp
+	}
+	hostPathWarnings = append(hostPathWarnings, resp.Warnings...)
+
+	// Mutate without creating secrets in dry-run mode
+	isDryRun := req.DryRun != nil && *req.DryRun
+	if target.shared || len(target.env) > 0 || target.projectMetadata || target.writable || len(target.keySecrets) > 0 {
+		opSuffix := ""
+		if isDryRun {
+			opSuffix = " (dry-run)"
+		}
+		return h.createTargetsMutationResponse(pod, []injectionTarget{target}, req.Namespace, startTime, req.Object.Raw, opSuffix).WithWarnings(hostPathWarnings...)
+	}
+	if isDryRun {
+		return h.handleDryRun(ctx, pod, secretName, mountPath, injectName, req.Namespace, startTime, req.Object.Raw).WithWarnings(hostPathWarnings...)
+	}
+
+	// Mutate Pod object and return response
+	return h.createMutationResponse(pod, secretName, mountPath, injectName, req.Namespace, startTime, req.Object.Raw).WithWarnings(hostPathWarnings...)
+}
+
+// checkHostPathVolumes applies hostPathPolicy to a Pod about to be injected
+// Returns the warnings to attach to the response, or a denial (resp.Result != nil).
+func (h *PodHandler) checkHostPathVolumes(pod *corev1.Pod, namespace string) ([]string, admission.Response) {
+	if h.hostPathPolicy == "" {
+		return nil, admission.Response{}
+	}
+	volumes := HostPathVolumes(pod)
+	if len(volumes) == 0 {
+		return nil, admission.Response{}
+	}
+	if h.hostPathPolicy == config.HostPathPolicyWarn {
+		return []string{fmt.Sprintf("zen-lock: injecting secrets into a Pod with hostPath volumes (%s); their plaintext could be copied to the host", strings.Join(volumes, ", "))}, admission.Response{}
+	}
+	metrics.RecordValidationFailure(namespace, "hostpath_volume")
+	return nil, admission.Denied(fmt.Sprintf("zen-lock does not inject into Pods with hostPath volumes (%s)", strings.Join(volumes, ", ")))
+}
+
+// isInjectionConfirmed reports whether the pod carries zen-lock/confirmed=true as a label or annotation
+func isInjectionConfirmed(pod *corev1.Pod) bool {
+	return pod.GetLabels()[config.AnnotationConfirmed] == "true" || pod.GetAnnotations()[config.AnnotationConfirmed] == "true"
+}
+
+// InjectionDisabled reports whether the ZenLock carries the zen-lock/disabled=true kill switch
+func InjectionDisabled(zenlock *securityv1alpha1.ZenLock) bool {
+	return zenlock.GetAnnotations()[config.AnnotationDisabled] == "true"
+}
+
+// recordInjectionFailure emits a Warning Event on the ZenLock naming the Pod and the failure reason
+// The Pod does not exist yet at admission time, so `kubectl describe zenlock` is where failures surface,
+// notably when failurePolicy=Ignore admits the Pod unmutated. Denials are also counted in the ZenLock's status.
+// Dry-run requests record nothing.
+func (h *PodHandler) recordInjectionFailure(req admission.Request, pod *corev1.Pod, zenlock *securityv1alpha1.ZenLock, resp admission.Response) {
+	if req.DryRun != nil && *req.DryRun {
+		return
+	}
+	reason := "unknown error"
+	if resp.Result != nil && resp.Result.Message != "" {
+		reason = common.RedactMessage(resp.Result.Message)
+	}
+	if h.Denials != nil && resp.Result != nil && resp.Result.Code == http.StatusForbidden {
+		h.Denials.Record(client.ObjectKeyFromObject(zenlock), reason)
+	}
+	if h.Recorder == nil {
+		return
+	}
+	podName := admissionPodName(pod)
+	h.Recorder.Eventf(zenlock, corev1.EventTypeWarning, EventReasonInjectionFailed,
+		"Injection into Pod %s/%s failed: %s", req.Namespace, podName, reason)
+}
+
+// admissionPodName returns the Pod's name, or its generateName prefix when the API server has yet to generate it
+func admissionPodName(pod *corev1.Pod) string {
+	if pod.Name == "" {
+		return pod.GenerateName + "<generated>"
+	}
+	return pod.Name
+}
+
+// materializeTarget validates access to the ZenLock, decrypts it and ensures the target's Secret exists
+// The Secret write is skipped in dry-run mode. Returns a response with a nil Result on success,
+// carrying a warning when optional keys were omitted.
+func (h *PodHandler) materializeTarget(ctx context.Context, req admission.Request, pod *corev1.Pod, zenlock *securityv1alpha1.ZenLock, target injectionTarget, startTime time.Time) admission.Response {
+	injectName := target.zenlockName
+	recordAuditZenLock(ctx, injectName)
+	zenlockKey := types.NamespacedName{
+		Name:      injectName,
+		Namespace: req.Namespace,
+	}
+
+	if resp := h.authorizeTarget(ctx, req, pod, zenlock, injectName, startTime); resp.Result != nil {
+		return resp
+	}
+
+	// Delegated mode: the controller decrypts and creates the Secret once the Pod exists
+	if h.delegateSecretCreation {
+		return admission.Response{}
+	}
+
+	// Wait for a decryption slot; queued admissions give up at the webhook deadline
+	if err := h.decryptLimiter.acquire(ctx); err != nil {
+		duration := time.Since(startTime).Seconds()
+		metrics.RecordWebhookInjection(req.Namespace, injectName, "error", duration)
+		return admission.Errored(http.StatusServiceUnavailable, fmt.Errorf("timed out waiting for decryption capacity"))
+	}
 
 	// Decrypt data
+	metrics.RecordKeyUse(metrics.ComponentWebhook)
 	decryptStart := time.Now()
-	decryptedMap, err := h.crypto.DecryptMap(zenlock.Spec.EncryptedData, h.privateKey)
+	var decryptedMap map[string][]byte
+	var omitted []string
+	encryptedData, err := crypto.EncryptedDataAsBase64(zenlock.Spec.EncryptedData, zenlock.Spec.Encoding)
+	if err == nil {
+		decryptedMap, omitted, err = decryptMapWithTimeout(ctx, h.crypto, encryptedData, zenlock.Spec.OptionalKeys, crypto.SelectKeyID(h.privateKey, zenlock.Spec.KeyID), h.decryptTimeout)
+	}
 	decryptDuration := time.Since(decryptStart).Seconds()
+	h.decryptLimiter.release()
+	if errors.Is(err, ErrDecryptTimeout) {
+		duration := time.Since(startTime).Seconds()
+		metrics.RecordWebhookInjection(req.Namespace, injectName, "error", duration)
+		metrics.RecordDecryption(ctx, req.Namespace, injectName, "timeout", decryptDuration)
+		metrics.RecordDecryptionTimeout(metrics.ComponentWebhook, req.Namespace, injectName)
+		return admission.Errored(http.StatusServiceUnavailable, fmt.Errorf("ZenLock %q: %w", injectName, err))
+	}
 	if err != nil {
 		duration := time.Since(startTime).Seconds()
 		metrics.RecordWebhookInjection(req.Namespace, injectName, "error", duration)
-		metrics.RecordDecryption(req.Namespace, injectName, "error", decryptDuration)
+		metrics.RecordDecryption(ctx, req.Namespace, injectName, "error", decryptDuration)
 		// Invalidate cache on decryption failure (might be stale)
 		h.cache.Invalidate(zenlockKey)
 		// Sanitize error to prevent information leakage
@@ -338,148 +957,623 @@ func (h *PodHandler) Handle(ctx context.Context, req admission.Request) admissio
 		return admission.Errored(http.StatusInternalServerError, sanitizedErr)
 	}
 
+	// Verify decrypted data against expected checksums (if specified)
+	if err := crypto.VerifyChecksums(decryptedMap, crypto.OmitKeys(zenlock.Spec.Checksums, omitted)); err != nil {
+		duration := time.Since(startTime).Seconds()
+		metrics.RecordWebhookInjection(req.Namespace, injectName, "error", duration)
+		metrics.RecordDecryption(ctx, req.Namespace, injectName, "error", decryptDuration)
+		// Invalidate cache on checksum failure (might be stale)
+		h.cache.Invalidate(zenlockKey)
+		sanitizedErr := SanitizeError(err, "verify ZenLock checksums")
+		return admission.Errored(http.StatusInternalServerError, sanitizedErr)
+	}
+
 	// Record successful decryption
-	metrics.RecordDecryption(req.Namespace, injectName, "success", decryptDuration)
+	metrics.RecordDecryption(ctx, req.Namespace, injectName, "success", decryptDuration)
+	warnings := h.omittedKeysWarnings(ctx, req, pod, zenlock, omitted)
+
+	// Resolve ${key} references between keys (opt-in per ZenLock)
+	if TemplatesEnabled(zenlock) {
+		expandedMap, err := ExpandTemplates(decryptedMap)
+		if err != nil {
+			duration := time.Since(startTime).Seconds()
+			metrics.RecordWebhookInjection(req.Namespace, injectName, "denied", duration)
+			return admission.Denied(fmt.Sprintf("ZenLock %q cannot be injected: %v", injectName, err))
+		}
+		decryptedMap = expandedMap
+	}
+
+	// Join keys into one additional entry, e.g. a PEM bundle (zen-lock/concatenate)
+	decryptedMap, err = ApplyConcatenation(zenlock, decryptedMap)
+	if err != nil {
+		duration := time.Since(startTime).Seconds()
+		metrics.RecordWebhookInjection(req.Namespace, injectName, "denied", duration)
+		return admission.Denied(fmt.Sprintf("ZenLock %q cannot be injected: %v", injectName, err))
+	}
+
+	// Append the configured file extensions to key names (zen-lock/key-extensions)
+	decryptedMap, err = ApplyKeyExtensions(zenlock, decryptedMap)
+	if err != nil {
+		duration := time.Since(startTime).Seconds()
+		metrics.RecordWebhookInjection(req.Namespace, injectName, "denied", duration)
+		return admission.Denied(fmt.Sprintf("ZenLock %q cannot be injected: %v", injectName, err))
+	}
+
+	// Normalize the case of key names (zen-lock/key-case)
+	decryptedMap, err = ApplyKeyCase(zenlock, decryptedMap)
+	if err != nil {
+		duration := time.Since(startTime).Seconds()
+		metrics.RecordWebhookInjection(req.Namespace, injectName, "denied", duration)
+		return admission.Denied(fmt.Sprintf("ZenLock %q cannot be injected: %v", injectName, err))
+	}
 
 	// Convert decrypted map to Kubernetes Secret format (base64-encoded strings)
 	// Pre-allocate with known size for better performance (Go 1.25 optimization)
 	secretData := make(map[string][]byte, len(decryptedMap))
 	for k, v := range decryptedMap {
+		// Keep intentionally empty values: an empty key is distinct from a missing one
+		if v == nil {
+			v = []byte{}
+		}
 		secretData[k] = v
 	}
 
-	// Generate stable secret name from namespace and pod name (available at admission time)
-	secretName := GenerateSecretName(req.Namespace, pod.Name)
+	// Ensure the decrypted data fits the requested Secret type
+	if err := ValidateSecretType(zenlock.Spec.SecretType, secretData); err != nil {
+		duration := time.Since(startTime).Seconds()
+		metrics.RecordWebhookInjection(req.Namespace, injectName, "denied", duration)
+		return admission.Denied(fmt.Sprintf("ZenLock %q cannot be injected: %v", injectName, err))
+	}
 
 	// Skip Secret creation/updates in dry-run mode (no side effects)
 	isDryRun := req.DryRun != nil && *req.DryRun
 	if isDryRun {
-		return h.handleDryRun(ctx, pod, secretName, mountPath, injectName, req.Namespace, startTime, req.Object.Raw)
-	}
-
-	// Create ephemeral Secret with labels (OwnerReference will be set by controller later)
-	secret := &corev1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      secretName,
-			Namespace: req.Namespace,
-			Labels: map[string]string{
-				common.LabelPodName:      pod.Name,
-				common.LabelPodNamespace: req.Namespace,
-				common.LabelZenLockName:  injectName,
+		return admission.Response{}.WithWarnings(warnings...)
+	}
+
+	// One Secret holds every key, unless each key gets its own (zen-lock/per-key-secrets)
+	writes, err := secretWrites(target, secretData)
+	if err != nil {
+		duration := time.Since(startTime).Seconds()
+		metrics.RecordWebhookInjection(req.Namespace, injectName, "denied", duration)
+		return admission.Denied(fmt.Sprintf("ZenLock %q cannot be injected: %v", injectName, err))
+	}
+	immutable, err := ResolveSecretImmutability(ctx, h.namespaceReader(), zenlock, h.immutableByDefault)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to read namespace immutability default, using the global default", "namespace", req.Namespace)
+	}
+	retryConfig := common.RetryConfig(config.DefaultRetryMaxAttempts, config.DefaultWebhookRetryInitialDelay, config.DefaultWebhookRetryMaxDelay)
+
+	names := make([]string, 0, len(writes))
+	for name := range writes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		data := writes[name]
+
+		// Create ephemeral Secret with labels (OwnerReference will be set by controller later)
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: req.Namespace,
+				Labels: map[string]string{
+					common.PodNameLabel():      pod.Name,
+					common.PodNamespaceLabel(): req.Namespace,
+					common.ZenLockNameLabel():  injectName,
+				},
 			},
-		},
-		Data: secretData,
+			Type: secretType(zenlock),
+			Data: data,
+		}
+		if immutable {
+			secret.Immutable = &immutable
+		}
+		podName := pod.Name
+		if target.shared {
+			// Shared Secrets outlive any single Pod: owned by the ZenLock and skipped by the orphan cleanup,
+			// which only considers Secrets carrying Pod labels
+			podName = ""
+			delete(secret.Labels, common.PodNameLabel())
+			delete(secret.Labels, common.PodNamespaceLabel())
+			secret.OwnerReferences = []metav1.OwnerReference{zenLockOwnerReference(zenlock, h.blockOwnerDeletion)}
+		}
+
+		// Ensure secret exists and is up-to-date
+		if err := h.ensureSecretExists(ctx, secret, name, injectName, req.Namespace, podName, data, startTime, retryConfig, isDryRun); err != nil {
+			duration := time.Since(startTime).Seconds()
+			var collision *SecretCollisionError
+			if errors.As(err, &collision) {
+				metrics.RecordWebhookInjection(req.Namespace, injectName, "denied", duration)
+				metrics.RecordValidationFailure(req.Namespace, "secret_collision")
+				return admission.Denied(fmt.Sprintf("cannot inject ZenLock %q: %v", injectName, collision))
+			}
+			metrics.RecordWebhookInjection(req.Namespace, injectName, "error", duration)
+			sanitizedErr := SanitizeError(err, "create ephemeral secret")
+			return admission.Errored(http.StatusInternalServerError, sanitizedErr)
+		}
+	}
+
+	return admission.Response{}.WithWarnings(warnings...)
+}
+
+// omittedKeysWarnings reports optional keys left out of the injected Secret because they failed to decrypt
+// The keys are logged and recorded as a Warning Event on the ZenLock (not in dry-run mode);
+// the returned admission warning quotes each key so ZEN_LOCK_REDACT_KEYS applies to it.
+func (h *PodHandler) omittedKeysWarnings(ctx context.Context, req admission.Request, pod *corev1.Pod, zenlock *securityv1alpha1.ZenLock, omitted []string) []string {
+	if len(omitted) == 0 {
+		return nil
+	}
+	quoted := make([]string, len(omitted))
+	for i, key := range omitted {
+		quoted[i] = strconv.Quote(key)
+	}
+	redacted := common.RedactKeyNames(omitted)
+	log.FromContext(ctx).Info("Omitting optional keys that failed to decrypt", "zenlock", zenlock.Name, "keys", redacted)
+	if h.Recorder != nil && (req.DryRun == nil || !*req.DryRun) {
+		h.Recorder.Eventf(zenlock, corev1.EventTypeWarning, EventReasonOptionalKeysOmitted,
+			"Optional keys omitted from Pod %s/%s (failed to decrypt): %s", req.Namespace, admissionPodName(pod), strings.Join(redacted, ", "))
+	}
+	return []string{fmt.Sprintf("zen-lock: ZenLock %q optional keys failed to decrypt and were omitted: %s", zenlock.Name, strings.Join(quoted, ", "))}
+}
+
+// authorizeTarget checks that the ZenLock may be injected into the Pod (kill switch, subjects, algorithm, nodes)
+// Returns a response with a nil Result when it may.
+func (h *PodHandler) authorizeTarget(ctx context.Context, req admission.Request, pod *corev1.Pod, zenlock *securityv1alpha1.ZenLock, injectName string, startTime time.Time) admission.Response {
+	if err := AuthorizeInjection(pod, zenlock); err != nil {
+		duration := time.Since(startTime).Seconds()
+		metrics.RecordWebhookInjection(req.Namespace, injectName, "denied", duration)
+		if algorithm := crypto.ResolveAlgorithm(zenlock.Spec.Algorithm); !crypto.IsRegistered(algorithm) {
+			metrics.RecordAlgorithmError(algorithm, "unsupported")
+		}
+		return admission.Denied(err.Error())
 	}
 
-	// Ensure secret exists and is up-to-date
-	retryConfig := retry.DefaultConfig()
-	retryConfig.MaxAttempts = config.DefaultRetryMaxAttempts
-	retryConfig.InitialDelay = config.DefaultWebhookRetryInitialDelay
-	retryConfig.MaxDelay = config.DefaultWebhookRetryMaxDelay
+	// Defer to the organization's policy engine when configured
+	if h.policyHook != nil {
+		if resp := h.consultPolicyHook(ctx, req, pod, injectName, startTime); resp.Result != nil {
+			return resp
+		}
+	}
 
-	if err := h.ensureSecretExists(ctx, secret, secretName, injectName, req.Namespace, pod.Name, secretData, startTime, retryConfig, isDryRun); err != nil {
+	return admission.Response{}
+}
+
+// AuthorizeInjection checks that the ZenLock may be injected into the Pod
+// The ZenLock must not be disabled, the Pod's ServiceAccount must be an allowed subject, the algorithm must be
+// supported and the Pod must be pinned to the required nodes. The controller runs it again before creating
+// delegated Secrets, as anyone able to annotate the Pod could otherwise request them.
+func AuthorizeInjection(pod *corev1.Pod, zenlock *securityv1alpha1.ZenLock) error {
+	// Kill switch: never inject a disabled ZenLock, whatever the mode
+	if InjectionDisabled(zenlock) {
+		return fmt.Errorf("ZenLock %q is disabled via the %s annotation", zenlock.Name, config.AnnotationDisabled)
+	}
+
+	// Validate AllowedSubjects if specified
+	if len(zenlock.Spec.AllowedSubjects) > 0 {
+		if err := validateAllowedSubjects(pod, zenlock.Spec.AllowedSubjects); err != nil {
+			return fmt.Errorf("Pod ServiceAccount not allowed to use ZenLock %q: %v", zenlock.Name, err)
+		}
+	}
+
+	// An empty algorithm resolves to the configured default, as in the validator and the controller
+	if algorithm := crypto.ResolveAlgorithm(zenlock.Spec.Algorithm); !crypto.IsRegistered(algorithm) {
+		return fmt.Errorf("ZenLock %q uses unsupported algorithm %q", zenlock.Name, algorithm)
+	}
+
+	// Only inject into Pods guaranteed to land on compliant nodes
+	if err := validateRequiredNodeSelector(pod, zenlock.Spec.RequiredNodeSelector); err != nil {
+		return fmt.Errorf("Pod is not pinned to the nodes required by ZenLock %q: %v", zenlock.Name, err)
+	}
+	return nil
+}
+
+// consultPolicyHook asks the external policy endpoint whether the ZenLock may be injected into the Pod
+// Returns a response with a nil Result when it may, or when the endpoint failed and the hook fails open.
+func (h *PodHandler) consultPolicyHook(ctx context.Context, req admission.Request, pod *corev1.Pod, injectName string, startTime time.Time) admission.Response {
+	decision, err := h.policyHook.Decide(ctx, newPolicyRequest(req.Namespace, pod, injectName))
+	if err != nil {
+		if h.policyHook.failOpen {
+			log.FromContext(ctx).Error(err, "Policy endpoint failed, injecting anyway (ZEN_LOCK_POLICY_FAIL_OPEN)", "namespace", req.Namespace, "zenlock", injectName)
+			return admission.Response{}
+		}
 		duration := time.Since(startTime).Seconds()
-		metrics.RecordWebhookInjection(req.Namespace, injectName, "error", duration)
-		sanitizedErr := SanitizeError(err, "create ephemeral secret")
-		return admission.Errored(http.StatusInternalServerError, sanitizedErr)
+		metrics.RecordWebhookInjection(req.Namespace, injectName, "denied", duration)
+		metrics.RecordValidationFailure(req.Namespace, "policy_unavailable")
+		return admission.Denied(fmt.Sprintf("policy endpoint could not authorize injection of ZenLock %q: %v", injectName, err))
 	}
+	if !decision.Allowed {
+		duration := time.Since(startTime).Seconds()
+		metrics.RecordWebhookInjection(req.Namespace, injectName, "denied", duration)
+		metrics.RecordValidationFailure(req.Namespace, "policy_denied")
+		reason := decision.Reason
+		if reason == "" {
+			reason = "no reason given"
+		}
+		return admission.Denied(fmt.Sprintf("policy endpoint denied injection of ZenLock %q: %s", injectName, reason))
+	}
+	return admission.Response{}
+}
 
-	// Mutate Pod object and return response
-	return h.createMutationResponse(pod, secretName, mountPath, injectName, req.Namespace, startTime, req.Object.Raw)
+// zenLockOwnerReference returns a controller OwnerReference to the ZenLock
+// BlockOwnerDeletion is only set when enabled: it requires update on zenlocks/finalizers.
+func zenLockOwnerReference(zenlock *securityv1alpha1.ZenLock, blockOwnerDeletion bool) metav1.OwnerReference {
+	isController := true
+	ref := metav1.OwnerReference{
+		APIVersion: securityv1alpha1.GroupVersion.String(),
+		Kind:       "ZenLock",
+		Name:       zenlock.Name,
+		UID:        zenlock.UID,
+		Controller: &isController,
+	}
+	if blockOwnerDeletion {
+		ref.BlockOwnerDeletion = &blockOwnerDeletion
+	}
+	return ref
+}
+
+// secretType returns the type of the Secret created for a ZenLock
+func secretType(zenlock *securityv1alpha1.ZenLock) corev1.SecretType {
+	if zenlock.Spec.SecretType == "" {
+		return corev1.SecretTypeOpaque
+	}
+	return zenlock.Spec.SecretType
+}
+
+// handleSelectorInjection injects every ZenLock whose InjectionSelector matches the pod's labels
+func (h *PodHandler) handleSelectorInjection(ctx context.Context, req admission.Request, pod *corev1.Pod, startTime time.Time) admission.Response {
+	zenlocks, warnings, err := h.matchSelectorZenLocks(ctx, req.Namespace, pod)
+	if err != nil {
+		// Never block unrelated pods because selector evaluation failed
+		metrics.RecordValidationFailure(req.Namespace, "selector_evaluation_failed")
+		return admission.Allowed("no zen-lock injection requested").WithWarnings(
+			fmt.Sprintf("zen-lock: %v", SanitizeError(err, "evaluate ZenLock injection selectors")))
+	}
+	if len(zenlocks) == 0 {
+		return admission.Allowed("no zen-lock injection requested").WithWarnings(warnings...)
+	}
+	if resp := denyPodWithoutContainers(pod, req.Namespace); resp.Result != nil {
+		return resp
+	}
+
+	mountPath := pod.GetAnnotations()[config.AnnotationMountPath]
+	if mountPath == "" {
+		mountPath = config.DefaultMountPath
+	}
+	if err := ValidateMountPath(mountPath); err != nil {
+		metrics.RecordValidationFailure(req.Namespace, "invalid_mount_path")
+		return admission.Denied(fmt.Sprintf("invalid mount path: %v", err))
+	}
+	injectScope := pod.GetAnnotations()[config.AnnotationInjectScope]
+	if err := ValidateInjectScope(injectScope); err != nil {
+		metrics.RecordValidationFailure(req.Namespace, "invalid_inject_scope")
+		return admission.Denied(fmt.Sprintf("invalid inject scope: %v", err))
+	}
+	secretNaming := pod.GetAnnotations()[config.AnnotationSecretNaming]
+	if err := ValidateSecretNaming(secretNaming); err != nil {
+		metrics.RecordValidationFailure(req.Namespace, "invalid_secret_naming")
+		return admission.Denied(fmt.Sprintf("invalid secret naming: %v", err))
+	}
+
+	targets := selectorTargets(req.Namespace, pod.Name, mountPath, zenlocks)
+	if err := applyMountPathOverrides(targets, pod.GetAnnotations()); err != nil {
+		metrics.RecordValidationFailure(req.Namespace, "invalid_mount_path")
+		return admission.Denied(fmt.Sprintf("invalid mount path: %v", err))
+	}
+	if err := validateUniqueMountPaths(targets); err != nil {
+		metrics.RecordValidationFailure(req.Namespace, "mount_path_collision")
+		return admission.Denied(fmt.Sprintf("invalid mount path: %v", err))
+	}
+	applySecretNaming(targets, secretNaming)
+	if prefix, ok := pod.GetAnnotations()[config.AnnotationEnvPrefix]; ok {
+		if err := applyEnvPrefix(targets, zenlocks, prefix); err != nil {
+			metrics.RecordValidationFailure(req.Namespace, "invalid_env_prefix")
+			return admission.Denied(fmt.Sprintf("invalid env prefix: %v", err))
+		}
+	}
+	if pod.GetAnnotations()[config.AnnotationProjectMetadata] == "true" {
+		if err := applyProjectMetadata(targets, zenlocks); err != nil {
+			metrics.RecordValidationFailure(req.Namespace, "invalid_project_metadata")
+			return admission.Denied(fmt.Sprintf("invalid metadata projection: %v", err))
+		}
+	}
+	if pod.GetAnnotations()[config.AnnotationPerKeySecrets] == "true" {
+		if err := h.applyPerKeySecrets(targets, zenlocks); err != nil {
+			metrics.RecordValidationFailure(req.Namespace, "invalid_per_key_secrets")
+			return admission.Denied(fmt.Sprintf("invalid per-key secrets: %v", err))
+		}
+	}
+	if pod.GetAnnotations()[config.AnnotationMountWritable] == "true" {
+		applyMountWritable(targets)
+	}
+	if err := applyInjectScope(targets, pod, injectScope); err != nil {
+		metrics.RecordValidationFailure(req.Namespace, "invalid_inject_scope")
+		return admission.Denied(fmt.Sprintf("invalid inject scope: %v", err))
+	}
+	hostPathWarnings, resp := h.checkHostPathVolumes(pod, req.Namespace)
+	if resp.Result != nil {
+		return resp
+	}
+	warnings = append(warnings, hostPathWarnings...)
+	if h.alreadyInjected(ctx, req.Namespace, pod, targets) {
+		return h.noopResponse(ctx, req, pod, zenlocks, startTime).WithWarnings(warnings...)
+	}
+	for i := range zenlocks {
+		resp := h.materializeTarget(ctx, req, pod, zenlocks[i], targets[i], startTime)
+		if resp.Result != nil {
+			h.recordInjectionFailure(req, pod, zenlocks[i], resp)
+			return resp
+		}
+		warnings = append(warnings, resp.Warnings...)
+	}
+
+	isDryRun := req.DryRun != nil && *req.DryRun
+	opSuffix := ""
+	if isDryRun {
+		opSuffix = " (dry-run)"
+	}
+	return h.createTargetsMutationResponse(pod, targets, req.Namespace, startTime, req.Object.Raw, opSuffix).WithWarnings(warnings...)
+}
+
+// matchSelectorZenLocks returns the ZenLocks in the namespace whose InjectionSelector matches the pod
+// The list is served by the manager's informer cache, so no API call is made per admission.
+func (h *PodHandler) matchSelectorZenLocks(ctx context.Context, namespace string, pod *corev1.Pod) ([]*securityv1alpha1.ZenLock, []string, error) {
+	zenlockList := &securityv1alpha1.ZenLockList{}
+	if err := h.Client.List(ctx, zenlockList, client.InNamespace(namespace)); err != nil {
+		return nil, nil, err
+	}
+
+	candidates := make([]*securityv1alpha1.ZenLock, 0, len(zenlockList.Items))
+	for i := range zenlockList.Items {
+		if zenlockList.Items[i].Spec.InjectionSelector != nil {
+			candidates = append(candidates, &zenlockList.Items[i])
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, nil, nil
+	}
+
+	// Evaluate in a stable order and bound the work done per admission
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Name < candidates[j].Name })
+	var warnings []string
+	maxZenLocks := h.maxSelectorZenLocks
+	if maxZenLocks <= 0 {
+		maxZenLocks = config.DefaultMaxSelectorZenLocks
+	}
+	if len(candidates) > maxZenLocks {
+		metrics.RecordValidationFailure(namespace, "selector_limit_exceeded")
+		warnings = append(warnings, fmt.Sprintf("zen-lock: namespace %q has %d ZenLocks with injectionSelector, only the first %d are evaluated", namespace, len(candidates), maxZenLocks))
+		candidates = candidates[:maxZenLocks]
+	}
+
+	podLabels := labels.Set(pod.GetLabels())
+	matched := make([]*securityv1alpha1.ZenLock, 0, len(candidates))
+	for _, zenlock := range candidates {
+		selector, err := metav1.LabelSelectorAsSelector(zenlock.Spec.InjectionSelector)
+		if err != nil {
+			metrics.RecordValidationFailure(namespace, "invalid_injection_selector")
+			warnings = append(warnings, fmt.Sprintf("zen-lock: ZenLock %q has an invalid injectionSelector: %v", zenlock.Name, err))
+			continue
+		}
+		if selector.Matches(podLabels) {
+			matched = append(matched, zenlock)
+		}
+	}
+
+	return matched, warnings, nil
+}
+
+// selectorTargets builds injection targets for selector-matched ZenLocks
+// A single match is injected exactly like an annotation request. Multiple matches each get their own
+// Secret and volume, mounted at <mountPath>/<zenlock-name> so they never collide.
+func selectorTargets(namespace, podName, mountPath string, zenlocks []*securityv1alpha1.ZenLock) []injectionTarget {
+	if len(zenlocks) == 1 {
+		return []injectionTarget{{
+			zenlockName: zenlocks[0].Name,
+			secretName:  GenerateSecretName(namespace, podName),
+			volumeName:  config.DefaultVolumeName,
+			mountPath:   mountPath,
+		}}
+	}
+
+	targets := make([]injectionTarget, 0, len(zenlocks))
+	for _, zenlock := range zenlocks {
+		targets = append(targets, injectionTarget{
+			zenlockName: zenlock.Name,
+			secretName:  GenerateSecretName(namespace, podName+"-"+zenlock.Name),
+			volumeName:  GenerateVolumeName(zenlock.Name),
+			mountPath:   path.Join(mountPath, zenlock.Name),
+		})
+	}
+	return targets
+}
+
+// applyMountPathOverrides replaces target mount paths with zen-lock/mount-path.<zenlock-name> annotations
+func applyMountPathOverrides(targets []injectionTarget, annotations map[string]string) error {
+	for i := range targets {
+		override, ok := annotations[config.AnnotationMountPathPrefix+targets[i].zenlockName]
+		if !ok {
+			continue
+		}
+		if err := ValidateMountPath(override); err != nil {
+			return fmt.Errorf("ZenLock %q: %w", targets[i].zenlockName, err)
+		}
+		targets[i].mountPath = override
+	}
+	return nil
+}
+
+// validateUniqueMountPaths ensures no two targets mount at the same path or inside one another
+func validateUniqueMountPaths(targets []injectionTarget) error {
+	for i := range targets {
+		for j := i + 1; j < len(targets); j++ {
+			a, b := targets[i], targets[j]
+			if a.mountPath == b.mountPath || strings.HasPrefix(a.mountPath, b.mountPath+"/") || strings.HasPrefix(b.mountPath, a.mountPath+"/") {
+				return fmt.Errorf("ZenLocks %q and %q collide at mount paths %q and %q", a.zenlockName, b.zenlockName, a.mountPath, b.mountPath)
+			}
+		}
+	}
+	return nil
 }
 
 // createMutationResponse mutates the pod and creates the admission response
 func (h *PodHandler) createMutationResponse(pod *corev1.Pod, secretName, mountPath, injectName, namespace string, startTime time.Time, originalObject []byte) admission.Response {
-	mutatedPod := pod.DeepCopy()
-	if err := h.mutatePod(mutatedPod, secretName, mountPath); err != nil {
+	targets := []injectionTarget{{
+		zenlockName: injectName,
+		secretName:  secretName,
+		volumeName:  config.DefaultVolumeName,
+		mountPath:   mountPath,
+	}}
+	return h.createTargetsMutationResponse(pod, targets, namespace, startTime, originalObject, "")
+}
+
+// createTargetsMutationResponse mutates the pod for every target and creates the admission response
+// opSuffix is appended to sanitized error operations (e.g. " (dry-run)")
+func (h *PodHandler) createTargetsMutationResponse(pod *corev1.Pod, targets []injectionTarget, namespace string, startTime time.Time, originalObject []byte, opSuffix string) admission.Response {
+	recordAll := func(result string) {
 		duration := time.Since(startTime).Seconds()
-		metrics.RecordWebhookInjection(namespace, injectName, "error", duration)
-		sanitizedErr := SanitizeError(err, "mutate pod")
-		return admission.Errored(http.StatusInternalServerError, sanitizedErr)
+		for _, target := range targets {
+			metrics.RecordWebhookInjection(namespace, target.zenlockName, result, duration)
+		}
+	}
+
+	mutatedPod := pod.DeepCopy()
+	for _, target := range targets {
+		if err := h.mutatePodForTarget(mutatedPod, target); err != nil {
+			recordAll("error")
+			sanitizedErr := SanitizeError(err, "mutate pod"+opSuffix)
+			return admission.Errored(http.StatusInternalServerError, sanitizedErr)
+		}
 	}
+	annotateDelegatedSecrets(mutatedPod, targets, h.delegateSecretCreation)
 
 	mutatedPodBytes, err := json.Marshal(mutatedPod)
 	if err != nil {
-		duration := time.Since(startTime).Seconds()
-		metrics.RecordWebhookInjection(namespace, injectName, "error", duration)
-		sanitizedErr := SanitizeError(err, "marshal mutated pod")
+		recordAll("error")
+		sanitizedErr := SanitizeError(err, "marshal mutated pod"+opSuffix)
 		return admission.Errored(http.StatusInternalServerError, sanitizedErr)
 	}
 
-	duration := time.Since(startTime).Seconds()
-	metrics.RecordWebhookInjection(namespace, injectName, "success", duration)
+	recordAll("success")
 	return admission.PatchResponseFromRaw(originalObject, mutatedPodBytes)
 }
 
+// annotateDelegatedSecrets records which Secrets the controller must create for the Pod
+// Any value the Pod was submitted with is replaced, or dropped when creation is not delegated.
+func annotateDelegatedSecrets(pod *corev1.Pod, targets []injectionTarget, delegate bool) {
+	delete(pod.Annotations, config.AnnotationDelegatedSecrets)
+	if !delegate {
+		return
+	}
+	secrets := make(map[string]string, len(targets))
+	for _, target := range targets {
+		secrets[target.zenlockName] = target.secretName
+	}
+	if len(secrets) == 0 {
+		return
+	}
+	if pod.Annotations == nil {
+		pod.Annotations = make(map[string]string, 1)
+	}
+	pod.Annotations[config.AnnotationDelegatedSecrets] = FormatDelegatedSecrets(secrets)
+}
+
 // mutatePod mutates the Pod object in-memory to add volume and volume mounts
 func (h *PodHandler) mutatePod(pod *corev1.Pod, secretName, mountPath string) error {
+	return h.mutatePodForTarget(pod, injectionTarget{
+		secretName: secretName,
+		volumeName: config.DefaultVolumeName,
+		mountPath:  mountPath,
+	})
+}
+
+// mutatePodForTarget adds the target's Secret volume and mounts it into every container
+func (h *PodHandler) mutatePodForTarget(pod *corev1.Pod, target injectionTarget) error {
 	// Check if volume already exists
 	volumeExists := false
 	for _, vol := range pod.Spec.Volumes {
-		if vol.Name == config.DefaultVolumeName {
+		if vol.Name == target.volumeName {
 			volumeExists = true
 			break
 		}
 	}
 
+	source := corev1.VolumeSource{
+		Secret: &corev1.SecretVolumeSource{
+			SecretName: target.secretName,
+		},
+	}
+	if len(target.keySecrets) > 0 {
+		source = perKeyVolumeSource(target)
+	} else if target.projectMetadata {
+		source = projectedMetadataVolumeSource(target.secretName)
+	}
+
 	// Add volume to pod spec if it doesn't exist
-	if !volumeExists {
-		volume := corev1.Volume{
-			Name: config.DefaultVolumeName,
-			VolumeSource: corev1.VolumeSource{
-				Secret: &corev1.SecretVolumeSource{
-					SecretName: secretName,
-				},
-			},
-		}
-		pod.Spec.Volumes = append(pod.Spec.Volumes, volume)
+	if target.writable {
+		addWritableVolumes(pod, target, source)
+	} else if !volumeExists {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{Name: target.volumeName, VolumeSource: source})
 	}
 
-	// Add volume mount to all containers
-	for i := range pod.Spec.Containers {
-		// Check if mount already exists
-		mountExists := false
-		for _, mount := range pod.Spec.Containers[i].VolumeMounts {
-			if mount.Name == config.DefaultVolumeName {
-				mountExists = true
-				break
-			}
+	// Add volume mount to the containers in scope (all containers and init containers by default)
+	containers := scopedContainers(pod, target.scope)
+	for _, c := range containers {
+		addVolumeMount(c, target)
+	}
+
+	// Expose keys as environment variables (zen-lock/env-prefix)
+	if len(target.env) > 0 {
+		for _, c := range containers {
+			addEnvVars(c, target.env)
 		}
-		if !mountExists {
-			volumeMount := corev1.VolumeMount{
-				Name:      config.DefaultVolumeName,
-				MountPath: mountPath,
-				ReadOnly:  true,
-			}
-			pod.Spec.Containers[i].VolumeMounts = append(pod.Spec.Containers[i].VolumeMounts, volumeMount)
+	}
+
+	return nil
+}
+
+// addVolumeMount mounts the target's volume into a container unless it is already mounted
+func addVolumeMount(container *corev1.Container, target injectionTarget) {
+	for _, mount := range container.VolumeMounts {
+		if mount.Name == target.volumeName {
+			return
 		}
 	}
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      target.volumeName,
+		MountPath: target.mountPath,
+		ReadOnly:  !target.writable,
+	})
+}
 
-	// Add volume mount to all init containers
-	for i := range pod.Spec.InitContainers {
-		// Check if mount already exists
-		mountExists := false
-		for _, mount := range pod.Spec.InitContainers[i].VolumeMounts {
-			if mount.Name == config.DefaultVolumeName {
-				mountExists = true
-				break
-			}
+// validateRequiredNodeSelector checks that the Pod's nodeSelector carries every required label
+// This is best-effort: node affinity is not evaluated, so a Pod must use nodeSelector to qualify.
+func validateRequiredNodeSelector(pod *corev1.Pod, required map[string]string) error {
+	keys := make([]string, 0, len(required))
+	for key := range required {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value, ok := pod.Spec.NodeSelector[key]
+		if !ok {
+			return fmt.Errorf("nodeSelector is missing %s=%s", key, required[key])
 		}
-		if !mountExists {
-			volumeMount := corev1.VolumeMount{
-				Name:      config.DefaultVolumeName,
-				MountPath: mountPath,
-				ReadOnly:  true,
-			}
-			pod.Spec.InitContainers[i].VolumeMounts = append(pod.Spec.InitContainers[i].VolumeMounts, volumeMount)
+		if value != required[key] {
+			return fmt.Errorf("nodeSelector has %s=%s, want %s", key, value, required[key])
 		}
 	}
-
 	return nil
 }
 
 // validateAllowedSubjects checks if the Pod's ServiceAccount is allowed to use the ZenLock
 func (h *PodHandler) validateAllowedSubjects(ctx context.Context, pod *corev1.Pod, allowedSubjects []securityv1alpha1.SubjectReference) error {
+	return validateAllowedSubjects(pod, allowedSubjects)
+}
+
+// validateAllowedSubjects checks if the Pod's ServiceAccount is one of the allowed subjects
+func validateAllowedSubjects(pod *corev1.Pod, allowedSubjects []securityv1alpha1.SubjectReference) error {
 	podServiceAccount := pod.Spec.ServiceAccountName
 	if podServiceAccount == "" {
 		podServiceAccount = "default"
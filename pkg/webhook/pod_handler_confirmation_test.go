@@ -0,0 +1,87 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+func TestPodHandler_Handle_Confirmation(t *testing.T) {
+	tests := []struct {
+		name                string
+		requireConfirmation bool
+		labels              map[string]string
+		annotations         map[string]string
+		wantInjected        bool
+	}{
+		{
+			name:         "disabled injects without confirmation",
+			wantInjected: true,
+		},
+		{
+			name:                "confirmed by label",
+			requireConfirmation: true,
+			labels:              map[string]string{config.AnnotationConfirmed: "true"},
+			wantInjected:        true,
+		},
+		{
+			name:                "confirmed by annotation",
+			requireConfirmation: true,
+			annotations:         map[string]string{config.AnnotationConfirmed: "true"},
+			wantInjected:        true,
+		},
+		{
+			name:                "unconfirmed is skipped",
+			requireConfirmation: true,
+		},
+		{
+			name:                "confirmation must be true",
+			requireConfirmation: true,
+			labels:              map[string]string{config.AnnotationConfirmed: "false"},
+		},
+	}
+
+	for _, mode := range injectionModes {
+		for _, tt := range tests {
+			t.Run(mode.name+"/"+tt.name, func(t *testing.T) {
+				zenlock := &securityv1alpha1.ZenLock{ObjectMeta: metav1.ObjectMeta{Name: "test-zenlock", Namespace: "default"}}
+				handler := injectionTestHandler(t, zenlock, mode.delegate)
+				handler.requireConfirmation = tt.requireConfirmation
+				pod := injectionTestPod(tt.labels, tt.annotations)
+
+				resp := admitTestPod(t, handler, pod)
+				if !resp.Allowed {
+					t.Fatalf("Expected request to be allowed, got: %v", resp.Result)
+				}
+				if injected := len(resp.Patches) > 0; injected != tt.wantInjected {
+					t.Errorf("Expected injected=%v, got %d patches", tt.wantInjected, len(resp.Patches))
+				}
+				if created := injectedSecretExists(t, handler, pod); created != (tt.wantInjected && !mode.delegate) {
+					t.Errorf("Expected Secret created by the webhook=%v, got %v", tt.wantInjected && !mode.delegate, created)
+				}
+				if !tt.wantInjected && len(resp.Warnings) == 0 {
+					t.Error("Expected a warning for the skipped injection")
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+func TestPodHandler_Handle_DelegatedSecretCreation(t *testing.T) {
+	handler, clientBuilder := setupTestPodHandler(t)
+	handler.delegateSecretCreation = true
+
+	// Not decryptable with the handler's key: delegated mode must not decrypt in the webhook
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-zenlock",
+			Namespace: "default",
+		},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{"key": "ZW5jcnlwdGVk"},
+		},
+	}
+	handler.Client = clientBuilder.WithObjects(zenlock).Build()
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pod",
+			Namespace: "default",
+			Annotations: map[string]string{
+				config.AnnotationInject: "test-zenlock",
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "test-container", Image: "nginx"},
+			},
+		},
+	}
+	podRaw, _ := json.Marshal(pod)
+	req := admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Object:    runtime.RawExtension{Raw: podRaw},
+			Namespace: "default",
+		},
+	}
+
+	resp := handler.Handle(context.Background(), req)
+	if !resp.Allowed {
+		t.Fatalf("Expected request to be allowed, got: %v", resp.Result)
+	}
+
+	// The Pod still gets its Secret volume, plus the annotation telling the controller what to create
+	foundVolume, foundAnnotation := false, false
+	expected := "test-zenlock=" + GenerateSecretName("default", "test-pod")
+	for _, patch := range resp.Patches {
+		if patch.Path == "/spec/volumes" {
+			foundVolume = true
+		}
+		if strings.HasPrefix(patch.Path, "/metadata/annotations") {
+			raw, _ := json.Marshal(patch.Value)
+			if strings.Contains(string(raw), expected) {
+				foundAnnotation = true
+			}
+		}
+	}
+	if !foundVolume {
+		t.Error("Expected Secret volume to be injected")
+	}
+	if !foundAnnotation {
+		t.Errorf("Expected %s annotation with %q, got patches %+v", config.AnnotationDelegatedSecrets, expected, resp.Patches)
+	}
+
+	secrets := &corev1.SecretList{}
+	if err := handler.Client.List(context.Background(), secrets); err != nil {
+		t.Fatalf("Failed to list secrets: %v", err)
+	}
+	if len(secrets.Items) != 0 {
+		t.Errorf("Expected webhook to create no Secret, got %d", len(secrets.Items))
+	}
+}
+
+func TestSecretCreationDelegated(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{value: "", want: false},
+		{value: "true", want: false},
+		{value: "false", want: true},
+		{value: "invalid", want: false},
+	}
+	for _, tt := range tests {
+		t.Setenv("ZEN_LOCK_WEBHOOK_CREATE_SECRET", tt.value)
+		if got := SecretCreationDelegated(); got != tt.want {
+			t.Errorf("SecretCreationDelegated() with %q = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestDelegatedSecretsRoundTrip(t *testing.T) {
+	secrets := map[string]string{"b-zenlock": "secret-b", "a-zenlock": "secret-a"}
+	value := FormatDelegatedSecrets(secrets)
+	if value != "a-zenlock=secret-a,b-zenlock=secret-b" {
+		t.Errorf("Expected sorted pairs, got %q", value)
+	}
+	parsed := ParseDelegatedSecrets(value + ",malformed,=x")
+	if len(parsed) != 2 || parsed["a-zenlock"] != "secret-a" || parsed["b-zenlock"] != "secret-b" {
+		t.Errorf("Unexpected parse result: %v", parsed)
+	}
+}
+
+func delegatedTestRequest(t *testing.T, annotations map[string]string) admission.Request {
+	t.Helper()
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-pod",
+			Namespace:   "default",
+			Annotations: annotations,
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "test-container", Image: "nginx"},
+			},
+		},
+	}
+	podRaw, err := json.Marshal(pod)
+	if err != nil {
+		t.Fatalf("Failed to marshal pod: %v", err)
+	}
+	return admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Object:    runtime.RawExtension{Raw: podRaw},
+			Namespace: "default",
+		},
+	}
+}
+
+func TestPodHandler_Handle_StripsSuppliedDelegatedSecrets(t *testing.T) {
+	handler, _ := setupTestPodHandler(t)
+	handler.delegateSecretCreation = true
+
+	// No injection requested: the Pod is admitted, without the annotation it was submitted with
+	req := delegatedTestRequest(t, map[string]string{
+		config.AnnotationDelegatedSecrets: "test-zenlock=victim-secret",
+	})
+	resp := handler.Handle(context.Background(), req)
+	if !resp.Allowed {
+		t.Fatalf("Expected request to be allowed, got: %v", resp.Result)
+	}
+	if len(resp.Patches) != 1 || resp.Patches[0].Operation != "remove" || resp.Patches[0].Path != "/metadata/annotations/zen-lock~1delegated-secrets" {
+		t.Errorf("Expected the supplied annotation to be removed, got patches %+v", resp.Patches)
+	}
+}
+
+func TestPodHandler_Handle_DropsSuppliedDelegatedSecretsWhenNotDelegating(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	handler, clientBuilder := setupTestPodHandlerWithKey(t, identity.String())
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-zenlock", Namespace: "default"},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{"key": encryptTestData(t, "value", identity.Recipient().String())},
+		},
+	}
+	handler.Client = clientBuilder.WithObjects(zenlock).Build()
+
+	req := delegatedTestRequest(t, map[string]string{
+		config.AnnotationInject:           "test-zenlock",
+		config.AnnotationDelegatedSecrets: "test-zenlock=victim-secret",
+	})
+	resp := handler.Handle(context.Background(), req)
+	if !resp.Allowed {
+		t.Fatalf("Expected request to be allowed, got: %v", resp.Result)
+	}
+	removed := false
+	for _, patch := range resp.Patches {
+		if patch.Path == "/metadata/annotations/zen-lock~1delegated-secrets" {
+			removed = patch.Operation == "remove"
+		}
+	}
+	if !removed {
+		t.Errorf("Expected the supplied annotation to be removed, got patches %+v", resp.Patches)
+	}
+}
+
+func TestDelegatedSecretNames(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "default"}}
+	names := DelegatedSecretNames(pod, []string{"a"})
+	if names["a"] != GenerateSecretName("default", "test-pod") {
+		t.Errorf("Single ZenLock: got %q", names["a"])
+	}
+
+	names = DelegatedSecretNames(pod, []string{"a", "b"})
+	if names["a"] != GenerateSecretName("default", "test-pod-a") || names["b"] != GenerateSecretName("default", "test-pod-b") {
+		t.Errorf("Multiple ZenLocks: got %v", names)
+	}
+
+	pod.Annotations = map[string]string{config.AnnotationSecretNaming: config.SecretNamingZenLock}
+	names = DelegatedSecretNames(pod, []string{"a"})
+	if names["a"] != GenerateZenLockSecretName("a") {
+		t.Errorf("Shared naming: got %q", names["a"])
+	}
+
+	// The webhook saw no name for Pods named from generateName
+	generated := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-abcde", GenerateName: "web-", Namespace: "default"}}
+	names = DelegatedSecretNames(generated, []string{"a"})
+	if names["a"] != GenerateSecretName("default", "") {
+		t.Errorf("generateName Pod: got %q", names["a"])
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+func TestPodHandler_Handle_DisabledZenLock(t *testing.T) {
+	for _, mode := range injectionModes {
+		t.Run(mode.name, func(t *testing.T) {
+			zenlock := &securityv1alpha1.ZenLock{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-zenlock",
+					Namespace:   "default",
+					Annotations: map[string]string{config.AnnotationDisabled: "true"},
+				},
+			}
+			handler := injectionTestHandler(t, zenlock, mode.delegate)
+			RegisterCache(handler.cache)
+			defer UnregisterCache(handler.cache)
+			pod := injectionTestPod(nil, nil)
+			ctx := context.Background()
+
+			resp := admitTestPod(t, handler, pod)
+			if resp.Allowed {
+				t.Fatal("Expected injection of a disabled ZenLock to be denied")
+			}
+			if resp.Result == nil || !strings.Contains(resp.Result.Message, "disabled") {
+				t.Errorf("Expected a ZenLock disabled message, got %v", resp.Result)
+			}
+			if injectedSecretExists(t, handler, pod) {
+				t.Error("Expected no Secret for a disabled ZenLock")
+			}
+
+			// Re-enable: the reconciler invalidates the cached copy, so the next admission sees the change
+			current := &securityv1alpha1.ZenLock{}
+			key := types.NamespacedName{Name: "test-zenlock", Namespace: "default"}
+			if err := handler.Client.Get(ctx, key, current); err != nil {
+				t.Fatalf("Failed to get ZenLock: %v", err)
+			}
+			delete(current.Annotations, config.AnnotationDisabled)
+			if err := handler.Client.Update(ctx, current); err != nil {
+				t.Fatalf("Failed to re-enable ZenLock: %v", err)
+			}
+			InvalidateZenLock(key)
+
+			resp = admitTestPod(t, handler, pod)
+			if !resp.Allowed {
+				t.Fatalf("Expected re-enabled ZenLock to be injected, got %v", resp.Result)
+			}
+			if len(resp.Patches) == 0 {
+				t.Error("Expected the Pod to be mutated after re-enabling")
+			}
+			if created := injectedSecretExists(t, handler, pod); created != !mode.delegate {
+				t.Errorf("Expected Secret created by the webhook=%v, got %v", !mode.delegate, created)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+func envTestZenLock(keys ...string) *securityv1alpha1.ZenLock {
+	data := make(map[string]string, len(keys))
+	for _, k := range keys {
+		data[k] = "ZW5jcnlwdGVk"
+	}
+	return &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-zenlock", Namespace: "default"},
+		Spec:       securityv1alpha1.ZenLockSpec{EncryptedData: data},
+	}
+}
+
+func TestEnvVarName(t *testing.T) {
+	tests := []struct {
+		prefix, key, want string
+	}{
+		{prefix: "APP_", key: "DB_PASSWORD", want: "APP_DB_PASSWORD"},
+		{prefix: "APP_", key: "db.host", want: "APP_DB_HOST"},
+		{prefix: "", key: "api-key", want: "API_KEY"},
+		{prefix: "APP_", key: "tls.crt", want: "APP_TLS_CRT"},
+	}
+	for _, tt := range tests {
+		if got := EnvVarName(tt.prefix, tt.key); got != tt.want {
+			t.Errorf("EnvVarName(%q, %q) = %q, want %q", tt.prefix, tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestApplyEnvPrefix(t *testing.T) {
+	target := injectionTarget{zenlockName: "test-zenlock", secretName: "zen-lock-inject-default-app"}
+
+	targets := []injectionTarget{target}
+	if err := applyEnvPrefix(targets, []*securityv1alpha1.ZenLock{envTestZenLock("password", "db.host")}, "APP_"); err != nil {
+		t.Fatalf("applyEnvPrefix failed: %v", err)
+	}
+	env := targets[0].env
+	if len(env) != 2 || env[0].Name != "APP_DB_HOST" || env[1].Name != "APP_PASSWORD" {
+		t.Fatalf("Unexpected env vars: %+v", env)
+	}
+	ref := env[0].ValueFrom.SecretKeyRef
+	if ref.Name != target.secretName || ref.Key != "db.host" {
+		t.Errorf("Expected APP_DB_HOST to read db.host from %s, got %+v", target.secretName, ref)
+	}
+
+	invalid := []struct {
+		name    string
+		prefix  string
+		zenlock *securityv1alpha1.ZenLock
+	}{
+		{name: "lowercase prefix", prefix: "app_", zenlock: envTestZenLock("key")},
+		{name: "prefix starting with digit", prefix: "1APP_", zenlock: envTestZenLock("key")},
+		{name: "key starting with digit", prefix: "", zenlock: envTestZenLock("1st")},
+		{name: "colliding keys", prefix: "APP_", zenlock: envTestZenLock("db.host", "DB_HOST")},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := applyEnvPrefix([]injectionTarget{target}, []*securityv1alpha1.ZenLock{tt.zenlock}, tt.prefix); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestAddEnvVars_KeepsExisting(t *testing.T) {
+	container := &corev1.Container{Env: []corev1.EnvVar{{Name: "APP_KEY", Value: "explicit"}}}
+	addEnvVars(container, []corev1.EnvVar{{Name: "APP_KEY", Value: "injected"}, {Name: "APP_OTHER", Value: "injected"}})
+
+	if len(container.Env) != 2 || container.Env[0].Value != "explicit" || container.Env[1].Name != "APP_OTHER" {
+		t.Errorf("Expected explicit env var to win, got %+v", container.Env)
+	}
+}
+
+func TestPodHandler_Handle_EnvPrefix(t *testing.T) {
+	resp := handleAnnotatedPod(t, map[string]string{config.AnnotationEnvPrefix: "APP_"})
+	if !resp.Allowed {
+		t.Fatalf("Expected injection to be allowed, got %v", resp.Result)
+	}
+	patches, err := json.Marshal(resp.Patches)
+	if err != nil {
+		t.Fatalf("Failed to marshal patches: %v", err)
+	}
+	for _, want := range []string{`"name":"APP_KEY"`, `"secretKeyRef"`, `/spec/volumes`} {
+		if !strings.Contains(string(patches), want) {
+			t.Errorf("Expected patches to contain %s, got %s", want, patches)
+		}
+	}
+
+	resp = handleAnnotatedPod(t, map[string]string{config.AnnotationEnvPrefix: "app-"})
+	if resp.Allowed {
+		t.Error("Expected an invalid env prefix to be denied")
+	}
+}
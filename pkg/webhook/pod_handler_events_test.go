@@ -0,0 +1,120 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+// handleUndecryptableInjection injects a ZenLock encrypted to a key the webhook does not hold
+func handleUndecryptableInjection(t *testing.T, dryRun bool) (admission.Response, *record.FakeRecorder) {
+	webhookIdentity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	otherIdentity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-zenlock",
+			Namespace: "default",
+		},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{
+				"key": encryptTestData(t, "value", otherIdentity.Recipient().String()),
+			},
+		},
+	}
+
+	handler, clientBuilder := setupTestPodHandlerWithKey(t, webhookIdentity.String())
+	handler.Client = clientBuilder.WithObjects(zenlock).Build()
+	recorder := record.NewFakeRecorder(10)
+	handler.Recorder = recorder
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "web-1",
+			Namespace:   "default",
+			Annotations: map[string]string{config.AnnotationInject: "test-zenlock"},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "test-container", Image: "nginx"}},
+		},
+	}
+	podRaw, _ := json.Marshal(pod)
+	req := admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Object:    runtime.RawExtension{Raw: podRaw},
+			Namespace: "default",
+			DryRun:    &dryRun,
+		},
+	}
+
+	return handler.Handle(context.Background(), req), recorder
+}
+
+func TestPodHandler_Handle_InjectionFailureEvent(t *testing.T) {
+	resp, recorder := handleUndecryptableInjection(t, false)
+	if resp.Allowed {
+		t.Fatal("Expected injection of an undecryptable ZenLock to fail")
+	}
+
+	select {
+	case event := <-recorder.Events:
+		if !strings.HasPrefix(event, corev1.EventTypeWarning+" "+EventReasonInjectionFailed) {
+			t.Errorf("Expected Warning %s event, got %q", EventReasonInjectionFailed, event)
+		}
+		if !strings.Contains(event, "default/web-1") {
+			t.Errorf("Expected event to name the Pod, got %q", event)
+		}
+		if !strings.Contains(event, resp.Result.Message) {
+			t.Errorf("Expected event to carry the failure reason %q, got %q", resp.Result.Message, event)
+		}
+	default:
+		t.Fatal("Expected an event on the ZenLock")
+	}
+}
+
+func TestPodHandler_Handle_InjectionFailureEventSkippedOnDryRun(t *testing.T) {
+	resp, recorder := handleUndecryptableInjection(t, true)
+	if resp.Allowed {
+		t.Fatal("Expected injection of an undecryptable ZenLock to fail")
+	}
+
+	select {
+	case event := <-recorder.Events:
+		t.Errorf("Expected no event for a dry-run request, got %q", event)
+	default:
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+func TestPodHandler_Handle_HostPathPolicy(t *testing.T) {
+	hostPath := []corev1.Volume{
+		{Name: "host-logs", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/var/log"}}},
+		{Name: "scratch", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+	}
+	emptyDir := hostPath[1:]
+
+	tests := []struct {
+		name         string
+		policy       string
+		volumes      []corev1.Volume
+		wantAllowed  bool
+		wantWarnings bool
+	}{
+		{name: "hostPath pod denied when enabled", policy: config.HostPathPolicyDeny, volumes: hostPath},
+		{name: "hostPath pod allowed when disabled", volumes: hostPath, wantAllowed: true},
+		{name: "hostPath pod warned", policy: config.HostPathPolicyWarn, volumes: hostPath, wantAllowed: true, wantWarnings: true},
+		{name: "pod without hostPath allowed when enabled", policy: config.HostPathPolicyDeny, volumes: emptyDir, wantAllowed: true},
+	}
+
+	for _, mode := range injectionModes {
+		for _, tt := range tests {
+			t.Run(mode.name+"/"+tt.name, func(t *testing.T) {
+				zenlock := &securityv1alpha1.ZenLock{ObjectMeta: metav1.ObjectMeta{Name: "test-zenlock", Namespace: "default"}}
+				handler := injectionTestHandler(t, zenlock, mode.delegate)
+				handler.hostPathPolicy = tt.policy
+				pod := injectionTestPod(nil, nil)
+				pod.Spec.Volumes = tt.volumes
+
+				resp := admitTestPod(t, handler, pod)
+				if resp.Allowed != tt.wantAllowed {
+					t.Fatalf("Expected allowed=%v, got: %v", tt.wantAllowed, resp.Result)
+				}
+				if tt.wantAllowed && len(resp.Patches) == 0 {
+					t.Error("Expected the Pod to be injected")
+				}
+				if created := injectedSecretExists(t, handler, pod); created != (tt.wantAllowed && !mode.delegate) {
+					t.Errorf("Expected Secret created by the webhook=%v, got %v", tt.wantAllowed && !mode.delegate, created)
+				}
+				if hasWarnings := len(resp.Warnings) > 0; hasWarnings != tt.wantWarnings {
+					t.Errorf("Expected warnings=%v, got %v", tt.wantWarnings, resp.Warnings)
+				}
+			})
+		}
+	}
+}
+
+func TestHostPathPolicy(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "", want: ""},
+		{value: "false", want: ""},
+		{value: "true", want: config.HostPathPolicyDeny},
+		{value: "deny", want: config.HostPathPolicyDeny},
+		{value: "Warn", want: config.HostPathPolicyWarn},
+		{value: "bogus", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv("ZEN_LOCK_DENY_HOSTPATH_PODS", tt.value)
+			if got := HostPathPolicy(); got != tt.want {
+				t.Errorf("HostPathPolicy() with %q = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"filippo.io/age"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/common"
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func immutableTestNamespace(annotation string) *corev1.Namespace {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
+	if annotation != "" {
+		ns.Annotations = map[string]string{config.AnnotationDefaultImmutable: annotation}
+	}
+	return ns
+}
+
+func TestResolveSecretImmutability(t *testing.T) {
+	tests := []struct {
+		name          string
+		spec          *bool
+		namespace     *corev1.Namespace
+		globalDefault bool
+		want          bool
+	}{
+		{name: "global default", namespace: immutableTestNamespace(""), globalDefault: true, want: true},
+		{name: "namespace default over global", namespace: immutableTestNamespace("true"), want: true},
+		{name: "namespace opt-out over global", namespace: immutableTestNamespace("false"), globalDefault: true},
+		{name: "ZenLock over namespace", spec: boolPtr(false), namespace: immutableTestNamespace("true")},
+		{name: "ZenLock over global", spec: boolPtr(true), namespace: immutableTestNamespace(""), want: true},
+		{name: "invalid namespace annotation ignored", namespace: immutableTestNamespace("yes"), globalDefault: true, want: true},
+		{name: "missing namespace uses global", globalDefault: true, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, clientBuilder := setupTestPodHandler(t)
+			if tt.namespace != nil {
+				clientBuilder = clientBuilder.WithObjects(tt.namespace)
+			}
+			handler.Client = clientBuilder.Build()
+
+			zenlock := &securityv1alpha1.ZenLock{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-zenlock", Namespace: "default"},
+				Spec:       securityv1alpha1.ZenLockSpec{Immutable: tt.spec},
+			}
+			got, err := ResolveSecretImmutability(context.Background(), handler.Client, zenlock, tt.globalDefault)
+			if err != nil {
+				t.Fatalf("ResolveSecretImmutability returned error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected immutable=%v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// immutableTestHandler returns a handler whose client, like the API server, rejects updates to immutable Secrets
+func immutableTestHandler(t *testing.T, spec *bool, objs ...client.Object) (*PodHandler, client.Client) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-zenlock", Namespace: "default"},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{"USERNAME": encryptTestData(t, "admin", identity.Recipient().String())},
+			Immutable:     spec,
+		},
+	}
+
+	handler, clientBuilder := setupTestPodHandlerWithKey(t, identity.String())
+	c := clientBuilder.WithObjects(append(objs, zenlock)...).WithInterceptorFuncs(interceptor.Funcs{
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			if secret, ok := obj.(*corev1.Secret); ok {
+				stored := &corev1.Secret{}
+				if err := c.Get(ctx, client.ObjectKeyFromObject(secret), stored); err == nil && SecretImmutable(stored) {
+					return fmt.Errorf("secret %q is immutable", secret.Name)
+				}
+			}
+			return c.Update(ctx, obj, opts...)
+		},
+	}).Build()
+	handler.Client = c
+	return handler, c
+}
+
+func handleImmutablePod(t *testing.T, handler *PodHandler, c client.Client) *corev1.Secret {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "app",
+			Namespace:   "default",
+			Annotations: map[string]string{config.AnnotationInject: "test-zenlock"},
+		},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "test-container", Image: "nginx"}}},
+	}
+	podRaw, _ := json.Marshal(pod)
+	req := admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			Object:    runtime.RawExtension{Raw: podRaw},
+			Namespace: "default",
+		},
+	}
+
+	resp := handler.Handle(context.Background(), req)
+	if !resp.Allowed {
+		t.Fatalf("Expected injection to be allowed, got: %v", resp.Result)
+	}
+	secret := &corev1.Secret{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: GenerateSecretName("default", "app"), Namespace: "default"}, secret); err != nil {
+		t.Fatalf("Failed to get Secret: %v", err)
+	}
+	return secret
+}
+
+func TestPodHandler_Handle_NamespaceDefaultImmutable(t *testing.T) {
+	handler, c := immutableTestHandler(t, nil, immutableTestNamespace("true"))
+
+	secret := handleImmutablePod(t, handler, c)
+	if !SecretImmutable(secret) {
+		t.Error("Expected the Secret to be immutable by namespace default")
+	}
+}
+
+func TestPodHandler_Handle_ZenLockOverridesNamespaceImmutable(t *testing.T) {
+	handler, c := immutableTestHandler(t, boolPtr(false), immutableTestNamespace("true"))
+
+	secret := handleImmutablePod(t, handler, c)
+	if SecretImmutable(secret) {
+		t.Error("Expected the ZenLock to override the namespace default to mutable")
+	}
+}
+
+func TestPodHandler_Handle_RefreshesImmutableSecret(t *testing.T) {
+	stale := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GenerateSecretName("default", "app"),
+			Namespace: "default",
+			Labels: map[string]string{
+				common.LabelPodName:      "app",
+				common.LabelPodNamespace: "default",
+				common.LabelZenLockName:  "test-zenlock",
+			},
+		},
+		Data:      map[string][]byte{"USERNAME": []byte("stale")},
+		Immutable: boolPtr(true),
+	}
+	handler, c := immutableTestHandler(t, nil, immutableTestNamespace("true"), stale)
+
+	secret := handleImmutablePod(t, handler, c)
+	if string(secret.Data["USERNAME"]) != "admin" {
+		t.Errorf("Expected the stale immutable Secret to be recreated with fresh data, got %q", secret.Data["USERNAME"])
+	}
+	if !SecretImmutable(secret) {
+		t.Error("Expected the recreated Secret to stay immutable")
+	}
+}
+
+func TestPodHandler_Handle_ImmutableSecretBecomesMutable(t *testing.T) {
+	current := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GenerateSecretName("default", "app"),
+			Namespace: "default",
+			Labels:    map[string]string{common.LabelZenLockName: "test-zenlock"},
+		},
+		Data:      map[string][]byte{"USERNAME": []byte("admin")},
+		Immutable: boolPtr(true),
+	}
+	handler, c := immutableTestHandler(t, boolPtr(false), current)
+
+	secret := handleImmutablePod(t, handler, c)
+	if SecretImmutable(secret) {
+		t.Error("Expected the Secret to be recreated as mutable")
+	}
+}
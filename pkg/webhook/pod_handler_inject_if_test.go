@@ -0,0 +1,103 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"testing"
+
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+func TestParseInjectCondition(t *testing.T) {
+	tests := []struct {
+		expr    string
+		want    InjectCondition
+		wantErr bool
+	}{
+		{expr: "env=prod", want: InjectCondition{Key: "env", Value: "prod"}},
+		{expr: "example.com/env != prod", want: InjectCondition{Key: "example.com/env", Value: "prod", Negate: true}},
+		{expr: "env=", want: InjectCondition{Key: "env"}},
+		{expr: "env", wantErr: true},
+		{expr: "=prod", wantErr: true},
+		{expr: "bad key=prod", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := ParseInjectCondition(tt.expr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseInjectCondition(%q) error = %v, wantErr %v", tt.expr, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseInjectCondition(%q) = %+v, want %+v", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPodHandler_Handle_InjectIf(t *testing.T) {
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		wantAllowed  bool
+		wantInjected bool
+	}{
+		{
+			name:         "matching condition injects",
+			annotations:  map[string]string{config.AnnotationInjectIf: "env=prod", "env": "prod"},
+			wantAllowed:  true,
+			wantInjected: true,
+		},
+		{
+			name:        "non-matching condition skips",
+			annotations: map[string]string{config.AnnotationInjectIf: "env=prod", "env": "staging"},
+			wantAllowed: true,
+		},
+		{
+			name:        "missing annotation skips",
+			annotations: map[string]string{config.AnnotationInjectIf: "env=prod"},
+			wantAllowed: true,
+		},
+		{
+			name:         "negated condition injects when annotation is absent",
+			annotations:  map[string]string{config.AnnotationInjectIf: "env!=dev"},
+			wantAllowed:  true,
+			wantInjected: true,
+		},
+		{
+			name:        "negated condition skips on match",
+			annotations: map[string]string{config.AnnotationInjectIf: "env!=dev", "env": "dev"},
+			wantAllowed: true,
+		},
+		{
+			name:        "malformed condition is denied",
+			annotations: map[string]string{config.AnnotationInjectIf: "env"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := handleAnnotatedPod(t, tt.annotations)
+			if resp.Allowed != tt.wantAllowed {
+				t.Fatalf("Expected allowed=%v, got: %v", tt.wantAllowed, resp.Result)
+			}
+			if injected := len(resp.Patches) > 0; injected != tt.wantInjected {
+				t.Errorf("Expected injected=%v, got %d patches", tt.wantInjected, len(resp.Patches))
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+// scopeTestPod returns a Pod with a main container, a plain init container and a native sidecar
+func scopeTestPod() *corev1.Pod {
+	always := corev1.ContainerRestartPolicyAlways
+	return &corev1.Pod{Spec: corev1.PodSpec{
+		Containers: []corev1.Container{{Name: "app"}},
+		InitContainers: []corev1.Container{
+			{Name: "migrate"},
+			{Name: "proxy", RestartPolicy: &always},
+		},
+	}}
+}
+
+// mountedContainers returns the names of the Pod's containers mounting volumeName
+func mountedContainers(pod *corev1.Pod, volumeName string) []string {
+	var names []string
+	for _, c := range append(append([]corev1.Container{}, pod.Spec.Containers...), pod.Spec.InitContainers...) {
+		for _, m := range c.VolumeMounts {
+			if m.Name == volumeName {
+				names = append(names, c.Name)
+				break
+			}
+		}
+	}
+	return names
+}
+
+func TestIsNativeSidecar(t *testing.T) {
+	always := corev1.ContainerRestartPolicyAlways
+	if !isNativeSidecar(&corev1.Container{RestartPolicy: &always}) {
+		t.Error("Expected an init container with restartPolicy Always to be a native sidecar")
+	}
+	if isNativeSidecar(&corev1.Container{}) {
+		t.Error("Expected a plain init container not to be a native sidecar")
+	}
+}
+
+func TestMutatePodForTarget_InjectScope(t *testing.T) {
+	tests := []struct {
+		scope string
+		want  string
+	}{
+		{scope: "", want: "app,migrate,proxy"},
+		{scope: config.InjectScopeAll, want: "app,migrate,proxy"},
+		{scope: config.InjectScopeMainOnly, want: "app"},
+		{scope: config.InjectScopeInitOnly, want: "migrate"},
+		{scope: config.InjectScopeSidecarOnly, want: "proxy"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.scope, func(t *testing.T) {
+			pod := scopeTestPod()
+			target := injectionTarget{
+				zenlockName: "test-zenlock",
+				secretName:  "zen-lock-inject-default-app",
+				volumeName:  config.DefaultVolumeName,
+				mountPath:   config.DefaultMountPath,
+				env:         []corev1.EnvVar{{Name: "APP_KEY"}},
+				scope:       tt.scope,
+			}
+			if err := (&PodHandler{}).mutatePodForTarget(pod, target); err != nil {
+				t.Fatalf("mutatePodForTarget failed: %v", err)
+			}
+			if got := strings.Join(mountedContainers(pod, config.DefaultVolumeName), ","); got != tt.want {
+				t.Errorf("Expected mounts in %s, got %s", tt.want, got)
+			}
+			for _, c := range pod.Spec.InitContainers {
+				if hasEnv := len(c.Env) > 0; hasEnv != strings.Contains(tt.want, c.Name) {
+					t.Errorf("Expected env vars in %s only, container %s has %+v", tt.want, c.Name, c.Env)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyInjectScope_NoMatchingContainers(t *testing.T) {
+	pod := &corev1.Pod{Spec: corev1.PodSpec{
+		Containers:     []corev1.Container{{Name: "app"}},
+		InitContainers: []corev1.Container{{Name: "migrate"}},
+	}}
+	targets := []injectionTarget{{zenlockName: "test-zenlock"}}
+	if err := applyInjectScope(targets, pod, config.InjectScopeSidecarOnly); err == nil {
+		t.Error("Expected sidecar-only to be rejected for a Pod without native sidecars")
+	}
+	if err := applyInjectScope(targets, pod, config.InjectScopeInitOnly); err != nil || targets[0].scope != config.InjectScopeInitOnly {
+		t.Errorf("Expected init-only to apply, got %v (%q)", err, targets[0].scope)
+	}
+}
+
+func TestPodHandler_Handle_InjectScope(t *testing.T) {
+	resp := handleAnnotatedPod(t, map[string]string{config.AnnotationInjectScope: config.InjectScopeMainOnly})
+	if !resp.Allowed {
+		t.Errorf("Expected main-only injection to be allowed, got %v", resp.Result)
+	}
+
+	resp = handleAnnotatedPod(t, map[string]string{config.AnnotationInjectScope: config.InjectScopeSidecarOnly})
+	if resp.Allowed || !strings.Contains(resp.Result.Message, "no containers in scope") {
+		t.Errorf("Expected sidecar-only to be denied for a Pod without native sidecars, got %v", resp.Result)
+	}
+
+	resp = handleAnnotatedPod(t, map[string]string{config.AnnotationInjectScope: "sidecars"})
+	if resp.Allowed || !strings.Contains(resp.Result.Message, "invalid inject scope") {
+		t.Errorf("Expected an unknown scope to be denied, got %v", resp.Result)
+	}
+}
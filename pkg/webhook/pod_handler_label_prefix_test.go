@@ -0,0 +1,73 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"filippo.io/age"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/common"
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+func TestPodHandler_Handle_CustomLabelPrefix(t *testing.T) {
+	t.Setenv("ZEN_LOCK_LABEL_PREFIX", "secrets.example.com")
+
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-zenlock", Namespace: "default"},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{"USERNAME": encryptTestData(t, "admin", identity.Recipient().String())},
+		},
+	}
+	handler, clientBuilder := setupTestPodHandlerWithKey(t, identity.String())
+	handler.Client = clientBuilder.WithObjects(zenlock).Build()
+
+	if resp := handler.Handle(context.Background(), sharedNamingRequest("app", config.SecretNamingPod)); !resp.Allowed {
+		t.Fatalf("Expected injection to be allowed, got: %v", resp.Result)
+	}
+
+	secrets := &corev1.SecretList{}
+	if err := handler.Client.List(context.Background(), secrets); err != nil {
+		t.Fatalf("Failed to list secrets: %v", err)
+	}
+	if len(secrets.Items) != 1 {
+		t.Fatalf("Expected one Secret, got %d", len(secrets.Items))
+	}
+	labels := secrets.Items[0].Labels
+	want := map[string]string{
+		"secrets.example.com/pod-name":      "app",
+		"secrets.example.com/pod-namespace": "default",
+		"secrets.example.com/zenlock-name":  "test-zenlock",
+	}
+	for key, value := range want {
+		if labels[key] != value {
+			t.Errorf("Expected label %s=%s, got %v", key, value, labels)
+		}
+	}
+	if _, ok := labels[common.LabelPodName]; ok {
+		t.Errorf("Expected no default-prefix labels, got %v", labels)
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+const testMessageSuffix = "See https://runbooks.example.com/zen-lock"
+
+func TestPodHandler_AppendMessageSuffix(t *testing.T) {
+	handler := &PodHandler{messageSuffix: testMessageSuffix}
+
+	denied := handler.appendMessageSuffix(admission.Denied("invalid mount path: must be absolute"))
+	if got := denied.Result.Message; got != "invalid mount path: must be absolute "+testMessageSuffix {
+		t.Errorf("Expected suffix after the reason, got %q", got)
+	}
+
+	warned := handler.appendMessageSuffix(admission.Allowed("skipped").WithWarnings("zen-lock: not confirmed"))
+	if len(warned.Warnings) != 1 || warned.Warnings[0] != "zen-lock: not confirmed "+testMessageSuffix {
+		t.Errorf("Expected suffix on warnings, got %v", warned.Warnings)
+	}
+	if strings.Contains(warned.Result.Message, testMessageSuffix) {
+		t.Errorf("Expected allowed message unchanged, got %q", warned.Result.Message)
+	}
+}
+
+func TestPodHandler_Handle_MessageSuffix(t *testing.T) {
+	handler, clientBuilder := setupTestPodHandler(t)
+	handler.Client = clientBuilder.Build()
+	handler.messageSuffix = testMessageSuffix
+
+	// The ZenLock does not exist, so injection is denied
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-pod",
+			Namespace:   "default",
+			Annotations: map[string]string{config.AnnotationInject: "missing-zenlock"},
+		},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "test-container", Image: "nginx"}}},
+	}
+	podRaw, _ := json.Marshal(pod)
+	req := admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Object:    runtime.RawExtension{Raw: podRaw},
+			Namespace: "default",
+		},
+	}
+
+	resp := handler.Handle(context.Background(), req)
+	if resp.Allowed {
+		t.Fatal("Expected injection of a missing ZenLock to be denied")
+	}
+	if !strings.HasSuffix(resp.Result.Message, " "+testMessageSuffix) {
+		t.Errorf("Expected denial to end with the suffix, got %q", resp.Result.Message)
+	}
+
+	handler.messageSuffix = ""
+	resp = handler.Handle(context.Background(), req)
+	if strings.Contains(resp.Result.Message, testMessageSuffix) {
+		t.Errorf("Expected no suffix when unset, got %q", resp.Result.Message)
+	}
+}
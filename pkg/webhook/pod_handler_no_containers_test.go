@@ -0,0 +1,86 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+func TestPodHandler_Handle_NoContainers(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-zenlock", Namespace: "default"},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{"USERNAME": encryptTestData(t, "admin", identity.Recipient().String())},
+		},
+	}
+	handler, clientBuilder := setupTestPodHandlerWithKey(t, identity.String())
+	handler.Client = clientBuilder.WithObjects(zenlock).Build()
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "empty-pod",
+			Namespace:   "default",
+			Annotations: map[string]string{config.AnnotationInject: "test-zenlock"},
+		},
+	}
+	podRaw, err := json.Marshal(pod)
+	if err != nil {
+		t.Fatalf("Failed to marshal pod: %v", err)
+	}
+	req := admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Object:    runtime.RawExtension{Raw: podRaw},
+			Namespace: "default",
+		},
+	}
+
+	resp := handler.Handle(context.Background(), req)
+	if resp.Allowed {
+		t.Fatal("Expected a Pod without containers to be denied")
+	}
+	if !strings.Contains(resp.Result.Message, "no containers") {
+		t.Errorf("Expected the denial to explain the Pod has no containers, got %q", resp.Result.Message)
+	}
+
+	if resp := handler.Handle(context.Background(), sharedNamingRequest("app-1", "")); !resp.Allowed {
+		t.Errorf("Expected a Pod with containers to be injected, got %v", resp.Result)
+	}
+}
+
+func TestDenyPodWithoutContainers_InitContainersOnly(t *testing.T) {
+	pod := &corev1.Pod{Spec: corev1.PodSpec{InitContainers: []corev1.Container{{Name: "setup", Image: "busybox"}}}}
+	if resp := denyPodWithoutContainers(pod, "default"); resp.Result != nil {
+		t.Errorf("Expected a Pod with only init containers to proceed, got %v", resp.Result)
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+)
+
+var confidentialNodes = map[string]string{"node-pool": "confidential"}
+
+func handleNodeSelectorPod(t *testing.T, nodeSelector map[string]string, delegate bool) (admission.Response, bool) {
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-zenlock", Namespace: "default"},
+		Spec:       securityv1alpha1.ZenLockSpec{RequiredNodeSelector: confidentialNodes},
+	}
+	handler := injectionTestHandler(t, zenlock, delegate)
+
+	pod := injectionTestPod(nil, nil)
+	pod.Spec.NodeSelector = nodeSelector
+	resp := admitTestPod(t, handler, pod)
+	return resp, injectedSecretExists(t, handler, pod)
+}
+
+func TestPodHandler_Handle_RequiredNodeSelector_Matching(t *testing.T) {
+	for _, mode := range injectionModes {
+		t.Run(mode.name, func(t *testing.T) {
+			resp, created := handleNodeSelectorPod(t, map[string]string{"node-pool": "confidential", "zone": "a"}, mode.delegate)
+			if !resp.Allowed {
+				t.Fatalf("Expected a Pod pinned to confidential nodes to be injected, got %v", resp.Result)
+			}
+			if len(resp.Patches) == 0 {
+				t.Error("Expected injection patches")
+			}
+			if created != !mode.delegate {
+				t.Errorf("Expected Secret created by the webhook=%v, got %v", !mode.delegate, created)
+			}
+		})
+	}
+}
+
+func TestPodHandler_Handle_RequiredNodeSelector_Denied(t *testing.T) {
+	tests := []struct {
+		name         string
+		nodeSelector map[string]string
+		want         string
+	}{
+		{name: "no nodeSelector", nodeSelector: nil, want: "missing node-pool=confidential"},
+		{name: "other pool", nodeSelector: map[string]string{"node-pool": "general"}, want: "node-pool=general"},
+	}
+	for _, mode := range injectionModes {
+		for _, tt := range tests {
+			t.Run(mode.name+"/"+tt.name, func(t *testing.T) {
+				resp, created := handleNodeSelectorPod(t, tt.nodeSelector, mode.delegate)
+				if resp.Allowed {
+					t.Fatal("Expected injection to be denied")
+				}
+				if !strings.Contains(resp.Result.Message, tt.want) {
+					t.Errorf("Expected denial naming %q, got %q", tt.want, resp.Result.Message)
+				}
+				if created {
+					t.Error("Expected no Secret for a denied Pod")
+				}
+			})
+		}
+	}
+}
+
+func TestZenLockValidatorHandler_Handle_Create_InvalidRequiredNodeSelector(t *testing.T) {
+	handler, _ := setupTestValidator(t)
+
+	zenlock := createTestZenLock(t, map[string]string{"key1": "dGVzdA=="}, "age", nil)
+	zenlock.Spec.RequiredNodeSelector = map[string]string{"node pool": "confidential"}
+
+	zenlockRaw, _ := json.Marshal(zenlock)
+	req := admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			Object:    runtime.RawExtension{Raw: zenlockRaw},
+		},
+	}
+
+	resp := handler.Handle(context.Background(), req)
+	if resp.Allowed {
+		t.Fatal("Expected an invalid node label key to be denied")
+	}
+	if !strings.Contains(resp.Result.Message, "requiredNodeSelector") {
+		t.Errorf("Expected requiredNodeSelector error, got %q", resp.Result.Message)
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+func TestMutatePodForTarget_ProjectMetadata(t *testing.T) {
+	handler := &PodHandler{}
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}}}
+	targets := []injectionTarget{{
+		zenlockName: "test-zenlock",
+		secretName:  "zen-lock-inject-default-app",
+		volumeName:  config.DefaultVolumeName,
+		mountPath:   config.DefaultMountPath,
+	}}
+	if err := applyProjectMetadata(targets, []*securityv1alpha1.ZenLock{envTestZenLock("API_KEY")}); err != nil {
+		t.Fatalf("applyProjectMetadata failed: %v", err)
+	}
+	if err := handler.mutatePodForTarget(pod, targets[0]); err != nil {
+		t.Fatalf("mutatePodForTarget failed: %v", err)
+	}
+
+	if len(pod.Spec.Volumes) != 1 || pod.Spec.Volumes[0].Projected == nil {
+		t.Fatalf("Expected one projected volume, got %+v", pod.Spec.Volumes)
+	}
+	sources := pod.Spec.Volumes[0].Projected.Sources
+	if len(sources) != 2 || sources[0].Secret == nil || sources[0].Secret.Name != "zen-lock-inject-default-app" {
+		t.Fatalf("Expected the Secret projection first, got %+v", sources)
+	}
+	if sources[1].DownwardAPI == nil {
+		t.Fatalf("Expected a downward API projection, got %+v", sources[1])
+	}
+	fields := make(map[string]string)
+	for _, item := range sources[1].DownwardAPI.Items {
+		fields[item.Path] = item.FieldRef.FieldPath
+	}
+	if fields[config.MetadataFilePodName] != "metadata.name" || fields[config.MetadataFilePodNamespace] != "metadata.namespace" {
+		t.Errorf("Expected Pod name and namespace items, got %v", fields)
+	}
+	if mounts := pod.Spec.Containers[0].VolumeMounts; len(mounts) != 1 || mounts[0].MountPath != config.DefaultMountPath {
+		t.Errorf("Expected the projected volume mounted at %s, got %+v", config.DefaultMountPath, mounts)
+	}
+}
+
+func TestApplyProjectMetadata_Errors(t *testing.T) {
+	target := injectionTarget{zenlockName: "test-zenlock", secretName: "zen-lock-inject-default-app"}
+
+	if err := applyProjectMetadata([]injectionTarget{target}, []*securityv1alpha1.ZenLock{envTestZenLock(config.MetadataFilePodName)}); err == nil {
+		t.Error("Expected a key colliding with a metadata file to be rejected")
+	}
+}
+
+func TestPodHandler_Handle_ProjectMetadata(t *testing.T) {
+	resp := handleAnnotatedPod(t, map[string]string{config.AnnotationProjectMetadata: "true"})
+	if !resp.Allowed {
+		t.Fatalf("Expected injection to be allowed, got %v", resp.Result)
+	}
+	patches, err := json.Marshal(resp.Patches)
+	if err != nil {
+		t.Fatalf("Failed to marshal patches: %v", err)
+	}
+	for _, want := range []string{`"projected"`, `"downwardAPI"`, `"metadata.name"`, `"metadata.namespace"`, `"secret"`} {
+		if !strings.Contains(string(patches), want) {
+			t.Errorf("Expected patches to contain %s, got %s", want, patches)
+		}
+	}
+
+	resp = handleAnnotatedPod(t, nil)
+	patches, _ = json.Marshal(resp.Patches)
+	if strings.Contains(string(patches), `"projected"`) {
+		t.Errorf("Expected a plain Secret volume without the annotation, got %s", patches)
+	}
+}
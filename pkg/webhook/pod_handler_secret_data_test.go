@@ -392,6 +392,8 @@ func TestPodHandler_EnsureSecretExists_ExistingSecretMatches(t *testing.T) {
 
 func TestPodHandler_EnsureSecretExists_NilLabels(t *testing.T) {
 	handler, clientBuilder := setupTestPodHandler(t)
+	// An unlabeled Secret is user-managed: it is only taken over in adoption mode
+	handler.adoptUnmanagedSecrets = true
 
 	secretName := "test-secret"
 	secretData := map[string][]byte{
@@ -0,0 +1,163 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/common"
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+func sharedNamingRequest(podName, naming string) admission.Request {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      podName,
+			Namespace: "default",
+			Annotations: map[string]string{
+				config.AnnotationInject:       "test-zenlock",
+				config.AnnotationSecretNaming: naming,
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "test-container", Image: "nginx"}},
+		},
+	}
+	podRaw, _ := json.Marshal(pod)
+	return admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Object:    runtime.RawExtension{Raw: podRaw},
+			Namespace: "default",
+		},
+	}
+}
+
+func TestGenerateZenLockSecretName(t *testing.T) {
+	if got := GenerateZenLockSecretName("db-credentials"); got != "zen-lock-shared-db-credentials" {
+		t.Errorf("Expected zen-lock-shared-db-credentials, got %q", got)
+	}
+
+	long := strings.Repeat("a", 253)
+	name := GenerateZenLockSecretName(long)
+	if len(name) > 253 {
+		t.Errorf("Expected name within 253 characters, got %d", len(name))
+	}
+	if name != GenerateZenLockSecretName(long) {
+		t.Error("Expected name to be deterministic")
+	}
+}
+
+func TestPodHandler_Handle_SharedSecretNaming(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-zenlock",
+			Namespace: "default",
+			UID:       "zenlock-uid",
+		},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{
+				"USERNAME": encryptTestData(t, "admin", identity.Recipient().String()),
+			},
+		},
+	}
+
+	handler, clientBuilder := setupTestPodHandlerWithKey(t, identity.String())
+	handler.Client = clientBuilder.WithObjects(zenlock).Build()
+
+	// Two replicas share one Secret named after the ZenLock
+	for _, podName := range []string{"app-1", "app-2"} {
+		resp := handler.Handle(context.Background(), sharedNamingRequest(podName, config.SecretNamingZenLock))
+		if !resp.Allowed {
+			t.Fatalf("Expected request for %s to be allowed, got: %v", podName, resp.Result)
+		}
+		found := false
+		for _, patch := range resp.Patches {
+			raw, _ := json.Marshal(patch.Value)
+			if strings.Contains(string(raw), `"secretName":"zen-lock-shared-test-zenlock"`) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected %s to mount the shared Secret, got patches %+v", podName, resp.Patches)
+		}
+	}
+
+	secrets := &corev1.SecretList{}
+	if err := handler.Client.List(context.Background(), secrets); err != nil {
+		t.Fatalf("Failed to list secrets: %v", err)
+	}
+	if len(secrets.Items) != 1 {
+		t.Fatalf("Expected a single shared Secret, got %d", len(secrets.Items))
+	}
+
+	secret := &corev1.Secret{}
+	if err := handler.Client.Get(context.Background(), types.NamespacedName{Name: "zen-lock-shared-test-zenlock", Namespace: "default"}, secret); err != nil {
+		t.Fatalf("Failed to get shared secret: %v", err)
+	}
+	if string(secret.Data["USERNAME"]) != "admin" {
+		t.Errorf("Expected USERNAME=admin, got %q", secret.Data["USERNAME"])
+	}
+	if _, ok := secret.Labels[common.LabelPodName]; ok {
+		t.Error("Expected shared Secret to carry no Pod labels")
+	}
+	if secret.Labels[common.LabelZenLockName] != "test-zenlock" {
+		t.Errorf("Expected ZenLock label, got %v", secret.Labels)
+	}
+	if len(secret.OwnerReferences) != 1 {
+		t.Fatalf("Expected one owner reference, got %+v", secret.OwnerReferences)
+	}
+	owner := secret.OwnerReferences[0]
+	if owner.Kind != "ZenLock" || owner.Name != "test-zenlock" || owner.UID != "zenlock-uid" || owner.Controller == nil || !*owner.Controller {
+		t.Errorf("Expected controller owner reference to the ZenLock, got %+v", owner)
+	}
+}
+
+func TestPodHandler_Handle_InvalidSecretNaming(t *testing.T) {
+	handler, _ := setupTestPodHandler(t)
+	resp := handler.Handle(context.Background(), sharedNamingRequest("app-1", "namespace"))
+	if resp.Allowed {
+		t.Error("Expected request with invalid secret naming to be denied")
+	}
+}
+
+func TestZenLockOwnerReference_BlockOwnerDeletion(t *testing.T) {
+	zenlock := &securityv1alpha1.ZenLock{ObjectMeta: metav1.ObjectMeta{Name: "test-zenlock", UID: "zenlock-uid"}}
+
+	if ref := zenLockOwnerReference(zenlock, false); ref.BlockOwnerDeletion != nil {
+		t.Errorf("Expected blockOwnerDeletion unset by default, got %v", *ref.BlockOwnerDeletion)
+	}
+	if ref := zenLockOwnerReference(zenlock, true); ref.BlockOwnerDeletion == nil || !*ref.BlockOwnerDeletion {
+		t.Errorf("Expected blockOwnerDeletion when enabled, got %+v", ref)
+	}
+}
@@ -25,11 +25,16 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	"filippo.io/age"
 	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
 	"github.com/kube-zen/zen-lock/pkg/common"
 	"github.com/kube-zen/zen-lock/pkg/config"
+	"github.com/kube-zen/zen-lock/pkg/controller/metrics"
+	"github.com/kube-zen/zen-lock/pkg/crypto"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
 func TestPodHandler_Handle_SecretAlreadyExists_Stale(t *testing.T) {
@@ -218,3 +223,190 @@ func TestPodHandler_Handle_SecretCreateError(t *testing.T) {
 	// Error is expected due to invalid ciphertext, but secret creation path was executed
 	_ = resp.Result
 }
+
+func TestPodHandler_Handle_EmptyDecryptedValue(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	publicKey := identity.Recipient().String()
+
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-zenlock",
+			Namespace: "default",
+		},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{
+				"EMPTY":    encryptTestData(t, "", publicKey),
+				"NONEMPTY": encryptTestData(t, "value", publicKey),
+			},
+		},
+	}
+
+	handler, clientBuilder := setupTestPodHandlerWithKey(t, identity.String())
+	handler.Client = clientBuilder.WithObjects(zenlock).Build()
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pod",
+			Namespace: "default",
+			Annotations: map[string]string{
+				config.AnnotationInject: "test-zenlock",
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "test-container", Image: "nginx"},
+			},
+		},
+	}
+	podRaw, _ := json.Marshal(pod)
+	req := admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Object:    runtime.RawExtension{Raw: podRaw},
+			Namespace: "default",
+		},
+	}
+
+	resp := handler.Handle(context.Background(), req)
+	if !resp.Allowed {
+		t.Fatalf("Expected request to be allowed, got: %v", resp.Result)
+	}
+
+	secret := &corev1.Secret{}
+	secretKey := types.NamespacedName{Name: GenerateSecretName("default", "test-pod"), Namespace: "default"}
+	if err := handler.Client.Get(context.Background(), secretKey, secret); err != nil {
+		t.Fatalf("Failed to get secret: %v", err)
+	}
+
+	value, ok := secret.Data["EMPTY"]
+	if !ok {
+		t.Fatal("Expected Secret to contain key EMPTY")
+	}
+	if len(value) != 0 {
+		t.Errorf("Expected EMPTY to have an empty value, got %q", string(value))
+	}
+	if string(secret.Data["NONEMPTY"]) != "value" {
+		t.Errorf("Expected NONEMPTY=value, got %q", string(secret.Data["NONEMPTY"]))
+	}
+	if _, ok := secret.Data["MISSING"]; ok {
+		t.Error("Expected key MISSING to be absent from the Secret")
+	}
+}
+
+func TestPodHandler_Handle_ChecksumMismatch(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-zenlock",
+			Namespace: "default",
+		},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{
+				"key": encryptTestData(t, "value", identity.Recipient().String()),
+			},
+			Checksums: map[string]string{
+				"key": crypto.Checksum([]byte("other")),
+			},
+		},
+	}
+
+	handler, clientBuilder := setupTestPodHandlerWithKey(t, identity.String())
+	handler.Client = clientBuilder.WithObjects(zenlock).Build()
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pod",
+			Namespace: "default",
+			Annotations: map[string]string{
+				config.AnnotationInject: "test-zenlock",
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "test-container", Image: "nginx"},
+			},
+		},
+	}
+	podRaw, _ := json.Marshal(pod)
+	req := admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Object:    runtime.RawExtension{Raw: podRaw},
+			Namespace: "default",
+		},
+	}
+
+	resp := handler.Handle(context.Background(), req)
+	if resp.Allowed {
+		t.Fatal("Expected request to fail on checksum mismatch")
+	}
+
+	secret := &corev1.Secret{}
+	secretKey := types.NamespacedName{Name: GenerateSecretName("default", "test-pod"), Namespace: "default"}
+	if err := handler.Client.Get(context.Background(), secretKey, secret); err == nil {
+		t.Error("Expected no Secret to be created on checksum mismatch")
+	}
+}
+
+func TestPodHandler_Handle_RecordsKeyUse(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-zenlock",
+			Namespace: "default",
+		},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{
+				"key": encryptTestData(t, "value", identity.Recipient().String()),
+			},
+		},
+	}
+
+	handler, clientBuilder := setupTestPodHandlerWithKey(t, identity.String())
+	handler.Client = clientBuilder.WithObjects(zenlock).Build()
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pod",
+			Namespace: "default",
+			Annotations: map[string]string{
+				config.AnnotationInject: "test-zenlock",
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "test-container", Image: "nginx"},
+			},
+		},
+	}
+	podRaw, _ := json.Marshal(pod)
+	req := admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Object:    runtime.RawExtension{Raw: podRaw},
+			Namespace: "default",
+		},
+	}
+
+	webhookBefore := testutil.ToFloat64(metrics.PrivateKeyUseTotal.WithLabelValues(metrics.ComponentWebhook))
+	controllerBefore := testutil.ToFloat64(metrics.PrivateKeyUseTotal.WithLabelValues(metrics.ComponentController))
+
+	if resp := handler.Handle(context.Background(), req); !resp.Allowed {
+		t.Fatalf("Expected request to be allowed, got: %v", resp.Result)
+	}
+
+	if got := testutil.ToFloat64(metrics.PrivateKeyUseTotal.WithLabelValues(metrics.ComponentWebhook)) - webhookBefore; got != 1 {
+		t.Errorf("Expected webhook key use to increase by 1, got %f", got)
+	}
+	if got := testutil.ToFloat64(metrics.PrivateKeyUseTotal.WithLabelValues(metrics.ComponentController)) - controllerBefore; got != 0 {
+		t.Errorf("Expected controller key use to be unchanged, got %f", got)
+	}
+}
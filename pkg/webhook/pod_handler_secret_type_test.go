@@ -0,0 +1,164 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"filippo.io/age"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+// injectWithSecretType runs an injection for a ZenLock with the given Secret type and keys
+func injectWithSecretType(t *testing.T, secretType corev1.SecretType, keys ...string) (*PodHandler, admission.Response) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	encryptedData := make(map[string]string, len(keys))
+	for _, key := range keys {
+		encryptedData[key] = encryptTestData(t, "value-"+key, identity.Recipient().String())
+	}
+
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-zenlock",
+			Namespace: "default",
+		},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: encryptedData,
+			SecretType:    secretType,
+		},
+	}
+
+	handler, clientBuilder := setupTestPodHandlerWithKey(t, identity.String())
+	handler.Client = clientBuilder.WithObjects(zenlock).Build()
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pod",
+			Namespace: "default",
+			Annotations: map[string]string{
+				config.AnnotationInject: "test-zenlock",
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "test-container", Image: "nginx"},
+			},
+		},
+	}
+	podRaw, _ := json.Marshal(pod)
+	req := admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Object:    runtime.RawExtension{Raw: podRaw},
+			Namespace: "default",
+		},
+	}
+
+	return handler, handler.Handle(context.Background(), req)
+}
+
+func getInjectedSecret(t *testing.T, handler *PodHandler) *corev1.Secret {
+	secret := &corev1.Secret{}
+	secretKey := types.NamespacedName{Name: GenerateSecretName("default", "test-pod"), Namespace: "default"}
+	if err := handler.Client.Get(context.Background(), secretKey, secret); err != nil {
+		t.Fatalf("Failed to get secret: %v", err)
+	}
+	return secret
+}
+
+func TestPodHandler_Handle_SecretTypeDefaultOpaque(t *testing.T) {
+	handler, resp := injectWithSecretType(t, "", "KEY")
+	if !resp.Allowed {
+		t.Fatalf("Expected request to be allowed, got: %v", resp.Result)
+	}
+	if secret := getInjectedSecret(t, handler); secret.Type != corev1.SecretTypeOpaque {
+		t.Errorf("Expected Secret type Opaque, got %q", secret.Type)
+	}
+}
+
+func TestPodHandler_Handle_SecretTypeTLS(t *testing.T) {
+	handler, resp := injectWithSecretType(t, corev1.SecretTypeTLS, corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+	if !resp.Allowed {
+		t.Fatalf("Expected request to be allowed, got: %v", resp.Result)
+	}
+	secret := getInjectedSecret(t, handler)
+	if secret.Type != corev1.SecretTypeTLS {
+		t.Errorf("Expected Secret type %q, got %q", corev1.SecretTypeTLS, secret.Type)
+	}
+	if string(secret.Data[corev1.TLSCertKey]) != "value-tls.crt" {
+		t.Errorf("Expected tls.crt to be decrypted, got %q", secret.Data[corev1.TLSCertKey])
+	}
+}
+
+func TestPodHandler_Handle_SecretTypeTLSMissingKey(t *testing.T) {
+	handler, resp := injectWithSecretType(t, corev1.SecretTypeTLS, corev1.TLSCertKey)
+	if resp.Allowed {
+		t.Fatal("Expected request to be denied for TLS Secret missing tls.key")
+	}
+
+	secrets := &corev1.SecretList{}
+	if err := handler.Client.List(context.Background(), secrets); err != nil {
+		t.Fatalf("Failed to list secrets: %v", err)
+	}
+	if len(secrets.Items) != 0 {
+		t.Errorf("Expected no Secret to be created, got %d", len(secrets.Items))
+	}
+}
+
+func TestValidateSecretType(t *testing.T) {
+	tests := []struct {
+		name       string
+		secretType corev1.SecretType
+		keys       []string
+		wantErr    bool
+	}{
+		{name: "default", secretType: "", keys: []string{"any"}},
+		{name: "opaque", secretType: corev1.SecretTypeOpaque},
+		{name: "tls complete", secretType: corev1.SecretTypeTLS, keys: []string{"tls.crt", "tls.key"}},
+		{name: "tls missing key", secretType: corev1.SecretTypeTLS, keys: []string{"tls.crt"}, wantErr: true},
+		{name: "dockerconfigjson", secretType: corev1.SecretTypeDockerConfigJson, keys: []string{".dockerconfigjson"}},
+		{name: "dockerconfigjson missing", secretType: corev1.SecretTypeDockerConfigJson, keys: []string{"config.json"}, wantErr: true},
+		{name: "basic-auth password only", secretType: corev1.SecretTypeBasicAuth, keys: []string{"password"}},
+		{name: "basic-auth empty", secretType: corev1.SecretTypeBasicAuth, keys: []string{"token"}, wantErr: true},
+		{name: "service account token", secretType: corev1.SecretTypeServiceAccountToken, keys: []string{"token"}, wantErr: true},
+		{name: "custom type", secretType: "example.com/custom", keys: []string{"any"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := make(map[string][]byte, len(tt.keys))
+			for _, key := range tt.keys {
+				data[key] = []byte("value")
+			}
+			if err := ValidateSecretType(tt.secretType, data); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSecretType() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
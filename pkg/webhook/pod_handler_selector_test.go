@@ -0,0 +1,285 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"filippo.io/age"
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
+	"github.com/kube-zen/zen-lock/pkg/crypto"
+)
+
+// createSelectorZenLock creates a ZenLock encrypted for publicKey that selects pods by label
+func createSelectorZenLock(t *testing.T, name, publicKey string, matchLabels map[string]string) *securityv1alpha1.ZenLock {
+	encryptor := crypto.NewAgeEncryptor()
+	ciphertext, err := encryptor.Encrypt([]byte("value-"+name), []string{publicKey})
+	if err != nil {
+		t.Fatalf("Failed to encrypt: %v", err)
+	}
+
+	return &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{
+				"key1": base64.StdEncoding.EncodeToString(ciphertext),
+			},
+			InjectionSelector: &metav1.LabelSelector{MatchLabels: matchLabels},
+		},
+	}
+}
+
+func selectorTestRequest(t *testing.T, podLabels map[string]string) admission.Request {
+	return selectorTestRequestWithAnnotations(t, podLabels, nil)
+}
+
+func selectorTestRequestWithAnnotations(t *testing.T, podLabels, annotations map[string]string) admission.Request {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-pod",
+			Namespace:   "default",
+			Labels:      podLabels,
+			Annotations: annotations,
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "test-container", Image: "nginx"},
+			},
+		},
+	}
+	podRaw, err := json.Marshal(pod)
+	if err != nil {
+		t.Fatalf("Failed to marshal pod: %v", err)
+	}
+	return admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Object:    runtime.RawExtension{Raw: podRaw},
+			Namespace: "default",
+		},
+	}
+}
+
+func TestPodHandler_Handle_InjectionSelectorMatch(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+
+	zenlock := createSelectorZenLock(t, "app-secrets", identity.Recipient().String(), map[string]string{"app": "web"})
+	handler, clientBuilder := setupTestPodHandlerWithKey(t, identity.String())
+	handler.Client = clientBuilder.WithObjects(zenlock).Build()
+
+	resp := handler.Handle(context.Background(), selectorTestRequest(t, map[string]string{"app": "web"}))
+	if !resp.Allowed {
+		t.Fatalf("Expected request to be allowed, got: %v", resp.Result)
+	}
+	if len(resp.Patches) == 0 {
+		t.Fatal("Expected pod to be mutated for matching InjectionSelector")
+	}
+
+	secret := &corev1.Secret{}
+	secretKey := types.NamespacedName{Name: GenerateSecretName("default", "test-pod"), Namespace: "default"}
+	if err := handler.Client.Get(context.Background(), secretKey, secret); err != nil {
+		t.Fatalf("Expected ephemeral secret to be created: %v", err)
+	}
+	if string(secret.Data["key1"]) != "value-app-secrets" {
+		t.Errorf("Expected decrypted value 'value-app-secrets', got %q", string(secret.Data["key1"]))
+	}
+}
+
+func TestPodHandler_Handle_InjectionSelectorNoMatch(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+
+	zenlock := createSelectorZenLock(t, "app-secrets", identity.Recipient().String(), map[string]string{"app": "web"})
+	handler, clientBuilder := setupTestPodHandlerWithKey(t, identity.String())
+	handler.Client = clientBuilder.WithObjects(zenlock).Build()
+
+	resp := handler.Handle(context.Background(), selectorTestRequest(t, map[string]string{"app": "batch"}))
+	if !resp.Allowed {
+		t.Fatalf("Expected request to be allowed, got: %v", resp.Result)
+	}
+	if len(resp.Patches) != 0 {
+		t.Errorf("Expected no mutation for non-matching pod, got %d patches", len(resp.Patches))
+	}
+}
+
+func TestPodHandler_Handle_InjectionSelectorMultipleMatches(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	publicKey := identity.Recipient().String()
+
+	db := createSelectorZenLock(t, "db", publicKey, map[string]string{"app": "web"})
+	api := createSelectorZenLock(t, "api", publicKey, nil) // empty selector matches every pod
+	handler, clientBuilder := setupTestPodHandlerWithKey(t, identity.String())
+	handler.Client = clientBuilder.WithObjects(db, api).Build()
+
+	resp := handler.Handle(context.Background(), selectorTestRequest(t, map[string]string{"app": "web"}))
+	if !resp.Allowed {
+		t.Fatalf("Expected request to be allowed, got: %v", resp.Result)
+	}
+
+	for _, name := range []string{"api", "db"} {
+		secret := &corev1.Secret{}
+		secretKey := types.NamespacedName{Name: GenerateSecretName("default", "test-pod-"+name), Namespace: "default"}
+		if err := handler.Client.Get(context.Background(), secretKey, secret); err != nil {
+			t.Errorf("Expected secret for ZenLock %q: %v", name, err)
+		}
+	}
+}
+
+func TestPodHandler_Handle_InjectionSelectorMountPaths(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	publicKey := identity.Recipient().String()
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantAllowed bool
+		wantPaths   []string
+	}{
+		{
+			name:        "auto-suffixed paths",
+			wantAllowed: true,
+			wantPaths:   []string{"/zen-lock/secrets/api", "/zen-lock/secrets/db"},
+		},
+		{
+			name: "distinct explicit path",
+			annotations: map[string]string{
+				config.AnnotationMountPathPrefix + "db": "/config/db",
+			},
+			wantAllowed: true,
+			wantPaths:   []string{"/zen-lock/secrets/api", "/config/db"},
+		},
+		{
+			name: "colliding explicit paths",
+			annotations: map[string]string{
+				config.AnnotationMountPathPrefix + "db":  "/config",
+				config.AnnotationMountPathPrefix + "api": "/config",
+			},
+			wantAllowed: false,
+		},
+		{
+			name: "explicit path colliding with auto-suffixed path",
+			annotations: map[string]string{
+				config.AnnotationMountPathPrefix + "db": "/zen-lock/secrets/api",
+			},
+			wantAllowed: false,
+		},
+		{
+			name: "nested explicit path",
+			annotations: map[string]string{
+				config.AnnotationMountPathPrefix + "db": "/zen-lock/secrets",
+			},
+			wantAllowed: false,
+		},
+		{
+			name: "invalid explicit path",
+			annotations: map[string]string{
+				config.AnnotationMountPathPrefix + "db": "relative/path",
+			},
+			wantAllowed: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := createSelectorZenLock(t, "db", publicKey, map[string]string{"app": "web"})
+			api := createSelectorZenLock(t, "api", publicKey, map[string]string{"app": "web"})
+			handler, clientBuilder := setupTestPodHandlerWithKey(t, identity.String())
+			handler.Client = clientBuilder.WithObjects(db, api).Build()
+
+			resp := handler.Handle(context.Background(), selectorTestRequestWithAnnotations(t, map[string]string{"app": "web"}, tt.annotations))
+			if resp.Allowed != tt.wantAllowed {
+				t.Fatalf("Expected allowed=%v, got %v: %v", tt.wantAllowed, resp.Allowed, resp.Result)
+			}
+
+			patches, err := json.Marshal(resp.Patches)
+			if err != nil {
+				t.Fatalf("Failed to marshal patches: %v", err)
+			}
+			for _, want := range tt.wantPaths {
+				if !strings.Contains(string(patches), `"mountPath":"`+want+`"`) {
+					t.Errorf("Expected a volume mounted at %s, got %s", want, patches)
+				}
+			}
+		})
+	}
+}
+
+func TestPodHandler_Handle_InjectionSelectorLimit(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	publicKey := identity.Recipient().String()
+
+	a := createSelectorZenLock(t, "a", publicKey, map[string]string{"app": "other"})
+	b := createSelectorZenLock(t, "b", publicKey, map[string]string{"app": "web"})
+	handler, clientBuilder := setupTestPodHandlerWithKey(t, identity.String())
+	handler.Client = clientBuilder.WithObjects(a, b).Build()
+	handler.maxSelectorZenLocks = 1
+
+	resp := handler.Handle(context.Background(), selectorTestRequest(t, map[string]string{"app": "web"}))
+	if !resp.Allowed {
+		t.Fatalf("Expected request to be allowed, got: %v", resp.Result)
+	}
+	if len(resp.Patches) != 0 {
+		t.Errorf("Expected ZenLocks beyond the limit to be skipped, got %d patches", len(resp.Patches))
+	}
+	if len(resp.Warnings) == 0 {
+		t.Error("Expected a warning when the selector limit is exceeded")
+	}
+}
+
+func TestGenerateVolumeName(t *testing.T) {
+	if got := GenerateVolumeName("db"); got != config.DefaultVolumeName+"-db" {
+		t.Errorf("Expected %q, got %q", config.DefaultVolumeName+"-db", got)
+	}
+
+	for _, name := range []string{"my.dotted.zenlock", strings.Repeat("a", 100)} {
+		got := GenerateVolumeName(name)
+		if len(got) > 63 {
+			t.Errorf("Volume name %q exceeds 63 characters", got)
+		}
+		if strings.Contains(got, ".") {
+			t.Errorf("Volume name %q contains a dot", got)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+func handleSystemNamespacePod(t *testing.T, allowSelfNamespace, delegate bool) (admission.Response, bool) {
+	zenlock := &securityv1alpha1.ZenLock{ObjectMeta: metav1.ObjectMeta{Name: "test-zenlock", Namespace: "zen-lock-system"}}
+	handler := injectionTestHandler(t, zenlock, delegate)
+	handler.systemNamespace = "zen-lock-system"
+	handler.allowSelfNamespace = allowSelfNamespace
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "zen-lock-webhook",
+			Namespace: "zen-lock-system",
+			Annotations: map[string]string{
+				config.AnnotationInject: "test-zenlock",
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "webhook", Image: "zen-lock"},
+			},
+		},
+	}
+	resp := admitTestPod(t, handler, pod)
+	return resp, injectedSecretExists(t, handler, pod)
+}
+
+func TestPodHandler_Handle_SystemNamespaceSkipped(t *testing.T) {
+	for _, mode := range injectionModes {
+		t.Run(mode.name, func(t *testing.T) {
+			resp, created := handleSystemNamespacePod(t, false, mode.delegate)
+			if !resp.Allowed {
+				t.Fatalf("Expected request to be allowed, got: %v", resp.Result)
+			}
+			if len(resp.Patches) != 0 || created {
+				t.Errorf("Expected no injection into the system namespace, got %d patches, Secret created=%v", len(resp.Patches), created)
+			}
+		})
+	}
+}
+
+func TestPodHandler_Handle_SystemNamespaceAllowed(t *testing.T) {
+	for _, mode := range injectionModes {
+		t.Run(mode.name, func(t *testing.T) {
+			resp, created := handleSystemNamespacePod(t, true, mode.delegate)
+			if !resp.Allowed {
+				t.Fatalf("Expected request to be allowed, got: %v", resp.Result)
+			}
+			if len(resp.Patches) == 0 {
+				t.Error("Expected injection into the system namespace when overridden")
+			}
+			if created != !mode.delegate {
+				t.Errorf("Expected Secret created by the webhook=%v, got %v", !mode.delegate, created)
+			}
+		})
+	}
+}
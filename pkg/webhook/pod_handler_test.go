@@ -22,15 +22,18 @@ import (
 	"testing"
 	"time"
 
+	"filippo.io/age"
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
 	"github.com/kube-zen/zen-lock/pkg/crypto"
 )
 
@@ -61,6 +64,85 @@ func setupTestPodHandler(t *testing.T) (*PodHandler, *fake.ClientBuilder) {
 	return handler, clientBuilder
 }
 
+// injectionModes are the two ways an admitted Pod gets its Secret: created by the webhook, or by the
+// controller when the webhook delegates creation
+var injectionModes = []struct {
+	name     string
+	delegate bool
+}{
+	{name: "webhook", delegate: false},
+	{name: "delegated", delegate: true},
+}
+
+// injectionTestHandler returns a handler whose client holds zenlock, with its data encrypted for the
+// handler's key so that the webhook can decrypt it when it does not delegate Secret creation
+func injectionTestHandler(t *testing.T, zenlock *securityv1alpha1.ZenLock, delegate bool) *PodHandler {
+	t.Helper()
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	zenlock.Spec.EncryptedData = map[string]string{"key": encryptTestData(t, "value", identity.Recipient().String())}
+
+	handler, clientBuilder := setupTestPodHandlerWithKey(t, identity.String())
+	handler.delegateSecretCreation = delegate
+	handler.Client = clientBuilder.WithObjects(zenlock).Build()
+	return handler
+}
+
+// admitTestPod sends the creation of pod to handler
+func admitTestPod(t *testing.T, handler *PodHandler, pod *corev1.Pod) admission.Response {
+	t.Helper()
+	podRaw, err := json.Marshal(pod)
+	if err != nil {
+		t.Fatalf("Failed to marshal Pod: %v", err)
+	}
+	return handler.Handle(context.Background(), admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			Object:    runtime.RawExtension{Raw: podRaw},
+			Namespace: pod.Namespace,
+		},
+	})
+}
+
+// injectionTestPod returns a Pod injecting the ZenLock "test-zenlock", with extra labels and annotations
+func injectionTestPod(labels, annotations map[string]string) *corev1.Pod {
+	podAnnotations := map[string]string{config.AnnotationInject: "test-zenlock"}
+	for k, v := range annotations {
+		podAnnotations[k] = v
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-pod",
+			Namespace:   "default",
+			Labels:      labels,
+			Annotations: podAnnotations,
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "test-container", Image: "nginx"}},
+		},
+	}
+}
+
+// handleAnnotatedPod admits an injectionTestPod with extra annotations, the webhook creating the Secret
+func handleAnnotatedPod(t *testing.T, annotations map[string]string) admission.Response {
+	t.Helper()
+	zenlock := &securityv1alpha1.ZenLock{ObjectMeta: metav1.ObjectMeta{Name: "test-zenlock", Namespace: "default"}}
+	return admitTestPod(t, injectionTestHandler(t, zenlock, false), injectionTestPod(nil, annotations))
+}
+
+// injectedSecretExists reports whether the webhook created the decrypted Secret of pod
+func injectedSecretExists(t *testing.T, handler *PodHandler, pod *corev1.Pod) bool {
+	t.Helper()
+	key := types.NamespacedName{Name: GenerateSecretName(pod.Namespace, pod.Name), Namespace: pod.Namespace}
+	secret := &corev1.Secret{}
+	if err := handler.Client.Get(context.Background(), key, secret); err != nil {
+		return false
+	}
+	return string(secret.Data["key"]) == "value"
+}
+
 func TestPodHandler_Handle_NoInjectionAnnotation(t *testing.T) {
 	handler, _ := setupTestPodHandler(t)
 
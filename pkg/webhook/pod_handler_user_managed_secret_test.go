@@ -0,0 +1,134 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/common"
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+// handleUserManagedSecretPod injects into Pod "app" while a hand-made Secret sits at its target name
+func handleUserManagedSecretPod(t *testing.T, adopt bool) (admission.Response, *corev1.Secret) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-zenlock", Namespace: "default"},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{"USERNAME": encryptTestData(t, "admin", identity.Recipient().String())},
+		},
+	}
+	secretName := GenerateSecretName("default", "app")
+	userSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: "default", Labels: map[string]string{"team": "payments"}},
+		Data:       map[string][]byte{"own-key": []byte("hand-made")},
+	}
+
+	handler, clientBuilder := setupTestPodHandlerWithKey(t, identity.String())
+	c := clientBuilder.WithObjects(zenlock, userSecret).Build()
+	handler.Client = c
+	handler.adoptUnmanagedSecrets = adopt
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "app",
+			Namespace:   "default",
+			Annotations: map[string]string{config.AnnotationInject: "test-zenlock"},
+		},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "test-container", Image: "nginx"}}},
+	}
+	podRaw, _ := json.Marshal(pod)
+	req := admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			Object:    runtime.RawExtension{Raw: podRaw},
+			Namespace: "default",
+		},
+	}
+	resp := handler.Handle(context.Background(), req)
+
+	secret := &corev1.Secret{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: secretName, Namespace: "default"}, secret); err != nil {
+		t.Fatalf("Failed to get Secret: %v", err)
+	}
+	return resp, secret
+}
+
+func TestPodHandler_Handle_RefusesUserManagedSecret(t *testing.T) {
+	resp, secret := handleUserManagedSecretPod(t, false)
+
+	if resp.Allowed {
+		t.Fatal("Expected injection over a user-managed Secret to be denied")
+	}
+	if !strings.Contains(resp.Result.Message, "not managed by zen-lock") || !strings.Contains(resp.Result.Message, secret.Name) {
+		t.Errorf("Expected a collision message naming the Secret, got %q", resp.Result.Message)
+	}
+	if string(secret.Data["own-key"]) != "hand-made" || len(secret.Data) != 1 {
+		t.Errorf("Expected the user-managed Secret to be left untouched, got %v", secret.Data)
+	}
+	if _, ok := secret.Labels[common.LabelZenLockName]; ok {
+		t.Errorf("Expected no zen-lock labels on the user-managed Secret, got %v", secret.Labels)
+	}
+}
+
+func TestPodHandler_Handle_AdoptsUserManagedSecret(t *testing.T) {
+	resp, secret := handleUserManagedSecretPod(t, true)
+
+	if !resp.Allowed {
+		t.Fatalf("Expected injection to be allowed in adoption mode, got %v", resp.Result)
+	}
+	if string(secret.Data["USERNAME"]) != "admin" || secret.Labels[common.LabelZenLockName] != "test-zenlock" {
+		t.Errorf("Expected the Secret to be taken over, got labels %v and keys %d", secret.Labels, len(secret.Data))
+	}
+}
+
+func TestIsUserManagedSecret(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels map[string]string
+		want   bool
+	}{
+		{name: "no labels", want: true},
+		{name: "unrelated labels", labels: map[string]string{"app": "web"}, want: true},
+		{name: "zenlock label", labels: map[string]string{common.LabelZenLockName: "db"}},
+		{name: "pod label", labels: map[string]string{common.LabelPodName: "app"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Labels: tt.labels}}
+			if got := IsUserManagedSecret(secret); got != tt.want {
+				t.Errorf("IsUserManagedSecret() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+func TestMutatePodForTarget_Writable(t *testing.T) {
+	handler := &PodHandler{}
+	uid := int64(1000)
+	pod := &corev1.Pod{Spec: corev1.PodSpec{
+		Containers:     []corev1.Container{{Name: "app", SecurityContext: &corev1.SecurityContext{RunAsUser: &uid}}},
+		InitContainers: []corev1.Container{{Name: "migrate"}},
+	}}
+	targets := []injectionTarget{{
+		zenlockName: "test-zenlock",
+		secretName:  "zen-lock-inject-default-app",
+		volumeName:  config.DefaultVolumeName,
+		mountPath:   config.DefaultMountPath,
+	}}
+	applyMountWritable(targets)
+	if err := handler.mutatePodForTarget(pod, targets[0]); err != nil {
+		t.Fatalf("mutatePodForTarget failed: %v", err)
+	}
+
+	volumes := make(map[string]corev1.VolumeSource)
+	for _, vol := range pod.Spec.Volumes {
+		volumes[vol.Name] = vol.VolumeSource
+	}
+	sourceName := WritableSourceVolumeName(config.DefaultVolumeName)
+	if src := volumes[sourceName]; src.Secret == nil || src.Secret.SecretName != "zen-lock-inject-default-app" {
+		t.Fatalf("Expected Secret volume %s, got %+v", sourceName, pod.Spec.Volumes)
+	}
+	if dst := volumes[config.DefaultVolumeName]; dst.EmptyDir == nil || dst.EmptyDir.Medium != corev1.StorageMediumMemory {
+		t.Fatalf("Expected memory emptyDir %s, got %+v", config.DefaultVolumeName, pod.Spec.Volumes)
+	}
+
+	if len(pod.Spec.InitContainers) != 2 || pod.Spec.InitContainers[0].Name != CopyContainerName(config.DefaultVolumeName) {
+		t.Fatalf("Expected the copy init container to run first, got %+v", pod.Spec.InitContainers)
+	}
+	copier := pod.Spec.InitContainers[0]
+	if copier.Image != config.DefaultCopyImage || copier.Command[len(copier.Command)-1] != config.DefaultMountPath {
+		t.Errorf("Unexpected copy container: %+v", copier)
+	}
+	mounts := make(map[string]corev1.VolumeMount)
+	for _, m := range copier.VolumeMounts {
+		mounts[m.Name] = m
+	}
+	if !mounts[sourceName].ReadOnly || mounts[config.DefaultVolumeName].ReadOnly || mounts[config.DefaultVolumeName].MountPath != config.DefaultMountPath {
+		t.Errorf("Expected the Secret mounted read-only and the emptyDir writable, got %+v", copier.VolumeMounts)
+	}
+	if copier.SecurityContext.RunAsUser == nil || *copier.SecurityContext.RunAsUser != uid {
+		t.Errorf("Expected the copy to run as the app's user, got %+v", copier.SecurityContext)
+	}
+
+	for _, c := range append(pod.Spec.Containers, pod.Spec.InitContainers[1]) {
+		if len(c.VolumeMounts) != 1 || c.VolumeMounts[0].Name != config.DefaultVolumeName || c.VolumeMounts[0].ReadOnly {
+			t.Errorf("Expected container %s to mount the emptyDir writable, got %+v", c.Name, c.VolumeMounts)
+		}
+	}
+}
+
+func TestMutatePodForTarget_ReadOnlyByDefault(t *testing.T) {
+	handler := &PodHandler{}
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}}}
+	target := injectionTarget{
+		zenlockName: "test-zenlock",
+		secretName:  "zen-lock-inject-default-app",
+		volumeName:  config.DefaultVolumeName,
+		mountPath:   config.DefaultMountPath,
+	}
+	if err := handler.mutatePodForTarget(pod, target); err != nil {
+		t.Fatalf("mutatePodForTarget failed: %v", err)
+	}
+
+	if len(pod.Spec.Volumes) != 1 || pod.Spec.Volumes[0].Secret == nil {
+		t.Fatalf("Expected a single Secret volume, got %+v", pod.Spec.Volumes)
+	}
+	if len(pod.Spec.InitContainers) != 0 {
+		t.Errorf("Expected no copy init container, got %+v", pod.Spec.InitContainers)
+	}
+	if mounts := pod.Spec.Containers[0].VolumeMounts; len(mounts) != 1 || !mounts[0].ReadOnly {
+		t.Errorf("Expected a read-only mount, got %+v", mounts)
+	}
+}
+
+func TestWritableSourceVolumeName_Bounded(t *testing.T) {
+	long := GenerateVolumeName(strings.Repeat("a", 100))
+	name := WritableSourceVolumeName(long)
+	if len(name) > 63 || !strings.HasSuffix(name, "-source") {
+		t.Errorf("Expected a bounded source volume name, got %q (%d)", name, len(name))
+	}
+	if WritableSourceVolumeName(long) != name {
+		t.Error("Expected a stable source volume name")
+	}
+}
+
+func TestPodHandler_Handle_MountWritable(t *testing.T) {
+	resp := handleAnnotatedPod(t, map[string]string{config.AnnotationMountWritable: "true"})
+	if !resp.Allowed {
+		t.Fatalf("Expected injection to be allowed, got %v", resp.Result)
+	}
+	patches, err := json.Marshal(resp.Patches)
+	if err != nil {
+		t.Fatalf("Failed to marshal patches: %v", err)
+	}
+	for _, want := range []string{`"emptyDir"`, CopyContainerName(config.DefaultVolumeName), WritableSourceVolumeName(config.DefaultVolumeName)} {
+		if !strings.Contains(string(patches), want) {
+			t.Errorf("Expected patches to contain %s, got %s", want, patches)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+// PolicyHook asks an external policy endpoint, e.g. an OPA or Kyverno service, whether a ZenLock may be
+// injected into a Pod. Only non-sensitive context is sent: never ZenLock data or keys.
+type PolicyHook struct {
+	url      string
+	client   *http.Client
+	failOpen bool
+}
+
+// PolicyRequest is the JSON body POSTed to the policy endpoint
+type PolicyRequest struct {
+	Namespace      string `json:"namespace"`
+	PodName        string `json:"podName"`
+	ServiceAccount string `json:"serviceAccount"`
+	ZenLock        string `json:"zenlock"`
+}
+
+// PolicyDecision is the JSON response expected from the policy endpoint
+type PolicyDecision struct {
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// NewPolicyHook creates a policy hook for the given endpoint
+// With failOpen, injection proceeds when the endpoint cannot be reached or answers badly; otherwise it is denied.
+func NewPolicyHook(endpoint string, timeout time.Duration, failOpen bool) *PolicyHook {
+	return &PolicyHook{
+		url:      endpoint,
+		client:   &http.Client{Timeout: timeout},
+		failOpen: failOpen,
+	}
+}
+
+// PolicyHookFromEnv returns the policy hook configured by ZEN_LOCK_POLICY_URL, or nil when unset
+// ZEN_LOCK_POLICY_TIMEOUT bounds each call and ZEN_LOCK_POLICY_FAIL_OPEN=true admits injections the
+// endpoint could not decide. An invalid URL or timeout is an error so a misconfigured webhook does not start.
+func PolicyHookFromEnv() (*PolicyHook, error) {
+	endpoint := os.Getenv("ZEN_LOCK_POLICY_URL")
+	if endpoint == "" {
+		return nil, nil
+	}
+	if parsed, err := url.Parse(endpoint); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("ZEN_LOCK_POLICY_URL %q must be an http or https URL", endpoint)
+	}
+	timeout := config.DefaultPolicyHookTimeout
+	if timeoutStr := os.Getenv("ZEN_LOCK_POLICY_TIMEOUT"); timeoutStr != "" {
+		parsedTimeout, err := time.ParseDuration(timeoutStr)
+		if err != nil || parsedTimeout <= 0 {
+			return nil, fmt.Errorf("ZEN_LOCK_POLICY_TIMEOUT %q must be a positive duration", timeoutStr)
+		}
+		timeout = parsedTimeout
+	}
+	failOpen, _ := strconv.ParseBool(os.Getenv("ZEN_LOCK_POLICY_FAIL_OPEN"))
+	return NewPolicyHook(endpoint, timeout, failOpen), nil
+}
+
+// newPolicyRequest describes the injection of a ZenLock into a Pod for the policy endpoint
+func newPolicyRequest(namespace string, pod *corev1.Pod, zenlockName string) PolicyRequest {
+	serviceAccount := pod.Spec.ServiceAccountName
+	if serviceAccount == "" {
+		serviceAccount = "default"
+	}
+	return PolicyRequest{
+		Namespace:      namespace,
+		PodName:        admissionPodName(pod),
+		ServiceAccount: serviceAccount,
+		ZenLock:        zenlockName,
+	}
+}
+
+// Authorize asks the policy endpoint whether the ZenLock may be injected into the Pod
+// It is the controller's counterpart of the webhook's check, used before creating delegated Secrets.
+// An endpoint failure is an error unless the hook fails open.
+func (p *PolicyHook) Authorize(ctx context.Context, pod *corev1.Pod, zenlockName string) error {
+	decision, err := p.Decide(ctx, newPolicyRequest(pod.Namespace, pod, zenlockName))
+	if err != nil {
+		if p.failOpen {
+			log.FromContext(ctx).Error(err, "Policy endpoint failed, injecting anyway (ZEN_LOCK_POLICY_FAIL_OPEN)", "namespace", pod.Namespace, "zenlock", zenlockName)
+			return nil
+		}
+		return fmt.Errorf("policy endpoint could not authorize injection of ZenLock %q: %v", zenlockName, err)
+	}
+	if !decision.Allowed {
+		reason := decision.Reason
+		if reason == "" {
+			reason = "no reason given"
+		}
+		return fmt.Errorf("policy endpoint denied injection of ZenLock %q: %s", zenlockName, reason)
+	}
+	return nil
+}
+
+// Decide asks the policy endpoint about an injection
+// A nil error means the endpoint answered; its decision is returned as is.
+func (p *PolicyHook) Decide(ctx context.Context, policyReq PolicyRequest) (PolicyDecision, error) {
+	body, err := json.Marshal(policyReq)
+	if err != nil {
+		return PolicyDecision{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return PolicyDecision{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return PolicyDecision{}, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return PolicyDecision{}, fmt.Errorf("policy endpoint returned %s", resp.Status)
+	}
+
+	var decision PolicyDecision
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 64*1024)).Decode(&decision); err != nil {
+		return PolicyDecision{}, fmt.Errorf("invalid policy decision: %v", err)
+	}
+	return decision, nil
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"filippo.io/age"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+)
+
+// policyTestHandler returns a handler for a decryptable test-zenlock that consults the given policy server
+func policyTestHandler(t *testing.T, server *httptest.Server, failOpen bool) *PodHandler {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-zenlock", Namespace: "default"},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{"USERNAME": encryptTestData(t, "admin", identity.Recipient().String())},
+		},
+	}
+	handler, clientBuilder := setupTestPodHandlerWithKey(t, identity.String())
+	handler.Client = clientBuilder.WithObjects(zenlock).Build()
+	handler.policyHook = NewPolicyHook(server.URL, time.Second, failOpen)
+	return handler
+}
+
+func TestPodHandler_Handle_PolicyHook(t *testing.T) {
+	var got PolicyRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("Failed to decode policy request: %v", err)
+		}
+		decision := PolicyDecision{Allowed: got.PodName != "blocked", Reason: "blocked by org policy"}
+		_ = json.NewEncoder(w).Encode(decision)
+	}))
+	defer server.Close()
+	handler := policyTestHandler(t, server, false)
+
+	if resp := handler.Handle(context.Background(), sharedNamingRequest("app-1", "")); !resp.Allowed {
+		t.Fatalf("Expected the policy endpoint to allow injection, got %v", resp.Result)
+	}
+	want := PolicyRequest{Namespace: "default", PodName: "app-1", ServiceAccount: "default", ZenLock: "test-zenlock"}
+	if got != want {
+		t.Errorf("Policy request = %+v, want %+v", got, want)
+	}
+
+	resp := handler.Handle(context.Background(), sharedNamingRequest("blocked", ""))
+	if resp.Allowed {
+		t.Fatal("Expected the policy endpoint to deny injection")
+	}
+	if !strings.Contains(resp.Result.Message, "blocked by org policy") {
+		t.Errorf("Expected the denial to carry the policy reason, got %q", resp.Result.Message)
+	}
+}
+
+func TestPodHandler_Handle_PolicyHookFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	t.Run("fail closed", func(t *testing.T) {
+		resp := policyTestHandler(t, server, false).Handle(context.Background(), sharedNamingRequest("app-1", ""))
+		if resp.Allowed {
+			t.Fatal("Expected a failing policy endpoint to deny injection by default")
+		}
+		if !strings.Contains(resp.Result.Message, "could not authorize") {
+			t.Errorf("Expected the denial to explain the endpoint failed, got %q", resp.Result.Message)
+		}
+	})
+
+	t.Run("fail open", func(t *testing.T) {
+		resp := policyTestHandler(t, server, true).Handle(context.Background(), sharedNamingRequest("app-1", ""))
+		if !resp.Allowed {
+			t.Errorf("Expected a failing policy endpoint to be ignored when failing open, got %v", resp.Result)
+		}
+	})
+}
+
+func TestPolicyHookFromEnv(t *testing.T) {
+	t.Setenv("ZEN_LOCK_POLICY_URL", "")
+	if hook, err := PolicyHookFromEnv(); hook != nil || err != nil {
+		t.Errorf("Expected no hook when unset, got %v, %v", hook, err)
+	}
+
+	t.Setenv("ZEN_LOCK_POLICY_URL", "policy.example:8181")
+	if _, err := PolicyHookFromEnv(); err == nil {
+		t.Error("Expected a URL without an http scheme to be rejected")
+	}
+
+	t.Setenv("ZEN_LOCK_POLICY_URL", "https://policy.example/v1/data/zenlock")
+	t.Setenv("ZEN_LOCK_POLICY_FAIL_OPEN", "true")
+	hook, err := PolicyHookFromEnv()
+	if err != nil || hook == nil || !hook.failOpen {
+		t.Errorf("Expected a fail-open hook, got %+v, %v", hook, err)
+	}
+
+	t.Setenv("ZEN_LOCK_POLICY_TIMEOUT", "500ms")
+	if hook, err := PolicyHookFromEnv(); err != nil || hook.client.Timeout != 500*time.Millisecond {
+		t.Errorf("Expected a 500ms timeout, got %+v, %v", hook, err)
+	}
+	for _, invalid := range []string{"soon", "0s", "-1s"} {
+		t.Setenv("ZEN_LOCK_POLICY_TIMEOUT", invalid)
+		if _, err := PolicyHookFromEnv(); err == nil {
+			t.Errorf("Expected ZEN_LOCK_POLICY_TIMEOUT=%q to be rejected", invalid)
+		}
+	}
+}
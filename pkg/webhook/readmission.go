@@ -0,0 +1,86 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/common"
+	"github.com/kube-zen/zen-lock/pkg/controller/metrics"
+)
+
+// alreadyInjected reports whether the Pod already carries every target's injection, with its Secret in place
+// This is the case when the Pod is admitted again after zen-lock mutated it, e.g. on webhook reinvocation.
+// The Secrets must exist, be managed by zen-lock for the same ZenLock and, unless shared, for the same Pod;
+// its data is not compared, as that would require decrypting.
+func (h *PodHandler) alreadyInjected(ctx context.Context, namespace string, pod *corev1.Pod, targets []injectionTarget) bool {
+	mutated := pod.DeepCopy()
+	for _, target := range targets {
+		if err := h.mutatePodForTarget(mutated, target); err != nil {
+			return false
+		}
+	}
+	annotateDelegatedSecrets(mutated, targets, h.delegateSecretCreation)
+	if !equality.Semantic.DeepEqual(pod.Spec, mutated.Spec) || !equality.Semantic.DeepEqual(pod.Annotations, mutated.Annotations) {
+		return false
+	}
+
+	// Delegated Secrets are created by the controller once the Pod exists
+	if h.delegateSecretCreation {
+		return true
+	}
+	for _, target := range targets {
+		for _, name := range target.requiredSecretNames() {
+			secret := &corev1.Secret{}
+			if err := h.Client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, secret); err != nil {
+				return false
+			}
+			if secret.Labels[common.ZenLockNameLabel()] != target.zenlockName {
+				return false
+			}
+			if !target.shared && secret.Labels[common.PodNameLabel()] != pod.Name {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// noopResponse admits an already-injected Pod unchanged, once the ZenLocks are still allowed to be injected
+// No decryption or API write happens; each ZenLock is counted with the "noop" result.
+func (h *PodHandler) noopResponse(ctx context.Context, req admission.Request, pod *corev1.Pod, zenlocks []*securityv1alpha1.ZenLock, startTime time.Time) admission.Response {
+	for _, zenlock := range zenlocks {
+		recordAuditZenLock(ctx, zenlock.Name)
+		if resp := h.authorizeTarget(ctx, req, pod, zenlock, zenlock.Name, startTime); resp.Result != nil {
+			h.recordInjectionFailure(req, pod, zenlock, resp)
+			return resp
+		}
+	}
+
+	duration := time.Since(startTime).Seconds()
+	for _, zenlock := range zenlocks {
+		metrics.RecordWebhookInjection(req.Namespace, zenlock.Name, "noop", duration)
+	}
+	return admission.Allowed(alreadyInjectedMessage)
+}
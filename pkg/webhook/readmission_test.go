@@ -0,0 +1,179 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
+	"github.com/kube-zen/zen-lock/pkg/controller/metrics"
+)
+
+// readmissionTestHandler returns a handler for a decryptable ZenLock and a pointer to its count of API writes
+func readmissionTestHandler(t *testing.T) (*PodHandler, *int) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	zenlock := &securityv1alpha1.ZenLock{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-zenlock", Namespace: "default"},
+		Spec: securityv1alpha1.ZenLockSpec{
+			EncryptedData: map[string]string{"USERNAME": encryptTestData(t, "admin", identity.Recipient().String())},
+		},
+	}
+
+	writes := 0
+	handler, clientBuilder := setupTestPodHandlerWithKey(t, identity.String())
+	handler.Client = clientBuilder.WithObjects(zenlock).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			writes++
+			return c.Create(ctx, obj, opts...)
+		},
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			writes++
+			return c.Update(ctx, obj, opts...)
+		},
+	}).Build()
+	return handler, &writes
+}
+
+// injectedPodRequest returns the admission request of the Pod from sharedNamingRequest, already mutated by zen-lock
+func injectedPodRequest(t *testing.T, handler *PodHandler, podName string) admission.Request {
+	req := sharedNamingRequest(podName, "")
+	pod := &corev1.Pod{}
+	if err := json.Unmarshal(req.Object.Raw, pod); err != nil {
+		t.Fatalf("Failed to decode pod: %v", err)
+	}
+	if err := handler.mutatePod(pod, GenerateSecretName("default", podName), config.DefaultMountPath); err != nil {
+		t.Fatalf("mutatePod failed: %v", err)
+	}
+	req.Object.Raw, _ = json.Marshal(pod)
+	return req
+}
+
+func TestPodHandler_Handle_ReadmissionIsNoop(t *testing.T) {
+	handler, writes := readmissionTestHandler(t)
+	if resp := handler.Handle(context.Background(), sharedNamingRequest("app-1", "")); !resp.Allowed || len(resp.Patches) == 0 {
+		t.Fatalf("Expected the first admission to inject, got %v", resp.Result)
+	}
+
+	*writes = 0
+	successBefore := testutil.ToFloat64(metrics.WebhookInjectionTotal.WithLabelValues("default", "test-zenlock", "success"))
+	noopBefore := testutil.ToFloat64(metrics.WebhookInjectionTotal.WithLabelValues("default", "test-zenlock", "noop"))
+
+	resp := handler.Handle(context.Background(), injectedPodRequest(t, handler, "app-1"))
+	if !resp.Allowed {
+		t.Fatalf("Expected re-admission to be allowed, got %v", resp.Result)
+	}
+	if len(resp.Patches) != 0 {
+		t.Errorf("Expected no patches on re-admission, got %+v", resp.Patches)
+	}
+	if *writes != 0 {
+		t.Errorf("Expected no API writes on re-admission, got %d", *writes)
+	}
+	if got := testutil.ToFloat64(metrics.WebhookInjectionTotal.WithLabelValues("default", "test-zenlock", "noop")) - noopBefore; got != 1 {
+		t.Errorf("Expected one noop injection, got %v", got)
+	}
+	if got := testutil.ToFloat64(metrics.WebhookInjectionTotal.WithLabelValues("default", "test-zenlock", "success")) - successBefore; got != 0 {
+		t.Errorf("Expected no additional successful injection, got %v", got)
+	}
+}
+
+func TestPodHandler_Handle_ReadmissionWithoutSecretInjects(t *testing.T) {
+	handler, writes := readmissionTestHandler(t)
+
+	// The Pod carries the volume, but its Secret is gone: the Secret is recreated
+	resp := handler.Handle(context.Background(), injectedPodRequest(t, handler, "app-1"))
+	if !resp.Allowed {
+		t.Fatalf("Expected admission to be allowed, got %v", resp.Result)
+	}
+	if *writes != 1 {
+		t.Errorf("Expected the Secret to be created, got %d writes", *writes)
+	}
+}
+
+func TestMutatePod_PartiallyMutatedPod(t *testing.T) {
+	handler := &PodHandler{}
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app"}},
+		},
+	}
+	if err := handler.mutatePod(pod, "test-secret", config.DefaultMountPath); err != nil {
+		t.Fatalf("mutatePod failed: %v", err)
+	}
+
+	// A later webhook adds a container after zen-lock ran
+	pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: "sidecar"})
+	if err := handler.mutatePod(pod, "test-secret", config.DefaultMountPath); err != nil {
+		t.Fatalf("mutatePod failed: %v", err)
+	}
+
+	if len(pod.Spec.Volumes) != 1 {
+		t.Errorf("Expected the volume to be added once, got %d volumes", len(pod.Spec.Volumes))
+	}
+	for _, c := range pod.Spec.Containers {
+		if len(c.VolumeMounts) != 1 || c.VolumeMounts[0].Name != config.DefaultVolumeName {
+			t.Errorf("Expected container %s to mount the volume once, got %+v", c.Name, c.VolumeMounts)
+		}
+	}
+}
+
+func TestPodHandler_Handle_ReinvocationMountsNewContainer(t *testing.T) {
+	handler, _ := readmissionTestHandler(t)
+	if resp := handler.Handle(context.Background(), sharedNamingRequest("app-1", "")); !resp.Allowed {
+		t.Fatalf("Expected the first admission to inject, got %v", resp.Result)
+	}
+
+	// Reinvoked after another webhook added a sidecar to the injected Pod
+	req := injectedPodRequest(t, handler, "app-1")
+	pod := &corev1.Pod{}
+	if err := json.Unmarshal(req.Object.Raw, pod); err != nil {
+		t.Fatalf("Failed to decode pod: %v", err)
+	}
+	pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: "sidecar", Image: "sidecar:latest"})
+	req.Object.Raw, _ = json.Marshal(pod)
+
+	resp := handler.Handle(context.Background(), req)
+	if !resp.Allowed {
+		t.Fatalf("Expected reinvocation to be allowed, got %v", resp.Result)
+	}
+	sidecarMount := false
+	for _, patch := range resp.Patches {
+		if strings.HasPrefix(patch.Path, "/spec/volumes") {
+			t.Errorf("Expected the existing volume to be left as is, got patch %+v", patch)
+		}
+		if patch.Path == "/spec/containers/1/volumeMounts" {
+			sidecarMount = true
+		}
+	}
+	if !sidecarMount {
+		t.Errorf("Expected the sidecar to get the volume mount, got patches %+v", resp.Patches)
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/kube-zen/zen-lock/pkg/common"
+	"github.com/kube-zen/zen-sdk/pkg/retry"
+)
+
+// conflictTestSecret returns a Secret for test-zenlock and test-pod holding value
+func conflictTestSecret(value string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-secret",
+			Namespace: "default",
+			Labels: map[string]string{
+				common.LabelZenLockName:  "test-zenlock",
+				common.LabelPodName:      "test-pod",
+				common.LabelPodNamespace: "default",
+			},
+		},
+		Data: map[string][]byte{"key": []byte(value)},
+	}
+}
+
+// conflictTestRetryConfig retries quickly so exhausted attempts do not slow the tests down
+func conflictTestRetryConfig() retry.Config {
+	retryConfig := retry.DefaultConfig()
+	retryConfig.MaxAttempts = 3
+	retryConfig.InitialDelay = time.Millisecond
+	retryConfig.MaxDelay = time.Millisecond
+	return retryConfig
+}
+
+func TestPodHandler_EnsureSecretExists_ConcurrentUpdateConverges(t *testing.T) {
+	handler, clientBuilder := setupTestPodHandler(t)
+
+	// Another replica rewrites the Secret between our read and our write, once
+	updates := 0
+	c := clientBuilder.WithObjects(conflictTestSecret("old-value")).WithInterceptorFuncs(interceptor.Funcs{
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			updates++
+			if updates == 1 {
+				concurrent := &corev1.Secret{}
+				if err := c.Get(ctx, client.ObjectKeyFromObject(obj), concurrent); err != nil {
+					return err
+				}
+				concurrent.Data = map[string][]byte{"key": []byte("other-replica")}
+				if err := c.Update(ctx, concurrent); err != nil {
+					return err
+				}
+			}
+			return c.Update(ctx, obj, opts...)
+		},
+	}).Build()
+	handler.Client = c
+
+	desired := conflictTestSecret("new-value")
+	ctx := context.Background()
+	if err := handler.ensureSecretExists(ctx, desired, "test-secret", "test-zenlock", "default", "test-pod", desired.Data, time.Now(), conflictTestRetryConfig(), false); err != nil {
+		t.Fatalf("ensureSecretExists() error = %v, want the conflict to be resolved", err)
+	}
+	if updates != 2 {
+		t.Errorf("Expected the stale write to be retried once on fresh data, got %d updates", updates)
+	}
+
+	got := &corev1.Secret{}
+	if err := c.Get(ctx, types.NamespacedName{Name: "test-secret", Namespace: "default"}, got); err != nil {
+		t.Fatalf("Failed to get Secret: %v", err)
+	}
+	if string(got.Data["key"]) != "new-value" {
+		t.Errorf("Expected the Secret to converge to new-value, got %q", got.Data["key"])
+	}
+}
+
+func TestPodHandler_EnsureSecretExists_ConflictAttemptsExhausted(t *testing.T) {
+	handler, clientBuilder := setupTestPodHandler(t)
+	handler.secretSyncAttempts = 2
+
+	updates := 0
+	handler.Client = clientBuilder.WithObjects(conflictTestSecret("old-value")).WithInterceptorFuncs(interceptor.Funcs{
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			updates++
+			return k8serrors.NewConflict(corev1.Resource("secrets"), obj.GetName(), nil)
+		},
+	}).Build()
+
+	desired := conflictTestSecret("new-value")
+	err := handler.ensureSecretExists(context.Background(), desired, "test-secret", "test-zenlock", "default", "test-pod", desired.Data, time.Now(), conflictTestRetryConfig(), false)
+	if !k8serrors.IsConflict(err) {
+		t.Fatalf("ensureSecretExists() error = %v, want a conflict", err)
+	}
+	// Each round writes once: conflicts are not resent with the same stale object
+	if updates != 2 {
+		t.Errorf("Expected one update per attempt, got %d updates", updates)
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+// templatePattern matches ${key} placeholders; key names follow Secret key rules
+var templatePattern = regexp.MustCompile(`\$\{([-._a-zA-Z0-9]+)\}`)
+
+// TemplatesEnabled reports whether the ZenLock opts into ${key} expansion via zen-lock/expand-templates=true
+func TemplatesEnabled(zenlock *securityv1alpha1.ZenLock) bool {
+	return zenlock.GetAnnotations()[config.AnnotationExpandTemplates] == "true"
+}
+
+// ExpandTemplates resolves ${key} placeholders in decrypted values using the other decrypted keys
+// References to undefined keys and reference cycles are errors. Errors name keys only, never values.
+func ExpandTemplates(data map[string][]byte) (map[string][]byte, error) {
+	expanded := make(map[string][]byte, len(data))
+	// visiting tracks the current resolution path for cycle detection
+	visiting := make(map[string]bool)
+	var path []string
+
+	var resolve func(key string) ([]byte, error)
+	resolve = func(key string) ([]byte, error) {
+		if value, ok := expanded[key]; ok {
+			return value, nil
+		}
+		if visiting[key] {
+			cycle := append(path[indexOf(path, key):], key)
+			return nil, fmt.Errorf("template cycle detected: %s", strings.Join(cycle, " -> "))
+		}
+
+		visiting[key] = true
+		path = append(path, key)
+		defer func() {
+			visiting[key] = false
+			path = path[:len(path)-1]
+		}()
+
+		var resolveErr error
+		value := templatePattern.ReplaceAllFunc(data[key], func(match []byte) []byte {
+			if resolveErr != nil {
+				return match
+			}
+			ref := string(templatePattern.FindSubmatch(match)[1])
+			if _, ok := data[ref]; !ok {
+				resolveErr = fmt.Errorf("key %q references undefined key %q", key, ref)
+				return match
+			}
+			refValue, err := resolve(ref)
+			if err != nil {
+				resolveErr = err
+				return match
+			}
+			return refValue
+		})
+		if resolveErr != nil {
+			return nil, resolveErr
+		}
+
+		expanded[key] = value
+		return value, nil
+	}
+
+	// Resolve in key order so errors are deterministic
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if _, err := resolve(key); err != nil {
+			return nil, err
+		}
+	}
+	return expanded, nil
+}
+
+// indexOf returns the position of s in list, or 0 if absent
+func indexOf(list []string, s string) int {
+	for i, v := range list {
+		if v == s {
+			return i
+		}
+	}
+	return 0
+}
@@ -18,16 +18,37 @@ package webhook
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/kube-zen/zen-lock/pkg/common"
+	"github.com/kube-zen/zen-lock/pkg/config"
 )
 
 const (
-	// MaxMountPathLength is the maximum reasonable mount path length
+	// MaxMountPathLength is the default maximum mount path length (ZEN_LOCK_MAX_MOUNT_PATH_LENGTH)
 	MaxMountPathLength = 1024
+
+	// MaxMountPathComponentLength is the longest file name most container filesystems accept (NAME_MAX)
+	MaxMountPathComponentLength = 255
 )
 
+// MountPathMaxLength returns ZEN_LOCK_MAX_MOUNT_PATH_LENGTH, or MaxMountPathLength when unset or invalid
+func MountPathMaxLength() int {
+	if maxStr := os.Getenv("ZEN_LOCK_MAX_MOUNT_PATH_LENGTH"); maxStr != "" {
+		if parsedMax, err := strconv.Atoi(maxStr); err == nil && parsedMax > 0 {
+			return parsedMax
+		}
+	}
+	return MaxMountPathLength
+}
+
 // ValidateInjectAnnotation validates the zen-lock/inject annotation value
 func ValidateInjectAnnotation(injectName string) error {
 	// Kubernetes annotation values must be valid DNS subdomain names
@@ -62,8 +83,9 @@ func ValidateMountPath(mountPath string) error {
 		return fmt.Errorf("mount path cannot be empty")
 	}
 
-	if len(mountPath) > MaxMountPathLength {
-		return fmt.Errorf("mount path exceeds maximum length of %d", MaxMountPathLength)
+	// Container runtimes fail to create mounts with paths near PATH_MAX
+	if maxLength := MountPathMaxLength(); len(mountPath) > maxLength {
+		return fmt.Errorf("mount path is %d characters long, exceeding the maximum of %d", len(mountPath), maxLength)
 	}
 
 	// Must be an absolute path
@@ -71,23 +93,141 @@ func ValidateMountPath(mountPath string) error {
 		return fmt.Errorf("mount path must be an absolute path")
 	}
 
+	for _, component := range strings.Split(mountPath, "/") {
+		if len(component) > MaxMountPathComponentLength {
+			return fmt.Errorf("mount path component %q... is %d characters long, exceeding the filesystem limit of %d",
+				component[:32], len(component), MaxMountPathComponentLength)
+		}
+	}
+
 	// Sanitize: prevent directory traversal attempts
 	cleanPath := filepath.Clean(mountPath)
 	if cleanPath != mountPath {
 		return fmt.Errorf("mount path contains invalid characters or directory traversal")
 	}
 
-	// Prevent dangerous paths
-	dangerousPaths := []string{"/", "/bin", "/sbin", "/usr", "/etc", "/var", "/sys", "/proc", "/dev"}
-	for _, dangerous := range dangerousPaths {
-		if mountPath == dangerous || strings.HasPrefix(mountPath, dangerous+"/") {
-			return fmt.Errorf("mount path cannot be in system directories")
+	// Prevent shadowing the root or the image's system directories
+	if mountPath == "/" {
+		return fmt.Errorf("mount path cannot be the root directory")
+	}
+	for _, forbidden := range ForbiddenMountPaths() {
+		if mountPath == forbidden || strings.HasPrefix(mountPath, forbidden+"/") {
+			return fmt.Errorf("mount path cannot be in system directory %s", forbidden)
 		}
 	}
 
 	return nil
 }
 
+// ForbiddenMountPaths returns the directories mount paths may not be in or under (ZEN_LOCK_FORBIDDEN_MOUNT_PATHS)
+// The comma-separated list replaces the defaults; entries that are not absolute paths are ignored.
+func ForbiddenMountPaths() []string {
+	value := os.Getenv("ZEN_LOCK_FORBIDDEN_MOUNT_PATHS")
+	if value == "" {
+		value = config.DefaultForbiddenMountPaths
+	}
+	var paths []string
+	for _, path := range strings.Split(value, ",") {
+		path = strings.TrimSpace(path)
+		if !filepath.IsAbs(path) {
+			continue
+		}
+		if path = filepath.Clean(path); path != "/" {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// ValidateInjectScope validates the zen-lock/inject-scope annotation value
+func ValidateInjectScope(scope string) error {
+	switch scope {
+	case "", config.InjectScopeAll, config.InjectScopeMainOnly, config.InjectScopeInitOnly, config.InjectScopeSidecarOnly:
+		return nil
+	default:
+		return fmt.Errorf("inject scope must be one of %q, %q, %q or %q",
+			config.InjectScopeAll, config.InjectScopeMainOnly, config.InjectScopeInitOnly, config.InjectScopeSidecarOnly)
+	}
+}
+
+// ValidateSecretNaming validates the zen-lock/secret-naming annotation value
+func ValidateSecretNaming(naming string) error {
+	switch naming {
+	case "", config.SecretNamingPod, config.SecretNamingZenLock:
+		return nil
+	default:
+		return fmt.Errorf("secret naming must be %q or %q", config.SecretNamingPod, config.SecretNamingZenLock)
+	}
+}
+
+// InjectCondition is a parsed zen-lock/inject-if condition on a Pod annotation
+type InjectCondition struct {
+	Key    string
+	Value  string
+	Negate bool
+}
+
+// ParseInjectCondition parses a zen-lock/inject-if value of the form key=value or key!=value
+// The key must be a valid annotation name; the value may be empty.
+func ParseInjectCondition(expr string) (InjectCondition, error) {
+	var cond InjectCondition
+	key, value, found := strings.Cut(expr, "!=")
+	if found {
+		cond.Negate = true
+	} else if key, value, found = strings.Cut(expr, "="); !found {
+		return InjectCondition{}, fmt.Errorf("condition %q must be of the form key=value or key!=value", expr)
+	}
+	key = strings.TrimSpace(key)
+	if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+		return InjectCondition{}, fmt.Errorf("condition %q has an invalid annotation key: %s", expr, strings.Join(errs, "; "))
+	}
+	cond.Key = key
+	cond.Value = strings.TrimSpace(value)
+	return cond, nil
+}
+
+// Matches reports whether the annotations satisfy the condition
+// A missing annotation never equals the value, so it only satisfies key!=value.
+func (c InjectCondition) Matches(annotations map[string]string) bool {
+	value, ok := annotations[c.Key]
+	equal := ok && value == c.Value
+	return equal != c.Negate
+}
+
+// secretTypeRequiredKeys lists the data keys Kubernetes requires for well-known Secret types
+var secretTypeRequiredKeys = map[corev1.SecretType][]string{
+	corev1.SecretTypeTLS:              {corev1.TLSCertKey, corev1.TLSPrivateKeyKey},
+	corev1.SecretTypeDockerConfigJson: {corev1.DockerConfigJsonKey},
+	corev1.SecretTypeDockercfg:        {corev1.DockerConfigKey},
+	corev1.SecretTypeSSHAuth:          {corev1.SSHAuthPrivateKey},
+}
+
+// ValidateSecretType validates that decrypted data satisfies the requirements of the Secret type
+func ValidateSecretType(secretType corev1.SecretType, data map[string][]byte) error {
+	switch secretType {
+	case "", corev1.SecretTypeOpaque:
+		return nil
+	case corev1.SecretTypeServiceAccountToken, corev1.SecretTypeBootstrapToken:
+		// Populated and managed by Kubernetes itself
+		return fmt.Errorf("secret type %q is not supported", secretType)
+	case corev1.SecretTypeBasicAuth:
+		if _, ok := data[corev1.BasicAuthUsernameKey]; ok {
+			return nil
+		}
+		if _, ok := data[corev1.BasicAuthPasswordKey]; ok {
+			return nil
+		}
+		return fmt.Errorf("secret type %q requires key %q or %q", secretType, corev1.BasicAuthUsernameKey, corev1.BasicAuthPasswordKey)
+	}
+
+	for _, key := range secretTypeRequiredKeys[secretType] {
+		if _, ok := data[key]; !ok {
+			return fmt.Errorf("secret type %q requires key %q", secretType, key)
+		}
+	}
+	return nil
+}
+
 // SanitizeError sanitizes error messages to prevent information leakage
 // Returns a safe error message that doesn't expose sensitive details
 func SanitizeError(err error, operation string) error {
@@ -95,7 +235,7 @@ func SanitizeError(err error, operation string) error {
 		return nil
 	}
 
-	errMsg := err.Error()
+	errMsg := common.RedactMessage(err.Error())
 
 	// Remove potential sensitive information patterns
 	// Remove full paths
@@ -110,3 +250,14 @@ func SanitizeError(err error, operation string) error {
 	// Generic error message
 	return fmt.Errorf("%s failed: %s", operation, errMsg)
 }
+
+// HostPathVolumes returns the names of the Pod's hostPath volumes
+func HostPathVolumes(pod *corev1.Pod) []string {
+	var names []string
+	for _, volume := range pod.Spec.Volumes {
+		if volume.HostPath != nil {
+			names = append(names, volume.Name)
+		}
+	}
+	return names
+}
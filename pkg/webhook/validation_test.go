@@ -17,7 +17,10 @@ limitations under the License.
 package webhook
 
 import (
+	"strings"
 	"testing"
+
+	"github.com/kube-zen/zen-lock/pkg/config"
 )
 
 func TestValidateInjectAnnotation(t *testing.T) {
@@ -129,6 +132,26 @@ func TestValidateMountPath(t *testing.T) {
 			input:   "/" + string(make([]byte, 1025)),
 			wantErr: true,
 		},
+		{
+			name:    "over-length path of valid components",
+			input:   strings.Repeat("/"+strings.Repeat("a", 200), 6),
+			wantErr: true,
+		},
+		{
+			name:    "over-length component",
+			input:   "/app/" + strings.Repeat("a", 256),
+			wantErr: true,
+		},
+		{
+			name:    "component at the limit",
+			input:   "/app/" + strings.Repeat("a", 255),
+			wantErr: false,
+		},
+		{
+			name:    "long path within limits",
+			input:   strings.Repeat("/"+strings.Repeat("a", 200), 5),
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -141,6 +164,34 @@ func TestValidateMountPath(t *testing.T) {
 	}
 }
 
+func TestValidateMountPath_ForbiddenPaths(t *testing.T) {
+	for _, forbidden := range strings.Split(config.DefaultForbiddenMountPaths, ",") {
+		for _, mountPath := range []string{forbidden, forbidden + "/zen-lock"} {
+			if err := ValidateMountPath(mountPath); err == nil || !strings.Contains(err.Error(), forbidden) {
+				t.Errorf("Expected %s to be denied naming %s, got %v", mountPath, forbidden, err)
+			}
+		}
+	}
+	for _, safe := range []string{"/zen-lock/secrets", "/run/secrets", "/library/secrets", "/var-data"} {
+		if err := ValidateMountPath(safe); err != nil {
+			t.Errorf("Expected %s to be allowed, got %v", safe, err)
+		}
+	}
+}
+
+func TestValidateMountPath_ForbiddenPathsOverride(t *testing.T) {
+	t.Setenv("ZEN_LOCK_FORBIDDEN_MOUNT_PATHS", "/opt/app/, relative, /etc")
+	if err := ValidateMountPath("/opt/app/secrets"); err == nil {
+		t.Error("Expected a path under a configured directory to be denied")
+	}
+	if err := ValidateMountPath("/usr/local/secrets"); err != nil {
+		t.Errorf("Expected the defaults to be replaced, got %v", err)
+	}
+	if err := ValidateMountPath("/"); err == nil {
+		t.Error("Expected the root directory to always be denied")
+	}
+}
+
 func TestSanitizeError(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -188,6 +239,30 @@ func TestSanitizeError(t *testing.T) {
 	}
 }
 
+func TestValidateInjectScope(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{name: "empty defaults to all", input: "", wantErr: false},
+		{name: "all", input: "all", wantErr: false},
+		{name: "main-only", input: "main-only", wantErr: false},
+		{name: "init-only", input: "init-only", wantErr: false},
+		{name: "sidecar-only", input: "sidecar-only", wantErr: false},
+		{name: "unknown scope", input: "sidecars", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateInjectScope(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateInjectScope() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 type testError struct {
 	msg string
 }
@@ -210,3 +285,19 @@ func containsMiddle(s, substr string) bool {
 	}
 	return false
 }
+
+func TestValidateMountPath_MaxLengthOverride(t *testing.T) {
+	t.Setenv("ZEN_LOCK_MAX_MOUNT_PATH_LENGTH", "16")
+	err := ValidateMountPath("/app/secrets/database")
+	if err == nil || !strings.Contains(err.Error(), "exceeding the maximum of 16") {
+		t.Errorf("Expected the configured maximum to apply, got %v", err)
+	}
+	if err := ValidateMountPath("/app/secrets"); err != nil {
+		t.Errorf("Expected a path within the configured maximum to be valid, got %v", err)
+	}
+
+	t.Setenv("ZEN_LOCK_MAX_MOUNT_PATH_LENGTH", "invalid")
+	if got := MountPathMaxLength(); got != MaxMountPathLength {
+		t.Errorf("Expected an invalid value to fall back to %d, got %d", MaxMountPathLength, got)
+	}
+}
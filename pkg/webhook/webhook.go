@@ -3,6 +3,8 @@ package webhook
 import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kube-zen/zen-lock/pkg/config"
 )
 
 // SetupWebhookWithManager sets up the webhook with the manager
@@ -12,6 +14,20 @@ func SetupWebhookWithManager(mgr manager.Manager) error {
 	if err != nil {
 		return err
 	}
+	podHandler.Recorder = mgr.GetEventRecorderFor("zen-lock-webhook")
+	// Denials are counted in ZenLock statuses off the hot path, in coalesced batches
+	podHandler.Denials = NewDenialQueue()
+	if err := mgr.Add(NewDenialStatusWriter(mgr.GetClient(), podHandler.Denials, config.DefaultDenialFlushInterval)); err != nil {
+		return err
+	}
+	// Refresh cached ZenLocks from the informer cache in case an invalidation was missed
+	if err := mgr.Add(NewCacheResync(mgr.GetClient(), podHandler.cache, InformerResyncFromEnv())); err != nil {
+		return err
+	}
+	if AuditConfigMapEnabled() {
+		// Read through the API reader so the webhook does not cache every ConfigMap in the cluster
+		podHandler.Auditor = NewAuditLog(mgr.GetClient(), mgr.GetAPIReader(), AuditMaxEntries())
+	}
 
 	// Create ZenLock validator handler
 	zenlockValidatorHandler, err := NewZenLockValidatorHandler(mgr.GetScheme())
@@ -24,6 +40,11 @@ func SetupWebhookWithManager(mgr manager.Manager) error {
 		Handler: podHandler,
 	})
 
+	// Register mutating webhook making ZenLock defaults (the algorithm) explicit
+	mgr.GetWebhookServer().Register("/mutate-zenlock", &admission.Webhook{
+		Handler: NewZenLockDefaulterHandler(mgr.GetScheme()),
+	})
+
 	// Register validating webhook for ZenLocks
 	mgr.GetWebhookServer().Register("/validate-zenlock", &admission.Webhook{
 		Handler: zenlockValidatorHandler,
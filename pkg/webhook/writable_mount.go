@@ -0,0 +1,136 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kube-zen/zen-lock/pkg/config"
+)
+
+// copyContainerBaseName is the name of the copy init container for the default volume
+const copyContainerBaseName = "zen-lock-copy"
+
+// copySourcePath is where the copy init container mounts the read-only Secret volume
+const copySourcePath = "/zen-lock/source"
+
+// copyScript copies every key of the source volume ($1) into the writable mount ($2)
+// Secret volumes hold keys as symlinks into ..data directories, which are skipped; -L copies the files themselves.
+const copyScript = `set -e; cd "$1"; for f in * .[!.]*; do [ -e "$f" ] || continue; cp -L "$f" "$2/"; done`
+
+// getCopyImage returns the configured image of the copy init container
+func getCopyImage() string {
+	if image := os.Getenv("ZEN_LOCK_COPY_IMAGE"); image != "" {
+		return image
+	}
+	return config.DefaultCopyImage
+}
+
+// applyMountWritable makes every target's mount writable (zen-lock/mount-writable=true)
+func applyMountWritable(targets []injectionTarget) {
+	for i := range targets {
+		targets[i].writable = true
+	}
+}
+
+// WritableSourceVolumeName returns the name of the read-only Secret volume backing a writable mount
+func WritableSourceVolumeName(volumeName string) string {
+	// Volume names must be DNS-1123 labels (<= 63 characters)
+	const suffix = "-source"
+	if len(volumeName)+len(suffix) <= 63 {
+		return volumeName + suffix
+	}
+	hash := sha256.Sum256([]byte(volumeName))
+	return volumeName[:63-len(suffix)-9] + "-" + hex.EncodeToString(hash[:4]) + suffix
+}
+
+// CopyContainerName returns the name of the init container filling a writable mount
+func CopyContainerName(volumeName string) string {
+	if volumeName == config.DefaultVolumeName {
+		return copyContainerBaseName
+	}
+	hash := sha256.Sum256([]byte(volumeName))
+	return copyContainerBaseName + "-" + hex.EncodeToString(hash[:4])
+}
+
+// addWritableVolumes backs a Secret-mode target with a memory emptyDir filled from the Secret by an init container
+// Secret volumes are always read-only, so the workload gets a copy: edits stay local to the Pod and
+// later Secret updates are not propagated.
+func addWritableVolumes(pod *corev1.Pod, target injectionTarget, source corev1.VolumeSource) {
+	sourceName := WritableSourceVolumeName(target.volumeName)
+	existing := make(map[string]bool, len(pod.Spec.Volumes))
+	for _, vol := range pod.Spec.Volumes {
+		existing[vol.Name] = true
+	}
+	if !existing[sourceName] {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{Name: sourceName, VolumeSource: source})
+	}
+	if !existing[target.volumeName] {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+			Name: target.volumeName,
+			VolumeSource: corev1.VolumeSource{
+				// Memory-backed so the plaintext copy never reaches the node's disk
+				EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory},
+			},
+		})
+	}
+
+	copyName := CopyContainerName(target.volumeName)
+	for _, c := range pod.Spec.InitContainers {
+		if c.Name == copyName {
+			return
+		}
+	}
+
+	// Run first so every other init container already sees the copied files
+	pod.Spec.InitContainers = append([]corev1.Container{buildCopyInitContainer(copyName, sourceName, target, pod)}, pod.Spec.InitContainers...)
+}
+
+// buildCopyInitContainer builds the init container copying the Secret volume into the writable mount
+// It runs as the first container's user and group, when set, so the workload owns the copied files.
+func buildCopyInitContainer(name, sourceName string, target injectionTarget, pod *corev1.Pod) corev1.Container {
+	readOnlyRootFilesystem := true
+	allowPrivilegeEscalation := false
+
+	securityContext := &corev1.SecurityContext{
+		ReadOnlyRootFilesystem:   &readOnlyRootFilesystem,
+		AllowPrivilegeEscalation: &allowPrivilegeEscalation,
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{"ALL"},
+		},
+	}
+	if len(pod.Spec.Containers) > 0 && pod.Spec.Containers[0].SecurityContext != nil {
+		securityContext.RunAsUser = pod.Spec.Containers[0].SecurityContext.RunAsUser
+		securityContext.RunAsGroup = pod.Spec.Containers[0].SecurityContext.RunAsGroup
+	}
+
+	return corev1.Container{
+		Name:    name,
+		Image:   getCopyImage(),
+		Command: []string{"sh", "-c", copyScript, "zen-lock-copy", copySourcePath, target.mountPath},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: sourceName, MountPath: copySourcePath, ReadOnly: true},
+			{Name: target.volumeName, MountPath: target.mountPath},
+		},
+		Resources:       getInitResources(),
+		SecurityContext: securityContext,
+	}
+}
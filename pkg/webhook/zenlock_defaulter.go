@@ -0,0 +1,75 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/crypto"
+)
+
+// ZenLockDefaulterHandler is a mutating admission handler that makes ZenLock defaults explicit
+// Stored objects then keep the algorithm they were created with, even if ZEN_LOCK_DEFAULT_ALGORITHM changes later.
+type ZenLockDefaulterHandler struct {
+	decoder admission.Decoder
+}
+
+// NewZenLockDefaulterHandler creates a new admission handler defaulting ZenLocks
+func NewZenLockDefaulterHandler(scheme *runtime.Scheme) *ZenLockDefaulterHandler {
+	return &ZenLockDefaulterHandler{decoder: admission.NewDecoder(scheme)}
+}
+
+// DefaultZenLock sets an empty spec.algorithm to the configured default and reports whether it changed anything
+func DefaultZenLock(zenlock *securityv1alpha1.ZenLock) bool {
+	if zenlock.Spec.Algorithm != "" {
+		return false
+	}
+	zenlock.Spec.Algorithm = crypto.DefaultAlgorithm()
+	return true
+}
+
+// Handle patches created or updated ZenLocks with their defaults
+func (h *ZenLockDefaulterHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return admission.Allowed("")
+	}
+
+	if err := checkZenLockGroup(req); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	zenlock := &securityv1alpha1.ZenLock{}
+	if err := h.decoder.Decode(req, zenlock); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if !DefaultZenLock(zenlock) {
+		return admission.Allowed("")
+	}
+
+	defaulted, err := json.Marshal(zenlock)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, defaulted)
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+)
+
+func zenlockRequest(t *testing.T, zenlock *securityv1alpha1.ZenLock) admission.Request {
+	t.Helper()
+	raw, err := json.Marshal(zenlock)
+	if err != nil {
+		t.Fatalf("Failed to marshal ZenLock: %v", err)
+	}
+	return admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			Object:    runtime.RawExtension{Raw: raw},
+		},
+	}
+}
+
+func newTestDefaulter() *ZenLockDefaulterHandler {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(securityv1alpha1.AddToScheme(scheme))
+	return NewZenLockDefaulterHandler(scheme)
+}
+
+func TestZenLockDefaulterHandler_DefaultsAlgorithm(t *testing.T) {
+	tests := []struct {
+		name          string
+		globalDefault string
+		wantAlgorithm string
+	}{
+		{name: "built-in default", globalDefault: "", wantAlgorithm: "age"},
+		{name: "configured default", globalDefault: "custom", wantAlgorithm: "custom"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("ZEN_LOCK_DEFAULT_ALGORITHM", tt.globalDefault)
+			zenlock := createTestZenLock(t, map[string]string{"key1": "dGVzdA=="}, "", nil)
+
+			resp := newTestDefaulter().Handle(context.Background(), zenlockRequest(t, zenlock))
+			if !resp.Allowed {
+				t.Fatalf("Expected the ZenLock to be allowed, got %v", resp.Result)
+			}
+			if len(resp.Patches) != 1 || resp.Patches[0].Path != "/spec/algorithm" || resp.Patches[0].Value != tt.wantAlgorithm {
+				t.Errorf("Expected a patch setting /spec/algorithm to %q, got %+v", tt.wantAlgorithm, resp.Patches)
+			}
+		})
+	}
+}
+
+func TestZenLockDefaulterHandler_KeepsExplicitAlgorithm(t *testing.T) {
+	t.Setenv("ZEN_LOCK_DEFAULT_ALGORITHM", "custom")
+	zenlock := createTestZenLock(t, map[string]string{"key1": "dGVzdA=="}, "age", nil)
+
+	resp := newTestDefaulter().Handle(context.Background(), zenlockRequest(t, zenlock))
+	if !resp.Allowed || len(resp.Patches) != 0 {
+		t.Errorf("Expected an explicit algorithm to be left alone, got %+v", resp)
+	}
+}
+
+func TestZenLockValidatorHandler_Handle_EmptyAlgorithmUsesGlobalDefault(t *testing.T) {
+	handler, _ := setupTestValidator(t)
+	zenlock := createTestZenLock(t, map[string]string{"key1": "dGVzdA=="}, "", nil)
+
+	t.Setenv("ZEN_LOCK_DEFAULT_ALGORITHM", "rot13")
+	resp := handler.Handle(context.Background(), zenlockRequest(t, zenlock))
+	if resp.Allowed {
+		t.Fatal("Expected an empty algorithm to resolve to the unsupported global default")
+	}
+	if !strings.Contains(resp.Result.Message, `"rot13"`) {
+		t.Errorf("Expected the denial to name the global default, got %q", resp.Result.Message)
+	}
+}
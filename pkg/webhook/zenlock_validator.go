@@ -18,15 +18,23 @@ package webhook
 
 import (
 	"context"
-	"encoding/base64"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+	"github.com/kube-zen/zen-lock/pkg/common"
 	"github.com/kube-zen/zen-lock/pkg/config"
 	"github.com/kube-zen/zen-lock/pkg/controller/metrics"
 	"github.com/kube-zen/zen-lock/pkg/crypto"
@@ -42,22 +50,68 @@ type ZenLockValidatorHandler struct {
 type ZenLockValidator struct {
 	crypto     crypto.Encryptor
 	privateKey string
+
+	// maxKeys and maxTotalBytes bound the size of a ZenLock (0 = default)
+	maxKeys       int
+	maxTotalBytes int
+	// maxSubjects bounds the number of allowedSubjects (ZEN_LOCK_MAX_SUBJECTS, 0 = default)
+	maxSubjects int
+
+	// decryptLimiter bounds concurrent decryptions across all admissions (nil = unlimited)
+	decryptLimiter *decryptLimiter
+	// decryptTimeout bounds a single decryption (ZEN_LOCK_DECRYPT_TIMEOUT, 0 = default)
+	decryptTimeout time.Duration
+
+	// requireSubjects rejects ZenLocks without allowedSubjects (ZEN_LOCK_REQUIRE_SUBJECTS)
+	requireSubjects bool
+	// tolerantBase64 also accepts unpadded base64, as decryption does (ZEN_LOCK_BASE64_TOLERANT)
+	tolerantBase64 bool
 }
 
 // NewZenLockValidator creates a new ZenLock validator
 func NewZenLockValidator(scheme *runtime.Scheme) (*ZenLockValidator, error) {
 	// Load private key from environment for validation
-	privateKey := os.Getenv("ZEN_LOCK_PRIVATE_KEY")
+	privateKey := crypto.ResolvePrivateKey()
 	if privateKey == "" {
-		return nil, fmt.Errorf("ZEN_LOCK_PRIVATE_KEY environment variable is not set")
+		return nil, fmt.Errorf("ZEN_LOCK_PRIVATE_KEY environment variable is not set and ZEN_LOCK_IDENTITIES_DIR provided no identities")
 	}
 
 	// Initialize crypto
 	encryptor := crypto.NewAgeEncryptor()
 
+	// Size limits (configurable via ZEN_LOCK_MAX_KEYS and ZEN_LOCK_MAX_TOTAL_BYTES env vars)
+	maxKeys := config.DefaultMaxKeys
+	if maxStr := os.Getenv("ZEN_LOCK_MAX_KEYS"); maxStr != "" {
+		if parsedMax, err := strconv.Atoi(maxStr); err == nil && parsedMax > 0 {
+			maxKeys = parsedMax
+		}
+	}
+	maxTotalBytes := config.DefaultMaxTotalBytes
+	if maxStr := os.Getenv("ZEN_LOCK_MAX_TOTAL_BYTES"); maxStr != "" {
+		if parsedMax, err := strconv.Atoi(maxStr); err == nil && parsedMax > 0 {
+			maxTotalBytes = parsedMax
+		}
+	}
+	maxSubjects := config.DefaultMaxSubjects
+	if maxStr := os.Getenv("ZEN_LOCK_MAX_SUBJECTS"); maxStr != "" {
+		if parsedMax, err := strconv.Atoi(maxStr); err == nil && parsedMax > 0 {
+			maxSubjects = parsedMax
+		}
+	}
+
+	// Strict mode: every ZenLock must name the ServiceAccounts allowed to inject it
+	requireSubjects, _ := strconv.ParseBool(os.Getenv("ZEN_LOCK_REQUIRE_SUBJECTS"))
+
 	return &ZenLockValidator{
-		crypto:     encryptor,
-		privateKey: privateKey,
+		crypto:          encryptor,
+		privateKey:      privateKey,
+		maxKeys:         maxKeys,
+		maxTotalBytes:   maxTotalBytes,
+		maxSubjects:     maxSubjects,
+		decryptLimiter:  getSharedDecryptLimiter(),
+		decryptTimeout:  getDecryptTimeout(),
+		requireSubjects: requireSubjects,
+		tolerantBase64:  crypto.Base64Tolerant(),
 	}, nil
 }
 
@@ -76,21 +130,32 @@ func NewZenLockValidatorHandler(scheme *runtime.Scheme) (*ZenLockValidatorHandle
 }
 
 // Handle processes admission requests for ZenLock validation
+// Validation, including the trial decryption, only reads the request and never writes anything,
+// so server-side dry-run requests (kubectl apply --dry-run=server) get the exact same verdict.
 func (h *ZenLockValidatorHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if err := checkZenLockGroup(req); err != nil {
+		return admission.Errored(400, err)
+	}
+
 	zenlock := &securityv1alpha1.ZenLock{}
 
 	if err := h.decoder.Decode(req, zenlock); err != nil {
 		return admission.Errored(400, err)
 	}
 
+	opSuffix := ""
+	if req.DryRun != nil && *req.DryRun {
+		opSuffix = " (dry-run)"
+	}
+
 	var err error
 	switch req.Operation {
 	case admissionv1.Create:
-		err = h.validator.validateZenLock(zenlock)
+		err = h.validator.validateZenLock(ctx, zenlock)
 	case admissionv1.Update:
 		// Validate the new object (old object decoding is optional)
 		_ = h.decoder.DecodeRaw(req.OldObject, &securityv1alpha1.ZenLock{})
-		err = h.validator.validateZenLock(zenlock)
+		err = h.validator.validateZenLock(ctx, zenlock)
 	case admissionv1.Delete:
 		// Allow deletion - finalizers handle cleanup
 		return admission.Allowed("")
@@ -99,40 +164,156 @@ func (h *ZenLockValidatorHandler) Handle(ctx context.Context, req admission.Requ
 	}
 
 	if err != nil {
-		return admission.Denied(err.Error())
+		return admission.Denied(common.RedactMessage(err.Error()) + opSuffix)
 	}
 
-	return admission.Allowed("")
+	return admission.Allowed("ZenLock is valid" + opSuffix)
 }
 
 // validateZenLock validates a ZenLock CRD
-func (v *ZenLockValidator) validateZenLock(zenlock *securityv1alpha1.ZenLock) error {
+func (v *ZenLockValidator) validateZenLock(ctx context.Context, zenlock *securityv1alpha1.ZenLock) error {
 	// Validate encrypted data is not empty
 	if len(zenlock.Spec.EncryptedData) == 0 {
 		return fmt.Errorf("encryptedData cannot be empty")
 	}
 
 	// Validate algorithm (if specified)
-	algorithm := zenlock.Spec.Algorithm
-	if algorithm == "" {
-		algorithm = config.DefaultAlgorithm
-	}
-	if algorithm != config.SupportedAlgorithm {
+	algorithm := crypto.ResolveAlgorithm(zenlock.Spec.Algorithm)
+	if !crypto.IsRegistered(algorithm) {
 		metrics.RecordAlgorithmError(algorithm, "unsupported")
-		return fmt.Errorf("unsupported algorithm %q, only %q is currently supported", algorithm, config.SupportedAlgorithm)
+		return fmt.Errorf("unsupported algorithm %q, supported: %s", algorithm, strings.Join(crypto.AlgorithmNames(), ", "))
 	}
 
-	// Validate encrypted data format (must be valid base64)
+	// Validate key count before decoding anything
+	maxKeys := v.maxKeys
+	if maxKeys <= 0 {
+		maxKeys = config.DefaultMaxKeys
+	}
+	if len(zenlock.Spec.EncryptedData) > maxKeys {
+		return fmt.Errorf("encryptedData has %d keys, exceeding the maximum of %d", len(zenlock.Spec.EncryptedData), maxKeys)
+	}
+
+	// Validate encrypted data format (valid base64, or age armor with encoding: armor)
 	for key, value := range zenlock.Spec.EncryptedData {
 		if value == "" {
 			return fmt.Errorf("encryptedData[%q] cannot be empty", key)
 		}
-		if _, err := base64.StdEncoding.DecodeString(value); err != nil {
+	}
+	encryptedData, err := crypto.EncryptedDataAsBase64(zenlock.Spec.EncryptedData, zenlock.Spec.Encoding)
+	if err != nil {
+		return err
+	}
+	totalBytes := 0
+	for key, value := range encryptedData {
+		decoded, err := crypto.DecodeBase64(value, v.tolerantBase64)
+		if errors.Is(err, crypto.ErrBase64Padding) {
+			return fmt.Errorf("encryptedData[%q]: %v", key, err)
+		}
+		if err != nil {
 			return fmt.Errorf("encryptedData[%q] is not valid base64: %v", key, err)
 		}
+		// Catch mislabeled data, e.g. passphrase-encrypted values, before it confuses decryption
+		if err := crypto.CheckCiphertextFormat(algorithm, decoded); err != nil {
+			metrics.RecordAlgorithmError(algorithm, "format_mismatch")
+			return fmt.Errorf("encryptedData[%q]: %v", key, err)
+		}
+		totalBytes += len(decoded)
+	}
+
+	// Validate total ciphertext size
+	maxTotalBytes := v.maxTotalBytes
+	if maxTotalBytes <= 0 {
+		maxTotalBytes = config.DefaultMaxTotalBytes
+	}
+	if totalBytes > maxTotalBytes {
+		return fmt.Errorf("encryptedData totals %d bytes of ciphertext, exceeding the maximum of %d bytes", totalBytes, maxTotalBytes)
+	}
+
+	// Validate checksums reference existing keys and are SHA-256 hex digests
+	for key, checksum := range zenlock.Spec.Checksums {
+		if _, ok := zenlock.Spec.EncryptedData[key]; !ok {
+			return fmt.Errorf("checksums[%q] has no matching encryptedData key", key)
+		}
+		if decoded, err := hex.DecodeString(checksum); err != nil || len(decoded) != sha256.Size {
+			return fmt.Errorf("checksums[%q] must be a hex-encoded SHA-256 digest", key)
+		}
+	}
+
+	// Validate every required key is present
+	var missing []string
+	for _, key := range zenlock.Spec.RequiredKeys {
+		if _, ok := zenlock.Spec.EncryptedData[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("encryptedData is missing required keys: %s", strings.Join(missing, ", "))
+	}
+
+	// Validate zen-lock/concatenate lists existing keys and adds a new one
+	keys := make([]string, 0, len(zenlock.Spec.EncryptedData)+1)
+	for key := range zenlock.Spec.EncryptedData {
+		keys = append(keys, key)
+	}
+	concatName, concatKeys, err := Concatenation(zenlock)
+	if err != nil {
+		return err
+	}
+	if concatName != "" {
+		if _, exists := zenlock.Spec.EncryptedData[concatName]; exists {
+			return fmt.Errorf("invalid %s: name %q is already an encryptedData key", config.AnnotationConcatenate, concatName)
+		}
+		for _, key := range concatKeys {
+			if _, ok := zenlock.Spec.EncryptedData[key]; !ok {
+				return fmt.Errorf("invalid %s: key %q is not in encryptedData", config.AnnotationConcatenate, key)
+			}
+		}
+		keys = append(keys, concatName)
 	}
 
-	// Validate AllowedSubjects
+	// Validate the file names produced by zen-lock/key-extensions
+	extensions, err := KeyExtensions(zenlock)
+	if err != nil {
+		return err
+	}
+	if len(extensions) > 0 {
+		names, err := KeyExtensionNames(keys, extensions)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %v", config.AnnotationKeyExtensions, err)
+		}
+		keys = keys[:0]
+		for _, name := range names {
+			keys = append(keys, name)
+		}
+	}
+
+	// Validate zen-lock/key-case does not make two keys collide
+	keyCase, err := KeyCase(zenlock)
+	if err != nil {
+		return err
+	}
+	if _, err := KeyCaseNames(keys, keyCase); err != nil {
+		return fmt.Errorf("invalid %s: %v", config.AnnotationKeyCase, err)
+	}
+
+	// Validate no key is both required and optional
+	for _, key := range zenlock.Spec.OptionalKeys {
+		if slices.Contains(zenlock.Spec.RequiredKeys, key) {
+			return fmt.Errorf("optionalKeys[%q] is also listed in requiredKeys", key)
+		}
+	}
+
+	// Validate AllowedSubjects (an empty list lets any ServiceAccount in the namespace inject the ZenLock)
+	if v.requireSubjects && len(zenlock.Spec.AllowedSubjects) == 0 {
+		return fmt.Errorf("allowedSubjects cannot be empty: ZEN_LOCK_REQUIRE_SUBJECTS requires every ZenLock to name the ServiceAccounts allowed to use it")
+	}
+	maxSubjects := v.maxSubjects
+	if maxSubjects <= 0 {
+		maxSubjects = config.DefaultMaxSubjects
+	}
+	if len(zenlock.Spec.AllowedSubjects) > maxSubjects {
+		return fmt.Errorf("allowedSubjects has %d entries, exceeding the maximum of %d; grant access by Pod labels with injectionSelector instead of listing every ServiceAccount", len(zenlock.Spec.AllowedSubjects), maxSubjects)
+	}
 	for i, subject := range zenlock.Spec.AllowedSubjects {
 		if subject.Kind == "" {
 			return fmt.Errorf("allowedSubjects[%d].kind is required", i)
@@ -148,14 +329,44 @@ func (v *ZenLockValidator) validateZenLock(zenlock *securityv1alpha1.ZenLock) er
 		}
 	}
 
+	// Validate RequiredNodeSelector holds valid node labels
+	for key, value := range zenlock.Spec.RequiredNodeSelector {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("requiredNodeSelector key %q is invalid: %s", key, strings.Join(errs, "; "))
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return fmt.Errorf("requiredNodeSelector[%q] value %q is invalid: %s", key, value, strings.Join(errs, "; "))
+		}
+	}
+
 	// Try to decrypt to verify the data is valid (optional - can be expensive)
 	// Only validate if we have a private key
 	if v.privateKey != "" {
-		_, err := v.crypto.DecryptMap(zenlock.Spec.EncryptedData, v.privateKey)
+		if err := v.decryptLimiter.acquire(ctx); err != nil {
+			return fmt.Errorf("timed out waiting to validate encryptedData: %v", err)
+		}
+		metrics.RecordKeyUse(metrics.ComponentValidator)
+		decrypted, omitted, err := decryptMapWithTimeout(ctx, v.crypto, encryptedData, zenlock.Spec.OptionalKeys, crypto.SelectKeyID(v.privateKey, zenlock.Spec.KeyID), v.decryptTimeout)
+		v.decryptLimiter.release()
+		if errors.Is(err, ErrDecryptTimeout) {
+			metrics.RecordDecryptionTimeout(metrics.ComponentValidator, zenlock.Namespace, zenlock.Name)
+			return fmt.Errorf("failed to validate encryptedData: %w", err)
+		}
 		if err != nil {
 			metrics.RecordAlgorithmError(algorithm, "decryption_failed")
 			return fmt.Errorf("failed to decrypt encryptedData: %v (data may be encrypted with a different key)", err)
 		}
+		if err := crypto.VerifyChecksums(decrypted, crypto.OmitKeys(zenlock.Spec.Checksums, omitted)); err != nil {
+			return err
+		}
+		if TemplatesEnabled(zenlock) {
+			if _, err := ExpandTemplates(decrypted); err != nil {
+				return err
+			}
+		}
+		if err := ValidateSecretType(zenlock.Spec.SecretType, decrypted); err != nil {
+			return err
+		}
 	}
 
 	return nil
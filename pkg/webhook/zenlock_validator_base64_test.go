@@ -0,0 +1,72 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+)
+
+func TestZenLockValidator_Base64Padding(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	t.Setenv("ZEN_LOCK_PRIVATE_KEY", identity.String())
+
+	// Find a value whose encoding needs padding
+	var padded string
+	for i := 0; !strings.HasSuffix(padded, "="); i++ {
+		padded = encryptTestData(t, strings.Repeat("v", i), identity.Recipient().String())
+	}
+	unpadded := strings.TrimRight(padded, "=")
+
+	tests := []struct {
+		name     string
+		value    string
+		tolerant string
+		wantErr  string
+	}{
+		{name: "padded", value: padded},
+		{name: "unpadded", value: unpadded, wantErr: "base64 padding is missing or incorrect"},
+		{name: "unpadded tolerant", value: unpadded, tolerant: "true"},
+		{name: "padded tolerant", value: padded, tolerant: "true"},
+		{name: "malformed", value: "not*base", wantErr: "is not valid base64"},
+		{name: "malformed tolerant", value: "not*base", tolerant: "true", wantErr: "is not valid base64"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("ZEN_LOCK_BASE64_TOLERANT", tt.tolerant)
+			validator, err := NewZenLockValidator(nil)
+			if err != nil {
+				t.Fatalf("Failed to create validator: %v", err)
+			}
+
+			// The validator and decryption agree: an accepted value also decrypts
+			err = validator.validateZenLock(context.Background(), createTestZenLock(t, map[string]string{"key": tt.value}, "age", nil))
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("Expected the value to be accepted, got %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("Expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
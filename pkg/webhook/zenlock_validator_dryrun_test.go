@@ -0,0 +1,98 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+)
+
+func TestZenLockValidatorHandler_Handle_DryRun(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	t.Setenv("ZEN_LOCK_IDENTITIES_DIR", "")
+	t.Setenv("ZEN_LOCK_PRIVATE_KEY", identity.String())
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(corev1.AddToScheme(scheme))
+	utilruntime.Must(securityv1alpha1.AddToScheme(scheme))
+	handler, err := NewZenLockValidatorHandler(scheme)
+	if err != nil {
+		t.Fatalf("Failed to create validator handler: %v", err)
+	}
+
+	valid := createTestZenLock(t, map[string]string{"key1": encryptTestData(t, "value1", identity.Recipient().String())}, "age", nil)
+	// Valid base64, but not decryptable with the configured key
+	undecryptable := createTestZenLock(t, map[string]string{"key1": "dGVzdA=="}, "age", nil)
+
+	dryRun := true
+	tests := []struct {
+		name        string
+		operation   admissionv1.Operation
+		zenlock     *securityv1alpha1.ZenLock
+		wantAllowed bool
+	}{
+		{name: "create valid", operation: admissionv1.Create, zenlock: valid, wantAllowed: true},
+		{name: "create undecryptable", operation: admissionv1.Create, zenlock: undecryptable},
+		{name: "update valid", operation: admissionv1.Update, zenlock: valid, wantAllowed: true},
+		{name: "update undecryptable", operation: admissionv1.Update, zenlock: undecryptable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, _ := json.Marshal(tt.zenlock)
+			original := bytes.Clone(raw)
+			req := admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: tt.operation,
+					DryRun:    &dryRun,
+					Object:    runtime.RawExtension{Raw: raw},
+					OldObject: runtime.RawExtension{Raw: raw},
+				},
+			}
+
+			resp := handler.Handle(context.Background(), req)
+			if resp.Allowed != tt.wantAllowed {
+				t.Fatalf("Expected allowed=%v, got: %v", tt.wantAllowed, resp.Result)
+			}
+			if !tt.wantAllowed && !strings.Contains(resp.Result.Message, "failed to decrypt") {
+				t.Errorf("Expected decryption failure to deny the dry-run, got %q", resp.Result.Message)
+			}
+			if !strings.HasSuffix(resp.Result.Message, "(dry-run)") {
+				t.Errorf("Expected the verdict to be marked as dry-run, got %q", resp.Result.Message)
+			}
+			// A validating webhook must neither patch nor alter the request
+			if len(resp.Patches) != 0 || !bytes.Equal(req.Object.Raw, original) {
+				t.Errorf("Expected no changes in dry-run, got patches %v", resp.Patches)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestZenLockValidator_RedactsKeyNames(t *testing.T) {
+	t.Setenv("ZEN_LOCK_REDACT_KEYS", "oauth-*")
+	handler, publicKey := subjectsTestValidator(t, "")
+	zenlock := createTestZenLock(t, map[string]string{"tls.crt": encryptTestData(t, "cert", publicKey)}, "age", nil)
+	zenlock.Spec.Checksums = map[string]string{"oauth-client-id": "abc"}
+
+	resp := handler.Handle(context.Background(), zenlockRequest(t, zenlock))
+	if resp.Allowed {
+		t.Fatal("Expected a checksum without encryptedData key to be denied")
+	}
+	if strings.Contains(resp.Result.Message, "oauth-client-id") || !strings.Contains(resp.Result.Message, `checksums["[redacted]"]`) {
+		t.Errorf("Expected the key name to be redacted, got %q", resp.Result.Message)
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+)
+
+func TestZenLockValidator_RequiredKeys(t *testing.T) {
+	handler, publicKey := subjectsTestValidator(t, "")
+	data := map[string]string{
+		"tls.crt": encryptTestData(t, "cert", publicKey),
+		"tls.key": encryptTestData(t, "key", publicKey),
+	}
+
+	tests := []struct {
+		name         string
+		requiredKeys []string
+		wantAllowed  bool
+	}{
+		{name: "all required keys present", requiredKeys: []string{"tls.crt", "tls.key"}, wantAllowed: true},
+		{name: "missing required key", requiredKeys: []string{"tls.crt", "ca.crt"}, wantAllowed: false},
+		{name: "no required keys", wantAllowed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			zenlock := createTestZenLock(t, data, "age", nil)
+			zenlock.Spec.RequiredKeys = tt.requiredKeys
+
+			resp := handler.Handle(context.Background(), zenlockRequest(t, zenlock))
+			if resp.Allowed != tt.wantAllowed {
+				t.Fatalf("Allowed = %v, want %v (%v)", resp.Allowed, tt.wantAllowed, resp.Result)
+			}
+			if !tt.wantAllowed && !strings.Contains(resp.Result.Message, "missing required keys: ca.crt") {
+				t.Errorf("Expected the denial to name the missing key, got %q", resp.Result.Message)
+			}
+		})
+	}
+}
+
+func TestZenLockValidator_OptionalKeys(t *testing.T) {
+	handler, publicKey := subjectsTestValidator(t, "")
+	other, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+
+	data := map[string]string{
+		"tls.crt": encryptTestData(t, "cert", publicKey),
+		"debug":   encryptTestData(t, "token", other.Recipient().String()),
+	}
+
+	// An undecryptable optional key does not block the ZenLock
+	zenlock := createTestZenLock(t, data, "age", nil)
+	zenlock.Spec.OptionalKeys = []string{"debug"}
+	if resp := handler.Handle(context.Background(), zenlockRequest(t, zenlock)); !resp.Allowed {
+		t.Errorf("Expected an undecryptable optional key to be allowed, got %v", resp.Result)
+	}
+
+	// The same key without the optional marker is denied
+	zenlock.Spec.OptionalKeys = nil
+	if resp := handler.Handle(context.Background(), zenlockRequest(t, zenlock)); resp.Allowed {
+		t.Error("Expected an undecryptable required key to be denied")
+	}
+
+	// A key cannot be both required and optional
+	zenlock.Spec.OptionalKeys = []string{"tls.crt"}
+	zenlock.Spec.RequiredKeys = []string{"tls.crt"}
+	resp := handler.Handle(context.Background(), zenlockRequest(t, zenlock))
+	if resp.Allowed || !strings.Contains(resp.Result.Message, "also listed in requiredKeys") {
+		t.Errorf("Expected a denial for a key both required and optional, got %v", resp.Result)
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+)
+
+// setupSizeLimitValidator creates a validator handler with known keys and the given size limits
+func setupSizeLimitValidator(t *testing.T, maxKeys, maxTotalBytes string) (*ZenLockValidatorHandler, string) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+
+	t.Setenv("ZEN_LOCK_PRIVATE_KEY", identity.String())
+	t.Setenv("ZEN_LOCK_MAX_KEYS", maxKeys)
+	t.Setenv("ZEN_LOCK_MAX_TOTAL_BYTES", maxTotalBytes)
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(corev1.AddToScheme(scheme))
+	utilruntime.Must(securityv1alpha1.AddToScheme(scheme))
+
+	handler, err := NewZenLockValidatorHandler(scheme)
+	if err != nil {
+		t.Fatalf("Failed to create validator handler: %v", err)
+	}
+	return handler, identity.Recipient().String()
+}
+
+func validateSizeRequest(t *testing.T, handler *ZenLockValidatorHandler, operation admissionv1.Operation, encryptedData map[string]string) admission.Response {
+	zenlock := createTestZenLock(t, encryptedData, "age", nil)
+	zenlockRaw, _ := json.Marshal(zenlock)
+	req := admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: operation,
+			Object:    runtime.RawExtension{Raw: zenlockRaw},
+			OldObject: runtime.RawExtension{Raw: zenlockRaw},
+		},
+	}
+	return handler.Handle(context.Background(), req)
+}
+
+func TestZenLockValidatorHandler_Handle_UnderSizeLimits(t *testing.T) {
+	handler, publicKey := setupSizeLimitValidator(t, "2", "4096")
+
+	encryptedData := map[string]string{
+		"key1": encryptTestData(t, "value1", publicKey),
+		"key2": encryptTestData(t, "value2", publicKey),
+	}
+
+	for _, op := range []admissionv1.Operation{admissionv1.Create, admissionv1.Update} {
+		if resp := validateSizeRequest(t, handler, op, encryptedData); !resp.Allowed {
+			t.Errorf("%s: expected request under limits to be allowed, got: %v", op, resp.Result)
+		}
+	}
+}
+
+func TestZenLockValidatorHandler_Handle_TooManyKeys(t *testing.T) {
+	handler, publicKey := setupSizeLimitValidator(t, "2", "")
+
+	encryptedData := make(map[string]string, 3)
+	for i := 0; i < 3; i++ {
+		encryptedData[fmt.Sprintf("key%d", i)] = encryptTestData(t, "value", publicKey)
+	}
+
+	for _, op := range []admissionv1.Operation{admissionv1.Create, admissionv1.Update} {
+		resp := validateSizeRequest(t, handler, op, encryptedData)
+		if resp.Allowed {
+			t.Fatalf("%s: expected request over key limit to be denied", op)
+		}
+		if !strings.Contains(resp.Result.Message, "3 keys") || !strings.Contains(resp.Result.Message, "maximum of 2") {
+			t.Errorf("%s: expected message with actual and allowed key counts, got %q", op, resp.Result.Message)
+		}
+	}
+}
+
+func TestZenLockValidatorHandler_Handle_TooManyBytes(t *testing.T) {
+	handler, _ := setupSizeLimitValidator(t, "", "100")
+
+	encryptedData := map[string]string{
+		"key1": base64.StdEncoding.EncodeToString(make([]byte, 60)),
+		"key2": base64.StdEncoding.EncodeToString(make([]byte, 60)),
+	}
+
+	for _, op := range []admissionv1.Operation{admissionv1.Create, admissionv1.Update} {
+		resp := validateSizeRequest(t, handler, op, encryptedData)
+		if resp.Allowed {
+			t.Fatalf("%s: expected request over byte limit to be denied", op)
+		}
+		if !strings.Contains(resp.Result.Message, "120 bytes") || !strings.Contains(resp.Result.Message, "maximum of 100 bytes") {
+			t.Errorf("%s: expected message with actual and allowed sizes, got %q", op, resp.Result.Message)
+		}
+	}
+}
+
+func TestNewZenLockValidator_SizeLimitDefaults(t *testing.T) {
+	t.Setenv("ZEN_LOCK_PRIVATE_KEY", "AGE-SECRET-KEY-TEST")
+	t.Setenv("ZEN_LOCK_MAX_KEYS", "invalid")
+	t.Setenv("ZEN_LOCK_MAX_TOTAL_BYTES", "")
+
+	validator, err := NewZenLockValidator(runtime.NewScheme())
+	if err != nil {
+		t.Fatalf("NewZenLockValidator() error = %v", err)
+	}
+	if validator.maxKeys != 256 {
+		t.Errorf("Expected default maxKeys 256 for invalid value, got %d", validator.maxKeys)
+	}
+	if validator.maxTotalBytes != 512*1024 {
+		t.Errorf("Expected default maxTotalBytes %d, got %d", 512*1024, validator.maxTotalBytes)
+	}
+}
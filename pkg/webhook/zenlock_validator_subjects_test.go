@@ -0,0 +1,109 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+
+	securityv1alpha1 "github.com/kube-zen/zen-lock/pkg/apis/security.kube-zen.io/v1alpha1"
+)
+
+// subjectsTestValidator returns a validator for a fresh key with ZEN_LOCK_REQUIRE_SUBJECTS set to requireSubjects
+func subjectsTestValidator(t *testing.T, requireSubjects string) (*ZenLockValidatorHandler, string) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	t.Setenv("ZEN_LOCK_PRIVATE_KEY", identity.String())
+	t.Setenv("ZEN_LOCK_REQUIRE_SUBJECTS", requireSubjects)
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(securityv1alpha1.AddToScheme(scheme))
+	handler, err := NewZenLockValidatorHandler(scheme)
+	if err != nil {
+		t.Fatalf("Failed to create validator handler: %v", err)
+	}
+	return handler, identity.Recipient().String()
+}
+
+func TestZenLockValidator_RequireSubjects(t *testing.T) {
+	handler, publicKey := subjectsTestValidator(t, "true")
+	data := map[string]string{"key": encryptTestData(t, "value", publicKey)}
+
+	resp := handler.Handle(context.Background(), zenlockRequest(t, createTestZenLock(t, data, "age", nil)))
+	if resp.Allowed || !strings.Contains(resp.Result.Message, "allowedSubjects cannot be empty") {
+		t.Errorf("Expected a ZenLock without subjects to be denied in strict mode, got %v", resp.Result)
+	}
+
+	subjects := []securityv1alpha1.SubjectReference{{Kind: "ServiceAccount", Name: "app", Namespace: "default"}}
+	resp = handler.Handle(context.Background(), zenlockRequest(t, createTestZenLock(t, data, "age", subjects)))
+	if !resp.Allowed {
+		t.Errorf("Expected a ZenLock with subjects to be allowed in strict mode, got %v", resp.Result)
+	}
+}
+
+func TestZenLockValidator_OpenAccessAllowedByDefault(t *testing.T) {
+	handler, publicKey := subjectsTestValidator(t, "")
+	data := map[string]string{"key": encryptTestData(t, "value", publicKey)}
+
+	resp := handler.Handle(context.Background(), zenlockRequest(t, createTestZenLock(t, data, "age", nil)))
+	if !resp.Allowed {
+		t.Errorf("Expected a ZenLock without subjects to be allowed by default, got %v", resp.Result)
+	}
+}
+
+func TestZenLockValidator_MaxSubjects(t *testing.T) {
+	t.Setenv("ZEN_LOCK_MAX_SUBJECTS", "3")
+	handler, publicKey := subjectsTestValidator(t, "")
+	data := map[string]string{"key": encryptTestData(t, "value", publicKey)}
+
+	subjects := func(n int) []securityv1alpha1.SubjectReference {
+		var refs []securityv1alpha1.SubjectReference
+		for i := 0; i < n; i++ {
+			refs = append(refs, securityv1alpha1.SubjectReference{Kind: "ServiceAccount", Name: fmt.Sprintf("app-%d", i), Namespace: "default"})
+		}
+		return refs
+	}
+
+	tests := []struct {
+		name    string
+		count   int
+		allowed bool
+	}{
+		{name: "under limit", count: 2, allowed: true},
+		{name: "at limit", count: 3, allowed: true},
+		{name: "over limit", count: 4, allowed: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := handler.Handle(context.Background(), zenlockRequest(t, createTestZenLock(t, data, "age", subjects(tt.count))))
+			if resp.Allowed != tt.allowed {
+				t.Fatalf("Expected allowed=%v for %d subjects, got %v", tt.allowed, tt.count, resp.Result)
+			}
+			if !tt.allowed && (!strings.Contains(resp.Result.Message, "exceeding the maximum of 3") || !strings.Contains(resp.Result.Message, "injectionSelector")) {
+				t.Errorf("Expected the denial to state the limit and suggest injectionSelector, got %q", resp.Result.Message)
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"os"
+	"strings"
 	"testing"
 
 	admissionv1 "k8s.io/api/admission/v1"
@@ -269,6 +270,42 @@ func TestZenLockValidatorHandler_Handle_Create_InvalidBase64(t *testing.T) {
 	}
 }
 
+func TestZenLockValidatorHandler_Handle_Create_InvalidChecksums(t *testing.T) {
+	tests := []struct {
+		name      string
+		checksums map[string]string
+	}{
+		{name: "unknown key", checksums: map[string]string{"other": crypto.Checksum([]byte("value"))}},
+		{name: "not hex", checksums: map[string]string{"key1": "not-a-checksum"}},
+		{name: "wrong length", checksums: map[string]string{"key1": "abcd"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, _ := setupTestValidator(t)
+
+			zenlock := createTestZenLock(t, map[string]string{"key1": "dGVzdA=="}, "age", nil)
+			zenlock.Spec.Checksums = tt.checksums
+
+			zenlockRaw, _ := json.Marshal(zenlock)
+			req := admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Object:    runtime.RawExtension{Raw: zenlockRaw},
+				},
+			}
+
+			resp := handler.Handle(context.Background(), req)
+			if resp.Allowed {
+				t.Fatal("Expected request to be denied for invalid checksums")
+			}
+			if !strings.Contains(resp.Result.Message, "checksums") {
+				t.Errorf("Expected checksums error, got %q", resp.Result.Message)
+			}
+		})
+	}
+}
+
 func TestZenLockValidatorHandler_Handle_Create_EmptyValue(t *testing.T) {
 	handler, _ := setupTestValidator(t)
 
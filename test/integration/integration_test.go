@@ -64,9 +64,14 @@ func setupTestEnvironment(t *testing.T) (context.Context, *fake.ClientBuilder, *
 }
 
 func TestZenLockReconciler_Integration(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+
 	// Set test private key before setup
 	originalKey := os.Getenv("ZEN_LOCK_PRIVATE_KEY")
-	os.Setenv("ZEN_LOCK_PRIVATE_KEY", "AGE-SECRET-1EXAMPLEEXAMPLEEXAMPLEEXAMPLEEXAMPLEEXAMPLEEXAMPLEEXAMPLEEXAMPLE")
+	os.Setenv("ZEN_LOCK_PRIVATE_KEY", identity.String())
 	defer func() {
 		if originalKey != "" {
 			os.Setenv("ZEN_LOCK_PRIVATE_KEY", originalKey)
@@ -75,7 +80,11 @@ func TestZenLockReconciler_Integration(t *testing.T) {
 		}
 	}()
 
-	ctx, clientBuilder, _ := setupTestEnvironment(t)
+	ctx, clientBuilder, encryptor := setupTestEnvironment(t)
+	ciphertext, err := encryptor.Encrypt([]byte("test-value"), []string{identity.Recipient().String()})
+	if err != nil {
+		t.Fatalf("Failed to encrypt: %v", err)
+	}
 
 	zenlock := &securityv1alpha1.ZenLock{
 		ObjectMeta: metav1.ObjectMeta{
@@ -84,7 +93,7 @@ func TestZenLockReconciler_Integration(t *testing.T) {
 		},
 		Spec: securityv1alpha1.ZenLockSpec{
 			EncryptedData: map[string]string{
-				"key1": "dGVzdC12YWx1ZQ==", // base64 encoded
+				"key1": base64.StdEncoding.EncodeToString(ciphertext),
 			},
 			Algorithm: "age",
 		},